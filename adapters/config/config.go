@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/diagnoxix/adapters/db"
@@ -53,12 +54,63 @@ type EnvConfiguration struct {
 	PAYSTACK_BASE_URL   string
 	PAYSTACK_SECRET_KEY string
 	PAYSTACK_PUBLIC_KEY string
+	// Flutterwave Service, enabled when the secret key is set
+	FLUTTERWAVE_BASE_URL   string
+	FLUTTERWAVE_SECRET_KEY string
+	// Stripe Service, enabled when the secret key is set
+	STRIPE_BASE_URL   string
+	STRIPE_SECRET_KEY string
+	// Monnify Service, enabled when the API key is set
+	MONNIFY_BASE_URL      string
+	MONNIFY_API_KEY       string
+	MONNIFY_SECRET_KEY    string
+	MONNIFY_CONTRACT_CODE string
+	// Comma-separated order payment providers are tried in when falling back,
+	// PAYSTACK,FLUTTERWAVE,STRIPE,MONNIFY when unset
+	PAYMENT_PROVIDER_ORDER string
+	// Where providers with a hosted checkout send the patient after paying
+	PAYMENT_REDIRECT_URL string
+	// SMS gateway text messages go through, TERMII or TWILIO. Text messages fail when unset.
+	SMS_PROVIDER string
+	// Termii SMS
+	TERMII_BASE_URL  string
+	TERMII_API_KEY   string
+	TERMII_SENDER_ID string
+	TERMII_CHANNEL   string
+	// Twilio SMS; the messaging service is used instead of the number when set
+	TWILIO_BASE_URL              string
+	TWILIO_ACCOUNT_SID           string
+	TWILIO_AUTH_TOKEN            string
+	TWILIO_FROM_NUMBER           string
+	TWILIO_MESSAGING_SERVICE_SID string
 	// OPEN API
 	OPEN_API_KEY string
 	// MONGODB_URL
 	MONGODB_URL string
 	// Redis Cache
 	REDIS_URL string
+	// Flat booking fee charged on top of the test price
+	BOOKING_FEE string
+	// Flat fee kept from the payment each time an appointment is rescheduled
+	RESCHEDULING_FEE string
+	// How many times a single booking may be rescheduled, 2 when unset
+	MAX_RESCHEDULES string
+	// Minutes a freed slot is held for the waitlisted patient it is offered to, 15 when unset
+	WAITLIST_HOLD_MINUTES string
+	// Hours of provider transactions each reconciliation run compares with payments, 48 when unset
+	PAYMENT_RECONCILIATION_HOURS string
+	// Minutes a pending appointment waits on its payment before it is expired, 60 when unset
+	UNPAID_APPOINTMENT_TIMEOUT_MINUTES string
+	// Minutes after its start a pay-at-centre appointment waits for its payment to be recorded, 30 when unset
+	PAY_AT_CENTRE_HOLD_MINUTES string
+	// Where the daily payment discrepancy report is emailed; it is only logged when unset
+	FINANCE_REPORT_EMAIL string
+	// Workers delivering queued email in parallel, 4 when unset
+	EMAIL_OUTBOX_WORKERS string
+	// Percentage of each payment the platform keeps before settling centres, 10 when unset
+	PLATFORM_COMMISSION_PERCENT string
+	// Percentage of tax included in payment amounts, shown on invoices, 0 when unset
+	INVOICE_TAX_PERCENT string
 }
 
 // LoadEnvironmentVariables loads configuration from env and .env for the MEDIVUE service.
@@ -88,6 +140,42 @@ func LoadEnvironmentVariables() (*EnvConfiguration, error) {
 		REDIS_URL:            os.Getenv("REDIS_URL"),
 		MONGODB_URL:          os.Getenv("MONGODB_URL"),
 		TOKEN_EXPIRED:        os.Getenv("TOKEN_EXPIRED"),
+		BOOKING_FEE:          os.Getenv("BOOKING_FEE"),
+		RESCHEDULING_FEE:     os.Getenv("RESCHEDULING_FEE"),
+		MAX_RESCHEDULES:      os.Getenv("MAX_RESCHEDULES"),
+
+		WAITLIST_HOLD_MINUTES: os.Getenv("WAITLIST_HOLD_MINUTES"),
+
+		FLUTTERWAVE_BASE_URL:   os.Getenv("FLUTTERWAVE_BASE_URL"),
+		FLUTTERWAVE_SECRET_KEY: os.Getenv("FLUTTERWAVE_SECRET_KEY"),
+		STRIPE_BASE_URL:        os.Getenv("STRIPE_BASE_URL"),
+		STRIPE_SECRET_KEY:      os.Getenv("STRIPE_SECRET_KEY"),
+		MONNIFY_BASE_URL:       os.Getenv("MONNIFY_BASE_URL"),
+		MONNIFY_API_KEY:        os.Getenv("MONNIFY_API_KEY"),
+		MONNIFY_SECRET_KEY:     os.Getenv("MONNIFY_SECRET_KEY"),
+		MONNIFY_CONTRACT_CODE:  os.Getenv("MONNIFY_CONTRACT_CODE"),
+		PAYMENT_PROVIDER_ORDER: os.Getenv("PAYMENT_PROVIDER_ORDER"),
+		PAYMENT_REDIRECT_URL:   os.Getenv("PAYMENT_REDIRECT_URL"),
+
+		SMS_PROVIDER:                 os.Getenv("SMS_PROVIDER"),
+		TERMII_BASE_URL:              os.Getenv("TERMII_BASE_URL"),
+		TERMII_API_KEY:               os.Getenv("TERMII_API_KEY"),
+		TERMII_SENDER_ID:             os.Getenv("TERMII_SENDER_ID"),
+		TERMII_CHANNEL:               os.Getenv("TERMII_CHANNEL"),
+		TWILIO_BASE_URL:              os.Getenv("TWILIO_BASE_URL"),
+		TWILIO_ACCOUNT_SID:           os.Getenv("TWILIO_ACCOUNT_SID"),
+		TWILIO_AUTH_TOKEN:            os.Getenv("TWILIO_AUTH_TOKEN"),
+		TWILIO_FROM_NUMBER:           os.Getenv("TWILIO_FROM_NUMBER"),
+		TWILIO_MESSAGING_SERVICE_SID: os.Getenv("TWILIO_MESSAGING_SERVICE_SID"),
+
+		PAYMENT_RECONCILIATION_HOURS:       os.Getenv("PAYMENT_RECONCILIATION_HOURS"),
+		UNPAID_APPOINTMENT_TIMEOUT_MINUTES: os.Getenv("UNPAID_APPOINTMENT_TIMEOUT_MINUTES"),
+		PAY_AT_CENTRE_HOLD_MINUTES:         os.Getenv("PAY_AT_CENTRE_HOLD_MINUTES"),
+		FINANCE_REPORT_EMAIL:               os.Getenv("FINANCE_REPORT_EMAIL"),
+		EMAIL_OUTBOX_WORKERS:               os.Getenv("EMAIL_OUTBOX_WORKERS"),
+
+		PLATFORM_COMMISSION_PERCENT: os.Getenv("PLATFORM_COMMISSION_PERCENT"),
+		INVOICE_TAX_PERCENT:         os.Getenv("INVOICE_TAX_PERCENT"),
 	}
 
 	// Validate required fields
@@ -95,6 +183,13 @@ func LoadEnvironmentVariables() (*EnvConfiguration, error) {
 		return nil, fmt.Errorf("missing required environment variables: PORT")
 	}
 
+	// Phone sign-ups cannot verify their number without a known SMS gateway
+	switch strings.ToUpper(strings.TrimSpace(cfg.SMS_PROVIDER)) {
+	case "", "TERMII", "TWILIO":
+	default:
+		return nil, fmt.Errorf("unknown SMS_PROVIDER %q, expected TERMII or TWILIO", cfg.SMS_PROVIDER)
+	}
+
 	// Validate Google OAuth config
 	if cfg.GOOGLE_CLIENT_ID == "" || cfg.GOOGLE_CLIENT_SECRET == "" {
 		utils.Warn("Google OAuth configuration is incomplete. Google login will be unavailable.")
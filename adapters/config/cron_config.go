@@ -2,12 +2,9 @@ package config
 
 import (
 	"log"
-	"strconv"
 	"sync"
 
-	"github.com/diagnoxix/adapters/external"
 	"github.com/diagnoxix/core/jobs"
-	"github.com/diagnoxix/core/ports"
 )
 
 // CronConfig manages cron jobs and services configuration
@@ -24,36 +21,8 @@ var (
 
 // GetConfig initializes and returns the configuration for cron jobs and services.
 // It ensures that initialization happens only once using sync.Once.
-func GetConfig(
-	useRepo ports.UserRepository,
-	diagnosticCentreRepo ports.DiagnosticRepository,
-	appointmentPort ports.AppointmentRepository,
-	con EnvConfiguration,
-) *CronConfig {
+func GetConfig(reminderJob *jobs.ReminderJob) *CronConfig {
 	once.Do(func() {
-
-		// Initialize repositories
-		port, err := strconv.Atoi(con.EMAIL_PORT)
-		if err != nil {
-			log.Fatal("Invalid EMAIL_PORT:", err)
-		}
-		emailConfig := ex.NewEmailConfig(ex.EmailConfig{
-			Host:     con.EMAIL_HOST,
-			Port:     port,
-			Username: con.EMAIL_USERNAME,
-			Password: con.EMAIL_APP_PASSWORD,
-			From:     con.EMAIL_FROM_ADDRESS,
-		})
-		notificationSvc := ex.NewNotificationAdapter(emailConfig)
-
-		// Initialize reminder job
-		reminderJob := jobs.NewReminderJob(
-			appointmentPort,
-			notificationSvc,
-			useRepo,
-			diagnosticCentreRepo,
-		)
-
 		if reminderJob == nil {
 			log.Fatal("Failed to initialize reminder job")
 		}
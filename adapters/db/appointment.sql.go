@@ -21,6 +21,7 @@ SET
     cancellation_fee = COALESCE($4, 0),
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 
+    AND status IN ('pending', 'confirmed')
 RETURNING id, patient_id, schedule_id, diagnostic_centre_id, appointment_date, time_slot, status, payment_id, payment_status, payment_amount, payment_date, check_in_time, completion_time, notes, cancellation_reason, cancelled_by, cancellation_time, cancellation_fee, original_appointment_id, rescheduling_reason, rescheduled_by, rescheduling_time, rescheduling_fee, created_at, updated_at, reminder_sent, reminder_sent_at
 `
 
@@ -71,6 +72,66 @@ func (q *Queries) CancelAppointment(ctx context.Context, arg CancelAppointmentPa
 	return &i, err
 }
 
+const confirmAppointmentsByPayment = `-- name: ConfirmAppointmentsByPayment :many
+UPDATE appointments
+SET
+    status = 'confirmed',
+    payment_status = 'success',
+    payment_date = COALESCE(payment_date, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE payment_id = $1
+    AND status = 'pending'
+RETURNING id, patient_id, schedule_id, diagnostic_centre_id, appointment_date, time_slot, status, payment_id, payment_status, payment_amount, payment_date, check_in_time, completion_time, notes, cancellation_reason, cancelled_by, cancellation_time, cancellation_fee, original_appointment_id, rescheduling_reason, rescheduled_by, rescheduling_time, rescheduling_fee, created_at, updated_at, reminder_sent, reminder_sent_at
+`
+
+func (q *Queries) ConfirmAppointmentsByPayment(ctx context.Context, paymentID pgtype.UUID) ([]*Appointment, error) {
+	rows, err := q.db.Query(ctx, confirmAppointmentsByPayment, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Appointment
+	for rows.Next() {
+		var i Appointment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PatientID,
+			&i.ScheduleID,
+			&i.DiagnosticCentreID,
+			&i.AppointmentDate,
+			&i.TimeSlot,
+			&i.Status,
+			&i.PaymentID,
+			&i.PaymentStatus,
+			&i.PaymentAmount,
+			&i.PaymentDate,
+			&i.CheckInTime,
+			&i.CompletionTime,
+			&i.Notes,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancellationTime,
+			&i.CancellationFee,
+			&i.OriginalAppointmentID,
+			&i.ReschedulingReason,
+			&i.RescheduledBy,
+			&i.ReschedulingTime,
+			&i.ReschedulingFee,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReminderSent,
+			&i.ReminderSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createAppointment = `-- name: CreateAppointment :one
 INSERT INTO appointments (
     patient_id,
@@ -231,23 +292,71 @@ func (q *Queries) GetAppointment(ctx context.Context, id string) (*Appointment,
 	return &i, err
 }
 
+const getAppointmentRescheduleSummary = `-- name: GetAppointmentRescheduleSummary :one
+WITH RECURSIVE chain AS (
+    SELECT appointments.id, appointments.original_appointment_id, appointments.rescheduling_fee, appointments.payment_id
+    FROM appointments
+    WHERE appointments.id = $1
+    UNION ALL
+    SELECT a.id, a.original_appointment_id, a.rescheduling_fee, a.payment_id
+    FROM appointments a
+    JOIN chain ON a.id = chain.original_appointment_id
+)
+SELECT
+    (COUNT(*) - 1)::int AS reschedule_count,
+    COALESCE(SUM(chain.rescheduling_fee), 0)::numeric AS rescheduling_fees,
+    -- Fees are paid against the appointment that replaced the rescheduled one, apart from
+    -- the payment the booking itself was settled with
+    (
+        SELECT COALESCE(SUM(payments.amount), 0)
+        FROM payments
+        WHERE payments.appointment_id IN (SELECT chain.id FROM chain)
+            AND payments.payment_status = 'success'
+            AND payments.id NOT IN (SELECT chain.payment_id FROM chain WHERE chain.payment_id IS NOT NULL)
+    )::numeric AS rescheduling_fees_paid
+FROM chain
+`
+
+type GetAppointmentRescheduleSummaryRow struct {
+	RescheduleCount      int32          `db:"reschedule_count" json:"reschedule_count"`
+	ReschedulingFees     pgtype.Numeric `db:"rescheduling_fees" json:"rescheduling_fees"`
+	ReschedulingFeesPaid pgtype.Numeric `db:"rescheduling_fees_paid" json:"rescheduling_fees_paid"`
+}
+
+// Counts how often an appointment was rescheduled and the fees charged along the way
+func (q *Queries) GetAppointmentRescheduleSummary(ctx context.Context, id string) (*GetAppointmentRescheduleSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getAppointmentRescheduleSummary, id)
+	var i GetAppointmentRescheduleSummaryRow
+	err := row.Scan(&i.RescheduleCount, &i.ReschedulingFees, &i.ReschedulingFeesPaid)
+	return &i, err
+}
+
 const getCentreAppointments = `-- name: GetCentreAppointments :many
 SELECT 
-    id,
-    diagnostic_centre_id,
-    status,
-    appointment_date,
-    time_slot,
-    created_at,
-    updated_at
-FROM appointments 
-WHERE diagnostic_centre_id = $1
+    a.id,
+    a.diagnostic_centre_id,
+    a.patient_id,
+    a.schedule_id,
+    a.status,
+    a.appointment_date,
+    a.time_slot,
+    a.payment_status,
+    a.check_in_time,
+    a.completion_time,
+    a.created_at,
+    a.updated_at,
+    u.fullname AS patient_name,
+    ds.test_type
+FROM appointments a
+JOIN users u ON u.id = a.patient_id
+JOIN diagnostic_schedules ds ON ds.id = a.schedule_id
+WHERE a.diagnostic_centre_id = $1
     AND (
-        status = ANY($2::text[])
+        a.status::text = ANY($2::text[])
     )
-    AND appointment_date >= $3
-    AND appointment_date < $4
-ORDER BY appointment_date ASC
+    AND a.appointment_date >= $3
+    AND a.appointment_date < $4
+ORDER BY a.appointment_date ASC
 LIMIT $5 OFFSET $6
 `
 
@@ -263,11 +372,18 @@ type GetCentreAppointmentsParams struct {
 type GetCentreAppointmentsRow struct {
 	ID                 string             `db:"id" json:"id"`
 	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	PatientID          string             `db:"patient_id" json:"patient_id"`
+	ScheduleID         string             `db:"schedule_id" json:"schedule_id"`
 	Status             AppointmentStatus  `db:"status" json:"status"`
 	AppointmentDate    pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
 	TimeSlot           string             `db:"time_slot" json:"time_slot"`
+	PaymentStatus      NullPaymentStatus  `db:"payment_status" json:"payment_status"`
+	CheckInTime        pgtype.Timestamptz `db:"check_in_time" json:"check_in_time"`
+	CompletionTime     pgtype.Timestamptz `db:"completion_time" json:"completion_time"`
 	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	PatientName        pgtype.Text        `db:"patient_name" json:"patient_name"`
+	TestType           string             `db:"test_type" json:"test_type"`
 }
 
 func (q *Queries) GetCentreAppointments(ctx context.Context, arg GetCentreAppointmentsParams) ([]*GetCentreAppointmentsRow, error) {
@@ -289,11 +405,18 @@ func (q *Queries) GetCentreAppointments(ctx context.Context, arg GetCentreAppoin
 		if err := rows.Scan(
 			&i.ID,
 			&i.DiagnosticCentreID,
+			&i.PatientID,
+			&i.ScheduleID,
 			&i.Status,
 			&i.AppointmentDate,
 			&i.TimeSlot,
+			&i.PaymentStatus,
+			&i.CheckInTime,
+			&i.CompletionTime,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PatientName,
+			&i.TestType,
 		); err != nil {
 			return nil, err
 		}
@@ -537,11 +660,15 @@ WITH old_appointment AS (
         rescheduling_fee = COALESCE($4, 0),
         updated_at = CURRENT_TIMESTAMP
     WHERE appointments.id = $1 
+        AND appointments.status IN ('pending', 'confirmed')
     RETURNING 
         appointments.id as original_appointment_id,
         appointments.patient_id,
         appointments.diagnostic_centre_id,
+        appointments.payment_id,
+        appointments.payment_status,
         appointments.payment_amount,
+        appointments.payment_date,
         appointments.notes
 )
 INSERT INTO appointments (
@@ -551,7 +678,10 @@ INSERT INTO appointments (
     appointment_date,
     time_slot,
     status,
+    payment_id,
+    payment_status,
     payment_amount,
+    payment_date,
     notes,
     original_appointment_id
 ) 
@@ -561,8 +691,11 @@ SELECT
     old_appointment.diagnostic_centre_id,
     $6,  -- new_appointment_date
     $7,  -- new_time_slot
-    'confirmed',
+    CASE WHEN old_appointment.payment_status = 'success' THEN 'confirmed' ELSE 'pending' END::appointment_status,
+    old_appointment.payment_id,
+    old_appointment.payment_status,
     old_appointment.payment_amount,
+    old_appointment.payment_date,
     old_appointment.notes,
     old_appointment.original_appointment_id
 FROM old_appointment
@@ -689,7 +822,7 @@ SET
     status = COALESCE($2, status),
     updated_at = CURRENT_TIMESTAMP,
     check_in_time = CASE 
-        WHEN $2 = 'in_progress' AND check_in_time IS NULL THEN CURRENT_TIMESTAMP 
+        WHEN $2 IN ('checked_in', 'in_progress') AND check_in_time IS NULL THEN CURRENT_TIMESTAMP 
         ELSE check_in_time 
     END,
     completion_time = CASE 
@@ -697,16 +830,18 @@ SET
         ELSE completion_time 
     END
 WHERE id = $1 
+    AND status = $3
 RETURNING id, patient_id, schedule_id, diagnostic_centre_id, appointment_date, time_slot, status, payment_id, payment_status, payment_amount, payment_date, check_in_time, completion_time, notes, cancellation_reason, cancelled_by, cancellation_time, cancellation_fee, original_appointment_id, rescheduling_reason, rescheduled_by, rescheduling_time, rescheduling_fee, created_at, updated_at, reminder_sent, reminder_sent_at
 `
 
 type UpdateAppointmentStatusParams struct {
-	ID     string            `db:"id" json:"id"`
-	Status AppointmentStatus `db:"status" json:"status"`
+	ID       string            `db:"id" json:"id"`
+	Status   AppointmentStatus `db:"status" json:"status"`
+	Status_2 AppointmentStatus `db:"status_2" json:"status_2"`
 }
 
 func (q *Queries) UpdateAppointmentStatus(ctx context.Context, arg UpdateAppointmentStatusParams) (*Appointment, error) {
-	row := q.db.QueryRow(ctx, updateAppointmentStatus, arg.ID, arg.Status)
+	row := q.db.QueryRow(ctx, updateAppointmentStatus, arg.ID, arg.Status, arg.Status_2)
 	var i Appointment
 	err := row.Scan(
 		&i.ID,
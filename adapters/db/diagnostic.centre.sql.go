@@ -797,6 +797,15 @@ WITH filtered_centres AS (
         AND dctp.test_type = $5
       )
     )
+    AND (
+      $8::date IS NULL OR NOT EXISTS (
+        SELECT 1
+        FROM diagnostic_centre_availability_exceptions dcae
+        WHERE dcae.diagnostic_centre_id = dc.id
+        AND dcae.exception_date = $8::date
+        AND dcae.is_closed = TRUE
+      )
+    )
 )
 SELECT
   dc.id,
@@ -868,6 +877,7 @@ type Get_Nearest_Diagnostic_CentresParams struct {
 	Column5   interface{} `db:"column_5" json:"column_5"`
 	Limit     int32       `db:"limit" json:"limit"`
 	Offset    int32       `db:"offset" json:"offset"`
+	Column8   pgtype.Date `db:"column_8" json:"column_8"`
 }
 
 type Get_Nearest_Diagnostic_CentresRow struct {
@@ -896,6 +906,7 @@ func (q *Queries) Get_Nearest_Diagnostic_Centres(ctx context.Context, arg Get_Ne
 		arg.Column5,
 		arg.Limit,
 		arg.Offset,
+		arg.Column8,
 	)
 	if err != nil {
 		return nil, err
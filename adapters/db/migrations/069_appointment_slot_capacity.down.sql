@@ -0,0 +1,40 @@
+DROP INDEX IF EXISTS idx_appointments_centre_date;
+
+CREATE OR REPLACE FUNCTION validate_appointment_time_slot() 
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Make sure appointment isn't in the past
+    IF NEW.appointment_date < CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'Cannot create appointments in the past';
+    END IF;
+
+    -- Validate time slot format (HH:MM-HH:MM)
+    IF NOT NEW.time_slot ~ '^([0-1][0-9]|2[0-3]):[0-5][0-9]-([0-1][0-9]|2[0-3]):[0-5][0-9]$' THEN
+        RAISE EXCEPTION 'Invalid time slot format. Must be HH:MM-HH:MM';
+    END IF;
+
+    -- Check if the schedule exists and is ACCEPTED
+    IF NOT EXISTS (
+        SELECT 1 FROM diagnostic_schedules 
+        WHERE id = NEW.schedule_id 
+        AND diagnostic_centre_id = NEW.diagnostic_centre_id
+        AND acceptance_status = 'ACCEPTED'
+    ) THEN
+        RAISE EXCEPTION 'Invalid or unconfirmed schedule';
+    END IF;
+
+    -- Check for double booking in the same time slot
+    IF EXISTS (
+        SELECT 1 FROM appointments
+        WHERE diagnostic_centre_id = NEW.diagnostic_centre_id
+        AND appointment_date = NEW.appointment_date
+        AND time_slot = NEW.time_slot
+        AND status NOT IN ('cancelled', 'rescheduled')
+        AND id != NEW.id
+    ) THEN
+        RAISE EXCEPTION 'Time slot already booked';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_test_price_timestamp ON diagnostic_centre_test_prices;
+DROP TRIGGER IF EXISTS test_price_sync_available_tests ON diagnostic_centre_test_prices;
+DROP TRIGGER IF EXISTS test_price_history ON diagnostic_centre_test_prices;
+DROP FUNCTION IF EXISTS sync_available_tests();
+DROP FUNCTION IF EXISTS record_test_price_history();
+DROP TABLE IF EXISTS diagnostic_centre_test_price_history;
@@ -0,0 +1,99 @@
+-- Price history so past bookings can be traced to the price in effect at the time
+CREATE TABLE IF NOT EXISTS diagnostic_centre_test_price_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    test_price_id UUID NOT NULL REFERENCES diagnostic_centre_test_prices(id) ON DELETE CASCADE,
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    test_type TEXT NOT NULL,
+    price NUMERIC(10,2) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    is_active BOOLEAN NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    effective_to TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX idx_test_price_history_price ON diagnostic_centre_test_price_history(test_price_id, effective_from DESC);
+CREATE INDEX idx_test_price_history_lookup ON diagnostic_centre_test_price_history(diagnostic_centre_id, test_type, effective_from DESC);
+
+-- Backfill the current prices as the first history entry
+INSERT INTO diagnostic_centre_test_price_history (
+    test_price_id, diagnostic_centre_id, test_type, price, currency, is_active, effective_from
+)
+SELECT id, diagnostic_centre_id, test_type, price, currency, is_active, created_at
+FROM diagnostic_centre_test_prices;
+
+-- Record a new history entry whenever the price, currency or status changes
+CREATE OR REPLACE FUNCTION record_test_price_history() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.price = OLD.price
+        AND NEW.currency = OLD.currency
+        AND NEW.is_active = OLD.is_active THEN
+        RETURN NEW;
+    END IF;
+
+    UPDATE diagnostic_centre_test_price_history
+    SET effective_to = CURRENT_TIMESTAMP
+    WHERE test_price_id = NEW.id
+    AND effective_to IS NULL;
+
+    INSERT INTO diagnostic_centre_test_price_history (
+        test_price_id, diagnostic_centre_id, test_type, price, currency, is_active
+    ) VALUES (
+        NEW.id, NEW.diagnostic_centre_id, NEW.test_type, NEW.price, NEW.currency, NEW.is_active
+    );
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER test_price_history
+    AFTER INSERT OR UPDATE ON diagnostic_centre_test_prices
+    FOR EACH ROW
+    EXECUTE FUNCTION record_test_price_history();
+
+-- Keep diagnostic_centres.available_tests in sync with the active price rows
+CREATE OR REPLACE FUNCTION sync_available_tests() RETURNS TRIGGER AS $$
+DECLARE
+    centre_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        centre_id := OLD.diagnostic_centre_id;
+    ELSE
+        centre_id := NEW.diagnostic_centre_id;
+    END IF;
+
+    UPDATE diagnostic_centres
+    SET available_tests = COALESCE((
+        SELECT ARRAY_AGG(tp.test_type ORDER BY tp.test_type)
+        FROM diagnostic_centre_test_prices tp
+        WHERE tp.diagnostic_centre_id = centre_id
+        AND tp.is_active = true
+    ), '{}')
+    WHERE id = centre_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER test_price_sync_available_tests
+    AFTER INSERT OR UPDATE OR DELETE ON diagnostic_centre_test_prices
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_available_tests();
+
+CREATE TRIGGER update_test_price_timestamp BEFORE UPDATE
+ON diagnostic_centre_test_prices FOR EACH ROW EXECUTE FUNCTION
+update_updated_at_column();
+
+-- Bring existing centres in line with their active prices
+UPDATE diagnostic_centres dc
+SET available_tests = COALESCE((
+    SELECT ARRAY_AGG(tp.test_type ORDER BY tp.test_type)
+    FROM diagnostic_centre_test_prices tp
+    WHERE tp.diagnostic_centre_id = dc.id
+    AND tp.is_active = true
+), '{}')
+WHERE EXISTS (
+    SELECT 1 FROM diagnostic_centre_test_prices tp WHERE tp.diagnostic_centre_id = dc.id
+);
@@ -0,0 +1,4 @@
+-- Enum values cannot be dropped, and the table may predate this migration,
+-- so only the indexes created here are removed.
+DROP INDEX IF EXISTS idx_notifications_unread;
+DROP INDEX IF EXISTS idx_notifications_user;
@@ -0,0 +1,31 @@
+-- The notifications table predates the migrations folder; create it when missing
+DO $$ BEGIN
+    CREATE TYPE notification_type AS ENUM (
+        'APPOINTMENT_CREATED',
+        'APPOINTMENT_CONFIRMED',
+        'APPOINTMENT_RESCHEDULED',
+        'APPOINTMENT_CANCELLED',
+        'APPOINTMENT_REMINDER'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type notification_type NOT NULL,
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    read BOOLEAN NOT NULL DEFAULT FALSE,
+    read_at TIMESTAMP WITH TIME ZONE NULL,
+    metadata JSONB NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read = FALSE;
+
+-- Sent when a centre closes or shortens hours over an existing booking
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'APPOINTMENT_DISRUPTED';
@@ -0,0 +1,161 @@
+-- Date-specific exceptions to the weekly availability: full-day closures
+-- (public holidays, maintenance, training) or overrides of hours and capacity.
+CREATE TABLE IF NOT EXISTS diagnostic_centre_availability_exceptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    exception_date DATE NOT NULL,
+    is_closed BOOLEAN NOT NULL DEFAULT TRUE,
+    start_time TIME NULL,
+    end_time TIME NULL,
+    max_appointments INTEGER NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_exception_hours CHECK ((start_time IS NULL) = (end_time IS NULL)),
+    CONSTRAINT check_exception_time_range CHECK (start_time IS NULL OR start_time < end_time),
+    CONSTRAINT check_exception_max_appointments CHECK (max_appointments IS NULL OR max_appointments > 0),
+    CONSTRAINT check_exception_override CHECK (is_closed OR start_time IS NOT NULL OR max_appointments IS NOT NULL),
+    UNIQUE (diagnostic_centre_id, exception_date)
+);
+
+CREATE INDEX idx_availability_exceptions_date ON diagnostic_centre_availability_exceptions(exception_date);
+
+DROP TRIGGER IF EXISTS update_availability_exception_timestamp ON diagnostic_centre_availability_exceptions;
+CREATE TRIGGER update_availability_exception_timestamp
+    BEFORE UPDATE ON diagnostic_centre_availability_exceptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Schedules must respect closures and overridden hours or capacity
+CREATE OR REPLACE FUNCTION check_slot_availability() RETURNS TRIGGER AS $$
+DECLARE
+    slot_count INTEGER;
+    max_slots INTEGER;
+    slot_range tstzrange;
+    day_name text;
+    day_start TIMESTAMP;
+    closure diagnostic_centre_availability_exceptions%ROWTYPE;
+BEGIN
+    -- Convert the day name to lowercase and trim spaces
+    day_name := TRIM(LOWER(TO_CHAR(NEW.schedule_time AT TIME ZONE 'UTC', 'day')));
+    day_start := date_trunc('day', NEW.schedule_time AT TIME ZONE 'UTC');
+
+    SELECT * INTO closure
+    FROM diagnostic_centre_availability_exceptions e
+    WHERE e.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND e.exception_date = day_start::date;
+
+    IF FOUND AND closure.is_closed THEN
+        RAISE EXCEPTION 'Diagnostic centre is closed on this day'
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    -- Get availability settings for that day
+    SELECT 
+        COALESCE(closure.max_appointments, a.max_appointments),
+        tstzrange(
+            day_start + COALESCE(closure.start_time, a.start_time),
+            day_start + COALESCE(closure.end_time, a.end_time)
+        ) INTO max_slots, slot_range
+    FROM diagnostic_centre_availability a
+    WHERE a.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND a.day_of_week = day_name;
+
+    IF max_slots IS NULL THEN
+        RAISE EXCEPTION 'No availability set for this day'
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    -- Check if schedule_time falls within the available time range
+    IF NOT (NEW.schedule_time <@ slot_range) THEN
+        RAISE EXCEPTION 'Schedule time is outside available hours'
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    -- Count existing appointments in the same time slot
+    SELECT COUNT(*) INTO slot_count
+    FROM diagnostic_schedules s
+    WHERE s.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND s.schedule_time = NEW.schedule_time
+    AND s.id != NEW.id;
+
+    IF slot_count >= max_slots THEN
+        RAISE EXCEPTION 'No available slots at this time'
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Appointments must not land on a closed day and use the overridden capacity
+CREATE OR REPLACE FUNCTION validate_appointment_time_slot() 
+RETURNS TRIGGER AS $$
+DECLARE
+    slot_capacity INTEGER;
+    booked_count INTEGER;
+    closure diagnostic_centre_availability_exceptions%ROWTYPE;
+BEGIN
+    -- Status transitions on an existing booking don't change the slot
+    IF TG_OP = 'UPDATE'
+        AND NEW.appointment_date = OLD.appointment_date
+        AND NEW.time_slot = OLD.time_slot THEN
+        RETURN NEW;
+    END IF;
+
+    -- Make sure appointment isn't in the past
+    IF NEW.appointment_date < CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'Cannot create appointments in the past';
+    END IF;
+
+    -- Validate time slot format (HH:MM-HH:MM)
+    IF NOT NEW.time_slot ~ '^([0-1][0-9]|2[0-3]):[0-5][0-9]-([0-1][0-9]|2[0-3]):[0-5][0-9]$' THEN
+        RAISE EXCEPTION 'Invalid time slot format. Must be HH:MM-HH:MM';
+    END IF;
+
+    -- Check if the schedule exists and is ACCEPTED
+    IF NOT EXISTS (
+        SELECT 1 FROM diagnostic_schedules 
+        WHERE id = NEW.schedule_id 
+        AND diagnostic_centre_id = NEW.diagnostic_centre_id
+        AND acceptance_status = 'ACCEPTED'
+    ) THEN
+        RAISE EXCEPTION 'Invalid or unconfirmed schedule';
+    END IF;
+
+    SELECT * INTO closure
+    FROM diagnostic_centre_availability_exceptions e
+    WHERE e.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND e.exception_date = (NEW.appointment_date AT TIME ZONE 'UTC')::date;
+
+    IF FOUND AND closure.is_closed THEN
+        RAISE EXCEPTION 'Diagnostic centre is closed on this day';
+    END IF;
+
+    -- Capacity of the slot comes from the exception or the availability of that weekday
+    SELECT COALESCE(closure.max_appointments, NULLIF(a.max_appointments, 0), 1) INTO slot_capacity
+    FROM diagnostic_centre_availability a
+    WHERE a.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND a.day_of_week = TRIM(LOWER(TO_CHAR(NEW.appointment_date AT TIME ZONE 'UTC', 'day')));
+
+    IF slot_capacity IS NULL THEN
+        slot_capacity := COALESCE(closure.max_appointments, 1);
+    END IF;
+
+    -- Check for overbooking in the same time slot
+    SELECT COUNT(*) INTO booked_count
+    FROM appointments
+    WHERE diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND appointment_date = NEW.appointment_date
+    AND time_slot = NEW.time_slot
+    AND status NOT IN ('cancelled', 'rescheduled')
+    AND id != NEW.id;
+
+    IF booked_count >= slot_capacity THEN
+        RAISE EXCEPTION 'Time slot already booked';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
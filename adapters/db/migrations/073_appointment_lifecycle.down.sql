@@ -0,0 +1,5 @@
+-- Enum values cannot be dropped; move rows off them so the old code paths keep working
+UPDATE appointments SET status = 'confirmed' WHERE status = 'checked_in';
+UPDATE appointments SET status = 'cancelled' WHERE status = 'no_show';
+
+DROP INDEX IF EXISTS idx_appointments_centre_status;
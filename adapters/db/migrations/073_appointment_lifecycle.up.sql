@@ -0,0 +1,11 @@
+-- Centre-side lifecycle: a patient is checked in on arrival, and an appointment
+-- nobody turned up for is closed as a no-show.
+ALTER TYPE appointment_status ADD VALUE IF NOT EXISTS 'checked_in' AFTER 'confirmed';
+ALTER TYPE appointment_status ADD VALUE IF NOT EXISTS 'no_show' AFTER 'completed';
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'APPOINTMENT_CHECKED_IN';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'APPOINTMENT_STARTED';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'APPOINTMENT_COMPLETED';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'APPOINTMENT_NO_SHOW';
+
+CREATE INDEX IF NOT EXISTS idx_appointments_centre_status ON appointments(diagnostic_centre_id, status);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_cancellation_policy_timestamp ON diagnostic_centre_cancellation_policies;
+DROP TABLE IF EXISTS diagnostic_centre_cancellation_policies;
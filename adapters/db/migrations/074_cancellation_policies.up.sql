@@ -0,0 +1,20 @@
+-- Per-centre cancellation policy: cancelling at least free_cancellation_hours
+-- before the appointment is free, later cancellations keep a percentage of the payment.
+CREATE TABLE IF NOT EXISTS diagnostic_centre_cancellation_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    free_cancellation_hours INTEGER NOT NULL DEFAULT 24,
+    late_cancellation_fee_percent NUMERIC(5,2) NOT NULL DEFAULT 50,
+    updated_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_free_cancellation_hours CHECK (free_cancellation_hours >= 0),
+    CONSTRAINT check_late_cancellation_fee_percent CHECK (late_cancellation_fee_percent BETWEEN 0 AND 100),
+    UNIQUE (diagnostic_centre_id)
+);
+
+DROP TRIGGER IF EXISTS update_cancellation_policy_timestamp ON diagnostic_centre_cancellation_policies;
+CREATE TRIGGER update_cancellation_policy_timestamp
+    BEFORE UPDATE ON diagnostic_centre_cancellation_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_waitlist_entry_timestamp ON appointment_waitlist_entries;
+DROP TABLE IF EXISTS appointment_waitlist_entries;
+DROP TYPE IF EXISTS waitlist_status;
@@ -0,0 +1,50 @@
+-- Waitlist for fully booked centres: a patient waits for any slot of a centre
+-- within a date window. When a slot frees up the first waiting patient is
+-- offered a hold on it until hold_expires_at.
+CREATE TYPE waitlist_status AS ENUM (
+    'waiting',
+    'offered',
+    'booked',
+    'expired',
+    'cancelled'
+);
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'WAITLIST_SLOT_OFFERED';
+
+CREATE TABLE IF NOT EXISTS appointment_waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    patient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    test_type TEXT NOT NULL,
+    date_from DATE NOT NULL,
+    date_to DATE NOT NULL,
+    status waitlist_status NOT NULL DEFAULT 'waiting',
+    offered_slot_start TIMESTAMP WITH TIME ZONE,
+    offered_time_slot TEXT,
+    hold_expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_waitlist_date_window CHECK (date_to >= date_from),
+    CONSTRAINT check_waitlist_offer CHECK (
+        status <> 'offered'
+        OR (offered_slot_start IS NOT NULL AND offered_time_slot IS NOT NULL AND hold_expires_at IS NOT NULL)
+    )
+);
+
+-- A patient waits at most once per centre and test
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_active_patient
+    ON appointment_waitlist_entries(patient_id, diagnostic_centre_id, test_type)
+    WHERE status IN ('waiting', 'offered');
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_centre_status
+    ON appointment_waitlist_entries(diagnostic_centre_id, status, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_hold_expiry
+    ON appointment_waitlist_entries(hold_expires_at)
+    WHERE status = 'offered';
+
+DROP TRIGGER IF EXISTS update_waitlist_entry_timestamp ON appointment_waitlist_entries;
+CREATE TRIGGER update_waitlist_entry_timestamp
+    BEFORE UPDATE ON appointment_waitlist_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
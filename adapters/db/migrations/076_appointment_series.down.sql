@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_appointments_payment;
+DROP TRIGGER IF EXISTS update_appointment_series_timestamp ON appointment_series;
+DROP TABLE IF EXISTS appointment_series_occurrences;
+DROP TABLE IF EXISTS appointment_series;
+DROP TYPE IF EXISTS series_status;
+DROP TYPE IF EXISTS series_payment_mode;
+DROP TYPE IF EXISTS series_recurrence;
@@ -0,0 +1,59 @@
+-- Recurring appointment series for patients who come in for the same test
+-- every few weeks. Each occurrence is an ordinary appointment; the occurrence
+-- row follows the appointment when it is rescheduled.
+CREATE TYPE series_recurrence AS ENUM (
+    'weekly',
+    'every_n_weeks',
+    'monthly',
+    'dates'
+);
+
+CREATE TYPE series_payment_mode AS ENUM (
+    'upfront',
+    'per_visit'
+);
+
+CREATE TYPE series_status AS ENUM (
+    'active',
+    'cancelled'
+);
+
+CREATE TABLE IF NOT EXISTS appointment_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    patient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    test_type TEXT NOT NULL,
+    recurrence series_recurrence NOT NULL,
+    interval_weeks INTEGER,
+    payment_mode series_payment_mode NOT NULL,
+    status series_status NOT NULL DEFAULT 'active',
+    notes TEXT,
+    cancellation_reason TEXT,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_series_interval CHECK (
+        (recurrence = 'every_n_weeks' AND interval_weeks >= 2)
+        OR (recurrence <> 'every_n_weeks' AND interval_weeks IS NULL)
+    ),
+    CONSTRAINT check_series_cancellation CHECK ((status = 'cancelled') = (cancelled_at IS NOT NULL))
+);
+
+CREATE TABLE IF NOT EXISTS appointment_series_occurrences (
+    series_id UUID NOT NULL REFERENCES appointment_series(id) ON DELETE CASCADE,
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    occurrence_number INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (series_id, occurrence_number),
+    UNIQUE (appointment_id),
+    CONSTRAINT check_occurrence_number CHECK (occurrence_number > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_series_patient ON appointment_series(patient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_appointments_payment ON appointments(payment_id) WHERE payment_id IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_appointment_series_timestamp ON appointment_series;
+CREATE TRIGGER update_appointment_series_timestamp
+    BEFORE UPDATE ON appointment_series
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_appointment_line_items_timestamp ON appointment_line_items;
+DROP TABLE IF EXISTS appointment_line_item_records;
+DROP TABLE IF EXISTS appointment_line_items;
+DROP TYPE IF EXISTS line_item_result_status;
@@ -0,0 +1,39 @@
+-- Tests booked under one appointment. Each line item is priced from the
+-- centre's price list at booking time and tracks its own result; medical
+-- records uploaded for the appointment can be attached to a line item.
+CREATE TYPE line_item_result_status AS ENUM (
+    'pending',
+    'processing',
+    'ready'
+);
+
+CREATE TABLE IF NOT EXISTS appointment_line_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    test_type TEXT NOT NULL,
+    test_price_id UUID REFERENCES diagnostic_centre_test_prices(id) ON DELETE SET NULL,
+    price NUMERIC(10,2) NOT NULL,
+    currency VARCHAR(10) NOT NULL DEFAULT 'NGN',
+    result_status line_item_result_status NOT NULL DEFAULT 'pending',
+    result_ready_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (appointment_id, test_type),
+    CONSTRAINT check_line_item_price CHECK (price >= 0),
+    CONSTRAINT check_line_item_result_ready CHECK ((result_status = 'ready') = (result_ready_at IS NOT NULL))
+);
+
+CREATE TABLE IF NOT EXISTS appointment_line_item_records (
+    line_item_id UUID NOT NULL REFERENCES appointment_line_items(id) ON DELETE CASCADE,
+    medical_record_id UUID NOT NULL REFERENCES medical_records(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (line_item_id, medical_record_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_line_item_records_record ON appointment_line_item_records(medical_record_id);
+
+DROP TRIGGER IF EXISTS update_appointment_line_items_timestamp ON appointment_line_items;
+CREATE TRIGGER update_appointment_line_items_timestamp
+    BEFORE UPDATE ON appointment_line_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
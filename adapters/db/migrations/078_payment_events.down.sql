@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS payment_events_append_only ON payment_events;
+DROP FUNCTION IF EXISTS prevent_payment_event_change();
+DROP TABLE IF EXISTS payment_events;
+DROP TYPE IF EXISTS payment_event_source;
@@ -0,0 +1,49 @@
+-- Append-only ledger of everything that happened to a payment: provider
+-- events (webhooks and verification lookups) and internal transitions. The
+-- status columns of payments are a projection of this ledger. A provider
+-- event is recorded once per provider_event_id, so redelivered webhooks and
+-- racing verifications are no-ops.
+CREATE TYPE payment_event_source AS ENUM (
+    'webhook',
+    'verification',
+    'internal'
+);
+
+CREATE TABLE IF NOT EXISTS payment_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE RESTRICT,
+    source payment_event_source NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payment_provider payment_provider NOT NULL,
+    provider_event_id VARCHAR(255),
+    from_status payment_status,
+    to_status payment_status,
+    amount NUMERIC(10,2),
+    payload JSONB,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_payment_event_amount CHECK (amount IS NULL OR amount >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_events_provider_event
+    ON payment_events(payment_provider, provider_event_id)
+    WHERE provider_event_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payment_events_payment ON payment_events(payment_id, created_at);
+
+CREATE OR REPLACE FUNCTION prevent_payment_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'payment_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS payment_events_append_only ON payment_events;
+CREATE TRIGGER payment_events_append_only
+    BEFORE UPDATE OR DELETE ON payment_events
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_payment_event_change();
+
+-- Seed the ledger with the current state of existing payments
+INSERT INTO payment_events (payment_id, source, event_type, payment_provider, to_status, amount, created_at)
+SELECT id, 'internal', 'payment.imported', payment_provider, payment_status, amount, created_at
+FROM payments;
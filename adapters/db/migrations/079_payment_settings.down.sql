@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_payment_settings_timestamp ON diagnostic_centre_payment_settings;
+DROP TABLE IF EXISTS diagnostic_centre_payment_settings;
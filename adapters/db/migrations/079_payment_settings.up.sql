@@ -0,0 +1,18 @@
+-- Per-centre payment settings: the provider a centre collects payments with and whether
+-- payments may start with another provider while that one is unavailable.
+CREATE TABLE IF NOT EXISTS diagnostic_centre_payment_settings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    payment_provider payment_provider NOT NULL DEFAULT 'PAYSTACK',
+    allow_fallback BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (diagnostic_centre_id)
+);
+
+DROP TRIGGER IF EXISTS update_payment_settings_timestamp ON diagnostic_centre_payment_settings;
+CREATE TRIGGER update_payment_settings_timestamp
+    BEFORE UPDATE ON diagnostic_centre_payment_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
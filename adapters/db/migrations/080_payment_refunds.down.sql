@@ -0,0 +1,9 @@
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_payment_refund;
+ALTER TABLE payments ADD CONSTRAINT payments_check CHECK (
+    (payment_status = 'refunded' AND refund_amount IS NOT NULL AND refund_reason IS NOT NULL AND refund_date IS NOT NULL AND refunded_by IS NOT NULL) OR
+    (payment_status != 'refunded' AND refund_amount IS NULL AND refund_reason IS NULL AND refund_date IS NULL AND refunded_by IS NULL)
+);
+
+DROP TRIGGER IF EXISTS update_payment_refund_timestamp ON payment_refunds;
+DROP TABLE IF EXISTS payment_refunds;
+DROP TYPE IF EXISTS payment_refund_status;
@@ -0,0 +1,60 @@
+-- Refunds executed through the payment provider. A refund is pending from the
+-- moment it is requested until the provider reports it processed or failed.
+-- A payment may be refunded in parts; refunds that have not failed may never
+-- add up to more than the amount captured. payments.refund_amount is the total
+-- processed so far and the payment moves to refunded once it covers the amount.
+CREATE TYPE payment_refund_status AS ENUM (
+    'pending',
+    'processed',
+    'failed'
+);
+
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE RESTRICT,
+    payment_provider payment_provider NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reason TEXT NOT NULL,
+    status payment_refund_status NOT NULL DEFAULT 'pending',
+    provider_refund_id VARCHAR(255),
+    failure_reason TEXT,
+    -- NULL for refunds started from the provider's dashboard
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_payment_refund_amount CHECK (amount > 0),
+    CONSTRAINT check_payment_refund_processed CHECK ((status = 'processed') = (processed_at IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_refunds_provider_refund
+    ON payment_refunds(payment_provider, provider_refund_id)
+    WHERE provider_refund_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_payment ON payment_refunds(payment_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_pending ON payment_refunds(created_at)
+    WHERE status = 'pending';
+
+DROP TRIGGER IF EXISTS update_payment_refund_timestamp ON payment_refunds;
+CREATE TRIGGER update_payment_refund_timestamp
+    BEFORE UPDATE ON payment_refunds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Partially refunded payments stay successful and carry a refund amount, and
+-- refunds processed by the provider have no requesting user
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_check;
+ALTER TABLE payments ADD CONSTRAINT check_payment_refund CHECK (
+    (payment_status = 'refunded' AND refund_amount IS NOT NULL AND refund_date IS NOT NULL) OR
+    (payment_status = 'success') OR
+    (refund_amount IS NULL AND refund_reason IS NULL AND refund_date IS NULL AND refunded_by IS NULL)
+);
+
+-- Refunds recorded before refunds went through the provider
+INSERT INTO payment_refunds (
+    payment_id, payment_provider, amount, currency, reason, status, requested_by, processed_at, created_at
+)
+SELECT id, payment_provider, refund_amount, currency, COALESCE(refund_reason, 'Refund'),
+    'processed', refunded_by, refund_date, refund_date
+FROM payments
+WHERE payment_status = 'refunded' AND refund_amount IS NOT NULL;
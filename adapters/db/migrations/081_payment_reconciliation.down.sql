@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_pending_created;
+DROP TRIGGER IF EXISTS update_payment_discrepancy_timestamp ON payment_discrepancies;
+DROP TABLE IF EXISTS payment_discrepancies;
+DROP TYPE IF EXISTS payment_discrepancy_kind;
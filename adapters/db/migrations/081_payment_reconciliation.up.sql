@@ -0,0 +1,40 @@
+-- Differences the reconciliation job found between a provider's transactions and
+-- the payments they belong to. Each transaction is recorded once per kind of
+-- difference; later runs refresh what they observe. Differences the job corrects
+-- itself are kept as resolved so the daily report shows them alongside the rest.
+CREATE TYPE payment_discrepancy_kind AS ENUM (
+    'status_mismatch',
+    'amount_mismatch',
+    'unknown_reference',
+    'paid_after_expiry'
+);
+
+CREATE TABLE IF NOT EXISTS payment_discrepancies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_provider payment_provider NOT NULL,
+    provider_reference VARCHAR(255) NOT NULL,
+    -- NULL when no payment carries the provider's reference
+    payment_id UUID REFERENCES payments(id) ON DELETE SET NULL,
+    kind payment_discrepancy_kind NOT NULL,
+    payment_status payment_status,
+    provider_status VARCHAR(50) NOT NULL,
+    payment_amount NUMERIC(10,2),
+    provider_amount NUMERIC(10,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_payment_discrepancy UNIQUE (payment_provider, provider_reference, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_discrepancies_detected ON payment_discrepancies(detected_at);
+
+-- Finds payments still waiting on the patient once their checkout has lapsed
+CREATE INDEX IF NOT EXISTS idx_payments_pending_created ON payments(created_at)
+    WHERE payment_status = 'pending';
+
+DROP TRIGGER IF EXISTS update_payment_discrepancy_timestamp ON payment_discrepancies;
+CREATE TRIGGER update_payment_discrepancy_timestamp
+    BEFORE UPDATE ON payment_discrepancies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_payment_refunds_processed;
+DROP INDEX IF EXISTS idx_payments_centre_paid;
+DROP TRIGGER IF EXISTS update_settlement_timestamp ON settlements;
+DROP TRIGGER IF EXISTS update_centre_payout_account_timestamp ON centre_payout_accounts;
+DROP TABLE IF EXISTS settlement_entries;
+DROP TABLE IF EXISTS settlements;
+DROP TABLE IF EXISTS centre_payout_accounts;
+DROP TYPE IF EXISTS settlement_entry_kind;
+DROP TYPE IF EXISTS settlement_status;
@@ -0,0 +1,108 @@
+-- Settlements pay each diagnostic centre what it earned from the payments collected
+-- on its behalf, less refunds and the platform commission. Every captured payment
+-- and every processed refund becomes exactly one settlement entry, so nothing is
+-- paid out twice. A statement closes the entries of a period that no earlier
+-- statement settled; a statement that nets to nothing or less is carried forward
+-- as the opening balance of the next one.
+CREATE TYPE settlement_status AS ENUM (
+    'pending',
+    'processing',
+    'paid',
+    'failed',
+    'carried_forward'
+);
+
+CREATE TYPE settlement_entry_kind AS ENUM (
+    'payment',
+    'refund'
+);
+
+-- The bank account a centre is paid into, registered with the payout provider
+CREATE TABLE IF NOT EXISTS centre_payout_accounts (
+    diagnostic_centre_id UUID PRIMARY KEY REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    bank_code VARCHAR(20) NOT NULL,
+    account_number VARCHAR(20) NOT NULL,
+    account_name VARCHAR(255) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    recipient_code VARCHAR(255) NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS settlements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE RESTRICT,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    commission_rate NUMERIC(5,2) NOT NULL,
+    opening_balance NUMERIC(12,2) NOT NULL DEFAULT 0,
+    gross_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    refunded_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    commission_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    net_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    payment_count INTEGER NOT NULL DEFAULT 0,
+    refund_count INTEGER NOT NULL DEFAULT 0,
+    status settlement_status NOT NULL DEFAULT 'pending',
+    -- A transfer that failed is retried under a new reference
+    payout_reference VARCHAR(64),
+    transfer_code VARCHAR(255),
+    payout_attempts INTEGER NOT NULL DEFAULT 0,
+    failure_reason TEXT,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_settlement_period UNIQUE (diagnostic_centre_id, currency, period_end),
+    CONSTRAINT check_settlement_period CHECK (period_end > period_start),
+    CONSTRAINT check_settlement_commission_rate CHECK (commission_rate >= 0 AND commission_rate < 100),
+    CONSTRAINT check_settlement_paid CHECK ((status = 'paid') = (paid_at IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_settlements_payout_reference
+    ON settlements(payout_reference)
+    WHERE payout_reference IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_settlements_centre ON settlements(diagnostic_centre_id, period_end DESC);
+CREATE INDEX IF NOT EXISTS idx_settlements_payable ON settlements(period_end)
+    WHERE status IN ('pending', 'failed');
+
+-- Refund entries are negative and give back the commission taken on what they refund
+CREATE TABLE IF NOT EXISTS settlement_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    settlement_id UUID NOT NULL REFERENCES settlements(id) ON DELETE CASCADE,
+    kind settlement_entry_kind NOT NULL,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE RESTRICT,
+    refund_id UUID REFERENCES payment_refunds(id) ON DELETE RESTRICT,
+    amount NUMERIC(12,2) NOT NULL,
+    commission_amount NUMERIC(12,2) NOT NULL,
+    net_amount NUMERIC(12,2) GENERATED ALWAYS AS (amount - commission_amount) STORED,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_settlement_entry_refund CHECK ((kind = 'refund') = (refund_id IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_settlement_entries_payment
+    ON settlement_entries(payment_id)
+    WHERE kind = 'payment';
+CREATE UNIQUE INDEX IF NOT EXISTS uq_settlement_entries_refund
+    ON settlement_entries(refund_id)
+    WHERE refund_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_settlement_entries_settlement ON settlement_entries(settlement_id, occurred_at);
+
+-- Finds captured payments and processed refunds no statement has settled yet
+CREATE INDEX IF NOT EXISTS idx_payments_centre_paid ON payments(diagnostic_centre_id, payment_date)
+    WHERE payment_status IN ('success', 'refunded');
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_processed ON payment_refunds(processed_at)
+    WHERE status = 'processed';
+
+DROP TRIGGER IF EXISTS update_centre_payout_account_timestamp ON centre_payout_accounts;
+CREATE TRIGGER update_centre_payout_account_timestamp
+    BEFORE UPDATE ON centre_payout_accounts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_settlement_timestamp ON settlements;
+CREATE TRIGGER update_settlement_timestamp
+    BEFORE UPDATE ON settlements
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
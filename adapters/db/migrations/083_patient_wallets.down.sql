@@ -0,0 +1,9 @@
+ALTER TABLE payment_refunds DROP COLUMN IF EXISTS destination;
+DROP TYPE IF EXISTS refund_destination;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS check_payment_wallet_amount;
+ALTER TABLE payments DROP COLUMN IF EXISTS wallet_amount;
+DROP TRIGGER IF EXISTS update_wallet_timestamp ON wallets;
+DROP TABLE IF EXISTS wallet_transactions;
+DROP TABLE IF EXISTS wallets;
+DROP TYPE IF EXISTS wallet_transaction_kind;
+DROP TYPE IF EXISTS wallet_transaction_direction;
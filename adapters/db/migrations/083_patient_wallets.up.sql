@@ -0,0 +1,74 @@
+-- Patient wallets hold credit the platform owes a patient, one wallet per
+-- currency. Every movement is a wallet transaction: a credit or debit between
+-- the wallet and the account its kind names (a refund paid into the wallet, or
+-- a booking payment taken from it and given back when the booking lapses).
+-- wallets.balance is kept equal to the sum of its transactions and each
+-- transaction records the balance it left, so the ledger can be audited row by row.
+CREATE TYPE wallet_transaction_direction AS ENUM (
+    'credit',
+    'debit'
+);
+
+CREATE TYPE wallet_transaction_kind AS ENUM (
+    'refund',
+    'payment',
+    'payment_reversal'
+);
+
+CREATE TABLE IF NOT EXISTS wallets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    currency VARCHAR(3) NOT NULL,
+    balance NUMERIC(12,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_wallet_currency UNIQUE (user_id, currency),
+    CONSTRAINT check_wallet_balance CHECK (balance >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS wallet_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    wallet_id UUID NOT NULL REFERENCES wallets(id) ON DELETE RESTRICT,
+    direction wallet_transaction_direction NOT NULL,
+    kind wallet_transaction_kind NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    balance_after NUMERIC(12,2) NOT NULL,
+    payment_id UUID REFERENCES payments(id) ON DELETE RESTRICT,
+    refund_id UUID REFERENCES payment_refunds(id) ON DELETE RESTRICT,
+    description TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_wallet_transaction_amount CHECK (amount > 0),
+    CONSTRAINT check_wallet_transaction_balance CHECK (balance_after >= 0),
+    CONSTRAINT check_wallet_transaction_direction CHECK ((kind = 'payment') = (direction = 'debit')),
+    CONSTRAINT check_wallet_transaction_refund CHECK ((kind = 'refund') = (refund_id IS NOT NULL))
+);
+
+-- A refund is credited once, and a booking payment is taken and given back at most once
+CREATE UNIQUE INDEX IF NOT EXISTS uq_wallet_transactions_refund
+    ON wallet_transactions(refund_id)
+    WHERE kind = 'refund';
+CREATE UNIQUE INDEX IF NOT EXISTS uq_wallet_transactions_payment
+    ON wallet_transactions(payment_id, kind)
+    WHERE kind IN ('payment', 'payment_reversal');
+CREATE INDEX IF NOT EXISTS idx_wallet_transactions_wallet ON wallet_transactions(wallet_id, created_at DESC);
+
+DROP TRIGGER IF EXISTS update_wallet_timestamp ON wallets;
+CREATE TRIGGER update_wallet_timestamp
+    BEFORE UPDATE ON wallets
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- The part of a payment taken from the patient's wallet; the provider is only
+-- charged the rest
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS wallet_amount NUMERIC(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD CONSTRAINT check_payment_wallet_amount
+    CHECK (wallet_amount >= 0 AND wallet_amount <= amount);
+
+-- Refunds go back through the payment provider or straight into the patient's wallet
+CREATE TYPE refund_destination AS ENUM (
+    'provider',
+    'wallet'
+);
+
+ALTER TABLE payment_refunds ADD COLUMN IF NOT EXISTS destination refund_destination NOT NULL DEFAULT 'provider';
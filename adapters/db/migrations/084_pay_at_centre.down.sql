@@ -0,0 +1,8 @@
+-- refund_destination keeps 'centre': enum values cannot be dropped
+DROP INDEX IF EXISTS idx_payments_centre_hold;
+DROP INDEX IF EXISTS uq_payments_centre_receipt;
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS received_at,
+    DROP COLUMN IF EXISTS received_by,
+    DROP COLUMN IF EXISTS receipt_number,
+    DROP COLUMN IF EXISTS hold_expires_at;
@@ -0,0 +1,21 @@
+-- Payments collected at the centre's counter, in cash or by bank transfer, are
+-- never sent to a payment provider. The booking holds its slot until
+-- hold_expires_at and a centre manager records the payment with the receipt
+-- the centre issued for it. The centre already holds that money, so these
+-- payments and their refunds stay out of its settlements.
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS hold_expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS receipt_number VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS received_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS received_at TIMESTAMP WITH TIME ZONE;
+
+-- A receipt is recorded against one payment of its centre
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payments_centre_receipt
+    ON payments(diagnostic_centre_id, receipt_number)
+    WHERE receipt_number IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_centre_hold
+    ON payments(hold_expires_at)
+    WHERE payment_status = 'pending' AND payment_method IN ('cash', 'transfer');
+
+-- The centre hands back refunds of money it collected itself
+ALTER TYPE refund_destination ADD VALUE IF NOT EXISTS 'centre';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payment_invoices;
+DROP TABLE IF EXISTS invoice_sequences;
@@ -0,0 +1,25 @@
+-- Each successful payment gets one invoice, numbered in sequence per centre.
+-- The number is taken from invoice_sequences inside the transaction that
+-- issues the invoice, so numbers are handed out without gaps. The document
+-- itself is rendered when it is downloaded or emailed; only what must not
+-- change afterwards (its number, issue date and tax rate) is stored.
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    diagnostic_centre_id UUID PRIMARY KEY REFERENCES diagnostic_centres(id) ON DELETE CASCADE,
+    last_number BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS payment_invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE RESTRICT,
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE RESTRICT,
+    sequence_number BIGINT NOT NULL,
+    invoice_number VARCHAR(32) NOT NULL,
+    -- Percentage of tax included in the amounts when the invoice was issued
+    tax_rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Set once the invoice went out with the payment confirmation email
+    emailed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT unique_payment_invoice UNIQUE (payment_id),
+    CONSTRAINT unique_centre_invoice_sequence UNIQUE (diagnostic_centre_id, sequence_number),
+    CONSTRAINT check_invoice_tax_rate CHECK (tax_rate >= 0 AND tax_rate < 100)
+);
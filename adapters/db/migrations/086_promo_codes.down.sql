@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_promo_code_timestamp ON promo_codes;
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_codes;
+DROP TYPE IF EXISTS promo_discount_type;
@@ -0,0 +1,78 @@
+-- Promo codes take a percentage or a fixed amount off the tests of a booking.
+-- A code can be limited to some centres and test types (an empty list allows
+-- any), to a validity window, to a patient's first booking, and in how often it
+-- is redeemed overall and by each patient. A redemption is stored with the
+-- payment it discounted, in the same transaction, and is released if that
+-- payment never completes. redemption_count is kept equal to the code's
+-- redemptions that were not released, so the overall limit is checked and
+-- taken in one conditional update.
+CREATE TYPE promo_discount_type AS ENUM (
+    'percentage',
+    'fixed'
+);
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- Stored in upper case; codes are matched case-insensitively
+    code VARCHAR(32) NOT NULL,
+    description TEXT,
+    discount_type promo_discount_type NOT NULL,
+    -- A percentage, or an amount in currency
+    discount_value NUMERIC(12,2) NOT NULL,
+    -- Caps a percentage discount
+    max_discount NUMERIC(12,2),
+    -- The currency of a fixed discount or cap; bookings in other currencies do not qualify
+    currency VARCHAR(3),
+    diagnostic_centre_ids UUID[] NOT NULL DEFAULT '{}',
+    test_types TEXT[] NOT NULL DEFAULT '{}',
+    first_booking_only BOOLEAN NOT NULL DEFAULT false,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    max_redemptions INTEGER,
+    max_redemptions_per_user INTEGER NOT NULL DEFAULT 1,
+    redemption_count INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_promo_code UNIQUE (code),
+    CONSTRAINT check_promo_discount_value CHECK (
+        discount_value > 0 AND (discount_type = 'fixed' OR discount_value <= 100)
+    ),
+    CONSTRAINT check_promo_currency CHECK (
+        currency IS NOT NULL OR (discount_type = 'percentage' AND max_discount IS NULL)
+    ),
+    CONSTRAINT check_promo_max_discount CHECK (max_discount IS NULL OR max_discount > 0),
+    CONSTRAINT check_promo_window CHECK (ends_at IS NULL OR ends_at > starts_at),
+    CONSTRAINT check_promo_max_redemptions CHECK (max_redemptions IS NULL OR max_redemptions > 0),
+    CONSTRAINT check_promo_max_redemptions_per_user CHECK (max_redemptions_per_user > 0),
+    CONSTRAINT check_promo_redemption_count CHECK (
+        redemption_count >= 0 AND (max_redemptions IS NULL OR redemption_count <= max_redemptions)
+    )
+);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE RESTRICT,
+    -- The code as it was redeemed, for receipts
+    code VARCHAR(32) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE RESTRICT,
+    discount_amount NUMERIC(12,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Set when the payment failed or was cancelled and the use was given back
+    released_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT unique_payment_promo_redemption UNIQUE (payment_id),
+    CONSTRAINT check_promo_redemption_discount CHECK (discount_amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_user
+    ON promo_redemptions(promo_code_id, user_id)
+    WHERE released_at IS NULL;
+
+DROP TRIGGER IF EXISTS update_promo_code_timestamp ON promo_codes;
+CREATE TRIGGER update_promo_code_timestamp
+    BEFORE UPDATE ON promo_codes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS update_insurance_claim_timestamp ON insurance_claims;
+DROP TRIGGER IF EXISTS update_patient_policy_timestamp ON patient_policies;
+DROP TRIGGER IF EXISTS update_coverage_rule_timestamp ON insurance_coverage_rules;
+DROP TRIGGER IF EXISTS update_insurance_plan_timestamp ON insurance_plans;
+DROP TRIGGER IF EXISTS update_insurer_timestamp ON insurers;
+DROP TABLE IF EXISTS appointment_coverages;
+DROP TABLE IF EXISTS insurance_claims;
+DROP SEQUENCE IF EXISTS insurance_claim_number_seq;
+DROP TABLE IF EXISTS patient_policies;
+DROP TABLE IF EXISTS insurance_coverage_rules;
+DROP TABLE IF EXISTS insurance_plans;
+DROP TABLE IF EXISTS insurers;
+DROP TYPE IF EXISTS insurance_claim_status;
@@ -0,0 +1,166 @@
+-- HMO cover splits a booking between the patient and their insurer. Insurers offer plans,
+-- and a plan's coverage rules say, per test, what share it pays, the copay the patient
+-- keeps paying and whether the insurer must authorize the test first. A patient enrols
+-- a policy on a plan; a booking made under it records the split as an appointment
+-- coverage, so only the patient's share goes through payments. Centres then batch the
+-- coverages of completed appointments into claims on each insurer and follow every
+-- claim through to settlement.
+CREATE TYPE insurance_claim_status AS ENUM (
+    'draft',
+    'submitted',
+    'approved',
+    'rejected',
+    'settled'
+);
+
+CREATE TABLE IF NOT EXISTS insurers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    -- Short upper-case code used in claim numbers
+    code VARCHAR(20) NOT NULL,
+    email VARCHAR(255),
+    phone VARCHAR(50),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_insurer_code UNIQUE (code)
+);
+
+CREATE TABLE IF NOT EXISTS insurance_plans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    insurer_id UUID NOT NULL REFERENCES insurers(id) ON DELETE RESTRICT,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(32) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'NGN',
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_insurance_plan_code UNIQUE (insurer_id, code)
+);
+
+-- A test without a rule is not covered by the plan
+CREATE TABLE IF NOT EXISTS insurance_coverage_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    plan_id UUID NOT NULL REFERENCES insurance_plans(id) ON DELETE CASCADE,
+    test_type TEXT NOT NULL,
+    -- The share of the price after the copay that the insurer pays
+    covered_percent NUMERIC(5,2) NOT NULL,
+    copay NUMERIC(12,2) NOT NULL DEFAULT 0,
+    requires_preauthorization BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_coverage_rule_test UNIQUE (plan_id, test_type),
+    CONSTRAINT check_coverage_rule_percent CHECK (covered_percent > 0 AND covered_percent <= 100),
+    CONSTRAINT check_coverage_rule_copay CHECK (copay >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS patient_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    patient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    plan_id UUID NOT NULL REFERENCES insurance_plans(id) ON DELETE RESTRICT,
+    -- The patient's enrollee number with the insurer, quoted on claims
+    member_number VARCHAR(64) NOT NULL,
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_patient_policy_validity CHECK (valid_until IS NULL OR valid_until > valid_from)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_patient_policies_member
+    ON patient_policies(plan_id, member_number)
+    WHERE active;
+CREATE INDEX IF NOT EXISTS idx_patient_policies_patient ON patient_policies(patient_id);
+
+-- Claim numbers run across all centres and insurers
+CREATE SEQUENCE IF NOT EXISTS insurance_claim_number_seq;
+
+CREATE TABLE IF NOT EXISTS insurance_claims (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    claim_number VARCHAR(40) NOT NULL,
+    insurer_id UUID NOT NULL REFERENCES insurers(id) ON DELETE RESTRICT,
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE RESTRICT,
+    currency VARCHAR(3) NOT NULL,
+    status insurance_claim_status NOT NULL DEFAULT 'draft',
+    claimed_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    -- What the insurer agreed to pay, which may be less than was claimed
+    approved_amount NUMERIC(12,2),
+    coverage_count INTEGER NOT NULL DEFAULT 0,
+    insurer_reference VARCHAR(255),
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    settled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_insurance_claim_number UNIQUE (claim_number),
+    CONSTRAINT check_insurance_claim_amounts CHECK (
+        claimed_amount >= 0 AND (approved_amount IS NULL OR (approved_amount >= 0 AND approved_amount <= claimed_amount))
+    ),
+    CONSTRAINT check_insurance_claim_settled CHECK ((status = 'settled') = (settled_at IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_insurance_claims_centre ON insurance_claims(diagnostic_centre_id, created_at DESC);
+
+-- The split of one booking between patient and insurer. Lines keep the price and rule of
+-- each test as they were when it was booked.
+CREATE TABLE IF NOT EXISTS appointment_coverages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    policy_id UUID NOT NULL REFERENCES patient_policies(id) ON DELETE RESTRICT,
+    insurer_id UUID NOT NULL REFERENCES insurers(id) ON DELETE RESTRICT,
+    plan_id UUID NOT NULL REFERENCES insurance_plans(id) ON DELETE RESTRICT,
+    patient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    diagnostic_centre_id UUID NOT NULL REFERENCES diagnostic_centres(id) ON DELETE RESTRICT,
+    member_number VARCHAR(64) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    total_amount NUMERIC(12,2) NOT NULL,
+    insurer_amount NUMERIC(12,2) NOT NULL,
+    patient_amount NUMERIC(12,2) NOT NULL,
+    preauthorization_code VARCHAR(64),
+    lines JSONB NOT NULL,
+    claim_id UUID REFERENCES insurance_claims(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_appointment_coverage UNIQUE (appointment_id),
+    CONSTRAINT check_appointment_coverage_amounts CHECK (
+        insurer_amount > 0 AND patient_amount >= 0 AND insurer_amount + patient_amount = total_amount
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_coverages_unclaimed
+    ON appointment_coverages(diagnostic_centre_id, insurer_id)
+    WHERE claim_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_appointment_coverages_claim ON appointment_coverages(claim_id);
+
+DROP TRIGGER IF EXISTS update_insurer_timestamp ON insurers;
+CREATE TRIGGER update_insurer_timestamp
+    BEFORE UPDATE ON insurers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_insurance_plan_timestamp ON insurance_plans;
+CREATE TRIGGER update_insurance_plan_timestamp
+    BEFORE UPDATE ON insurance_plans
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_coverage_rule_timestamp ON insurance_coverage_rules;
+CREATE TRIGGER update_coverage_rule_timestamp
+    BEFORE UPDATE ON insurance_coverage_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_patient_policy_timestamp ON patient_policies;
+CREATE TRIGGER update_patient_policy_timestamp
+    BEFORE UPDATE ON patient_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_insurance_claim_timestamp ON insurance_claims;
+CREATE TRIGGER update_insurance_claim_timestamp
+    BEFORE UPDATE ON insurance_claims
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
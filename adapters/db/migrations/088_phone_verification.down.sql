@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS phone_verification_tokens;
+DROP INDEX IF EXISTS unique_users_verified_phone;
+ALTER TABLE users DROP COLUMN IF EXISTS phone_verified_at;
@@ -0,0 +1,38 @@
+-- Phone-only accounts must prove they own their number before they can sign in. A
+-- verification sends a one-time code by SMS; only a keyed hash of the code is stored,
+-- and each code expires, allows a few attempts and is consumed once used. A number
+-- can belong to only one verified account.
+ALTER TABLE users ADD COLUMN phone_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Phone-only accounts already signing in keep doing so: each number's oldest such account
+-- counts as verified. Later accounts sharing its number stay unverified, as only one
+-- account can hold a verified number.
+UPDATE users
+SET phone_verified_at = created_at
+WHERE id IN (
+    SELECT DISTINCT ON (phone_number) id
+    FROM users
+    WHERE email IS NULL
+        AND phone_number IS NOT NULL
+    ORDER BY phone_number, created_at, id
+);
+
+CREATE UNIQUE INDEX unique_users_verified_phone
+    ON users(phone_number)
+    WHERE phone_verified_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS phone_verification_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phone_number VARCHAR(20) NOT NULL,
+    -- HMAC-SHA256 of the code, hex encoded
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Latest code per number, and how many were sent recently
+CREATE INDEX idx_phone_verification_tokens_phone
+    ON phone_verification_tokens(phone_number, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_email_outbox_timestamp ON email_outbox;
+DROP TABLE IF EXISTS email_outbox;
+DROP TYPE IF EXISTS email_outbox_status;
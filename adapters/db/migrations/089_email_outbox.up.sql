@@ -0,0 +1,44 @@
+-- Outbound email goes through an outbox so a message survives SMTP outages and restarts.
+-- A message is rendered and queued, in the same transaction as the change it reports
+-- where there is one, and workers deliver it, retrying with exponential backoff. A
+-- message that keeps failing is dead-lettered until an admin replays it.
+CREATE TYPE email_outbox_status AS ENUM (
+    'pending',
+    'sending',
+    'sent',
+    'dead'
+);
+
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    template VARCHAR(100) NOT NULL,
+    html_body TEXT NOT NULL,
+    -- [{"Filename": ..., "ContentType": ..., "Content": <base64>}]
+    attachments JSONB NOT NULL DEFAULT '[]',
+    status email_outbox_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 8,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- A message still sending once its lock lapses was lost by its worker and is
+    -- claimed again
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Messages waiting for delivery, oldest due first
+CREATE INDEX idx_email_outbox_due
+    ON email_outbox(next_attempt_at)
+    WHERE status IN ('pending', 'sending');
+
+CREATE INDEX idx_email_outbox_status ON email_outbox(status, created_at DESC);
+
+DROP TRIGGER IF EXISTS update_email_outbox_timestamp ON email_outbox;
+CREATE TRIGGER update_email_outbox_timestamp
+    BEFORE UPDATE ON email_outbox
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notification_deliveries;
+DROP TRIGGER IF EXISTS update_notification_quiet_hours_timestamp ON notification_quiet_hours;
+DROP TABLE IF EXISTS notification_quiet_hours;
+DROP TRIGGER IF EXISTS update_notification_preferences_timestamp ON notification_preferences;
+DROP TABLE IF EXISTS notification_preferences;
+-- Values cannot be dropped from notification_type; the added ones stay
@@ -0,0 +1,58 @@
+-- Notification types that were only ever pushed over WebSocket and are now stored too
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'LAB_RESULT_READY';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'AI_ANALYSIS_COMPLETE';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'SYSTEM_NOTIFICATION';
+
+-- The channels a user wants one type of notification on. Types without a row go out on the
+-- default channels.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type notification_type NOT NULL,
+    email BOOLEAN NOT NULL,
+    sms BOOLEAN NOT NULL,
+    in_app BOOLEAN NOT NULL,
+    websocket BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, type)
+);
+
+DROP TRIGGER IF EXISTS update_notification_preferences_timestamp ON notification_preferences;
+CREATE TRIGGER update_notification_preferences_timestamp
+    BEFORE UPDATE ON notification_preferences
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Hours, in the user's time zone, when nothing is sent by SMS or pushed over WebSocket. A
+-- window that ends before it starts runs past midnight.
+CREATE TABLE IF NOT EXISTS notification_quiet_hours (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIME NOT NULL,
+    ends_at TIME NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'Africa/Lagos',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT notification_quiet_hours_window CHECK (starts_at <> ends_at)
+);
+
+DROP TRIGGER IF EXISTS update_notification_quiet_hours_timestamp ON notification_quiet_hours;
+CREATE TRIGGER update_notification_quiet_hours_timestamp
+    BEFORE UPDATE ON notification_quiet_hours
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Every notification dispatched, with the channels it went out on, those it was held back
+-- from and those that failed
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type notification_type NOT NULL,
+    notification_id UUID NULL REFERENCES notifications(id) ON DELETE SET NULL,
+    delivered TEXT[] NOT NULL DEFAULT '{}',
+    skipped TEXT[] NOT NULL DEFAULT '{}',
+    failed TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_user
+    ON notification_deliveries(user_id, created_at DESC);
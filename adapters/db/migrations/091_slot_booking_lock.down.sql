@@ -0,0 +1,70 @@
+-- Restore the unlocked capacity check from 072
+CREATE OR REPLACE FUNCTION validate_appointment_time_slot() 
+RETURNS TRIGGER AS $$
+DECLARE
+    slot_capacity INTEGER;
+    booked_count INTEGER;
+    closure diagnostic_centre_availability_exceptions%ROWTYPE;
+BEGIN
+    -- Status transitions on an existing booking don't change the slot
+    IF TG_OP = 'UPDATE'
+        AND NEW.appointment_date = OLD.appointment_date
+        AND NEW.time_slot = OLD.time_slot THEN
+        RETURN NEW;
+    END IF;
+
+    -- Make sure appointment isn't in the past
+    IF NEW.appointment_date < CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'Cannot create appointments in the past';
+    END IF;
+
+    -- Validate time slot format (HH:MM-HH:MM)
+    IF NOT NEW.time_slot ~ '^([0-1][0-9]|2[0-3]):[0-5][0-9]-([0-1][0-9]|2[0-3]):[0-5][0-9]$' THEN
+        RAISE EXCEPTION 'Invalid time slot format. Must be HH:MM-HH:MM';
+    END IF;
+
+    -- Check if the schedule exists and is ACCEPTED
+    IF NOT EXISTS (
+        SELECT 1 FROM diagnostic_schedules 
+        WHERE id = NEW.schedule_id 
+        AND diagnostic_centre_id = NEW.diagnostic_centre_id
+        AND acceptance_status = 'ACCEPTED'
+    ) THEN
+        RAISE EXCEPTION 'Invalid or unconfirmed schedule';
+    END IF;
+
+    SELECT * INTO closure
+    FROM diagnostic_centre_availability_exceptions e
+    WHERE e.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND e.exception_date = (NEW.appointment_date AT TIME ZONE 'UTC')::date;
+
+    IF FOUND AND closure.is_closed THEN
+        RAISE EXCEPTION 'Diagnostic centre is closed on this day';
+    END IF;
+
+    -- Capacity of the slot comes from the exception or the availability of that weekday
+    SELECT COALESCE(closure.max_appointments, NULLIF(a.max_appointments, 0), 1) INTO slot_capacity
+    FROM diagnostic_centre_availability a
+    WHERE a.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND a.day_of_week = TRIM(LOWER(TO_CHAR(NEW.appointment_date AT TIME ZONE 'UTC', 'day')));
+
+    IF slot_capacity IS NULL THEN
+        slot_capacity := COALESCE(closure.max_appointments, 1);
+    END IF;
+
+    -- Check for overbooking in the same time slot
+    SELECT COUNT(*) INTO booked_count
+    FROM appointments
+    WHERE diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND appointment_date = NEW.appointment_date
+    AND time_slot = NEW.time_slot
+    AND status NOT IN ('cancelled', 'rescheduled')
+    AND id != NEW.id;
+
+    IF booked_count >= slot_capacity THEN
+        RAISE EXCEPTION 'Time slot already booked';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
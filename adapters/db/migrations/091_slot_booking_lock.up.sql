@@ -0,0 +1,78 @@
+-- Serialize bookings of the same slot so two concurrent bookings cannot both take
+-- its last seat, and count the same statuses as the slot engine.
+CREATE OR REPLACE FUNCTION validate_appointment_time_slot() 
+RETURNS TRIGGER AS $$
+DECLARE
+    slot_capacity INTEGER;
+    booked_count INTEGER;
+    closure diagnostic_centre_availability_exceptions%ROWTYPE;
+BEGIN
+    -- Status transitions on an existing booking don't change the slot
+    IF TG_OP = 'UPDATE'
+        AND NEW.appointment_date = OLD.appointment_date
+        AND NEW.time_slot = OLD.time_slot THEN
+        RETURN NEW;
+    END IF;
+
+    -- Make sure appointment isn't in the past
+    IF NEW.appointment_date < CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'Cannot create appointments in the past';
+    END IF;
+
+    -- Validate time slot format (HH:MM-HH:MM)
+    IF NOT NEW.time_slot ~ '^([0-1][0-9]|2[0-3]):[0-5][0-9]-([0-1][0-9]|2[0-3]):[0-5][0-9]$' THEN
+        RAISE EXCEPTION 'Invalid time slot format. Must be HH:MM-HH:MM';
+    END IF;
+
+    -- Check if the schedule exists and is ACCEPTED
+    IF NOT EXISTS (
+        SELECT 1 FROM diagnostic_schedules 
+        WHERE id = NEW.schedule_id 
+        AND diagnostic_centre_id = NEW.diagnostic_centre_id
+        AND acceptance_status = 'ACCEPTED'
+    ) THEN
+        RAISE EXCEPTION 'Invalid or unconfirmed schedule';
+    END IF;
+
+    SELECT * INTO closure
+    FROM diagnostic_centre_availability_exceptions e
+    WHERE e.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND e.exception_date = (NEW.appointment_date AT TIME ZONE 'UTC')::date;
+
+    IF FOUND AND closure.is_closed THEN
+        RAISE EXCEPTION 'Diagnostic centre is closed on this day';
+    END IF;
+
+    -- Capacity of the slot comes from the exception or the availability of that weekday
+    SELECT COALESCE(closure.max_appointments, NULLIF(a.max_appointments, 0), 1) INTO slot_capacity
+    FROM diagnostic_centre_availability a
+    WHERE a.diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND a.day_of_week = TRIM(LOWER(TO_CHAR(NEW.appointment_date AT TIME ZONE 'UTC', 'day')));
+
+    IF slot_capacity IS NULL THEN
+        slot_capacity := COALESCE(closure.max_appointments, 1);
+    END IF;
+
+    -- Concurrent bookings of the same slot queue here until the first commits, so the
+    -- count below sees every booking that could take the last seat
+    PERFORM pg_advisory_xact_lock(hashtext(
+        NEW.diagnostic_centre_id::text || NEW.appointment_date::text || NEW.time_slot
+    ));
+
+    -- Check for overbooking in the same time slot. The statuses match
+    -- activeBookingStatuses in the slot engine, so a slot it lists as free is bookable.
+    SELECT COUNT(*) INTO booked_count
+    FROM appointments
+    WHERE diagnostic_centre_id = NEW.diagnostic_centre_id
+    AND appointment_date = NEW.appointment_date
+    AND time_slot = NEW.time_slot
+    AND status IN ('pending', 'confirmed', 'checked_in', 'in_progress', 'completed')
+    AND id != NEW.id;
+
+    IF booked_count >= slot_capacity THEN
+        RAISE EXCEPTION 'Time slot already booked';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
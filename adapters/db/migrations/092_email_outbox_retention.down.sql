@@ -0,0 +1,3 @@
+UPDATE email_outbox SET html_body = '' WHERE html_body IS NULL;
+ALTER TABLE email_outbox DROP COLUMN IF EXISTS sensitive;
+ALTER TABLE email_outbox ALTER COLUMN html_body SET NOT NULL;
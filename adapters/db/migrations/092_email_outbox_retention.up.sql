@@ -0,0 +1,14 @@
+-- A rendered email is kept only while it may still have to be sent. Delivered messages
+-- lose their body, and sensitive ones, carrying passwords or account tokens, lose it when
+-- dead-lettered too, as they are never replayed.
+ALTER TABLE email_outbox ALTER COLUMN html_body DROP NOT NULL;
+ALTER TABLE email_outbox ADD COLUMN sensitive BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE email_outbox
+SET sensitive = TRUE
+WHERE template IN ('password_reset', 'email_verification', 'dc_manager_notification');
+
+UPDATE email_outbox
+SET html_body = NULL
+WHERE status = 'sent'
+   OR (status = 'dead' AND sensitive);
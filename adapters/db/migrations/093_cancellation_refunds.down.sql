@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS appointment_refunds;
+DROP INDEX IF EXISTS idx_payment_refunds_unsent;
+ALTER TABLE payment_refunds DROP COLUMN IF EXISTS sent_at;
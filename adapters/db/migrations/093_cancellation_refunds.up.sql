@@ -0,0 +1,17 @@
+-- A provider refund is opened before it is sent, so one whose request never reached the
+-- provider can be told apart and sent again. Refunds recorded so far were all sent.
+ALTER TABLE payment_refunds ADD COLUMN IF NOT EXISTS sent_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE payment_refunds
+SET sent_at = created_at
+WHERE destination = 'provider';
+
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_unsent ON payment_refunds(created_at)
+    WHERE status = 'pending' AND sent_at IS NULL;
+
+-- The appointments a cancellation refund covers, marked refunded once it is processed
+CREATE TABLE IF NOT EXISTS appointment_refunds (
+    refund_id UUID NOT NULL REFERENCES payment_refunds(id) ON DELETE CASCADE,
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    PRIMARY KEY (refund_id, appointment_id)
+);
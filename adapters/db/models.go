@@ -16,8 +16,10 @@ type AppointmentStatus string
 const (
 	AppointmentStatusPending     AppointmentStatus = "pending"
 	AppointmentStatusConfirmed   AppointmentStatus = "confirmed"
+	AppointmentStatusCheckedIn   AppointmentStatus = "checked_in"
 	AppointmentStatusInProgress  AppointmentStatus = "in_progress"
 	AppointmentStatusCompleted   AppointmentStatus = "completed"
+	AppointmentStatusNoShow      AppointmentStatus = "no_show"
 	AppointmentStatusCancelled   AppointmentStatus = "cancelled"
 	AppointmentStatusRescheduled AppointmentStatus = "rescheduled"
 )
@@ -61,8 +63,10 @@ func (e AppointmentStatus) Valid() bool {
 	switch e {
 	case AppointmentStatusPending,
 		AppointmentStatusConfirmed,
+		AppointmentStatusCheckedIn,
 		AppointmentStatusInProgress,
 		AppointmentStatusCompleted,
+		AppointmentStatusNoShow,
 		AppointmentStatusCancelled,
 		AppointmentStatusRescheduled:
 		return true
@@ -74,8 +78,10 @@ func AllAppointmentStatusValues() []AppointmentStatus {
 	return []AppointmentStatus{
 		AppointmentStatusPending,
 		AppointmentStatusConfirmed,
+		AppointmentStatusCheckedIn,
 		AppointmentStatusInProgress,
 		AppointmentStatusCompleted,
+		AppointmentStatusNoShow,
 		AppointmentStatusCancelled,
 		AppointmentStatusRescheduled,
 	}
@@ -405,6 +411,198 @@ func AllDocumentTypeValues() []DocumentType {
 	}
 }
 
+type EmailOutboxStatus string
+
+const (
+	EmailOutboxStatusPending EmailOutboxStatus = "pending"
+	EmailOutboxStatusSending EmailOutboxStatus = "sending"
+	EmailOutboxStatusSent    EmailOutboxStatus = "sent"
+	EmailOutboxStatusDead    EmailOutboxStatus = "dead"
+)
+
+func (e *EmailOutboxStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailOutboxStatus(s)
+	case string:
+		*e = EmailOutboxStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailOutboxStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEmailOutboxStatus struct {
+	EmailOutboxStatus EmailOutboxStatus `json:"email_outbox_status"`
+	Valid             bool              `json:"valid"` // Valid is true if EmailOutboxStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailOutboxStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailOutboxStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailOutboxStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailOutboxStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailOutboxStatus), nil
+}
+
+func (e EmailOutboxStatus) Valid() bool {
+	switch e {
+	case EmailOutboxStatusPending,
+		EmailOutboxStatusSending,
+		EmailOutboxStatusSent,
+		EmailOutboxStatusDead:
+		return true
+	}
+	return false
+}
+
+func AllEmailOutboxStatusValues() []EmailOutboxStatus {
+	return []EmailOutboxStatus{
+		EmailOutboxStatusPending,
+		EmailOutboxStatusSending,
+		EmailOutboxStatusSent,
+		EmailOutboxStatusDead,
+	}
+}
+
+type InsuranceClaimStatus string
+
+const (
+	InsuranceClaimStatusDraft     InsuranceClaimStatus = "draft"
+	InsuranceClaimStatusSubmitted InsuranceClaimStatus = "submitted"
+	InsuranceClaimStatusApproved  InsuranceClaimStatus = "approved"
+	InsuranceClaimStatusRejected  InsuranceClaimStatus = "rejected"
+	InsuranceClaimStatusSettled   InsuranceClaimStatus = "settled"
+)
+
+func (e *InsuranceClaimStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = InsuranceClaimStatus(s)
+	case string:
+		*e = InsuranceClaimStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for InsuranceClaimStatus: %T", src)
+	}
+	return nil
+}
+
+type NullInsuranceClaimStatus struct {
+	InsuranceClaimStatus InsuranceClaimStatus `json:"insurance_claim_status"`
+	Valid                bool                 `json:"valid"` // Valid is true if InsuranceClaimStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullInsuranceClaimStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.InsuranceClaimStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.InsuranceClaimStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullInsuranceClaimStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.InsuranceClaimStatus), nil
+}
+
+func (e InsuranceClaimStatus) Valid() bool {
+	switch e {
+	case InsuranceClaimStatusDraft,
+		InsuranceClaimStatusSubmitted,
+		InsuranceClaimStatusApproved,
+		InsuranceClaimStatusRejected,
+		InsuranceClaimStatusSettled:
+		return true
+	}
+	return false
+}
+
+func AllInsuranceClaimStatusValues() []InsuranceClaimStatus {
+	return []InsuranceClaimStatus{
+		InsuranceClaimStatusDraft,
+		InsuranceClaimStatusSubmitted,
+		InsuranceClaimStatusApproved,
+		InsuranceClaimStatusRejected,
+		InsuranceClaimStatusSettled,
+	}
+}
+
+type LineItemResultStatus string
+
+const (
+	LineItemResultStatusPending    LineItemResultStatus = "pending"
+	LineItemResultStatusProcessing LineItemResultStatus = "processing"
+	LineItemResultStatusReady      LineItemResultStatus = "ready"
+)
+
+func (e *LineItemResultStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LineItemResultStatus(s)
+	case string:
+		*e = LineItemResultStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LineItemResultStatus: %T", src)
+	}
+	return nil
+}
+
+type NullLineItemResultStatus struct {
+	LineItemResultStatus LineItemResultStatus `json:"line_item_result_status"`
+	Valid                bool                 `json:"valid"` // Valid is true if LineItemResultStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLineItemResultStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.LineItemResultStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LineItemResultStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLineItemResultStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LineItemResultStatus), nil
+}
+
+func (e LineItemResultStatus) Valid() bool {
+	switch e {
+	case LineItemResultStatusPending,
+		LineItemResultStatusProcessing,
+		LineItemResultStatusReady:
+		return true
+	}
+	return false
+}
+
+func AllLineItemResultStatusValues() []LineItemResultStatus {
+	return []LineItemResultStatus{
+		LineItemResultStatusPending,
+		LineItemResultStatusProcessing,
+		LineItemResultStatusReady,
+	}
+}
+
 type NotificationType string
 
 const (
@@ -413,6 +611,15 @@ const (
 	NotificationTypeAPPOINTMENTRESCHEDULED NotificationType = "APPOINTMENT_RESCHEDULED"
 	NotificationTypeAPPOINTMENTCANCELLED   NotificationType = "APPOINTMENT_CANCELLED"
 	NotificationTypeAPPOINTMENTREMINDER    NotificationType = "APPOINTMENT_REMINDER"
+	NotificationTypeAPPOINTMENTDISRUPTED   NotificationType = "APPOINTMENT_DISRUPTED"
+	NotificationTypeAPPOINTMENTCHECKEDIN   NotificationType = "APPOINTMENT_CHECKED_IN"
+	NotificationTypeAPPOINTMENTSTARTED     NotificationType = "APPOINTMENT_STARTED"
+	NotificationTypeAPPOINTMENTCOMPLETED   NotificationType = "APPOINTMENT_COMPLETED"
+	NotificationTypeAPPOINTMENTNOSHOW      NotificationType = "APPOINTMENT_NO_SHOW"
+	NotificationTypeWAITLISTSLOTOFFERED    NotificationType = "WAITLIST_SLOT_OFFERED"
+	NotificationTypeLABRESULTREADY         NotificationType = "LAB_RESULT_READY"
+	NotificationTypeAIANALYSISCOMPLETE     NotificationType = "AI_ANALYSIS_COMPLETE"
+	NotificationTypeSYSTEMNOTIFICATION     NotificationType = "SYSTEM_NOTIFICATION"
 )
 
 func (e *NotificationType) Scan(src interface{}) error {
@@ -456,7 +663,16 @@ func (e NotificationType) Valid() bool {
 		NotificationTypeAPPOINTMENTCONFIRMED,
 		NotificationTypeAPPOINTMENTRESCHEDULED,
 		NotificationTypeAPPOINTMENTCANCELLED,
-		NotificationTypeAPPOINTMENTREMINDER:
+		NotificationTypeAPPOINTMENTREMINDER,
+		NotificationTypeAPPOINTMENTDISRUPTED,
+		NotificationTypeAPPOINTMENTCHECKEDIN,
+		NotificationTypeAPPOINTMENTSTARTED,
+		NotificationTypeAPPOINTMENTCOMPLETED,
+		NotificationTypeAPPOINTMENTNOSHOW,
+		NotificationTypeWAITLISTSLOTOFFERED,
+		NotificationTypeLABRESULTREADY,
+		NotificationTypeAIANALYSISCOMPLETE,
+		NotificationTypeSYSTEMNOTIFICATION:
 		return true
 	}
 	return false
@@ -469,6 +685,140 @@ func AllNotificationTypeValues() []NotificationType {
 		NotificationTypeAPPOINTMENTRESCHEDULED,
 		NotificationTypeAPPOINTMENTCANCELLED,
 		NotificationTypeAPPOINTMENTREMINDER,
+		NotificationTypeAPPOINTMENTDISRUPTED,
+		NotificationTypeAPPOINTMENTCHECKEDIN,
+		NotificationTypeAPPOINTMENTSTARTED,
+		NotificationTypeAPPOINTMENTCOMPLETED,
+		NotificationTypeAPPOINTMENTNOSHOW,
+		NotificationTypeWAITLISTSLOTOFFERED,
+		NotificationTypeLABRESULTREADY,
+		NotificationTypeAIANALYSISCOMPLETE,
+		NotificationTypeSYSTEMNOTIFICATION,
+	}
+}
+
+type PaymentDiscrepancyKind string
+
+const (
+	PaymentDiscrepancyKindStatusMismatch   PaymentDiscrepancyKind = "status_mismatch"
+	PaymentDiscrepancyKindAmountMismatch   PaymentDiscrepancyKind = "amount_mismatch"
+	PaymentDiscrepancyKindUnknownReference PaymentDiscrepancyKind = "unknown_reference"
+	PaymentDiscrepancyKindPaidAfterExpiry  PaymentDiscrepancyKind = "paid_after_expiry"
+)
+
+func (e *PaymentDiscrepancyKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentDiscrepancyKind(s)
+	case string:
+		*e = PaymentDiscrepancyKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentDiscrepancyKind: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentDiscrepancyKind struct {
+	PaymentDiscrepancyKind PaymentDiscrepancyKind `json:"payment_discrepancy_kind"`
+	Valid                  bool                   `json:"valid"` // Valid is true if PaymentDiscrepancyKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentDiscrepancyKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentDiscrepancyKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentDiscrepancyKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentDiscrepancyKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentDiscrepancyKind), nil
+}
+
+func (e PaymentDiscrepancyKind) Valid() bool {
+	switch e {
+	case PaymentDiscrepancyKindStatusMismatch,
+		PaymentDiscrepancyKindAmountMismatch,
+		PaymentDiscrepancyKindUnknownReference,
+		PaymentDiscrepancyKindPaidAfterExpiry:
+		return true
+	}
+	return false
+}
+
+func AllPaymentDiscrepancyKindValues() []PaymentDiscrepancyKind {
+	return []PaymentDiscrepancyKind{
+		PaymentDiscrepancyKindStatusMismatch,
+		PaymentDiscrepancyKindAmountMismatch,
+		PaymentDiscrepancyKindUnknownReference,
+		PaymentDiscrepancyKindPaidAfterExpiry,
+	}
+}
+
+type PaymentEventSource string
+
+const (
+	PaymentEventSourceWebhook      PaymentEventSource = "webhook"
+	PaymentEventSourceVerification PaymentEventSource = "verification"
+	PaymentEventSourceInternal     PaymentEventSource = "internal"
+)
+
+func (e *PaymentEventSource) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentEventSource(s)
+	case string:
+		*e = PaymentEventSource(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentEventSource: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentEventSource struct {
+	PaymentEventSource PaymentEventSource `json:"payment_event_source"`
+	Valid              bool               `json:"valid"` // Valid is true if PaymentEventSource is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentEventSource) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentEventSource, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentEventSource.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentEventSource) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentEventSource), nil
+}
+
+func (e PaymentEventSource) Valid() bool {
+	switch e {
+	case PaymentEventSourceWebhook,
+		PaymentEventSourceVerification,
+		PaymentEventSourceInternal:
+		return true
+	}
+	return false
+}
+
+func AllPaymentEventSourceValues() []PaymentEventSource {
+	return []PaymentEventSource{
+		PaymentEventSourceWebhook,
+		PaymentEventSourceVerification,
+		PaymentEventSourceInternal,
 	}
 }
 
@@ -600,6 +950,67 @@ func AllPaymentProviderValues() []PaymentProvider {
 	}
 }
 
+type PaymentRefundStatus string
+
+const (
+	PaymentRefundStatusPending   PaymentRefundStatus = "pending"
+	PaymentRefundStatusProcessed PaymentRefundStatus = "processed"
+	PaymentRefundStatusFailed    PaymentRefundStatus = "failed"
+)
+
+func (e *PaymentRefundStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentRefundStatus(s)
+	case string:
+		*e = PaymentRefundStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentRefundStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentRefundStatus struct {
+	PaymentRefundStatus PaymentRefundStatus `json:"payment_refund_status"`
+	Valid               bool                `json:"valid"` // Valid is true if PaymentRefundStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentRefundStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentRefundStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentRefundStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentRefundStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentRefundStatus), nil
+}
+
+func (e PaymentRefundStatus) Valid() bool {
+	switch e {
+	case PaymentRefundStatusPending,
+		PaymentRefundStatusProcessed,
+		PaymentRefundStatusFailed:
+		return true
+	}
+	return false
+}
+
+func AllPaymentRefundStatusValues() []PaymentRefundStatus {
+	return []PaymentRefundStatus{
+		PaymentRefundStatusPending,
+		PaymentRefundStatusProcessed,
+		PaymentRefundStatusFailed,
+	}
+}
+
 type PaymentStatus string
 
 const (
@@ -622,246 +1033,856 @@ func (e *PaymentStatus) Scan(src interface{}) error {
 	return nil
 }
 
-type NullPaymentStatus struct {
-	PaymentStatus PaymentStatus `json:"payment_status"`
-	Valid         bool          `json:"valid"` // Valid is true if PaymentStatus is not NULL
+type NullPaymentStatus struct {
+	PaymentStatus PaymentStatus `json:"payment_status"`
+	Valid         bool          `json:"valid"` // Valid is true if PaymentStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentStatus), nil
+}
+
+func (e PaymentStatus) Valid() bool {
+	switch e {
+	case PaymentStatusPending,
+		PaymentStatusSuccess,
+		PaymentStatusFailed,
+		PaymentStatusRefunded,
+		PaymentStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func AllPaymentStatusValues() []PaymentStatus {
+	return []PaymentStatus{
+		PaymentStatusPending,
+		PaymentStatusSuccess,
+		PaymentStatusFailed,
+		PaymentStatusRefunded,
+		PaymentStatusCancelled,
+	}
+}
+
+type PromoDiscountType string
+
+const (
+	PromoDiscountTypePercentage PromoDiscountType = "percentage"
+	PromoDiscountTypeFixed      PromoDiscountType = "fixed"
+)
+
+func (e *PromoDiscountType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PromoDiscountType(s)
+	case string:
+		*e = PromoDiscountType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PromoDiscountType: %T", src)
+	}
+	return nil
+}
+
+type NullPromoDiscountType struct {
+	PromoDiscountType PromoDiscountType `json:"promo_discount_type"`
+	Valid             bool              `json:"valid"` // Valid is true if PromoDiscountType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPromoDiscountType) Scan(value interface{}) error {
+	if value == nil {
+		ns.PromoDiscountType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PromoDiscountType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPromoDiscountType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PromoDiscountType), nil
+}
+
+func (e PromoDiscountType) Valid() bool {
+	switch e {
+	case PromoDiscountTypePercentage,
+		PromoDiscountTypeFixed:
+		return true
+	}
+	return false
+}
+
+func AllPromoDiscountTypeValues() []PromoDiscountType {
+	return []PromoDiscountType{
+		PromoDiscountTypePercentage,
+		PromoDiscountTypeFixed,
+	}
+}
+
+type RefundDestination string
+
+const (
+	RefundDestinationProvider RefundDestination = "provider"
+	RefundDestinationWallet   RefundDestination = "wallet"
+	RefundDestinationCentre   RefundDestination = "centre"
+)
+
+func (e *RefundDestination) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RefundDestination(s)
+	case string:
+		*e = RefundDestination(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RefundDestination: %T", src)
+	}
+	return nil
+}
+
+type NullRefundDestination struct {
+	RefundDestination RefundDestination `json:"refund_destination"`
+	Valid             bool              `json:"valid"` // Valid is true if RefundDestination is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRefundDestination) Scan(value interface{}) error {
+	if value == nil {
+		ns.RefundDestination, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RefundDestination.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRefundDestination) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RefundDestination), nil
+}
+
+func (e RefundDestination) Valid() bool {
+	switch e {
+	case RefundDestinationProvider,
+		RefundDestinationWallet,
+		RefundDestinationCentre:
+		return true
+	}
+	return false
+}
+
+func AllRefundDestinationValues() []RefundDestination {
+	return []RefundDestination{
+		RefundDestinationProvider,
+		RefundDestinationWallet,
+		RefundDestinationCentre,
+	}
+}
+
+type ScheduleAcceptanceStatus string
+
+const (
+	ScheduleAcceptanceStatusPENDING  ScheduleAcceptanceStatus = "PENDING"
+	ScheduleAcceptanceStatusACCEPTED ScheduleAcceptanceStatus = "ACCEPTED"
+	ScheduleAcceptanceStatusREJECTED ScheduleAcceptanceStatus = "REJECTED"
+)
+
+func (e *ScheduleAcceptanceStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduleAcceptanceStatus(s)
+	case string:
+		*e = ScheduleAcceptanceStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduleAcceptanceStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduleAcceptanceStatus struct {
+	ScheduleAcceptanceStatus ScheduleAcceptanceStatus `json:"schedule_acceptance_status"`
+	Valid                    bool                     `json:"valid"` // Valid is true if ScheduleAcceptanceStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduleAcceptanceStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduleAcceptanceStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduleAcceptanceStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduleAcceptanceStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduleAcceptanceStatus), nil
+}
+
+func (e ScheduleAcceptanceStatus) Valid() bool {
+	switch e {
+	case ScheduleAcceptanceStatusPENDING,
+		ScheduleAcceptanceStatusACCEPTED,
+		ScheduleAcceptanceStatusREJECTED:
+		return true
+	}
+	return false
+}
+
+func AllScheduleAcceptanceStatusValues() []ScheduleAcceptanceStatus {
+	return []ScheduleAcceptanceStatus{
+		ScheduleAcceptanceStatusPENDING,
+		ScheduleAcceptanceStatusACCEPTED,
+		ScheduleAcceptanceStatusREJECTED,
+	}
+}
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusSCHEDULED ScheduleStatus = "SCHEDULED"
+	ScheduleStatusCOMPLETED ScheduleStatus = "COMPLETED"
+	ScheduleStatusCANCELED  ScheduleStatus = "CANCELED"
+)
+
+func (e *ScheduleStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduleStatus(s)
+	case string:
+		*e = ScheduleStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduleStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduleStatus struct {
+	ScheduleStatus ScheduleStatus `json:"schedule_status"`
+	Valid          bool           `json:"valid"` // Valid is true if ScheduleStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduleStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduleStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduleStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduleStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduleStatus), nil
+}
+
+func (e ScheduleStatus) Valid() bool {
+	switch e {
+	case ScheduleStatusSCHEDULED,
+		ScheduleStatusCOMPLETED,
+		ScheduleStatusCANCELED:
+		return true
+	}
+	return false
+}
+
+func AllScheduleStatusValues() []ScheduleStatus {
+	return []ScheduleStatus{
+		ScheduleStatusSCHEDULED,
+		ScheduleStatusCOMPLETED,
+		ScheduleStatusCANCELED,
+	}
+}
+
+type SeriesPaymentMode string
+
+const (
+	SeriesPaymentModeUpfront  SeriesPaymentMode = "upfront"
+	SeriesPaymentModePerVisit SeriesPaymentMode = "per_visit"
+)
+
+func (e *SeriesPaymentMode) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SeriesPaymentMode(s)
+	case string:
+		*e = SeriesPaymentMode(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SeriesPaymentMode: %T", src)
+	}
+	return nil
+}
+
+type NullSeriesPaymentMode struct {
+	SeriesPaymentMode SeriesPaymentMode `json:"series_payment_mode"`
+	Valid             bool              `json:"valid"` // Valid is true if SeriesPaymentMode is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSeriesPaymentMode) Scan(value interface{}) error {
+	if value == nil {
+		ns.SeriesPaymentMode, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SeriesPaymentMode.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSeriesPaymentMode) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SeriesPaymentMode), nil
+}
+
+func (e SeriesPaymentMode) Valid() bool {
+	switch e {
+	case SeriesPaymentModeUpfront,
+		SeriesPaymentModePerVisit:
+		return true
+	}
+	return false
+}
+
+func AllSeriesPaymentModeValues() []SeriesPaymentMode {
+	return []SeriesPaymentMode{
+		SeriesPaymentModeUpfront,
+		SeriesPaymentModePerVisit,
+	}
+}
+
+type SeriesRecurrence string
+
+const (
+	SeriesRecurrenceWeekly      SeriesRecurrence = "weekly"
+	SeriesRecurrenceEveryNWeeks SeriesRecurrence = "every_n_weeks"
+	SeriesRecurrenceMonthly     SeriesRecurrence = "monthly"
+	SeriesRecurrenceDates       SeriesRecurrence = "dates"
+)
+
+func (e *SeriesRecurrence) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SeriesRecurrence(s)
+	case string:
+		*e = SeriesRecurrence(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SeriesRecurrence: %T", src)
+	}
+	return nil
+}
+
+type NullSeriesRecurrence struct {
+	SeriesRecurrence SeriesRecurrence `json:"series_recurrence"`
+	Valid            bool             `json:"valid"` // Valid is true if SeriesRecurrence is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSeriesRecurrence) Scan(value interface{}) error {
+	if value == nil {
+		ns.SeriesRecurrence, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SeriesRecurrence.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSeriesRecurrence) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SeriesRecurrence), nil
+}
+
+func (e SeriesRecurrence) Valid() bool {
+	switch e {
+	case SeriesRecurrenceWeekly,
+		SeriesRecurrenceEveryNWeeks,
+		SeriesRecurrenceMonthly,
+		SeriesRecurrenceDates:
+		return true
+	}
+	return false
+}
+
+func AllSeriesRecurrenceValues() []SeriesRecurrence {
+	return []SeriesRecurrence{
+		SeriesRecurrenceWeekly,
+		SeriesRecurrenceEveryNWeeks,
+		SeriesRecurrenceMonthly,
+		SeriesRecurrenceDates,
+	}
+}
+
+type SeriesStatus string
+
+const (
+	SeriesStatusActive    SeriesStatus = "active"
+	SeriesStatusCancelled SeriesStatus = "cancelled"
+)
+
+func (e *SeriesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SeriesStatus(s)
+	case string:
+		*e = SeriesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SeriesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullSeriesStatus struct {
+	SeriesStatus SeriesStatus `json:"series_status"`
+	Valid        bool         `json:"valid"` // Valid is true if SeriesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSeriesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.SeriesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SeriesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSeriesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SeriesStatus), nil
+}
+
+func (e SeriesStatus) Valid() bool {
+	switch e {
+	case SeriesStatusActive,
+		SeriesStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func AllSeriesStatusValues() []SeriesStatus {
+	return []SeriesStatus{
+		SeriesStatusActive,
+		SeriesStatusCancelled,
+	}
+}
+
+type SettlementEntryKind string
+
+const (
+	SettlementEntryKindPayment SettlementEntryKind = "payment"
+	SettlementEntryKindRefund  SettlementEntryKind = "refund"
+)
+
+func (e *SettlementEntryKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SettlementEntryKind(s)
+	case string:
+		*e = SettlementEntryKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SettlementEntryKind: %T", src)
+	}
+	return nil
+}
+
+type NullSettlementEntryKind struct {
+	SettlementEntryKind SettlementEntryKind `json:"settlement_entry_kind"`
+	Valid               bool                `json:"valid"` // Valid is true if SettlementEntryKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSettlementEntryKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.SettlementEntryKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SettlementEntryKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSettlementEntryKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SettlementEntryKind), nil
+}
+
+func (e SettlementEntryKind) Valid() bool {
+	switch e {
+	case SettlementEntryKindPayment,
+		SettlementEntryKindRefund:
+		return true
+	}
+	return false
+}
+
+func AllSettlementEntryKindValues() []SettlementEntryKind {
+	return []SettlementEntryKind{
+		SettlementEntryKindPayment,
+		SettlementEntryKindRefund,
+	}
+}
+
+type SettlementStatus string
+
+const (
+	SettlementStatusPending        SettlementStatus = "pending"
+	SettlementStatusProcessing     SettlementStatus = "processing"
+	SettlementStatusPaid           SettlementStatus = "paid"
+	SettlementStatusFailed         SettlementStatus = "failed"
+	SettlementStatusCarriedForward SettlementStatus = "carried_forward"
+)
+
+func (e *SettlementStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SettlementStatus(s)
+	case string:
+		*e = SettlementStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SettlementStatus: %T", src)
+	}
+	return nil
+}
+
+type NullSettlementStatus struct {
+	SettlementStatus SettlementStatus `json:"settlement_status"`
+	Valid            bool             `json:"valid"` // Valid is true if SettlementStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSettlementStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.SettlementStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SettlementStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSettlementStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SettlementStatus), nil
+}
+
+func (e SettlementStatus) Valid() bool {
+	switch e {
+	case SettlementStatusPending,
+		SettlementStatusProcessing,
+		SettlementStatusPaid,
+		SettlementStatusFailed,
+		SettlementStatusCarriedForward:
+		return true
+	}
+	return false
+}
+
+func AllSettlementStatusValues() []SettlementStatus {
+	return []SettlementStatus{
+		SettlementStatusPending,
+		SettlementStatusProcessing,
+		SettlementStatusPaid,
+		SettlementStatusFailed,
+		SettlementStatusCarriedForward,
+	}
+}
+
+type UserEnum string
+
+const (
+	UserEnumUSER                    UserEnum = "USER"
+	UserEnumDIAGNOSTICCENTRE        UserEnum = "DIAGNOSTIC_CENTRE"
+	UserEnumHOSPITAL                UserEnum = "HOSPITAL"
+	UserEnumADMIN                   UserEnum = "ADMIN"
+	UserEnumDIAGNOSTICMANAGER       UserEnum = "DIAGNOSTIC_MANAGER"
+	UserEnumDIAGNOSTICCENTREOWNER   UserEnum = "DIAGNOSTIC_CENTRE_OWNER"
+	UserEnumDIAGNOSTICCENTREMANAGER UserEnum = "DIAGNOSTIC_CENTRE_MANAGER"
+	UserEnumPATIENT                 UserEnum = "PATIENT"
+)
+
+func (e *UserEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserEnum(s)
+	case string:
+		*e = UserEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserEnum: %T", src)
+	}
+	return nil
+}
+
+type NullUserEnum struct {
+	UserEnum UserEnum `json:"user_enum"`
+	Valid    bool     `json:"valid"` // Valid is true if UserEnum is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullPaymentStatus) Scan(value interface{}) error {
+func (ns *NullUserEnum) Scan(value interface{}) error {
 	if value == nil {
-		ns.PaymentStatus, ns.Valid = "", false
+		ns.UserEnum, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.PaymentStatus.Scan(value)
+	return ns.UserEnum.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullPaymentStatus) Value() (driver.Value, error) {
+func (ns NullUserEnum) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.PaymentStatus), nil
+	return string(ns.UserEnum), nil
 }
 
-func (e PaymentStatus) Valid() bool {
+func (e UserEnum) Valid() bool {
 	switch e {
-	case PaymentStatusPending,
-		PaymentStatusSuccess,
-		PaymentStatusFailed,
-		PaymentStatusRefunded,
-		PaymentStatusCancelled:
+	case UserEnumUSER,
+		UserEnumDIAGNOSTICCENTRE,
+		UserEnumHOSPITAL,
+		UserEnumADMIN,
+		UserEnumDIAGNOSTICMANAGER,
+		UserEnumDIAGNOSTICCENTREOWNER,
+		UserEnumDIAGNOSTICCENTREMANAGER,
+		UserEnumPATIENT:
 		return true
 	}
 	return false
 }
 
-func AllPaymentStatusValues() []PaymentStatus {
-	return []PaymentStatus{
-		PaymentStatusPending,
-		PaymentStatusSuccess,
-		PaymentStatusFailed,
-		PaymentStatusRefunded,
-		PaymentStatusCancelled,
+func AllUserEnumValues() []UserEnum {
+	return []UserEnum{
+		UserEnumUSER,
+		UserEnumDIAGNOSTICCENTRE,
+		UserEnumHOSPITAL,
+		UserEnumADMIN,
+		UserEnumDIAGNOSTICMANAGER,
+		UserEnumDIAGNOSTICCENTREOWNER,
+		UserEnumDIAGNOSTICCENTREMANAGER,
+		UserEnumPATIENT,
 	}
 }
 
-type ScheduleAcceptanceStatus string
+type WaitlistStatus string
 
 const (
-	ScheduleAcceptanceStatusPENDING  ScheduleAcceptanceStatus = "PENDING"
-	ScheduleAcceptanceStatusACCEPTED ScheduleAcceptanceStatus = "ACCEPTED"
-	ScheduleAcceptanceStatusREJECTED ScheduleAcceptanceStatus = "REJECTED"
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"
+	WaitlistStatusOffered   WaitlistStatus = "offered"
+	WaitlistStatusBooked    WaitlistStatus = "booked"
+	WaitlistStatusExpired   WaitlistStatus = "expired"
+	WaitlistStatusCancelled WaitlistStatus = "cancelled"
 )
 
-func (e *ScheduleAcceptanceStatus) Scan(src interface{}) error {
+func (e *WaitlistStatus) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = ScheduleAcceptanceStatus(s)
+		*e = WaitlistStatus(s)
 	case string:
-		*e = ScheduleAcceptanceStatus(s)
+		*e = WaitlistStatus(s)
 	default:
-		return fmt.Errorf("unsupported scan type for ScheduleAcceptanceStatus: %T", src)
+		return fmt.Errorf("unsupported scan type for WaitlistStatus: %T", src)
 	}
 	return nil
 }
 
-type NullScheduleAcceptanceStatus struct {
-	ScheduleAcceptanceStatus ScheduleAcceptanceStatus `json:"schedule_acceptance_status"`
-	Valid                    bool                     `json:"valid"` // Valid is true if ScheduleAcceptanceStatus is not NULL
+type NullWaitlistStatus struct {
+	WaitlistStatus WaitlistStatus `json:"waitlist_status"`
+	Valid          bool           `json:"valid"` // Valid is true if WaitlistStatus is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullScheduleAcceptanceStatus) Scan(value interface{}) error {
+func (ns *NullWaitlistStatus) Scan(value interface{}) error {
 	if value == nil {
-		ns.ScheduleAcceptanceStatus, ns.Valid = "", false
+		ns.WaitlistStatus, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.ScheduleAcceptanceStatus.Scan(value)
+	return ns.WaitlistStatus.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullScheduleAcceptanceStatus) Value() (driver.Value, error) {
+func (ns NullWaitlistStatus) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.ScheduleAcceptanceStatus), nil
+	return string(ns.WaitlistStatus), nil
 }
 
-func (e ScheduleAcceptanceStatus) Valid() bool {
+func (e WaitlistStatus) Valid() bool {
 	switch e {
-	case ScheduleAcceptanceStatusPENDING,
-		ScheduleAcceptanceStatusACCEPTED,
-		ScheduleAcceptanceStatusREJECTED:
+	case WaitlistStatusWaiting,
+		WaitlistStatusOffered,
+		WaitlistStatusBooked,
+		WaitlistStatusExpired,
+		WaitlistStatusCancelled:
 		return true
 	}
 	return false
 }
 
-func AllScheduleAcceptanceStatusValues() []ScheduleAcceptanceStatus {
-	return []ScheduleAcceptanceStatus{
-		ScheduleAcceptanceStatusPENDING,
-		ScheduleAcceptanceStatusACCEPTED,
-		ScheduleAcceptanceStatusREJECTED,
+func AllWaitlistStatusValues() []WaitlistStatus {
+	return []WaitlistStatus{
+		WaitlistStatusWaiting,
+		WaitlistStatusOffered,
+		WaitlistStatusBooked,
+		WaitlistStatusExpired,
+		WaitlistStatusCancelled,
 	}
 }
 
-type ScheduleStatus string
+type WalletTransactionDirection string
 
 const (
-	ScheduleStatusSCHEDULED ScheduleStatus = "SCHEDULED"
-	ScheduleStatusCOMPLETED ScheduleStatus = "COMPLETED"
-	ScheduleStatusCANCELED  ScheduleStatus = "CANCELED"
+	WalletTransactionDirectionCredit WalletTransactionDirection = "credit"
+	WalletTransactionDirectionDebit  WalletTransactionDirection = "debit"
 )
 
-func (e *ScheduleStatus) Scan(src interface{}) error {
+func (e *WalletTransactionDirection) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = ScheduleStatus(s)
+		*e = WalletTransactionDirection(s)
 	case string:
-		*e = ScheduleStatus(s)
+		*e = WalletTransactionDirection(s)
 	default:
-		return fmt.Errorf("unsupported scan type for ScheduleStatus: %T", src)
+		return fmt.Errorf("unsupported scan type for WalletTransactionDirection: %T", src)
 	}
 	return nil
 }
 
-type NullScheduleStatus struct {
-	ScheduleStatus ScheduleStatus `json:"schedule_status"`
-	Valid          bool           `json:"valid"` // Valid is true if ScheduleStatus is not NULL
+type NullWalletTransactionDirection struct {
+	WalletTransactionDirection WalletTransactionDirection `json:"wallet_transaction_direction"`
+	Valid                      bool                       `json:"valid"` // Valid is true if WalletTransactionDirection is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullScheduleStatus) Scan(value interface{}) error {
+func (ns *NullWalletTransactionDirection) Scan(value interface{}) error {
 	if value == nil {
-		ns.ScheduleStatus, ns.Valid = "", false
+		ns.WalletTransactionDirection, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.ScheduleStatus.Scan(value)
+	return ns.WalletTransactionDirection.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullScheduleStatus) Value() (driver.Value, error) {
+func (ns NullWalletTransactionDirection) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.ScheduleStatus), nil
+	return string(ns.WalletTransactionDirection), nil
 }
 
-func (e ScheduleStatus) Valid() bool {
+func (e WalletTransactionDirection) Valid() bool {
 	switch e {
-	case ScheduleStatusSCHEDULED,
-		ScheduleStatusCOMPLETED,
-		ScheduleStatusCANCELED:
+	case WalletTransactionDirectionCredit,
+		WalletTransactionDirectionDebit:
 		return true
 	}
 	return false
 }
 
-func AllScheduleStatusValues() []ScheduleStatus {
-	return []ScheduleStatus{
-		ScheduleStatusSCHEDULED,
-		ScheduleStatusCOMPLETED,
-		ScheduleStatusCANCELED,
+func AllWalletTransactionDirectionValues() []WalletTransactionDirection {
+	return []WalletTransactionDirection{
+		WalletTransactionDirectionCredit,
+		WalletTransactionDirectionDebit,
 	}
 }
 
-type UserEnum string
+type WalletTransactionKind string
 
 const (
-	UserEnumUSER                    UserEnum = "USER"
-	UserEnumDIAGNOSTICCENTRE        UserEnum = "DIAGNOSTIC_CENTRE"
-	UserEnumHOSPITAL                UserEnum = "HOSPITAL"
-	UserEnumADMIN                   UserEnum = "ADMIN"
-	UserEnumDIAGNOSTICMANAGER       UserEnum = "DIAGNOSTIC_MANAGER"
-	UserEnumDIAGNOSTICCENTREOWNER   UserEnum = "DIAGNOSTIC_CENTRE_OWNER"
-	UserEnumDIAGNOSTICCENTREMANAGER UserEnum = "DIAGNOSTIC_CENTRE_MANAGER"
-	UserEnumPATIENT                 UserEnum = "PATIENT"
+	WalletTransactionKindRefund          WalletTransactionKind = "refund"
+	WalletTransactionKindPayment         WalletTransactionKind = "payment"
+	WalletTransactionKindPaymentReversal WalletTransactionKind = "payment_reversal"
 )
 
-func (e *UserEnum) Scan(src interface{}) error {
+func (e *WalletTransactionKind) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = UserEnum(s)
+		*e = WalletTransactionKind(s)
 	case string:
-		*e = UserEnum(s)
+		*e = WalletTransactionKind(s)
 	default:
-		return fmt.Errorf("unsupported scan type for UserEnum: %T", src)
+		return fmt.Errorf("unsupported scan type for WalletTransactionKind: %T", src)
 	}
 	return nil
 }
 
-type NullUserEnum struct {
-	UserEnum UserEnum `json:"user_enum"`
-	Valid    bool     `json:"valid"` // Valid is true if UserEnum is not NULL
+type NullWalletTransactionKind struct {
+	WalletTransactionKind WalletTransactionKind `json:"wallet_transaction_kind"`
+	Valid                 bool                  `json:"valid"` // Valid is true if WalletTransactionKind is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullUserEnum) Scan(value interface{}) error {
+func (ns *NullWalletTransactionKind) Scan(value interface{}) error {
 	if value == nil {
-		ns.UserEnum, ns.Valid = "", false
+		ns.WalletTransactionKind, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.UserEnum.Scan(value)
+	return ns.WalletTransactionKind.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullUserEnum) Value() (driver.Value, error) {
+func (ns NullWalletTransactionKind) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.UserEnum), nil
+	return string(ns.WalletTransactionKind), nil
 }
 
-func (e UserEnum) Valid() bool {
+func (e WalletTransactionKind) Valid() bool {
 	switch e {
-	case UserEnumUSER,
-		UserEnumDIAGNOSTICCENTRE,
-		UserEnumHOSPITAL,
-		UserEnumADMIN,
-		UserEnumDIAGNOSTICMANAGER,
-		UserEnumDIAGNOSTICCENTREOWNER,
-		UserEnumDIAGNOSTICCENTREMANAGER,
-		UserEnumPATIENT:
+	case WalletTransactionKindRefund,
+		WalletTransactionKindPayment,
+		WalletTransactionKindPaymentReversal:
 		return true
 	}
 	return false
 }
 
-func AllUserEnumValues() []UserEnum {
-	return []UserEnum{
-		UserEnumUSER,
-		UserEnumDIAGNOSTICCENTRE,
-		UserEnumHOSPITAL,
-		UserEnumADMIN,
-		UserEnumDIAGNOSTICMANAGER,
-		UserEnumDIAGNOSTICCENTREOWNER,
-		UserEnumDIAGNOSTICCENTREMANAGER,
-		UserEnumPATIENT,
+func AllWalletTransactionKindValues() []WalletTransactionKind {
+	return []WalletTransactionKind{
+		WalletTransactionKindRefund,
+		WalletTransactionKindPayment,
+		WalletTransactionKindPaymentReversal,
 	}
 }
 
@@ -968,6 +1989,99 @@ type Appointment struct {
 	ReminderSentAt        pgtype.Timestamptz `db:"reminder_sent_at" json:"reminder_sent_at"`
 }
 
+type AppointmentCoverage struct {
+	ID                   string             `db:"id" json:"id"`
+	AppointmentID        string             `db:"appointment_id" json:"appointment_id"`
+	PolicyID             string             `db:"policy_id" json:"policy_id"`
+	InsurerID            string             `db:"insurer_id" json:"insurer_id"`
+	PlanID               string             `db:"plan_id" json:"plan_id"`
+	PatientID            string             `db:"patient_id" json:"patient_id"`
+	DiagnosticCentreID   string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	MemberNumber         string             `db:"member_number" json:"member_number"`
+	Currency             string             `db:"currency" json:"currency"`
+	TotalAmount          pgtype.Numeric     `db:"total_amount" json:"total_amount"`
+	InsurerAmount        pgtype.Numeric     `db:"insurer_amount" json:"insurer_amount"`
+	PatientAmount        pgtype.Numeric     `db:"patient_amount" json:"patient_amount"`
+	PreauthorizationCode pgtype.Text        `db:"preauthorization_code" json:"preauthorization_code"`
+	Lines                []byte             `db:"lines" json:"lines"`
+	ClaimID              pgtype.UUID        `db:"claim_id" json:"claim_id"`
+	CreatedAt            pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type AppointmentLineItem struct {
+	ID            string               `db:"id" json:"id"`
+	AppointmentID string               `db:"appointment_id" json:"appointment_id"`
+	TestType      string               `db:"test_type" json:"test_type"`
+	TestPriceID   pgtype.UUID          `db:"test_price_id" json:"test_price_id"`
+	Price         pgtype.Numeric       `db:"price" json:"price"`
+	Currency      string               `db:"currency" json:"currency"`
+	ResultStatus  LineItemResultStatus `db:"result_status" json:"result_status"`
+	ResultReadyAt pgtype.Timestamptz   `db:"result_ready_at" json:"result_ready_at"`
+	CreatedAt     pgtype.Timestamptz   `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz   `db:"updated_at" json:"updated_at"`
+}
+
+type AppointmentLineItemRecord struct {
+	LineItemID      string             `db:"line_item_id" json:"line_item_id"`
+	MedicalRecordID string             `db:"medical_record_id" json:"medical_record_id"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type AppointmentRefund struct {
+	RefundID      string `db:"refund_id" json:"refund_id"`
+	AppointmentID string `db:"appointment_id" json:"appointment_id"`
+}
+
+type AppointmentSeries struct {
+	ID                 string             `db:"id" json:"id"`
+	PatientID          string             `db:"patient_id" json:"patient_id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	TestType           string             `db:"test_type" json:"test_type"`
+	Recurrence         SeriesRecurrence   `db:"recurrence" json:"recurrence"`
+	IntervalWeeks      pgtype.Int4        `db:"interval_weeks" json:"interval_weeks"`
+	PaymentMode        SeriesPaymentMode  `db:"payment_mode" json:"payment_mode"`
+	Status             SeriesStatus       `db:"status" json:"status"`
+	Notes              pgtype.Text        `db:"notes" json:"notes"`
+	CancellationReason pgtype.Text        `db:"cancellation_reason" json:"cancellation_reason"`
+	CancelledAt        pgtype.Timestamptz `db:"cancelled_at" json:"cancelled_at"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type AppointmentSeriesOccurrence struct {
+	SeriesID         string             `db:"series_id" json:"series_id"`
+	AppointmentID    string             `db:"appointment_id" json:"appointment_id"`
+	OccurrenceNumber int32              `db:"occurrence_number" json:"occurrence_number"`
+	CreatedAt        pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type AppointmentWaitlistEntry struct {
+	ID                 string             `db:"id" json:"id"`
+	PatientID          string             `db:"patient_id" json:"patient_id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	TestType           string             `db:"test_type" json:"test_type"`
+	DateFrom           pgtype.Date        `db:"date_from" json:"date_from"`
+	DateTo             pgtype.Date        `db:"date_to" json:"date_to"`
+	Status             WaitlistStatus     `db:"status" json:"status"`
+	OfferedSlotStart   pgtype.Timestamptz `db:"offered_slot_start" json:"offered_slot_start"`
+	OfferedTimeSlot    pgtype.Text        `db:"offered_time_slot" json:"offered_time_slot"`
+	HoldExpiresAt      pgtype.Timestamptz `db:"hold_expires_at" json:"hold_expires_at"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type CentrePayoutAccount struct {
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	BankCode           string             `db:"bank_code" json:"bank_code"`
+	AccountNumber      string             `db:"account_number" json:"account_number"`
+	AccountName        string             `db:"account_name" json:"account_name"`
+	Currency           string             `db:"currency" json:"currency"`
+	RecipientCode      string             `db:"recipient_code" json:"recipient_code"`
+	UpdatedBy          pgtype.UUID        `db:"updated_by" json:"updated_by"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type DiagnosticCentre struct {
 	ID                   string             `db:"id" json:"id"`
 	DiagnosticCentreName string             `db:"diagnostic_centre_name" json:"diagnostic_centre_name"`
@@ -1001,6 +2115,40 @@ type DiagnosticCentreAvailability struct {
 	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type DiagnosticCentreAvailabilityException struct {
+	ID                 string             `db:"id" json:"id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	ExceptionDate      pgtype.Date        `db:"exception_date" json:"exception_date"`
+	IsClosed           bool               `db:"is_closed" json:"is_closed"`
+	StartTime          pgtype.Time        `db:"start_time" json:"start_time"`
+	EndTime            pgtype.Time        `db:"end_time" json:"end_time"`
+	MaxAppointments    pgtype.Int4        `db:"max_appointments" json:"max_appointments"`
+	Reason             string             `db:"reason" json:"reason"`
+	CreatedBy          string             `db:"created_by" json:"created_by"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type DiagnosticCentreCancellationPolicy struct {
+	ID                         string             `db:"id" json:"id"`
+	DiagnosticCentreID         string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	FreeCancellationHours      int32              `db:"free_cancellation_hours" json:"free_cancellation_hours"`
+	LateCancellationFeePercent pgtype.Numeric     `db:"late_cancellation_fee_percent" json:"late_cancellation_fee_percent"`
+	UpdatedBy                  string             `db:"updated_by" json:"updated_by"`
+	CreatedAt                  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt                  pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type DiagnosticCentrePaymentSetting struct {
+	ID                 string             `db:"id" json:"id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	PaymentProvider    PaymentProvider    `db:"payment_provider" json:"payment_provider"`
+	AllowFallback      bool               `db:"allow_fallback" json:"allow_fallback"`
+	UpdatedBy          string             `db:"updated_by" json:"updated_by"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type DiagnosticCentreTestPrice struct {
 	ID                 string             `db:"id" json:"id"`
 	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
@@ -1012,6 +2160,19 @@ type DiagnosticCentreTestPrice struct {
 	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type DiagnosticCentreTestPriceHistory struct {
+	ID                 string             `db:"id" json:"id"`
+	TestPriceID        string             `db:"test_price_id" json:"test_price_id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	TestType           string             `db:"test_type" json:"test_type"`
+	Price              pgtype.Numeric     `db:"price" json:"price"`
+	Currency           string             `db:"currency" json:"currency"`
+	IsActive           bool               `db:"is_active" json:"is_active"`
+	EffectiveFrom      pgtype.Timestamptz `db:"effective_from" json:"effective_from"`
+	EffectiveTo        pgtype.Timestamptz `db:"effective_to" json:"effective_to"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type DiagnosticSchedule struct {
 	ID                 string                   `db:"id" json:"id"`
 	UserID             string                   `db:"user_id" json:"user_id"`
@@ -1027,6 +2188,25 @@ type DiagnosticSchedule struct {
 	UpdatedAt          pgtype.Timestamptz       `db:"updated_at" json:"updated_at"`
 }
 
+type EmailOutbox struct {
+	ID            string             `db:"id" json:"id"`
+	Recipient     string             `db:"recipient" json:"recipient"`
+	Subject       string             `db:"subject" json:"subject"`
+	Template      string             `db:"template" json:"template"`
+	HtmlBody      pgtype.Text        `db:"html_body" json:"html_body"`
+	Attachments   []byte             `db:"attachments" json:"attachments"`
+	Status        EmailOutboxStatus  `db:"status" json:"status"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	MaxAttempts   int32              `db:"max_attempts" json:"max_attempts"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	LockedUntil   pgtype.Timestamptz `db:"locked_until" json:"locked_until"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	SentAt        pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Sensitive     bool               `db:"sensitive" json:"sensitive"`
+}
+
 type EmailVerificationToken struct {
 	ID        string             `db:"id" json:"id"`
 	Email     string             `db:"email" json:"email"`
@@ -1036,6 +2216,60 @@ type EmailVerificationToken struct {
 	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
+type InsuranceClaim struct {
+	ID                 string               `db:"id" json:"id"`
+	ClaimNumber        string               `db:"claim_number" json:"claim_number"`
+	InsurerID          string               `db:"insurer_id" json:"insurer_id"`
+	DiagnosticCentreID string               `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	Currency           string               `db:"currency" json:"currency"`
+	Status             InsuranceClaimStatus `db:"status" json:"status"`
+	ClaimedAmount      pgtype.Numeric       `db:"claimed_amount" json:"claimed_amount"`
+	ApprovedAmount     pgtype.Numeric       `db:"approved_amount" json:"approved_amount"`
+	CoverageCount      int32                `db:"coverage_count" json:"coverage_count"`
+	InsurerReference   pgtype.Text          `db:"insurer_reference" json:"insurer_reference"`
+	Notes              pgtype.Text          `db:"notes" json:"notes"`
+	CreatedBy          pgtype.UUID          `db:"created_by" json:"created_by"`
+	SubmittedAt        pgtype.Timestamptz   `db:"submitted_at" json:"submitted_at"`
+	DecidedAt          pgtype.Timestamptz   `db:"decided_at" json:"decided_at"`
+	SettledAt          pgtype.Timestamptz   `db:"settled_at" json:"settled_at"`
+	CreatedAt          pgtype.Timestamptz   `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz   `db:"updated_at" json:"updated_at"`
+}
+
+type InsuranceCoverageRule struct {
+	ID                       string             `db:"id" json:"id"`
+	PlanID                   string             `db:"plan_id" json:"plan_id"`
+	TestType                 string             `db:"test_type" json:"test_type"`
+	CoveredPercent           pgtype.Numeric     `db:"covered_percent" json:"covered_percent"`
+	Copay                    pgtype.Numeric     `db:"copay" json:"copay"`
+	RequiresPreauthorization bool               `db:"requires_preauthorization" json:"requires_preauthorization"`
+	CreatedAt                pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt                pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type InsurancePlan struct {
+	ID        string             `db:"id" json:"id"`
+	InsurerID string             `db:"insurer_id" json:"insurer_id"`
+	Name      string             `db:"name" json:"name"`
+	Code      string             `db:"code" json:"code"`
+	Currency  string             `db:"currency" json:"currency"`
+	Active    bool               `db:"active" json:"active"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type Insurer struct {
+	ID        string             `db:"id" json:"id"`
+	Name      string             `db:"name" json:"name"`
+	Code      string             `db:"code" json:"code"`
+	Email     pgtype.Text        `db:"email" json:"email"`
+	Phone     pgtype.Text        `db:"phone" json:"phone"`
+	Active    bool               `db:"active" json:"active"`
+	CreatedBy pgtype.UUID        `db:"created_by" json:"created_by"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type MedicalRecord struct {
 	ID              string             `db:"id" json:"id"`
 	UserID          string             `db:"user_id" json:"user_id"`
@@ -1070,6 +2304,37 @@ type Notification struct {
 	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type NotificationDelivery struct {
+	ID             string             `db:"id" json:"id"`
+	UserID         string             `db:"user_id" json:"user_id"`
+	Type           NotificationType   `db:"type" json:"type"`
+	NotificationID pgtype.UUID        `db:"notification_id" json:"notification_id"`
+	Delivered      []string           `db:"delivered" json:"delivered"`
+	Skipped        []string           `db:"skipped" json:"skipped"`
+	Failed         []string           `db:"failed" json:"failed"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type NotificationPreference struct {
+	UserID    string             `db:"user_id" json:"user_id"`
+	Type      NotificationType   `db:"type" json:"type"`
+	Email     bool               `db:"email" json:"email"`
+	Sms       bool               `db:"sms" json:"sms"`
+	InApp     bool               `db:"in_app" json:"in_app"`
+	Websocket bool               `db:"websocket" json:"websocket"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type NotificationQuietHour struct {
+	UserID    string             `db:"user_id" json:"user_id"`
+	StartsAt  pgtype.Time        `db:"starts_at" json:"starts_at"`
+	EndsAt    pgtype.Time        `db:"ends_at" json:"ends_at"`
+	Timezone  string             `db:"timezone" json:"timezone"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type PasswordResetToken struct {
 	ID        string             `db:"id" json:"id"`
 	Email     string             `db:"email" json:"email"`
@@ -1079,6 +2344,18 @@ type PasswordResetToken struct {
 	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
+type PatientPolicy struct {
+	ID           string             `db:"id" json:"id"`
+	PatientID    string             `db:"patient_id" json:"patient_id"`
+	PlanID       string             `db:"plan_id" json:"plan_id"`
+	MemberNumber string             `db:"member_number" json:"member_number"`
+	ValidFrom    pgtype.Timestamptz `db:"valid_from" json:"valid_from"`
+	ValidUntil   pgtype.Timestamptz `db:"valid_until" json:"valid_until"`
+	Active       bool               `db:"active" json:"active"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type Payment struct {
 	ID                 string             `db:"id" json:"id"`
 	AppointmentID      string             `db:"appointment_id" json:"appointment_id"`
@@ -1100,6 +2377,153 @@ type Payment struct {
 	PaymentProvider    PaymentProvider    `db:"payment_provider" json:"payment_provider"`
 	ProviderReference  pgtype.Text        `db:"provider_reference" json:"provider_reference"`
 	ProviderMetadata   []byte             `db:"provider_metadata" json:"provider_metadata"`
+	WalletAmount       pgtype.Numeric     `db:"wallet_amount" json:"wallet_amount"`
+	HoldExpiresAt      pgtype.Timestamptz `db:"hold_expires_at" json:"hold_expires_at"`
+	ReceiptNumber      pgtype.Text        `db:"receipt_number" json:"receipt_number"`
+	ReceivedBy         pgtype.UUID        `db:"received_by" json:"received_by"`
+	ReceivedAt         pgtype.Timestamptz `db:"received_at" json:"received_at"`
+}
+
+type PaymentDiscrepancy struct {
+	ID                string                 `db:"id" json:"id"`
+	PaymentProvider   PaymentProvider        `db:"payment_provider" json:"payment_provider"`
+	ProviderReference string                 `db:"provider_reference" json:"provider_reference"`
+	PaymentID         pgtype.UUID            `db:"payment_id" json:"payment_id"`
+	Kind              PaymentDiscrepancyKind `db:"kind" json:"kind"`
+	PaymentStatus     NullPaymentStatus      `db:"payment_status" json:"payment_status"`
+	ProviderStatus    string                 `db:"provider_status" json:"provider_status"`
+	PaymentAmount     pgtype.Numeric         `db:"payment_amount" json:"payment_amount"`
+	ProviderAmount    pgtype.Numeric         `db:"provider_amount" json:"provider_amount"`
+	Currency          string                 `db:"currency" json:"currency"`
+	Resolved          bool                   `db:"resolved" json:"resolved"`
+	DetectedAt        pgtype.Timestamptz     `db:"detected_at" json:"detected_at"`
+	UpdatedAt         pgtype.Timestamptz     `db:"updated_at" json:"updated_at"`
+}
+
+type PaymentEvent struct {
+	ID              string             `db:"id" json:"id"`
+	PaymentID       string             `db:"payment_id" json:"payment_id"`
+	Source          PaymentEventSource `db:"source" json:"source"`
+	EventType       string             `db:"event_type" json:"event_type"`
+	PaymentProvider PaymentProvider    `db:"payment_provider" json:"payment_provider"`
+	ProviderEventID pgtype.Text        `db:"provider_event_id" json:"provider_event_id"`
+	FromStatus      NullPaymentStatus  `db:"from_status" json:"from_status"`
+	ToStatus        NullPaymentStatus  `db:"to_status" json:"to_status"`
+	Amount          pgtype.Numeric     `db:"amount" json:"amount"`
+	Payload         []byte             `db:"payload" json:"payload"`
+	ActorID         pgtype.UUID        `db:"actor_id" json:"actor_id"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type PaymentInvoice struct {
+	ID                 string             `db:"id" json:"id"`
+	PaymentID          string             `db:"payment_id" json:"payment_id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	SequenceNumber     int64              `db:"sequence_number" json:"sequence_number"`
+	InvoiceNumber      string             `db:"invoice_number" json:"invoice_number"`
+	TaxRate            pgtype.Numeric     `db:"tax_rate" json:"tax_rate"`
+	IssuedAt           pgtype.Timestamptz `db:"issued_at" json:"issued_at"`
+	EmailedAt          pgtype.Timestamptz `db:"emailed_at" json:"emailed_at"`
+}
+
+type PaymentRefund struct {
+	ID               string              `db:"id" json:"id"`
+	PaymentID        string              `db:"payment_id" json:"payment_id"`
+	PaymentProvider  PaymentProvider     `db:"payment_provider" json:"payment_provider"`
+	Amount           pgtype.Numeric      `db:"amount" json:"amount"`
+	Currency         string              `db:"currency" json:"currency"`
+	Reason           string              `db:"reason" json:"reason"`
+	Status           PaymentRefundStatus `db:"status" json:"status"`
+	ProviderRefundID pgtype.Text         `db:"provider_refund_id" json:"provider_refund_id"`
+	FailureReason    pgtype.Text         `db:"failure_reason" json:"failure_reason"`
+	RequestedBy      pgtype.UUID         `db:"requested_by" json:"requested_by"`
+	ProcessedAt      pgtype.Timestamptz  `db:"processed_at" json:"processed_at"`
+	CreatedAt        pgtype.Timestamptz  `db:"created_at" json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz  `db:"updated_at" json:"updated_at"`
+	Destination      RefundDestination   `db:"destination" json:"destination"`
+	SentAt           pgtype.Timestamptz  `db:"sent_at" json:"sent_at"`
+}
+
+type PhoneVerificationToken struct {
+	ID          string             `db:"id" json:"id"`
+	UserID      string             `db:"user_id" json:"user_id"`
+	PhoneNumber string             `db:"phone_number" json:"phone_number"`
+	CodeHash    string             `db:"code_hash" json:"code_hash"`
+	Attempts    int32              `db:"attempts" json:"attempts"`
+	ExpiresAt   pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	ConsumedAt  pgtype.Timestamptz `db:"consumed_at" json:"consumed_at"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type PromoCode struct {
+	ID                    string             `db:"id" json:"id"`
+	Code                  string             `db:"code" json:"code"`
+	Description           pgtype.Text        `db:"description" json:"description"`
+	DiscountType          PromoDiscountType  `db:"discount_type" json:"discount_type"`
+	DiscountValue         pgtype.Numeric     `db:"discount_value" json:"discount_value"`
+	MaxDiscount           pgtype.Numeric     `db:"max_discount" json:"max_discount"`
+	Currency              pgtype.Text        `db:"currency" json:"currency"`
+	DiagnosticCentreIds   []string           `db:"diagnostic_centre_ids" json:"diagnostic_centre_ids"`
+	TestTypes             []string           `db:"test_types" json:"test_types"`
+	FirstBookingOnly      bool               `db:"first_booking_only" json:"first_booking_only"`
+	StartsAt              pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt                pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	MaxRedemptions        pgtype.Int4        `db:"max_redemptions" json:"max_redemptions"`
+	MaxRedemptionsPerUser int32              `db:"max_redemptions_per_user" json:"max_redemptions_per_user"`
+	RedemptionCount       int32              `db:"redemption_count" json:"redemption_count"`
+	Active                bool               `db:"active" json:"active"`
+	CreatedBy             string             `db:"created_by" json:"created_by"`
+	CreatedAt             pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type PromoRedemption struct {
+	ID             string             `db:"id" json:"id"`
+	PromoCodeID    string             `db:"promo_code_id" json:"promo_code_id"`
+	Code           string             `db:"code" json:"code"`
+	UserID         string             `db:"user_id" json:"user_id"`
+	PaymentID      string             `db:"payment_id" json:"payment_id"`
+	DiscountAmount pgtype.Numeric     `db:"discount_amount" json:"discount_amount"`
+	Currency       string             `db:"currency" json:"currency"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	ReleasedAt     pgtype.Timestamptz `db:"released_at" json:"released_at"`
+}
+
+type Settlement struct {
+	ID                 string             `db:"id" json:"id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	PeriodStart        pgtype.Timestamptz `db:"period_start" json:"period_start"`
+	PeriodEnd          pgtype.Timestamptz `db:"period_end" json:"period_end"`
+	Currency           string             `db:"currency" json:"currency"`
+	CommissionRate     pgtype.Numeric     `db:"commission_rate" json:"commission_rate"`
+	OpeningBalance     pgtype.Numeric     `db:"opening_balance" json:"opening_balance"`
+	GrossAmount        pgtype.Numeric     `db:"gross_amount" json:"gross_amount"`
+	RefundedAmount     pgtype.Numeric     `db:"refunded_amount" json:"refunded_amount"`
+	CommissionAmount   pgtype.Numeric     `db:"commission_amount" json:"commission_amount"`
+	NetAmount          pgtype.Numeric     `db:"net_amount" json:"net_amount"`
+	PaymentCount       int32              `db:"payment_count" json:"payment_count"`
+	RefundCount        int32              `db:"refund_count" json:"refund_count"`
+	Status             SettlementStatus   `db:"status" json:"status"`
+	PayoutReference    pgtype.Text        `db:"payout_reference" json:"payout_reference"`
+	TransferCode       pgtype.Text        `db:"transfer_code" json:"transfer_code"`
+	PayoutAttempts     int32              `db:"payout_attempts" json:"payout_attempts"`
+	FailureReason      pgtype.Text        `db:"failure_reason" json:"failure_reason"`
+	PaidAt             pgtype.Timestamptz `db:"paid_at" json:"paid_at"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type SettlementEntry struct {
+	ID               string              `db:"id" json:"id"`
+	SettlementID     string              `db:"settlement_id" json:"settlement_id"`
+	Kind             SettlementEntryKind `db:"kind" json:"kind"`
+	PaymentID        string              `db:"payment_id" json:"payment_id"`
+	RefundID         pgtype.UUID         `db:"refund_id" json:"refund_id"`
+	Amount           pgtype.Numeric      `db:"amount" json:"amount"`
+	CommissionAmount pgtype.Numeric      `db:"commission_amount" json:"commission_amount"`
+	NetAmount        pgtype.Numeric      `db:"net_amount" json:"net_amount"`
+	OccurredAt       pgtype.Timestamptz  `db:"occurred_at" json:"occurred_at"`
+	CreatedAt        pgtype.Timestamptz  `db:"created_at" json:"created_at"`
 }
 
 type User struct {
@@ -1115,4 +2539,28 @@ type User struct {
 	EmailVerifiedAt pgtype.Timestamptz `db:"email_verified_at" json:"email_verified_at"`
 	PhoneNumber     pgtype.Text        `db:"phone_number" json:"phone_number"`
 	CreatedAdmin    pgtype.UUID        `db:"created_admin" json:"created_admin"`
+	PhoneVerifiedAt pgtype.Timestamptz `db:"phone_verified_at" json:"phone_verified_at"`
+}
+
+type Wallet struct {
+	ID        string             `db:"id" json:"id"`
+	UserID    string             `db:"user_id" json:"user_id"`
+	Currency  string             `db:"currency" json:"currency"`
+	Balance   pgtype.Numeric     `db:"balance" json:"balance"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type WalletTransaction struct {
+	ID           string                     `db:"id" json:"id"`
+	WalletID     string                     `db:"wallet_id" json:"wallet_id"`
+	Direction    WalletTransactionDirection `db:"direction" json:"direction"`
+	Kind         WalletTransactionKind      `db:"kind" json:"kind"`
+	Amount       pgtype.Numeric             `db:"amount" json:"amount"`
+	BalanceAfter pgtype.Numeric             `db:"balance_after" json:"balance_after"`
+	PaymentID    pgtype.UUID                `db:"payment_id" json:"payment_id"`
+	RefundID     pgtype.UUID                `db:"refund_id" json:"refund_id"`
+	Description  string                     `db:"description" json:"description"`
+	CreatedBy    pgtype.UUID                `db:"created_by" json:"created_by"`
+	CreatedAt    pgtype.Timestamptz         `db:"created_at" json:"created_at"`
 }
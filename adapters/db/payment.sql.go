@@ -23,24 +23,28 @@ INSERT INTO payments (
     payment_provider,
     provider_reference,
     provider_metadata,
-    transaction_id
+    transaction_id,
+    wallet_amount,
+    hold_expires_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
-) RETURNING id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata, wallet_amount, hold_expires_at, receipt_number, received_by, received_at
 `
 
 type Create_PaymentParams struct {
-	AppointmentID      string          `db:"appointment_id" json:"appointment_id"`
-	PatientID          string          `db:"patient_id" json:"patient_id"`
-	DiagnosticCentreID string          `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
-	Amount             pgtype.Numeric  `db:"amount" json:"amount"`
-	Currency           string          `db:"currency" json:"currency"`
-	PaymentMethod      PaymentMethod   `db:"payment_method" json:"payment_method"`
-	PaymentMetadata    []byte          `db:"payment_metadata" json:"payment_metadata"`
-	PaymentProvider    PaymentProvider `db:"payment_provider" json:"payment_provider"`
-	ProviderReference  pgtype.Text     `db:"provider_reference" json:"provider_reference"`
-	ProviderMetadata   []byte          `db:"provider_metadata" json:"provider_metadata"`
-	TransactionID      pgtype.Text     `db:"transaction_id" json:"transaction_id"`
+	AppointmentID      string             `db:"appointment_id" json:"appointment_id"`
+	PatientID          string             `db:"patient_id" json:"patient_id"`
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	Amount             pgtype.Numeric     `db:"amount" json:"amount"`
+	Currency           string             `db:"currency" json:"currency"`
+	PaymentMethod      PaymentMethod      `db:"payment_method" json:"payment_method"`
+	PaymentMetadata    []byte             `db:"payment_metadata" json:"payment_metadata"`
+	PaymentProvider    PaymentProvider    `db:"payment_provider" json:"payment_provider"`
+	ProviderReference  pgtype.Text        `db:"provider_reference" json:"provider_reference"`
+	ProviderMetadata   []byte             `db:"provider_metadata" json:"provider_metadata"`
+	TransactionID      pgtype.Text        `db:"transaction_id" json:"transaction_id"`
+	WalletAmount       pgtype.Numeric     `db:"wallet_amount" json:"wallet_amount"`
+	HoldExpiresAt      pgtype.Timestamptz `db:"hold_expires_at" json:"hold_expires_at"`
 }
 
 func (q *Queries) Create_Payment(ctx context.Context, arg Create_PaymentParams) (*Payment, error) {
@@ -56,6 +60,8 @@ func (q *Queries) Create_Payment(ctx context.Context, arg Create_PaymentParams)
 		arg.ProviderReference,
 		arg.ProviderMetadata,
 		arg.TransactionID,
+		arg.WalletAmount,
+		arg.HoldExpiresAt,
 	)
 	var i Payment
 	err := row.Scan(
@@ -79,12 +85,17 @@ func (q *Queries) Create_Payment(ctx context.Context, arg Create_PaymentParams)
 		&i.PaymentProvider,
 		&i.ProviderReference,
 		&i.ProviderMetadata,
+		&i.WalletAmount,
+		&i.HoldExpiresAt,
+		&i.ReceiptNumber,
+		&i.ReceivedBy,
+		&i.ReceivedAt,
 	)
 	return &i, err
 }
 
 const getPaymentByReference = `-- name: GetPaymentByReference :one
-SELECT id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata FROM payments WHERE provider_reference = $1 LIMIT 1
+SELECT id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata, wallet_amount, hold_expires_at, receipt_number, received_by, received_at FROM payments WHERE provider_reference = $1 LIMIT 1
 `
 
 func (q *Queries) GetPaymentByReference(ctx context.Context, providerReference pgtype.Text) (*Payment, error) {
@@ -111,12 +122,17 @@ func (q *Queries) GetPaymentByReference(ctx context.Context, providerReference p
 		&i.PaymentProvider,
 		&i.ProviderReference,
 		&i.ProviderMetadata,
+		&i.WalletAmount,
+		&i.HoldExpiresAt,
+		&i.ReceiptNumber,
+		&i.ReceivedBy,
+		&i.ReceivedAt,
 	)
 	return &i, err
 }
 
 const get_Payment = `-- name: Get_Payment :one
-SELECT id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata FROM payments WHERE id = $1
+SELECT id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata, wallet_amount, hold_expires_at, receipt_number, received_by, received_at FROM payments WHERE id = $1
 `
 
 func (q *Queries) Get_Payment(ctx context.Context, id string) (*Payment, error) {
@@ -143,12 +159,17 @@ func (q *Queries) Get_Payment(ctx context.Context, id string) (*Payment, error)
 		&i.PaymentProvider,
 		&i.ProviderReference,
 		&i.ProviderMetadata,
+		&i.WalletAmount,
+		&i.HoldExpiresAt,
+		&i.ReceiptNumber,
+		&i.ReceivedBy,
+		&i.ReceivedAt,
 	)
 	return &i, err
 }
 
 const list_Payments = `-- name: List_Payments :many
-SELECT id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata FROM payments 
+SELECT id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata, wallet_amount, hold_expires_at, receipt_number, received_by, received_at FROM payments 
 WHERE 
     (CASE WHEN $1::UUID IS NOT NULL THEN diagnostic_centre_id = $1 ELSE TRUE END) AND
     (CASE WHEN $2::UUID IS NOT NULL THEN patient_id = $2 ELSE TRUE END) AND
@@ -207,6 +228,11 @@ func (q *Queries) List_Payments(ctx context.Context, arg List_PaymentsParams) ([
 			&i.PaymentProvider,
 			&i.ProviderReference,
 			&i.ProviderMetadata,
+			&i.WalletAmount,
+			&i.HoldExpiresAt,
+			&i.ReceiptNumber,
+			&i.ReceivedBy,
+			&i.ReceivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -234,7 +260,7 @@ WHERE
         SELECT 1 FROM payments AS p
         WHERE p.id = payments.id AND p.refund_amount IS NOT NULL
     )
-RETURNING id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata
+RETURNING id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata, wallet_amount, hold_expires_at, receipt_number, received_by, received_at
 `
 
 type Refund_PaymentParams struct {
@@ -273,6 +299,11 @@ func (q *Queries) Refund_Payment(ctx context.Context, arg Refund_PaymentParams)
 		&i.PaymentProvider,
 		&i.ProviderReference,
 		&i.ProviderMetadata,
+		&i.WalletAmount,
+		&i.HoldExpiresAt,
+		&i.ReceiptNumber,
+		&i.ReceivedBy,
+		&i.ReceivedAt,
 	)
 	return &i, err
 }
@@ -286,7 +317,7 @@ SET
     payment_date = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 
-RETURNING id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata
+RETURNING id, appointment_id, patient_id, diagnostic_centre_id, amount, currency, payment_method, payment_status, transaction_id, payment_metadata, payment_date, refund_amount, refund_reason, refund_date, refunded_by, created_at, updated_at, payment_provider, provider_reference, provider_metadata, wallet_amount, hold_expires_at, receipt_number, received_by, received_at
 `
 
 type Update_Payment_StatusParams struct {
@@ -325,6 +356,11 @@ func (q *Queries) Update_Payment_Status(ctx context.Context, arg Update_Payment_
 		&i.PaymentProvider,
 		&i.ProviderReference,
 		&i.ProviderMetadata,
+		&i.WalletAmount,
+		&i.HoldExpiresAt,
+		&i.ReceiptNumber,
+		&i.ReceivedBy,
+		&i.ReceivedAt,
 	)
 	return &i, err
 }
@@ -11,34 +11,78 @@ import (
 )
 
 type Querier interface {
+	Add_Series_Occurrence(ctx context.Context, arg Add_Series_OccurrenceParams) error
+	Add_Settlement_Payment_Entries(ctx context.Context, id string) (int64, error)
+	Add_Settlement_Refund_Entries(ctx context.Context, id string) (int64, error)
 	AssignAdmin(ctx context.Context, arg AssignAdminParams) (*DiagnosticCentre, error)
+	Attach_Claim_Coverages(ctx context.Context, arg Attach_Claim_CoveragesParams) ([]*AppointmentCoverage, error)
+	Attach_Line_Item_Record(ctx context.Context, arg Attach_Line_Item_RecordParams) error
 	CancelAppointment(ctx context.Context, arg CancelAppointmentParams) (*Appointment, error)
+	Cancel_Appointment_Series(ctx context.Context, arg Cancel_Appointment_SeriesParams) (*AppointmentSeries, error)
+	Cancel_Waitlist_Entry(ctx context.Context, arg Cancel_Waitlist_EntryParams) (*AppointmentWaitlistEntry, error)
+	Claim_Outbox_Emails(ctx context.Context, arg Claim_Outbox_EmailsParams) ([]*EmailOutbox, error)
+	Claim_Promo_Code(ctx context.Context, id string) (*PromoCode, error)
+	ConfirmAppointmentsByPayment(ctx context.Context, paymentID pgtype.UUID) ([]*Appointment, error)
+	ConsumePhoneVerificationToken(ctx context.Context, id string) (int64, error)
+	CountPhoneVerificationTokensSince(ctx context.Context, arg CountPhoneVerificationTokensSinceParams) (int64, error)
+	Count_Patient_Payments(ctx context.Context, patientID string) (int64, error)
+	Count_User_Promo_Redemptions(ctx context.Context, arg Count_User_Promo_RedemptionsParams) (int64, error)
 	CreateAppointment(ctx context.Context, arg CreateAppointmentParams) (*Appointment, error)
 	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (*EmailVerificationToken, error)
 	// Create a Medical Record
 	CreateMedicalRecord(ctx context.Context, arg CreateMedicalRecordParams) (*MedicalRecord, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (*Notification, error)
 	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
+	CreatePhoneVerificationToken(ctx context.Context, arg CreatePhoneVerificationTokenParams) (*PhoneVerificationToken, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (*User, error)
+	Create_Appointment_Coverage(ctx context.Context, arg Create_Appointment_CoverageParams) (*AppointmentCoverage, error)
+	Create_Appointment_Line_Item(ctx context.Context, arg Create_Appointment_Line_ItemParams) (*AppointmentLineItem, error)
+	Create_Appointment_Series(ctx context.Context, arg Create_Appointment_SeriesParams) (*AppointmentSeries, error)
 	Create_Availability(ctx context.Context, arg Create_AvailabilityParams) ([]*DiagnosticCentreAvailability, error)
 	// Inserts a new diagnostic record into the diagnostic_centres table.
 	Create_Diagnostic_Centre(ctx context.Context, arg Create_Diagnostic_CentreParams) (*DiagnosticCentre, error)
 	// Create a diagnostic schedule
 	Create_Diagnostic_Schedule(ctx context.Context, arg Create_Diagnostic_ScheduleParams) (*DiagnosticSchedule, error)
+	Create_Insurance_Claim(ctx context.Context, arg Create_Insurance_ClaimParams) (*InsuranceClaim, error)
+	Create_Insurance_Plan(ctx context.Context, arg Create_Insurance_PlanParams) (*InsurancePlan, error)
+	Create_Insurer(ctx context.Context, arg Create_InsurerParams) (*Insurer, error)
+	Create_Patient_Policy(ctx context.Context, arg Create_Patient_PolicyParams) (*PatientPolicy, error)
 	Create_Payment(ctx context.Context, arg Create_PaymentParams) (*Payment, error)
+	Create_Payment_Event(ctx context.Context, arg Create_Payment_EventParams) (*PaymentEvent, error)
+	Create_Payment_Invoice(ctx context.Context, arg Create_Payment_InvoiceParams) (*PaymentInvoice, error)
+	Create_Appointment_Refund(ctx context.Context, arg Create_Appointment_RefundParams) error
+	Create_Payment_Refund(ctx context.Context, arg Create_Payment_RefundParams) (*PaymentRefund, error)
+	Create_Promo_Code(ctx context.Context, arg Create_Promo_CodeParams) (*PromoCode, error)
+	Create_Promo_Redemption(ctx context.Context, arg Create_Promo_RedemptionParams) (*PromoRedemption, error)
+	Create_Settlement(ctx context.Context, arg Create_SettlementParams) (*Settlement, error)
 	Create_Single_Availability(ctx context.Context, arg Create_Single_AvailabilityParams) (*DiagnosticCentreAvailability, error)
 	Create_Test_Price(ctx context.Context, arg Create_Test_PriceParams) ([]*DiagnosticCentreTestPrice, error)
+	Create_Waitlist_Entry(ctx context.Context, arg Create_Waitlist_EntryParams) (*AppointmentWaitlistEntry, error)
+	Create_Wallet_Transaction(ctx context.Context, arg Create_Wallet_TransactionParams) (*WalletTransaction, error)
+	Credit_Wallet(ctx context.Context, arg Credit_WalletParams) (*Wallet, error)
+	Deactivate_Promo_Code(ctx context.Context, id string) (*PromoCode, error)
+	Dead_Letter_Email(ctx context.Context, arg Dead_Letter_EmailParams) error
+	Debit_Wallet(ctx context.Context, arg Debit_WalletParams) (*Wallet, error)
 	DeleteAppointment(ctx context.Context, id string) error
 	Delete_Availability(ctx context.Context, arg Delete_AvailabilityParams) error
+	Delete_Availability_Exception(ctx context.Context, arg Delete_Availability_ExceptionParams) error
 	// Deletes a diagnosticCentre only by the created_by.
 	Delete_Diagnostic_Centre_ByOwner(ctx context.Context, arg Delete_Diagnostic_Centre_ByOwnerParams) (*DiagnosticCentre, error)
 	Delete_Diagnostic_Schedule(ctx context.Context, arg Delete_Diagnostic_ScheduleParams) (*DiagnosticSchedule, error)
+	Delete_Notification_Quiet_Hours(ctx context.Context, userID string) error
+	End_Patient_Policy(ctx context.Context, arg End_Patient_PolicyParams) (*PatientPolicy, error)
+	Enqueue_Email(ctx context.Context, arg Enqueue_EmailParams) (*EmailOutbox, error)
+	Expire_Appointments_By_Payment(ctx context.Context, arg Expire_Appointments_By_PaymentParams) ([]*Appointment, error)
+	Expire_Waitlist_Entries(ctx context.Context) ([]*AppointmentWaitlistEntry, error)
 	Find_Nearest_Diagnostic_Centres_WhenRejected(ctx context.Context, arg Find_Nearest_Diagnostic_Centres_WhenRejectedParams) ([]*Find_Nearest_Diagnostic_Centres_WhenRejectedRow, error)
 	GetAPatientAppointment(ctx context.Context, arg GetAPatientAppointmentParams) (*Appointment, error)
 	GetAdminHistory(ctx context.Context, id string) ([]*GetAdminHistoryRow, error)
 	GetAppointment(ctx context.Context, id string) (*Appointment, error)
+	// Counts how often an appointment was rescheduled and the fees charged along the way
+	GetAppointmentRescheduleSummary(ctx context.Context, id string) (*GetAppointmentRescheduleSummaryRow, error)
 	GetCentreAppointments(ctx context.Context, arg GetCentreAppointmentsParams) ([]*GetCentreAppointmentsRow, error)
 	GetEmailVerificationToken(ctx context.Context, token string) (*EmailVerificationToken, error)
+	GetLatestPhoneVerificationToken(ctx context.Context, phoneNumber string) (*PhoneVerificationToken, error)
 	// Get a Medical Record
 	GetMedicalRecord(ctx context.Context, arg GetMedicalRecordParams) (*GetMedicalRecordRow, error)
 	// Get Medical Records
@@ -61,9 +105,16 @@ type Querier interface {
 	GetUploaderMedicalRecords(ctx context.Context, arg GetUploaderMedicalRecordsParams) ([]*GetUploaderMedicalRecordsRow, error)
 	GetUser(ctx context.Context, id string) (*User, error)
 	GetUserByEmail(ctx context.Context, email pgtype.Text) (*User, error)
+	GetUserByPhoneNumber(ctx context.Context, phoneNumber pgtype.Text) (*User, error)
 	GetUserNotifications(ctx context.Context, arg GetUserNotificationsParams) ([]*Notification, error)
 	GetUsers(ctx context.Context, arg GetUsersParams) ([]*User, error)
+	Get_Active_Test_Price(ctx context.Context, arg Get_Active_Test_PriceParams) (*DiagnosticCentreTestPrice, error)
+	Get_Appointment_Line_Item(ctx context.Context, id string) (*AppointmentLineItem, error)
+	Get_Appointment_Series(ctx context.Context, id string) (*AppointmentSeries, error)
 	Get_Availability(ctx context.Context, arg Get_AvailabilityParams) ([]*DiagnosticCentreAvailability, error)
+	Get_Availability_Exception(ctx context.Context, arg Get_Availability_ExceptionParams) (*DiagnosticCentreAvailabilityException, error)
+	Get_Cancellation_Policy(ctx context.Context, diagnosticCentreID string) (*DiagnosticCentreCancellationPolicy, error)
+	Get_Centre_Payout_Account(ctx context.Context, diagnosticCentreID string) (*CentrePayoutAccount, error)
 	Get_Diagnostic_Availability(ctx context.Context, diagnosticCentreID string) ([]*DiagnosticCentreAvailability, error)
 	// Retrieves a single diagnostic record by its ID and admin.
 	Get_Diagnostic_Centre(ctx context.Context, id string) (*Get_Diagnostic_CentreRow, error)
@@ -80,26 +131,104 @@ type Querier interface {
 	Get_Diagnostic_Schedules(ctx context.Context, arg Get_Diagnostic_SchedulesParams) ([]*DiagnosticSchedule, error)
 	Get_Diagnsotic_Schedule_By_Centre(ctx context.Context, arg Get_Diagnsotic_Schedule_By_CentreParams) (*DiagnosticSchedule, error)
 	Get_Diagnsotic_Schedules_By_Centre(ctx context.Context, arg Get_Diagnsotic_Schedules_By_CentreParams) ([]*DiagnosticSchedule, error)
+	Get_Insurance_Claim(ctx context.Context, id string) (*InsuranceClaim, error)
+	Get_Insurance_Plan(ctx context.Context, id string) (*InsurancePlan, error)
+	Get_Insurer(ctx context.Context, id string) (*Insurer, error)
+	Get_Latest_Settlement(ctx context.Context, arg Get_Latest_SettlementParams) (*Settlement, error)
 	// Retrieves the nearest diagnostic centres based on latitude and longitude.
 	Get_Nearest_Diagnostic_Centres(ctx context.Context, arg Get_Nearest_Diagnostic_CentresParams) ([]*Get_Nearest_Diagnostic_CentresRow, error)
+	Get_Notification_Preference(ctx context.Context, arg Get_Notification_PreferenceParams) (*NotificationPreference, error)
+	Get_Notification_Quiet_Hours(ctx context.Context, userID string) (*NotificationQuietHour, error)
+	Get_Patient_Policy(ctx context.Context, arg Get_Patient_PolicyParams) (*PatientPolicy, error)
 	Get_Payment(ctx context.Context, id string) (*Payment, error)
+	Get_Payment_Invoice(ctx context.Context, paymentID string) (*PaymentInvoice, error)
+	Get_Payment_Promo_Redemption(ctx context.Context, paymentID string) (*PromoRedemption, error)
+	Get_Payment_Refund_By_Provider_ID(ctx context.Context, arg Get_Payment_Refund_By_Provider_IDParams) (*PaymentRefund, error)
+	Get_Payment_Settings(ctx context.Context, diagnosticCentreID string) (*DiagnosticCentrePaymentSetting, error)
+	Get_Promo_Code(ctx context.Context, id string) (*PromoCode, error)
+	Get_Promo_Code_By_Code(ctx context.Context, code string) (*PromoCode, error)
+	Get_Settlement(ctx context.Context, id string) (*Settlement, error)
+	Get_Settlement_By_Payout_Reference(ctx context.Context, payoutReference pgtype.Text) (*Settlement, error)
+	Get_Test_Price(ctx context.Context, arg Get_Test_PriceParams) (*DiagnosticCentreTestPrice, error)
+	// Returns the price that was in effect for a test at a given time.
+	Get_Test_Price_At(ctx context.Context, arg Get_Test_Price_AtParams) (*DiagnosticCentreTestPriceHistory, error)
+	Get_Unmatched_Payment_Refund(ctx context.Context, arg Get_Unmatched_Payment_RefundParams) (*PaymentRefund, error)
+	IncrementPhoneVerificationAttempts(ctx context.Context, id string) (*PhoneVerificationToken, error)
 	ListUsersByAdmin(ctx context.Context, arg ListUsersByAdminParams) ([]*ListUsersByAdminRow, error)
+	List_Appointment_Line_Item_Records(ctx context.Context, appointmentID string) ([]*AppointmentLineItemRecord, error)
+	List_Appointment_Line_Items(ctx context.Context, appointmentID string) ([]*AppointmentLineItem, error)
+	List_Availability_Exceptions(ctx context.Context, arg List_Availability_ExceptionsParams) ([]*DiagnosticCentreAvailabilityException, error)
+	List_Centre_Settlements(ctx context.Context, arg List_Centre_SettlementsParams) ([]*Settlement, error)
+	List_Claim_Entries(ctx context.Context, claimID pgtype.UUID) ([]*List_Claim_EntriesRow, error)
+	List_Coverage_Rules(ctx context.Context, planID string) ([]*InsuranceCoverageRule, error)
 	// Retrieves all diagnostic records for a specific owner.
 	List_Diagnostic_Centres_ByOwner(ctx context.Context, arg List_Diagnostic_Centres_ByOwnerParams) ([]*List_Diagnostic_Centres_ByOwnerRow, error)
+	List_Expired_Centre_Payments(ctx context.Context, arg List_Expired_Centre_PaymentsParams) ([]*Payment, error)
+	List_Insurance_Claims(ctx context.Context, arg List_Insurance_ClaimsParams) ([]*InsuranceClaim, error)
+	List_Insurance_Plans(ctx context.Context, insurerID string) ([]*InsurancePlan, error)
+	List_Insurers(ctx context.Context, arg List_InsurersParams) ([]*Insurer, error)
+	List_Notification_Preferences(ctx context.Context, userID string) ([]*NotificationPreference, error)
+	List_Outbox_Emails(ctx context.Context, arg List_Outbox_EmailsParams) ([]*EmailOutbox, error)
+	List_Patient_Policies(ctx context.Context, patientID string) ([]*PatientPolicy, error)
+	List_Patient_Waitlist_Entries(ctx context.Context, arg List_Patient_Waitlist_EntriesParams) ([]*AppointmentWaitlistEntry, error)
+	List_Payable_Settlements(ctx context.Context, arg List_Payable_SettlementsParams) ([]*Settlement, error)
+	List_Payment_Appointments(ctx context.Context, paymentID pgtype.UUID) ([]*Appointment, error)
+	List_Payment_Coverages(ctx context.Context, paymentID pgtype.UUID) ([]*AppointmentCoverage, error)
+	List_Payment_Discrepancies(ctx context.Context, arg List_Payment_DiscrepanciesParams) ([]*PaymentDiscrepancy, error)
+	List_Payment_Events(ctx context.Context, paymentID string) ([]*PaymentEvent, error)
+	List_Payment_Refunds(ctx context.Context, paymentID string) ([]*PaymentRefund, error)
+	List_Pending_Provider_Refunds(ctx context.Context, arg List_Pending_Provider_RefundsParams) ([]*PaymentRefund, error)
 	List_Payments(ctx context.Context, arg List_PaymentsParams) ([]*Payment, error)
+	List_Payments_By_References(ctx context.Context, arg List_Payments_By_ReferencesParams) ([]*Payment, error)
+	List_Promo_Codes(ctx context.Context, arg List_Promo_CodesParams) ([]*PromoCode, error)
+	List_Series_Appointments(ctx context.Context, seriesID string) ([]*Appointment, error)
+	List_Settlement_Entries(ctx context.Context, settlementID string) ([]*SettlementEntry, error)
+	List_Test_Price_History(ctx context.Context, arg List_Test_Price_HistoryParams) ([]*DiagnosticCentreTestPriceHistory, error)
+	List_Test_Prices(ctx context.Context, arg List_Test_PricesParams) ([]*DiagnosticCentreTestPrice, error)
+	List_Unpaid_Appointment_Payments(ctx context.Context, arg List_Unpaid_Appointment_PaymentsParams) ([]*Payment, error)
+	List_Unreceipted_Payments(ctx context.Context, arg List_Unreceipted_PaymentsParams) ([]*Payment, error)
+	List_Unsent_Provider_Refunds(ctx context.Context, arg List_Unsent_Provider_RefundsParams) ([]*PaymentRefund, error)
+	List_Unsettled_Centres(ctx context.Context, paymentDate pgtype.Timestamptz) ([]*List_Unsettled_CentresRow, error)
+	List_User_Wallets(ctx context.Context, userID string) ([]*Wallet, error)
+	List_Waitlist_Holds(ctx context.Context, arg List_Waitlist_HoldsParams) ([]*AppointmentWaitlistEntry, error)
+	List_Wallet_Transactions(ctx context.Context, arg List_Wallet_TransactionsParams) ([]*WalletTransaction, error)
+	Lock_Payment(ctx context.Context, id string) (*Payment, error)
+	Lock_Wallet(ctx context.Context, arg Lock_WalletParams) (*Wallet, error)
 	MarkAllAsRead(ctx context.Context, userID string) error
 	MarkAsRead(ctx context.Context, arg MarkAsReadParams) (*Notification, error)
 	MarkEmailAsVerified(ctx context.Context, email pgtype.Text) error
 	MarkEmailVerificationTokenUsed(ctx context.Context, id string) error
 	MarkResetTokenUsed(ctx context.Context, id string) error
+	Mark_Email_Sent(ctx context.Context, id string) error
+	Mark_Invoice_Emailed(ctx context.Context, id string) (*PaymentInvoice, error)
+	Mark_Payment_Refund_Sent(ctx context.Context, id string) (*PaymentRefund, error)
+	Mark_Refunded_Appointments(ctx context.Context, refundID string) error
+	Mark_Waitlist_Entry_Booked(ctx context.Context, arg Mark_Waitlist_Entry_BookedParams) error
+	Move_Appointment_Line_Items(ctx context.Context, arg Move_Appointment_Line_ItemsParams) error
+	Move_Series_Occurrence(ctx context.Context, arg Move_Series_OccurrenceParams) error
+	Next_Invoice_Number(ctx context.Context, diagnosticCentreID string) (int64, error)
+	Offer_Waitlist_Slot(ctx context.Context, arg Offer_Waitlist_SlotParams) (*AppointmentWaitlistEntry, error)
+	Project_Payment_Refund(ctx context.Context, arg Project_Payment_RefundParams) (*Payment, error)
+	Project_Payment_Status(ctx context.Context, arg Project_Payment_StatusParams) (*Payment, error)
+	Record_Centre_Payment(ctx context.Context, arg Record_Centre_PaymentParams) (*Payment, error)
+	Record_Notification_Delivery(ctx context.Context, arg Record_Notification_DeliveryParams) (*NotificationDelivery, error)
 	Refund_Payment(ctx context.Context, arg Refund_PaymentParams) (*Payment, error)
+	Release_Promo_Redemption(ctx context.Context, paymentID string) (int64, error)
+	Replay_Email(ctx context.Context, id string) (*EmailOutbox, error)
 	RescheduleAppointment(ctx context.Context, arg RescheduleAppointmentParams) (*Appointment, error)
+	ResetUnverifiedPhoneUser(ctx context.Context, arg ResetUnverifiedPhoneUserParams) (*User, error)
 	// Retrieves all diagnostic records with pagination.
 	Retrieve_Diagnostic_Centres(ctx context.Context, arg Retrieve_Diagnostic_CentresParams) ([]*Retrieve_Diagnostic_CentresRow, error)
+	Retry_Email(ctx context.Context, arg Retry_EmailParams) error
 	// Searches diagnostic_centres by name with pagination.
 	Search_Diagnostic_Centres(ctx context.Context, arg Search_Diagnostic_CentresParams) ([]*Search_Diagnostic_CentresRow, error)
 	// SearchDiagnosticWith Doctor type
 	Search_Diagnostic_Centres_ByDoctor(ctx context.Context, arg Search_Diagnostic_Centres_ByDoctorParams) ([]*Search_Diagnostic_Centres_ByDoctorRow, error)
+	Settle_Payment_Refund(ctx context.Context, arg Settle_Payment_RefundParams) (*PaymentRefund, error)
+	Start_Settlement_Payout(ctx context.Context, arg Start_Settlement_PayoutParams) (*Settlement, error)
+	Sum_Payment_Refunds(ctx context.Context, paymentID string) (*Sum_Payment_RefundsRow, error)
+	Total_Insurance_Claim(ctx context.Context, id string) (*InsuranceClaim, error)
+	Total_Settlement(ctx context.Context, settlementID string) (*Settlement, error)
 	UnassignAdmin(ctx context.Context, arg UnassignAdminParams) (*DiagnosticCentre, error)
 	UpdateAppointmentPayment(ctx context.Context, arg UpdateAppointmentPaymentParams) (*Appointment, error)
 	UpdateAppointmentStatus(ctx context.Context, arg UpdateAppointmentStatusParams) (*Appointment, error)
@@ -117,8 +246,22 @@ type Querier interface {
 	// Update a diagnostic schedule
 	Update_Diagnostic_Schedule(ctx context.Context, arg Update_Diagnostic_ScheduleParams) (*DiagnosticSchedule, error)
 	Update_Diagnostic_Schedule_By_Centre(ctx context.Context, arg Update_Diagnostic_Schedule_By_CentreParams) (*DiagnosticSchedule, error)
+	Update_Insurance_Claim_Status(ctx context.Context, arg Update_Insurance_Claim_StatusParams) (*InsuranceClaim, error)
+	Update_Line_Item_Result_Status(ctx context.Context, arg Update_Line_Item_Result_StatusParams) (*AppointmentLineItem, error)
 	Update_Many_Availability(ctx context.Context, arg Update_Many_AvailabilityParams) ([]*DiagnosticCentreAvailability, error)
 	Update_Payment_Status(ctx context.Context, arg Update_Payment_StatusParams) (*Payment, error)
+	Update_Settlement_Payout(ctx context.Context, arg Update_Settlement_PayoutParams) (*Settlement, error)
+	Update_Test_Price(ctx context.Context, arg Update_Test_PriceParams) (*DiagnosticCentreTestPrice, error)
+	Upsert_Availability_Exception(ctx context.Context, arg Upsert_Availability_ExceptionParams) (*DiagnosticCentreAvailabilityException, error)
+	Upsert_Cancellation_Policy(ctx context.Context, arg Upsert_Cancellation_PolicyParams) (*DiagnosticCentreCancellationPolicy, error)
+	Upsert_Centre_Payout_Account(ctx context.Context, arg Upsert_Centre_Payout_AccountParams) (*CentrePayoutAccount, error)
+	Upsert_Coverage_Rule(ctx context.Context, arg Upsert_Coverage_RuleParams) (*InsuranceCoverageRule, error)
+	Upsert_Notification_Preference(ctx context.Context, arg Upsert_Notification_PreferenceParams) (*NotificationPreference, error)
+	Upsert_Notification_Quiet_Hours(ctx context.Context, arg Upsert_Notification_Quiet_HoursParams) (*NotificationQuietHour, error)
+	Upsert_Payment_Discrepancy(ctx context.Context, arg Upsert_Payment_DiscrepancyParams) (*PaymentDiscrepancy, error)
+	Upsert_Payment_Settings(ctx context.Context, arg Upsert_Payment_SettingsParams) (*DiagnosticCentrePaymentSetting, error)
+	// Adds a test price, reactivating and repricing it if the test type already exists.
+	Upsert_Test_Price(ctx context.Context, arg Upsert_Test_PriceParams) (*DiagnosticCentreTestPrice, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -15,6 +15,31 @@ INSERT INTO appointments (
 -- name: GetAppointment :one
 SELECT * FROM appointments WHERE id = $1;
 
+-- Counts how often an appointment was rescheduled and the fees charged along the way
+-- name: GetAppointmentRescheduleSummary :one
+WITH RECURSIVE chain AS (
+    SELECT appointments.id, appointments.original_appointment_id, appointments.rescheduling_fee, appointments.payment_id
+    FROM appointments
+    WHERE appointments.id = $1
+    UNION ALL
+    SELECT a.id, a.original_appointment_id, a.rescheduling_fee, a.payment_id
+    FROM appointments a
+    JOIN chain ON a.id = chain.original_appointment_id
+)
+SELECT
+    (COUNT(*) - 1)::int AS reschedule_count,
+    COALESCE(SUM(chain.rescheduling_fee), 0)::numeric AS rescheduling_fees,
+    -- Fees are paid against the appointment that replaced the rescheduled one, apart from
+    -- the payment the booking itself was settled with
+    (
+        SELECT COALESCE(SUM(payments.amount), 0)
+        FROM payments
+        WHERE payments.appointment_id IN (SELECT chain.id FROM chain)
+            AND payments.payment_status = 'success'
+            AND payments.id NOT IN (SELECT chain.payment_id FROM chain WHERE chain.payment_id IS NOT NULL)
+    )::numeric AS rescheduling_fees_paid
+FROM chain;
+
 -- name: GetAPatientAppointment :one
 SELECT * FROM appointments WHERE id = $1 AND patient_id = $2;
 
@@ -48,21 +73,30 @@ LIMIT $5 OFFSET $6;
 
 -- name: GetCentreAppointments :many
 SELECT 
-    id,
-    diagnostic_centre_id,
-    status,
-    appointment_date,
-    time_slot,
-    created_at,
-    updated_at
-FROM appointments 
-WHERE diagnostic_centre_id = $1
+    a.id,
+    a.diagnostic_centre_id,
+    a.patient_id,
+    a.schedule_id,
+    a.status,
+    a.appointment_date,
+    a.time_slot,
+    a.payment_status,
+    a.check_in_time,
+    a.completion_time,
+    a.created_at,
+    a.updated_at,
+    u.fullname AS patient_name,
+    ds.test_type
+FROM appointments a
+JOIN users u ON u.id = a.patient_id
+JOIN diagnostic_schedules ds ON ds.id = a.schedule_id
+WHERE a.diagnostic_centre_id = $1
     AND (
-        status = ANY($2::text[])
+        a.status::text = ANY($2::text[])
     )
-    AND appointment_date >= $3
-    AND appointment_date < $4
-ORDER BY appointment_date ASC
+    AND a.appointment_date >= $3
+    AND a.appointment_date < $4
+ORDER BY a.appointment_date ASC
 LIMIT $5 OFFSET $6;
 
 -- name: UpdateAppointmentStatus :one
@@ -71,7 +105,7 @@ SET
     status = COALESCE($2, status),
     updated_at = CURRENT_TIMESTAMP,
     check_in_time = CASE 
-        WHEN $2 = 'in_progress' AND check_in_time IS NULL THEN CURRENT_TIMESTAMP 
+        WHEN $2 IN ('checked_in', 'in_progress') AND check_in_time IS NULL THEN CURRENT_TIMESTAMP 
         ELSE check_in_time 
     END,
     completion_time = CASE 
@@ -79,6 +113,7 @@ SET
         ELSE completion_time 
     END
 WHERE id = $1 
+    AND status = $3
 RETURNING *;
 
 -- name: CancelAppointment :one
@@ -91,6 +126,18 @@ SET
     cancellation_fee = COALESCE($4, 0),
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 
+    AND status IN ('pending', 'confirmed')
+RETURNING *;
+
+-- name: ConfirmAppointmentsByPayment :many
+UPDATE appointments
+SET
+    status = 'confirmed',
+    payment_status = 'success',
+    payment_date = COALESCE(payment_date, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE payment_id = $1
+    AND status = 'pending'
 RETURNING *;
 
 -- name: RescheduleAppointment :one
@@ -104,11 +151,15 @@ WITH old_appointment AS (
         rescheduling_fee = COALESCE($4, 0),
         updated_at = CURRENT_TIMESTAMP
     WHERE appointments.id = $1 
+        AND appointments.status IN ('pending', 'confirmed')
     RETURNING 
         appointments.id as original_appointment_id,
         appointments.patient_id,
         appointments.diagnostic_centre_id,
+        appointments.payment_id,
+        appointments.payment_status,
         appointments.payment_amount,
+        appointments.payment_date,
         appointments.notes
 )
 INSERT INTO appointments (
@@ -118,7 +169,10 @@ INSERT INTO appointments (
     appointment_date,
     time_slot,
     status,
+    payment_id,
+    payment_status,
     payment_amount,
+    payment_date,
     notes,
     original_appointment_id
 ) 
@@ -128,8 +182,11 @@ SELECT
     old_appointment.diagnostic_centre_id,
     $6,  -- new_appointment_date
     $7,  -- new_time_slot
-    'confirmed',
+    CASE WHEN old_appointment.payment_status = 'success' THEN 'confirmed' ELSE 'pending' END::appointment_status,
+    old_appointment.payment_id,
+    old_appointment.payment_status,
     old_appointment.payment_amount,
+    old_appointment.payment_date,
     old_appointment.notes,
     old_appointment.original_appointment_id
 FROM old_appointment
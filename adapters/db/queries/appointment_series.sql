@@ -0,0 +1,48 @@
+-- name: Add_Series_Occurrence :exec
+INSERT INTO appointment_series_occurrences (
+    series_id,
+    appointment_id,
+    occurrence_number
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: Cancel_Appointment_Series :one
+UPDATE appointment_series
+SET
+    status = 'cancelled',
+    cancellation_reason = $3,
+    cancelled_at = CURRENT_TIMESTAMP
+WHERE id = $1
+AND patient_id = $2
+AND status = 'active'
+RETURNING *;
+
+-- name: Create_Appointment_Series :one
+INSERT INTO appointment_series (
+    patient_id,
+    diagnostic_centre_id,
+    test_type,
+    recurrence,
+    interval_weeks,
+    payment_mode,
+    notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: Get_Appointment_Series :one
+SELECT * FROM appointment_series
+WHERE id = $1;
+
+-- name: List_Series_Appointments :many
+SELECT appointments.* FROM appointments
+JOIN appointment_series_occurrences AS occurrences ON occurrences.appointment_id = appointments.id
+WHERE occurrences.series_id = $1
+ORDER BY occurrences.occurrence_number ASC;
+
+-- name: Move_Series_Occurrence :exec
+UPDATE appointment_series_occurrences
+SET appointment_id = $2
+WHERE appointment_id = $1;
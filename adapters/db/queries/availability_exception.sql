@@ -0,0 +1,40 @@
+-- name: Delete_Availability_Exception :exec
+DELETE FROM diagnostic_centre_availability_exceptions
+WHERE id = $1
+AND diagnostic_centre_id = $2;
+
+-- name: Get_Availability_Exception :one
+SELECT * FROM diagnostic_centre_availability_exceptions
+WHERE id = $1
+AND diagnostic_centre_id = $2;
+
+-- name: List_Availability_Exceptions :many
+SELECT * FROM diagnostic_centre_availability_exceptions
+WHERE diagnostic_centre_id = $1
+AND exception_date >= $2::date
+AND exception_date <= $3::date
+ORDER BY exception_date ASC;
+
+-- name: Upsert_Availability_Exception :one
+INSERT INTO diagnostic_centre_availability_exceptions (
+    diagnostic_centre_id,
+    exception_date,
+    is_closed,
+    start_time,
+    end_time,
+    max_appointments,
+    reason,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (diagnostic_centre_id, exception_date) DO UPDATE
+SET
+    is_closed = EXCLUDED.is_closed,
+    start_time = EXCLUDED.start_time,
+    end_time = EXCLUDED.end_time,
+    max_appointments = EXCLUDED.max_appointments,
+    reason = EXCLUDED.reason,
+    created_by = EXCLUDED.created_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
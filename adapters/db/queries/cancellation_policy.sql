@@ -0,0 +1,20 @@
+-- name: Get_Cancellation_Policy :one
+SELECT * FROM diagnostic_centre_cancellation_policies
+WHERE diagnostic_centre_id = $1;
+
+-- name: Upsert_Cancellation_Policy :one
+INSERT INTO diagnostic_centre_cancellation_policies (
+    diagnostic_centre_id,
+    free_cancellation_hours,
+    late_cancellation_fee_percent,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (diagnostic_centre_id) DO UPDATE
+SET
+    free_cancellation_hours = EXCLUDED.free_cancellation_hours,
+    late_cancellation_fee_percent = EXCLUDED.late_cancellation_fee_percent,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
@@ -0,0 +1,25 @@
+-- name: Record_Centre_Payment :one
+UPDATE payments
+SET
+    payment_method = $2,
+    receipt_number = $3,
+    received_by = $4,
+    received_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND payment_status = 'pending'
+    AND payment_method IN ('cash', 'transfer')
+RETURNING *;
+
+-- name: List_Expired_Centre_Payments :many
+SELECT * FROM payments
+WHERE payment_status = 'pending'
+    AND payment_method IN ('cash', 'transfer')
+    AND hold_expires_at < $1
+    AND EXISTS (
+        SELECT 1 FROM appointments
+        WHERE appointments.payment_id = payments.id
+            AND appointments.status = 'pending'
+    )
+ORDER BY hold_expires_at
+LIMIT $2;
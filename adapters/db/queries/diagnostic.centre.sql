@@ -333,6 +333,15 @@ WITH filtered_centres AS (
         AND dctp.test_type = $5
       )
     )
+    AND (
+      $8::date IS NULL OR NOT EXISTS (
+        SELECT 1
+        FROM diagnostic_centre_availability_exceptions dcae
+        WHERE dcae.diagnostic_centre_id = dc.id
+        AND dcae.exception_date = $8::date
+        AND dcae.is_closed = TRUE
+      )
+    )
 )
 SELECT
   dc.id,
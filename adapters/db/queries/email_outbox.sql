@@ -0,0 +1,71 @@
+-- name: Enqueue_Email :one
+INSERT INTO email_outbox (
+    recipient,
+    subject,
+    template,
+    html_body,
+    attachments,
+    sensitive
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: Claim_Outbox_Emails :many
+UPDATE email_outbox
+SET status = 'sending',
+    attempts = attempts + 1,
+    locked_until = $2
+WHERE id IN (
+    SELECT id FROM email_outbox
+    WHERE (status = 'pending' AND next_attempt_at <= NOW())
+       OR (status = 'sending' AND locked_until < NOW())
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: Mark_Email_Sent :exec
+UPDATE email_outbox
+SET status = 'sent',
+    sent_at = NOW(),
+    html_body = NULL,
+    locked_until = NULL,
+    last_error = NULL
+WHERE id = $1
+  AND status = 'sending';
+
+-- name: Retry_Email :exec
+UPDATE email_outbox
+SET status = 'pending',
+    next_attempt_at = $2,
+    locked_until = NULL,
+    last_error = $3
+WHERE id = $1
+  AND status = 'sending';
+
+-- name: Dead_Letter_Email :exec
+UPDATE email_outbox
+SET status = 'dead',
+    html_body = CASE WHEN sensitive THEN NULL ELSE html_body END,
+    locked_until = NULL,
+    last_error = $2
+WHERE id = $1
+  AND status = 'sending';
+
+-- name: List_Outbox_Emails :many
+SELECT * FROM email_outbox
+WHERE status = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: Replay_Email :one
+UPDATE email_outbox
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = NOW(),
+    last_error = NULL
+WHERE id = $1
+  AND status = 'dead'
+  AND html_body IS NOT NULL
+RETURNING *;
@@ -0,0 +1,93 @@
+-- name: Create_Insurer :one
+INSERT INTO insurers (
+    name,
+    code,
+    email,
+    phone,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: Get_Insurer :one
+SELECT * FROM insurers
+WHERE id = $1;
+
+-- name: List_Insurers :many
+SELECT * FROM insurers
+WHERE active
+ORDER BY name
+LIMIT $1 OFFSET $2;
+
+-- name: Create_Insurance_Plan :one
+INSERT INTO insurance_plans (
+    insurer_id,
+    name,
+    code,
+    currency
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: Get_Insurance_Plan :one
+SELECT * FROM insurance_plans
+WHERE id = $1;
+
+-- name: List_Insurance_Plans :many
+SELECT * FROM insurance_plans
+WHERE insurer_id = $1
+    AND active
+ORDER BY name;
+
+-- name: Upsert_Coverage_Rule :one
+INSERT INTO insurance_coverage_rules (
+    plan_id,
+    test_type,
+    covered_percent,
+    copay,
+    requires_preauthorization
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (plan_id, test_type) DO UPDATE
+SET covered_percent = EXCLUDED.covered_percent,
+    copay = EXCLUDED.copay,
+    requires_preauthorization = EXCLUDED.requires_preauthorization
+RETURNING *;
+
+-- name: List_Coverage_Rules :many
+SELECT * FROM insurance_coverage_rules
+WHERE plan_id = $1
+ORDER BY test_type;
+
+-- name: Create_Patient_Policy :one
+INSERT INTO patient_policies (
+    patient_id,
+    plan_id,
+    member_number,
+    valid_from,
+    valid_until
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: Get_Patient_Policy :one
+SELECT * FROM patient_policies
+WHERE id = $1
+    AND patient_id = $2;
+
+-- name: List_Patient_Policies :many
+SELECT * FROM patient_policies
+WHERE patient_id = $1
+ORDER BY active DESC, created_at DESC;
+
+-- name: End_Patient_Policy :one
+UPDATE patient_policies
+SET active = false
+WHERE id = $1
+    AND patient_id = $2
+    AND active
+RETURNING *;
@@ -0,0 +1,106 @@
+-- name: Create_Appointment_Coverage :one
+INSERT INTO appointment_coverages (
+    appointment_id,
+    policy_id,
+    insurer_id,
+    plan_id,
+    patient_id,
+    diagnostic_centre_id,
+    member_number,
+    currency,
+    total_amount,
+    insurer_amount,
+    patient_amount,
+    preauthorization_code,
+    lines
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+)
+RETURNING *;
+
+-- name: List_Payment_Coverages :many
+SELECT appointment_coverages.* FROM appointment_coverages
+JOIN appointments ON appointments.id = appointment_coverages.appointment_id
+WHERE appointments.payment_id = $1
+ORDER BY appointment_coverages.created_at;
+
+-- name: Create_Insurance_Claim :one
+INSERT INTO insurance_claims (
+    claim_number,
+    insurer_id,
+    diagnostic_centre_id,
+    currency,
+    created_by
+) VALUES (
+    'CLM-' || $1::text || '-' || lpad(nextval('insurance_claim_number_seq')::text, 6, '0'),
+    $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: Attach_Claim_Coverages :many
+UPDATE appointment_coverages
+SET claim_id = $1
+WHERE claim_id IS NULL
+    AND insurer_id = $2
+    AND diagnostic_centre_id = $3
+    AND currency = $4
+    AND appointment_id IN (
+        SELECT id FROM appointments
+        WHERE status = 'completed'
+            AND appointment_date < $5
+    )
+RETURNING *;
+
+-- name: Total_Insurance_Claim :one
+UPDATE insurance_claims
+SET claimed_amount = totals.claimed_amount,
+    coverage_count = totals.coverage_count
+FROM (
+    SELECT
+        COALESCE(SUM(insurer_amount), 0)::NUMERIC(12,2) AS claimed_amount,
+        COUNT(*)::INTEGER AS coverage_count
+    FROM appointment_coverages
+    WHERE claim_id = $1
+) AS totals
+WHERE insurance_claims.id = $1
+RETURNING insurance_claims.*;
+
+-- name: Get_Insurance_Claim :one
+SELECT * FROM insurance_claims
+WHERE id = $1;
+
+-- name: List_Insurance_Claims :many
+SELECT * FROM insurance_claims
+WHERE diagnostic_centre_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: List_Claim_Entries :many
+SELECT
+    appointment_coverages.appointment_id,
+    appointment_coverages.member_number,
+    appointment_coverages.preauthorization_code,
+    appointment_coverages.total_amount,
+    appointment_coverages.insurer_amount,
+    appointment_coverages.patient_amount,
+    appointment_coverages.lines,
+    appointments.appointment_date,
+    users.fullname AS patient_name
+FROM appointment_coverages
+JOIN appointments ON appointments.id = appointment_coverages.appointment_id
+JOIN users ON users.id = appointment_coverages.patient_id
+WHERE appointment_coverages.claim_id = $1
+ORDER BY appointments.appointment_date, appointment_coverages.appointment_id;
+
+-- name: Update_Insurance_Claim_Status :one
+UPDATE insurance_claims
+SET status = $2,
+    approved_amount = COALESCE($3, approved_amount),
+    insurer_reference = COALESCE($4, insurer_reference),
+    notes = COALESCE($5, notes),
+    submitted_at = CASE WHEN $2 = 'submitted' THEN CURRENT_TIMESTAMP ELSE submitted_at END,
+    decided_at = CASE WHEN $2 IN ('approved', 'rejected') THEN CURRENT_TIMESTAMP ELSE decided_at END,
+    settled_at = CASE WHEN $2 = 'settled' THEN CURRENT_TIMESTAMP ELSE settled_at END
+WHERE id = $1
+    AND status = $6
+RETURNING *;
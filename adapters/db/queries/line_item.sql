@@ -0,0 +1,49 @@
+-- name: Attach_Line_Item_Record :exec
+INSERT INTO appointment_line_item_records (
+    line_item_id,
+    medical_record_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (line_item_id, medical_record_id) DO NOTHING;
+
+-- name: Create_Appointment_Line_Item :one
+INSERT INTO appointment_line_items (
+    appointment_id,
+    test_type,
+    test_price_id,
+    price,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: Get_Appointment_Line_Item :one
+SELECT * FROM appointment_line_items
+WHERE id = $1;
+
+-- name: List_Appointment_Line_Item_Records :many
+SELECT records.* FROM appointment_line_item_records AS records
+JOIN appointment_line_items AS items ON items.id = records.line_item_id
+WHERE items.appointment_id = $1
+ORDER BY records.created_at ASC;
+
+-- name: List_Appointment_Line_Items :many
+SELECT * FROM appointment_line_items
+WHERE appointment_id = $1
+ORDER BY created_at ASC, test_type ASC;
+
+-- name: Move_Appointment_Line_Items :exec
+UPDATE appointment_line_items
+SET appointment_id = $2
+WHERE appointment_id = $1;
+
+-- name: Update_Line_Item_Result_Status :one
+UPDATE appointment_line_items
+SET
+    result_status = $2,
+    result_ready_at = CASE WHEN $2 = 'ready' THEN CURRENT_TIMESTAMP END
+WHERE id = $1
+AND result_status = $3
+RETURNING *;
@@ -0,0 +1,36 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    user_id,
+    type,
+    title,
+    message,
+    metadata
+) VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetUnreadCount :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = $1 AND read = FALSE;
+
+-- name: GetUserNotifications :many
+SELECT * FROM notifications
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: MarkAllAsRead :exec
+UPDATE notifications
+SET 
+    read = TRUE,
+    read_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = $1 AND read = FALSE;
+
+-- name: MarkAsRead :one
+UPDATE notifications
+SET 
+    read = TRUE,
+    read_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
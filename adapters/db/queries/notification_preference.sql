@@ -0,0 +1,61 @@
+-- name: List_Notification_Preferences :many
+SELECT * FROM notification_preferences
+WHERE user_id = $1
+ORDER BY type;
+
+-- name: Get_Notification_Preference :one
+SELECT * FROM notification_preferences
+WHERE user_id = $1 AND type = $2;
+
+-- name: Upsert_Notification_Preference :one
+INSERT INTO notification_preferences (
+    user_id,
+    type,
+    email,
+    sms,
+    in_app,
+    websocket
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id, type) DO UPDATE
+SET email = EXCLUDED.email,
+    sms = EXCLUDED.sms,
+    in_app = EXCLUDED.in_app,
+    websocket = EXCLUDED.websocket
+RETURNING *;
+
+-- name: Get_Notification_Quiet_Hours :one
+SELECT * FROM notification_quiet_hours
+WHERE user_id = $1;
+
+-- name: Upsert_Notification_Quiet_Hours :one
+INSERT INTO notification_quiet_hours (
+    user_id,
+    starts_at,
+    ends_at,
+    timezone
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id) DO UPDATE
+SET starts_at = EXCLUDED.starts_at,
+    ends_at = EXCLUDED.ends_at,
+    timezone = EXCLUDED.timezone
+RETURNING *;
+
+-- name: Delete_Notification_Quiet_Hours :exec
+DELETE FROM notification_quiet_hours
+WHERE user_id = $1;
+
+-- name: Record_Notification_Delivery :one
+INSERT INTO notification_deliveries (
+    user_id,
+    type,
+    notification_id,
+    delivered,
+    skipped,
+    failed
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
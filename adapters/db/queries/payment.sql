@@ -10,9 +10,11 @@ INSERT INTO payments (
     payment_provider,
     provider_reference,
     provider_metadata,
-    transaction_id
+    transaction_id,
+    wallet_amount,
+    hold_expires_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 ) RETURNING *;
 
 -- name: Get_Payment :one
@@ -0,0 +1,38 @@
+-- name: Create_Payment_Event :one
+INSERT INTO payment_events (
+    payment_id,
+    source,
+    event_type,
+    payment_provider,
+    provider_event_id,
+    from_status,
+    to_status,
+    amount,
+    payload,
+    actor_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+ON CONFLICT (payment_provider, provider_event_id) WHERE provider_event_id IS NOT NULL DO NOTHING
+RETURNING *;
+
+-- name: List_Payment_Events :many
+SELECT * FROM payment_events
+WHERE payment_id = $1
+ORDER BY created_at, id;
+
+-- name: Project_Payment_Status :one
+UPDATE payments
+SET
+    payment_status = $2,
+    transaction_id = COALESCE($4, transaction_id),
+    payment_metadata = COALESCE($5, payment_metadata),
+    payment_date = CASE WHEN $2 = 'success' THEN CURRENT_TIMESTAMP ELSE payment_date END,
+    refund_amount = COALESCE($6, refund_amount),
+    refund_reason = COALESCE($7, refund_reason),
+    refund_date = CASE WHEN $2 = 'refunded' THEN CURRENT_TIMESTAMP ELSE refund_date END,
+    refunded_by = COALESCE($8, refunded_by),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND payment_status = $3
+RETURNING *;
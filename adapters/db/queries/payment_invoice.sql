@@ -0,0 +1,48 @@
+-- name: Next_Invoice_Number :one
+INSERT INTO invoice_sequences (diagnostic_centre_id, last_number)
+VALUES ($1, 1)
+ON CONFLICT (diagnostic_centre_id) DO UPDATE
+SET last_number = invoice_sequences.last_number + 1
+RETURNING last_number;
+
+-- name: Create_Payment_Invoice :one
+INSERT INTO payment_invoices (
+    payment_id,
+    diagnostic_centre_id,
+    sequence_number,
+    invoice_number,
+    tax_rate
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (payment_id) DO NOTHING
+RETURNING *;
+
+-- name: Get_Payment_Invoice :one
+SELECT * FROM payment_invoices
+WHERE payment_id = $1;
+
+-- name: Mark_Invoice_Emailed :one
+UPDATE payment_invoices
+SET emailed_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND emailed_at IS NULL
+RETURNING *;
+
+-- name: List_Payment_Appointments :many
+SELECT * FROM appointments
+WHERE payment_id = $1
+ORDER BY appointment_date, id;
+
+-- name: List_Unreceipted_Payments :many
+SELECT * FROM payments
+WHERE payment_status = 'success'
+    AND updated_at >= $1
+    AND updated_at < $2
+    AND NOT EXISTS (
+        SELECT 1 FROM payment_invoices
+        WHERE payment_invoices.payment_id = payments.id
+            AND payment_invoices.emailed_at IS NOT NULL
+    )
+ORDER BY updated_at
+LIMIT $3;
@@ -0,0 +1,61 @@
+-- name: List_Payments_By_References :many
+SELECT * FROM payments
+WHERE payment_provider = $1
+    AND provider_reference = ANY($2::text[]);
+
+-- name: List_Unpaid_Appointment_Payments :many
+SELECT * FROM payments
+WHERE payment_status = 'pending'
+    AND payment_method NOT IN ('cash', 'transfer')
+    AND created_at < $1
+    AND EXISTS (
+        SELECT 1 FROM appointments
+        WHERE appointments.payment_id = payments.id
+            AND appointments.status = 'pending'
+    )
+ORDER BY created_at
+LIMIT $2;
+
+-- name: Expire_Appointments_By_Payment :many
+UPDATE appointments
+SET
+    status = 'cancelled',
+    payment_status = 'cancelled',
+    cancellation_reason = $2,
+    cancellation_time = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE payment_id = $1
+    AND status = 'pending'
+RETURNING *;
+
+-- name: Upsert_Payment_Discrepancy :one
+INSERT INTO payment_discrepancies (
+    payment_provider,
+    provider_reference,
+    payment_id,
+    kind,
+    payment_status,
+    provider_status,
+    payment_amount,
+    provider_amount,
+    currency,
+    resolved
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+ON CONFLICT (payment_provider, provider_reference, kind) DO UPDATE
+SET
+    payment_id = EXCLUDED.payment_id,
+    payment_status = EXCLUDED.payment_status,
+    provider_status = EXCLUDED.provider_status,
+    payment_amount = EXCLUDED.payment_amount,
+    provider_amount = EXCLUDED.provider_amount,
+    resolved = payment_discrepancies.resolved OR EXCLUDED.resolved,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: List_Payment_Discrepancies :many
+SELECT * FROM payment_discrepancies
+WHERE detected_at >= $1
+    AND detected_at < $2
+ORDER BY detected_at;
@@ -0,0 +1,112 @@
+-- name: Lock_Payment :one
+SELECT * FROM payments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: Create_Payment_Refund :one
+INSERT INTO payment_refunds (
+    payment_id,
+    payment_provider,
+    amount,
+    currency,
+    reason,
+    status,
+    provider_refund_id,
+    requested_by,
+    destination
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: Create_Appointment_Refund :exec
+INSERT INTO appointment_refunds (refund_id, appointment_id)
+VALUES ($1, $2);
+
+-- name: Sum_Payment_Refunds :one
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE status <> 'failed'), 0)::NUMERIC(10,2) AS committed,
+    COALESCE(SUM(amount) FILTER (WHERE status = 'processed'), 0)::NUMERIC(10,2) AS processed,
+    COALESCE(SUM(amount) FILTER (WHERE status <> 'failed' AND destination = 'provider'), 0)::NUMERIC(10,2) AS provider_committed
+FROM payment_refunds
+WHERE payment_id = $1;
+
+-- name: Get_Payment_Refund_By_Provider_ID :one
+SELECT * FROM payment_refunds
+WHERE payment_provider = $1 AND provider_refund_id = $2;
+
+-- name: Get_Unmatched_Payment_Refund :one
+SELECT * FROM payment_refunds
+WHERE payment_id = $1
+    AND amount = $2
+    AND status = 'pending'
+    AND provider_refund_id IS NULL
+ORDER BY created_at
+LIMIT 1;
+
+-- name: Settle_Payment_Refund :one
+UPDATE payment_refunds
+SET
+    status = $2,
+    provider_refund_id = COALESCE($3, provider_refund_id),
+    failure_reason = $4,
+    processed_at = CASE WHEN $2 = 'processed' THEN CURRENT_TIMESTAMP ELSE processed_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND status = 'pending'
+RETURNING *;
+
+-- name: List_Payment_Refunds :many
+SELECT * FROM payment_refunds
+WHERE payment_id = $1
+ORDER BY created_at, id;
+
+-- name: List_Pending_Provider_Refunds :many
+SELECT * FROM payment_refunds
+WHERE status = 'pending'
+    AND destination = 'provider'
+    AND provider_refund_id IS NOT NULL
+    AND created_at < $1
+ORDER BY created_at
+LIMIT $2;
+
+-- name: List_Unsent_Provider_Refunds :many
+SELECT * FROM payment_refunds
+WHERE status = 'pending'
+    AND destination = 'provider'
+    AND sent_at IS NULL
+    AND provider_refund_id IS NULL
+    AND created_at < $1
+ORDER BY created_at
+LIMIT $2;
+
+-- name: Mark_Payment_Refund_Sent :one
+UPDATE payment_refunds
+SET
+    sent_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND status = 'pending'
+    AND sent_at IS NULL
+RETURNING *;
+
+-- name: Mark_Refunded_Appointments :exec
+UPDATE appointments
+SET
+    payment_status = 'refunded',
+    updated_at = CURRENT_TIMESTAMP
+FROM appointment_refunds
+WHERE appointment_refunds.appointment_id = appointments.id
+    AND appointment_refunds.refund_id = $1;
+
+-- name: Project_Payment_Refund :one
+UPDATE payments
+SET
+    refund_amount = $2,
+    refund_reason = $3,
+    refund_date = CURRENT_TIMESTAMP,
+    refunded_by = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND payment_status = 'success'
+RETURNING *;
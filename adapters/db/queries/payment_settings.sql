@@ -0,0 +1,20 @@
+-- name: Get_Payment_Settings :one
+SELECT * FROM diagnostic_centre_payment_settings
+WHERE diagnostic_centre_id = $1;
+
+-- name: Upsert_Payment_Settings :one
+INSERT INTO diagnostic_centre_payment_settings (
+    diagnostic_centre_id,
+    payment_provider,
+    allow_fallback,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (diagnostic_centre_id) DO UPDATE
+SET
+    payment_provider = EXCLUDED.payment_provider,
+    allow_fallback = EXCLUDED.allow_fallback,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
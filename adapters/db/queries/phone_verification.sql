@@ -0,0 +1,43 @@
+-- name: CreatePhoneVerificationToken :one
+INSERT INTO phone_verification_tokens (
+    user_id,
+    phone_number,
+    code_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetLatestPhoneVerificationToken :one
+SELECT * FROM phone_verification_tokens
+WHERE phone_number = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CountPhoneVerificationTokensSince :one
+SELECT COUNT(*) FROM phone_verification_tokens
+WHERE phone_number = $1
+  AND created_at >= $2;
+
+-- name: IncrementPhoneVerificationAttempts :one
+UPDATE phone_verification_tokens
+SET attempts = attempts + 1
+WHERE id = $1
+  AND consumed_at IS NULL
+RETURNING *;
+
+-- name: ConsumePhoneVerificationToken :execrows
+WITH consumed AS (
+    UPDATE phone_verification_tokens
+    SET consumed_at = NOW()
+    WHERE id = $1
+      AND consumed_at IS NULL
+      AND expires_at > NOW()
+    RETURNING user_id, phone_number
+)
+UPDATE users u
+SET phone_verified_at = NOW(),
+    updated_at = NOW()
+FROM consumed c
+WHERE u.id = c.user_id
+  AND u.phone_number = c.phone_number;
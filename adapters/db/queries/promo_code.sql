@@ -0,0 +1,88 @@
+-- name: Create_Promo_Code :one
+INSERT INTO promo_codes (
+    code,
+    description,
+    discount_type,
+    discount_value,
+    max_discount,
+    currency,
+    diagnostic_centre_ids,
+    test_types,
+    first_booking_only,
+    starts_at,
+    ends_at,
+    max_redemptions,
+    max_redemptions_per_user,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+)
+RETURNING *;
+
+-- name: Get_Promo_Code :one
+SELECT * FROM promo_codes
+WHERE id = $1;
+
+-- name: Get_Promo_Code_By_Code :one
+SELECT * FROM promo_codes
+WHERE code = $1;
+
+-- name: List_Promo_Codes :many
+SELECT * FROM promo_codes
+WHERE created_by = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: Deactivate_Promo_Code :one
+UPDATE promo_codes
+SET active = false
+WHERE id = $1
+RETURNING *;
+
+-- name: Claim_Promo_Code :one
+UPDATE promo_codes
+SET redemption_count = redemption_count + 1
+WHERE id = $1
+    AND active
+    AND (max_redemptions IS NULL OR redemption_count < max_redemptions)
+RETURNING *;
+
+-- name: Count_User_Promo_Redemptions :one
+SELECT COUNT(*) FROM promo_redemptions
+WHERE promo_code_id = $1
+    AND user_id = $2
+    AND released_at IS NULL;
+
+-- name: Count_Patient_Payments :one
+SELECT COUNT(*) FROM payments
+WHERE patient_id = $1
+    AND payment_status IN ('pending', 'success', 'refunded');
+
+-- name: Create_Promo_Redemption :one
+INSERT INTO promo_redemptions (
+    promo_code_id,
+    code,
+    user_id,
+    payment_id,
+    discount_amount,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: Get_Payment_Promo_Redemption :one
+SELECT * FROM promo_redemptions
+WHERE payment_id = $1;
+
+-- name: Release_Promo_Redemption :execrows
+WITH released AS (
+    UPDATE promo_redemptions
+    SET released_at = CURRENT_TIMESTAMP
+    WHERE payment_id = $1
+        AND released_at IS NULL
+    RETURNING promo_code_id
+)
+UPDATE promo_codes
+SET redemption_count = redemption_count - 1
+WHERE id IN (SELECT promo_code_id FROM released);
@@ -0,0 +1,204 @@
+-- name: Upsert_Centre_Payout_Account :one
+INSERT INTO centre_payout_accounts (
+    diagnostic_centre_id,
+    bank_code,
+    account_number,
+    account_name,
+    currency,
+    recipient_code,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (diagnostic_centre_id) DO UPDATE
+SET
+    bank_code = EXCLUDED.bank_code,
+    account_number = EXCLUDED.account_number,
+    account_name = EXCLUDED.account_name,
+    currency = EXCLUDED.currency,
+    recipient_code = EXCLUDED.recipient_code,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: Get_Centre_Payout_Account :one
+SELECT * FROM centre_payout_accounts
+WHERE diagnostic_centre_id = $1;
+
+-- name: List_Unsettled_Centres :many
+SELECT DISTINCT diagnostic_centre_id, currency FROM (
+    SELECT payments.diagnostic_centre_id, payments.currency
+    FROM payments
+    WHERE payments.payment_status IN ('success', 'refunded')
+        AND payments.payment_method NOT IN ('cash', 'transfer')
+        AND payments.payment_date < $1
+        AND NOT EXISTS (
+            SELECT 1 FROM settlement_entries
+            WHERE settlement_entries.payment_id = payments.id
+                AND settlement_entries.kind = 'payment'
+        )
+    UNION
+    SELECT payments.diagnostic_centre_id, payments.currency
+    FROM payment_refunds
+    JOIN payments ON payments.id = payment_refunds.payment_id
+    WHERE payment_refunds.status = 'processed'
+        AND payments.payment_method NOT IN ('cash', 'transfer')
+        AND payment_refunds.processed_at < $1
+        AND NOT EXISTS (
+            SELECT 1 FROM settlement_entries
+            WHERE settlement_entries.refund_id = payment_refunds.id
+        )
+) AS unsettled
+ORDER BY diagnostic_centre_id, currency;
+
+-- name: Get_Latest_Settlement :one
+SELECT * FROM settlements
+WHERE diagnostic_centre_id = $1
+    AND currency = $2
+ORDER BY period_end DESC
+LIMIT 1;
+
+-- name: Create_Settlement :one
+INSERT INTO settlements (
+    diagnostic_centre_id,
+    period_start,
+    period_end,
+    currency,
+    commission_rate,
+    opening_balance
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (diagnostic_centre_id, currency, period_end) DO NOTHING
+RETURNING *;
+
+-- name: Add_Settlement_Payment_Entries :execrows
+INSERT INTO settlement_entries (
+    settlement_id,
+    kind,
+    payment_id,
+    amount,
+    commission_amount,
+    occurred_at
+)
+SELECT
+    settlements.id,
+    'payment',
+    payments.id,
+    payments.amount,
+    ROUND(payments.amount * settlements.commission_rate / 100, 2),
+    payments.payment_date
+FROM settlements
+JOIN payments ON payments.diagnostic_centre_id = settlements.diagnostic_centre_id
+    AND payments.currency = settlements.currency
+WHERE settlements.id = $1
+    AND payments.payment_status IN ('success', 'refunded')
+    AND payments.payment_method NOT IN ('cash', 'transfer')
+    AND payments.payment_date < settlements.period_end
+ON CONFLICT DO NOTHING;
+
+-- name: Add_Settlement_Refund_Entries :execrows
+INSERT INTO settlement_entries (
+    settlement_id,
+    kind,
+    payment_id,
+    refund_id,
+    amount,
+    commission_amount,
+    occurred_at
+)
+SELECT
+    settlements.id,
+    'refund',
+    payments.id,
+    payment_refunds.id,
+    -payment_refunds.amount,
+    -ROUND(payment_refunds.amount * settlements.commission_rate / 100, 2),
+    payment_refunds.processed_at
+FROM settlements
+JOIN payments ON payments.diagnostic_centre_id = settlements.diagnostic_centre_id
+    AND payments.currency = settlements.currency
+JOIN payment_refunds ON payment_refunds.payment_id = payments.id
+WHERE settlements.id = $1
+    AND payment_refunds.status = 'processed'
+    AND payments.payment_method NOT IN ('cash', 'transfer')
+    AND payment_refunds.processed_at < settlements.period_end
+ON CONFLICT DO NOTHING;
+
+-- name: Total_Settlement :one
+UPDATE settlements
+SET
+    gross_amount = totals.gross,
+    refunded_amount = totals.refunded,
+    commission_amount = totals.commission,
+    net_amount = settlements.opening_balance + totals.net,
+    payment_count = totals.payments,
+    refund_count = totals.refunds,
+    status = CASE
+        WHEN settlements.opening_balance + totals.net > 0 THEN 'pending'::settlement_status
+        ELSE 'carried_forward'::settlement_status
+    END,
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT
+        COALESCE(SUM(amount) FILTER (WHERE kind = 'payment'), 0) AS gross,
+        COALESCE(-SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS refunded,
+        COALESCE(SUM(commission_amount), 0) AS commission,
+        COALESCE(SUM(net_amount), 0) AS net,
+        COUNT(*) FILTER (WHERE kind = 'payment')::INTEGER AS payments,
+        COUNT(*) FILTER (WHERE kind = 'refund')::INTEGER AS refunds
+    FROM settlement_entries
+    WHERE settlement_id = $1
+) AS totals
+WHERE settlements.id = $1
+RETURNING *;
+
+-- name: List_Payable_Settlements :many
+SELECT * FROM settlements
+WHERE status = 'pending'
+    OR (status = 'failed' AND payout_attempts < $1)
+ORDER BY period_end, id
+LIMIT $2;
+
+-- name: Start_Settlement_Payout :one
+UPDATE settlements
+SET
+    status = 'processing',
+    payout_reference = $2,
+    payout_attempts = payout_attempts + 1,
+    failure_reason = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND status IN ('pending', 'failed')
+RETURNING *;
+
+-- name: Update_Settlement_Payout :one
+UPDATE settlements
+SET
+    status = $2,
+    transfer_code = COALESCE($3, transfer_code),
+    failure_reason = $4,
+    paid_at = CASE WHEN $2 = 'paid' THEN CURRENT_TIMESTAMP ELSE paid_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND status = 'processing'
+RETURNING *;
+
+-- name: Get_Settlement :one
+SELECT * FROM settlements
+WHERE id = $1;
+
+-- name: Get_Settlement_By_Payout_Reference :one
+SELECT * FROM settlements
+WHERE payout_reference = $1;
+
+-- name: List_Centre_Settlements :many
+SELECT * FROM settlements
+WHERE diagnostic_centre_id = $1
+ORDER BY period_end DESC, currency
+LIMIT $2 OFFSET $3;
+
+-- name: List_Settlement_Entries :many
+SELECT * FROM settlement_entries
+WHERE settlement_id = $1
+ORDER BY occurred_at, id;
@@ -16,3 +16,63 @@ INSERT INTO diagnostic_centre_test_prices (
 ) 
 SELECT * FROM test_price_params
 RETURNING *;
+
+-- name: Get_Active_Test_Price :one
+SELECT * FROM diagnostic_centre_test_prices
+WHERE diagnostic_centre_id = $1
+    AND test_type = $2
+    AND is_active = true;
+
+-- name: Get_Test_Price :one
+SELECT * FROM diagnostic_centre_test_prices
+WHERE id = $1 AND diagnostic_centre_id = $2;
+
+-- name: List_Test_Prices :many
+SELECT * FROM diagnostic_centre_test_prices
+WHERE diagnostic_centre_id = $1
+    AND ($2::boolean = false OR is_active = true)
+ORDER BY test_type ASC;
+
+-- Adds a test price, reactivating and repricing it if the test type already exists.
+-- name: Upsert_Test_Price :one
+INSERT INTO diagnostic_centre_test_prices (
+    diagnostic_centre_id,
+    test_type,
+    price,
+    currency,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, true
+)
+ON CONFLICT (diagnostic_centre_id, test_type) DO UPDATE
+SET
+    price = EXCLUDED.price,
+    currency = EXCLUDED.currency,
+    is_active = true,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: Update_Test_Price :one
+UPDATE diagnostic_centre_test_prices
+SET
+    price = $3,
+    currency = $4,
+    is_active = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND diagnostic_centre_id = $2
+RETURNING *;
+
+-- name: List_Test_Price_History :many
+SELECT * FROM diagnostic_centre_test_price_history
+WHERE test_price_id = $1 AND diagnostic_centre_id = $2
+ORDER BY effective_from DESC;
+
+-- Returns the price that was in effect for a test at a given time.
+-- name: Get_Test_Price_At :one
+SELECT * FROM diagnostic_centre_test_price_history
+WHERE diagnostic_centre_id = $1
+    AND test_type = $2
+    AND effective_from <= $3
+    AND (effective_to IS NULL OR effective_to > $3)
+ORDER BY effective_from DESC
+LIMIT 1;
@@ -18,6 +18,30 @@ INSERT INTO users (
 -- name: GetUserByEmail :one
 SELECT * FROM users WHERE email = $1;
 
+-- name: GetUserByPhoneNumber :one
+SELECT * FROM users
+WHERE phone_number = $1
+ORDER BY phone_verified_at IS NULL, created_at DESC
+LIMIT 1;
+
+-- name: ResetUnverifiedPhoneUser :one
+UPDATE users
+SET
+  password = $2,
+  user_type = $3,
+  fullname = $4,
+  updated_at = NOW()
+WHERE id = $1
+  AND email IS NULL
+  AND phone_verified_at IS NULL
+  -- Only accounts that phone sign-up sent a code to; older accounts sharing a verified
+  -- number are never handed over
+  AND EXISTS (
+    SELECT 1 FROM phone_verification_tokens
+    WHERE phone_verification_tokens.user_id = users.id
+  )
+RETURNING *;
+
 -- name: GetUsers :many
 SELECT * FROM users
 ORDER BY id
@@ -29,6 +53,7 @@ SET
   nin = COALESCE($2, nin),
   fullname = COALESCE($3, fullname),
   phone_number = COALESCE($4, phone_number),
+  phone_verified_at = CASE WHEN $4 IS NULL OR $4 = phone_number THEN phone_verified_at END,
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, email, nin, user_type, fullname, phone_number, email_verified, email_verified_at, created_at, updated_at;
@@ -0,0 +1,67 @@
+-- name: Cancel_Waitlist_Entry :one
+UPDATE appointment_waitlist_entries
+SET status = 'cancelled'
+WHERE id = $1
+AND patient_id = $2
+AND status IN ('waiting', 'offered')
+RETURNING *;
+
+-- name: Create_Waitlist_Entry :one
+INSERT INTO appointment_waitlist_entries (
+    patient_id,
+    diagnostic_centre_id,
+    test_type,
+    date_from,
+    date_to
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (patient_id, diagnostic_centre_id, test_type) WHERE status IN ('waiting', 'offered') DO NOTHING
+RETURNING *;
+
+-- name: Expire_Waitlist_Entries :many
+UPDATE appointment_waitlist_entries
+SET status = 'expired'
+WHERE (status = 'offered' AND hold_expires_at <= NOW())
+OR (status = 'waiting' AND date_to < CURRENT_DATE)
+RETURNING *;
+
+-- name: List_Patient_Waitlist_Entries :many
+SELECT * FROM appointment_waitlist_entries
+WHERE patient_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: List_Waitlist_Holds :many
+SELECT * FROM appointment_waitlist_entries
+WHERE diagnostic_centre_id = $1
+AND status = 'offered'
+AND hold_expires_at > NOW()
+AND offered_slot_start >= $2
+AND offered_slot_start < $3;
+
+-- name: Mark_Waitlist_Entry_Booked :exec
+UPDATE appointment_waitlist_entries
+SET status = 'booked'
+WHERE patient_id = $1
+AND diagnostic_centre_id = $2
+AND offered_slot_start = $3
+AND status = 'offered';
+
+-- name: Offer_Waitlist_Slot :one
+UPDATE appointment_waitlist_entries
+SET
+    status = 'offered',
+    offered_slot_start = $2,
+    offered_time_slot = $3,
+    hold_expires_at = $4
+WHERE id = (
+    SELECT id FROM appointment_waitlist_entries AS waiting
+    WHERE waiting.diagnostic_centre_id = $1
+    AND waiting.status = 'waiting'
+    AND ($2::timestamptz AT TIME ZONE 'UTC')::date BETWEEN waiting.date_from AND waiting.date_to
+    ORDER BY waiting.created_at ASC
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
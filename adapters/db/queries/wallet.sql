@@ -0,0 +1,56 @@
+-- name: Lock_Wallet :one
+SELECT * FROM wallets
+WHERE user_id = $1
+    AND currency = $2
+FOR UPDATE;
+
+-- name: Credit_Wallet :one
+INSERT INTO wallets (
+    user_id,
+    currency,
+    balance
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, currency) DO UPDATE
+SET
+    balance = wallets.balance + EXCLUDED.balance,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: Debit_Wallet :one
+UPDATE wallets
+SET
+    balance = balance - $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND balance >= $2
+RETURNING *;
+
+-- name: Create_Wallet_Transaction :one
+INSERT INTO wallet_transactions (
+    wallet_id,
+    direction,
+    kind,
+    amount,
+    balance_after,
+    payment_id,
+    refund_id,
+    description,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: List_User_Wallets :many
+SELECT * FROM wallets
+WHERE user_id = $1
+ORDER BY currency;
+
+-- name: List_Wallet_Transactions :many
+SELECT wallet_transactions.* FROM wallet_transactions
+JOIN wallets ON wallets.id = wallet_transactions.wallet_id
+WHERE wallets.user_id = $1
+ORDER BY wallet_transactions.created_at DESC, wallet_transactions.id
+LIMIT $2 OFFSET $3;
@@ -72,6 +72,20 @@ func (repo *Repository) ListAppointments(
 	return repo.database.GetPatientAppointments(ctx, params)
 }
 
+func (repo *Repository) GetCentreAppointments(
+	ctx context.Context,
+	params db.GetCentreAppointmentsParams,
+) ([]*db.GetCentreAppointmentsRow, error) {
+	return repo.database.GetCentreAppointments(ctx, params)
+}
+
+func (repo *Repository) UpdateAppointmentStatus(
+	ctx context.Context,
+	params db.UpdateAppointmentStatusParams,
+) (*db.Appointment, error) {
+	return repo.database.UpdateAppointmentStatus(ctx, params)
+}
+
 func (repo *Repository) UpdateAppointment(
 	ctx context.Context,
 	params db.UpdateAppointmentPaymentParams,
@@ -88,9 +102,9 @@ func (repo *Repository) GetUpcomingAppointments(
 
 func (repo *Repository) CancelAppointment(
 	ctx context.Context,
-	id string,
-) error {
-	return repo.database.DeleteAppointment(ctx, id)
+	params db.CancelAppointmentParams,
+) (*db.Appointment, error) {
+	return repo.database.CancelAppointment(ctx, params)
 }
 
 func (repo *Repository) MarkReminderSent(
@@ -107,6 +121,13 @@ func (repo *Repository) RescheduleAppointment(
 	return repo.database.RescheduleAppointment(ctx, params)
 }
 
+func (repo *Repository) GetAppointmentRescheduleSummary(
+	ctx context.Context,
+	id string,
+) (*db.GetAppointmentRescheduleSummaryRow, error) {
+	return repo.database.GetAppointmentRescheduleSummary(ctx, id)
+}
+
 // BeginTx starts a new transaction
 func (r *Repository) BeginTx(
 	ctx context.Context,
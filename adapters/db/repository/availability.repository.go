@@ -31,6 +31,13 @@ func (repo *Repository) DeleteAvailability(
 	return repo.database.Delete_Availability(ctx, param)
 }
 
+func (repo *Repository) DeleteAvailabilityException(
+	ctx context.Context,
+	params db.Delete_Availability_ExceptionParams,
+) error {
+	return repo.database.Delete_Availability_Exception(ctx, params)
+}
+
 func (repo *Repository) GetAvailability(
 	ctx context.Context,
 	params db.Get_AvailabilityParams,
@@ -38,6 +45,13 @@ func (repo *Repository) GetAvailability(
 	return repo.database.Get_Availability(ctx, params)
 }
 
+func (repo *Repository) GetAvailabilityException(
+	ctx context.Context,
+	params db.Get_Availability_ExceptionParams,
+) (*db.DiagnosticCentreAvailabilityException, error) {
+	return repo.database.Get_Availability_Exception(ctx, params)
+}
+
 func (repo *Repository) GetDiagnosticAvailability(
 	ctx context.Context,
 	req string,
@@ -45,6 +59,13 @@ func (repo *Repository) GetDiagnosticAvailability(
 	return repo.database.Get_Diagnostic_Availability(ctx, req)
 }
 
+func (repo *Repository) ListAvailabilityExceptions(
+	ctx context.Context,
+	params db.List_Availability_ExceptionsParams,
+) ([]*db.DiagnosticCentreAvailabilityException, error) {
+	return repo.database.List_Availability_Exceptions(ctx, params)
+}
+
 func (repo *Repository) UpdateAvailability(
 	ctx context.Context,
 	params db.Update_AvailabilityParams,
@@ -58,3 +79,10 @@ func (repo *Repository) UpdateManyAvailability(
 ) ([]*db.DiagnosticCentreAvailability, error) {
 	return repo.database.Update_Many_Availability(ctx, arg)
 }
+
+func (repo *Repository) UpsertAvailabilityException(
+	ctx context.Context,
+	params db.Upsert_Availability_ExceptionParams,
+) (*db.DiagnosticCentreAvailabilityException, error) {
+	return repo.database.Upsert_Availability_Exception(ctx, params)
+}
@@ -109,6 +109,34 @@ func (repo *Repository) UnAssignAdmin(
 	return repo.database.UnassignAdmin(ctx, arg)
 }
 
+func (repo *Repository) GetCancellationPolicy(
+	ctx context.Context,
+	diagnosticCentreID string,
+) (*db.DiagnosticCentreCancellationPolicy, error) {
+	return repo.database.Get_Cancellation_Policy(ctx, diagnosticCentreID)
+}
+
+func (repo *Repository) UpsertCancellationPolicy(
+	ctx context.Context,
+	arg db.Upsert_Cancellation_PolicyParams,
+) (*db.DiagnosticCentreCancellationPolicy, error) {
+	return repo.database.Upsert_Cancellation_Policy(ctx, arg)
+}
+
+func (repo *Repository) GetPaymentSettings(
+	ctx context.Context,
+	diagnosticCentreID string,
+) (*db.DiagnosticCentrePaymentSetting, error) {
+	return repo.database.Get_Payment_Settings(ctx, diagnosticCentreID)
+}
+
+func (repo *Repository) UpsertPaymentSettings(
+	ctx context.Context,
+	arg db.Upsert_Payment_SettingsParams,
+) (*db.DiagnosticCentrePaymentSetting, error) {
+	return repo.database.Upsert_Payment_Settings(ctx, arg)
+}
+
 // BeginTx starts a new transaction
 func (r *Repository) BeginDiagnostic(
 	ctx context.Context,
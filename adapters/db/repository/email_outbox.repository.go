@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure Repository implements EmailOutboxRepository
+var _ ports.EmailOutboxRepository = (*Repository)(nil)
+
+func (repo *Repository) EnqueueEmail(
+	ctx context.Context,
+	arg db.Enqueue_EmailParams,
+) (*db.EmailOutbox, error) {
+	return repo.database.Enqueue_Email(ctx, arg)
+}
+
+func (repo *Repository) ClaimOutboxEmails(
+	ctx context.Context,
+	arg db.Claim_Outbox_EmailsParams,
+) ([]*db.EmailOutbox, error) {
+	return repo.database.Claim_Outbox_Emails(ctx, arg)
+}
+
+func (repo *Repository) MarkEmailSent(
+	ctx context.Context,
+	id string,
+) error {
+	return repo.database.Mark_Email_Sent(ctx, id)
+}
+
+func (repo *Repository) RetryEmail(
+	ctx context.Context,
+	arg db.Retry_EmailParams,
+) error {
+	return repo.database.Retry_Email(ctx, arg)
+}
+
+func (repo *Repository) DeadLetterEmail(
+	ctx context.Context,
+	arg db.Dead_Letter_EmailParams,
+) error {
+	return repo.database.Dead_Letter_Email(ctx, arg)
+}
+
+func (repo *Repository) ListOutboxEmails(
+	ctx context.Context,
+	arg db.List_Outbox_EmailsParams,
+) ([]*db.EmailOutbox, error) {
+	return repo.database.List_Outbox_Emails(ctx, arg)
+}
+
+func (repo *Repository) ReplayEmail(
+	ctx context.Context,
+	id string,
+) (*db.EmailOutbox, error) {
+	return repo.database.Replay_Email(ctx, id)
+}
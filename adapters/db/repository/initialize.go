@@ -16,6 +16,15 @@ type Repositories struct {
 	Appointment  ports.AppointmentRepository
 	TestPrice    ports.TestPriceRepository
 	Notification ports.NotificationRepository
+	Waitlist     ports.WaitlistRepository
+	Series       ports.AppointmentSeriesRepository
+	LineItem     ports.LineItemRepository
+	Settlement   ports.SettlementRepository
+	Wallet       ports.WalletRepository
+	Invoice      ports.InvoiceRepository
+	PromoCode    ports.PromoCodeRepository
+	Insurance    ports.InsuranceRepository
+	EmailOutbox  ports.EmailOutboxRepository
 }
 
 // InitializeRepositories creates and returns all repositories
@@ -27,6 +36,15 @@ func InitializeRepositories(store *db.Queries, conn *pgxpool.Pool) *Repositories
 		TestPrice:    NewTestPriceRepository(store),
 		Availability: NewAvailabilityRepository(store),
 		Notification: NewNotificationRepository(store),
+		Waitlist:     NewWaitlistRepository(store),
+		Series:       NewAppointmentSeriesRepository(store),
+		LineItem:     NewLineItemRepository(store),
+		Settlement:   NewSettlementRepository(store),
+		Wallet:       NewWalletRepository(store),
+		Invoice:      NewInvoiceRepository(store),
+		PromoCode:    NewPromoCodeRepository(store),
+		Insurance:    NewInsuranceRepository(store),
+		EmailOutbox:  NewEmailOutboxRepository(store),
 
 		Payment:     NewPaymentRepository(store, conn),
 		Appointment: NewAppointmentRepository(store, conn),
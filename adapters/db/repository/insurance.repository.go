@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ensure Repository implements InsuranceRepository
+var _ ports.InsuranceRepository = (*Repository)(nil)
+
+func (repo *Repository) CreateInsurer(
+	ctx context.Context,
+	arg db.Create_InsurerParams,
+) (*db.Insurer, error) {
+	return repo.database.Create_Insurer(ctx, arg)
+}
+
+func (repo *Repository) GetInsurer(
+	ctx context.Context,
+	id string,
+) (*db.Insurer, error) {
+	return repo.database.Get_Insurer(ctx, id)
+}
+
+func (repo *Repository) ListInsurers(
+	ctx context.Context,
+	arg db.List_InsurersParams,
+) ([]*db.Insurer, error) {
+	return repo.database.List_Insurers(ctx, arg)
+}
+
+func (repo *Repository) CreateInsurancePlan(
+	ctx context.Context,
+	arg db.Create_Insurance_PlanParams,
+) (*db.InsurancePlan, error) {
+	return repo.database.Create_Insurance_Plan(ctx, arg)
+}
+
+func (repo *Repository) GetInsurancePlan(
+	ctx context.Context,
+	id string,
+) (*db.InsurancePlan, error) {
+	return repo.database.Get_Insurance_Plan(ctx, id)
+}
+
+func (repo *Repository) ListInsurancePlans(
+	ctx context.Context,
+	insurerID string,
+) ([]*db.InsurancePlan, error) {
+	return repo.database.List_Insurance_Plans(ctx, insurerID)
+}
+
+func (repo *Repository) UpsertCoverageRule(
+	ctx context.Context,
+	arg db.Upsert_Coverage_RuleParams,
+) (*db.InsuranceCoverageRule, error) {
+	return repo.database.Upsert_Coverage_Rule(ctx, arg)
+}
+
+func (repo *Repository) ListCoverageRules(
+	ctx context.Context,
+	planID string,
+) ([]*db.InsuranceCoverageRule, error) {
+	return repo.database.List_Coverage_Rules(ctx, planID)
+}
+
+func (repo *Repository) CreatePatientPolicy(
+	ctx context.Context,
+	arg db.Create_Patient_PolicyParams,
+) (*db.PatientPolicy, error) {
+	return repo.database.Create_Patient_Policy(ctx, arg)
+}
+
+func (repo *Repository) GetPatientPolicy(
+	ctx context.Context,
+	arg db.Get_Patient_PolicyParams,
+) (*db.PatientPolicy, error) {
+	return repo.database.Get_Patient_Policy(ctx, arg)
+}
+
+func (repo *Repository) ListPatientPolicies(
+	ctx context.Context,
+	patientID string,
+) ([]*db.PatientPolicy, error) {
+	return repo.database.List_Patient_Policies(ctx, patientID)
+}
+
+func (repo *Repository) EndPatientPolicy(
+	ctx context.Context,
+	arg db.End_Patient_PolicyParams,
+) (*db.PatientPolicy, error) {
+	return repo.database.End_Patient_Policy(ctx, arg)
+}
+
+func (repo *Repository) ListPaymentCoverages(
+	ctx context.Context,
+	paymentID pgtype.UUID,
+) ([]*db.AppointmentCoverage, error) {
+	return repo.database.List_Payment_Coverages(ctx, paymentID)
+}
+
+func (repo *Repository) GetInsuranceClaim(
+	ctx context.Context,
+	id string,
+) (*db.InsuranceClaim, error) {
+	return repo.database.Get_Insurance_Claim(ctx, id)
+}
+
+func (repo *Repository) ListInsuranceClaims(
+	ctx context.Context,
+	arg db.List_Insurance_ClaimsParams,
+) ([]*db.InsuranceClaim, error) {
+	return repo.database.List_Insurance_Claims(ctx, arg)
+}
+
+func (repo *Repository) ListClaimEntries(
+	ctx context.Context,
+	claimID pgtype.UUID,
+) ([]*db.List_Claim_EntriesRow, error) {
+	return repo.database.List_Claim_Entries(ctx, claimID)
+}
+
+func (repo *Repository) UpdateInsuranceClaimStatus(
+	ctx context.Context,
+	arg db.Update_Insurance_Claim_StatusParams,
+) (*db.InsuranceClaim, error) {
+	return repo.database.Update_Insurance_Claim_Status(ctx, arg)
+}
+
+func (repo *Repository) CreateAppointmentCoverage(
+	ctx context.Context,
+	arg db.Create_Appointment_CoverageParams,
+) (*db.AppointmentCoverage, error) {
+	return repo.database.Create_Appointment_Coverage(ctx, arg)
+}
+
+func (repo *Repository) CreateInsuranceClaim(
+	ctx context.Context,
+	arg db.Create_Insurance_ClaimParams,
+) (*db.InsuranceClaim, error) {
+	return repo.database.Create_Insurance_Claim(ctx, arg)
+}
+
+func (repo *Repository) AttachClaimCoverages(
+	ctx context.Context,
+	arg db.Attach_Claim_CoveragesParams,
+) ([]*db.AppointmentCoverage, error) {
+	return repo.database.Attach_Claim_Coverages(ctx, arg)
+}
+
+func (repo *Repository) TotalInsuranceClaim(
+	ctx context.Context,
+	id string,
+) (*db.InsuranceClaim, error) {
+	return repo.database.Total_Insurance_Claim(ctx, id)
+}
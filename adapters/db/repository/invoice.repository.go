@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ensure Repository implements InvoiceRepository
+var _ ports.InvoiceRepository = (*Repository)(nil)
+
+func (repo *Repository) GetPaymentInvoice(
+	ctx context.Context,
+	paymentID string,
+) (*db.PaymentInvoice, error) {
+	return repo.database.Get_Payment_Invoice(ctx, paymentID)
+}
+
+func (repo *Repository) MarkInvoiceEmailed(
+	ctx context.Context,
+	id string,
+) (*db.PaymentInvoice, error) {
+	return repo.database.Mark_Invoice_Emailed(ctx, id)
+}
+
+func (repo *Repository) ListPaymentAppointments(
+	ctx context.Context,
+	paymentID pgtype.UUID,
+) ([]*db.Appointment, error) {
+	return repo.database.List_Payment_Appointments(ctx, paymentID)
+}
+
+func (repo *Repository) NextInvoiceNumber(
+	ctx context.Context,
+	diagnosticCentreID string,
+) (int64, error) {
+	return repo.database.Next_Invoice_Number(ctx, diagnosticCentreID)
+}
+
+func (repo *Repository) CreatePaymentInvoice(
+	ctx context.Context,
+	arg db.Create_Payment_InvoiceParams,
+) (*db.PaymentInvoice, error) {
+	return repo.database.Create_Payment_Invoice(ctx, arg)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure Repository implements LineItemRepository
+var _ ports.LineItemRepository = (*Repository)(nil)
+
+func (repo *Repository) CreateAppointmentLineItem(
+	ctx context.Context,
+	arg db.Create_Appointment_Line_ItemParams,
+) (*db.AppointmentLineItem, error) {
+	return repo.database.Create_Appointment_Line_Item(ctx, arg)
+}
+
+func (repo *Repository) GetAppointmentLineItem(
+	ctx context.Context,
+	id string,
+) (*db.AppointmentLineItem, error) {
+	return repo.database.Get_Appointment_Line_Item(ctx, id)
+}
+
+func (repo *Repository) ListAppointmentLineItems(
+	ctx context.Context,
+	appointmentID string,
+) ([]*db.AppointmentLineItem, error) {
+	return repo.database.List_Appointment_Line_Items(ctx, appointmentID)
+}
+
+func (repo *Repository) ListAppointmentLineItemRecords(
+	ctx context.Context,
+	appointmentID string,
+) ([]*db.AppointmentLineItemRecord, error) {
+	return repo.database.List_Appointment_Line_Item_Records(ctx, appointmentID)
+}
+
+func (repo *Repository) UpdateLineItemResultStatus(
+	ctx context.Context,
+	arg db.Update_Line_Item_Result_StatusParams,
+) (*db.AppointmentLineItem, error) {
+	return repo.database.Update_Line_Item_Result_Status(ctx, arg)
+}
+
+func (repo *Repository) AttachLineItemRecord(
+	ctx context.Context,
+	arg db.Attach_Line_Item_RecordParams,
+) error {
+	return repo.database.Attach_Line_Item_Record(ctx, arg)
+}
+
+func (repo *Repository) MoveAppointmentLineItems(
+	ctx context.Context,
+	arg db.Move_Appointment_Line_ItemsParams,
+) error {
+	return repo.database.Move_Appointment_Line_Items(ctx, arg)
+}
@@ -23,4 +23,32 @@ func (r *Repository) MarkNotificationReadAll(ctx context.Context, arg string) er
 	return r.database.MarkAllAsRead(ctx, arg)
 }
 
+func (r *Repository) ListNotificationPreferences(ctx context.Context, userID string) ([]*db.NotificationPreference, error) {
+	return r.database.List_Notification_Preferences(ctx, userID)
+}
+
+func (r *Repository) GetNotificationPreference(ctx context.Context, arg db.Get_Notification_PreferenceParams) (*db.NotificationPreference, error) {
+	return r.database.Get_Notification_Preference(ctx, arg)
+}
+
+func (r *Repository) UpsertNotificationPreference(ctx context.Context, arg db.Upsert_Notification_PreferenceParams) (*db.NotificationPreference, error) {
+	return r.database.Upsert_Notification_Preference(ctx, arg)
+}
+
+func (r *Repository) GetNotificationQuietHours(ctx context.Context, userID string) (*db.NotificationQuietHour, error) {
+	return r.database.Get_Notification_Quiet_Hours(ctx, userID)
+}
+
+func (r *Repository) UpsertNotificationQuietHours(ctx context.Context, arg db.Upsert_Notification_Quiet_HoursParams) (*db.NotificationQuietHour, error) {
+	return r.database.Upsert_Notification_Quiet_Hours(ctx, arg)
+}
+
+func (r *Repository) DeleteNotificationQuietHours(ctx context.Context, userID string) error {
+	return r.database.Delete_Notification_Quiet_Hours(ctx, userID)
+}
+
+func (r *Repository) RecordNotificationDelivery(ctx context.Context, arg db.Record_Notification_DeliveryParams) (*db.NotificationDelivery, error) {
+	return r.database.Record_Notification_Delivery(ctx, arg)
+}
+
 var _ ports.NotificationRepository = (*Repository)(nil)
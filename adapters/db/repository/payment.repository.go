@@ -33,28 +33,182 @@ func (repo *Repository) ListPayments(
 	return repo.database.List_Payments(ctx, params)
 }
 
-func (repo *Repository) RefundPayment(
+func (repo *Repository) GetPaymentByReference(
 	ctx context.Context,
-	params db.Refund_PaymentParams,
+	reference string,
 ) (*db.Payment, error) {
-	return repo.database.Refund_Payment(ctx, params)
+	return repo.database.GetPaymentByReference(ctx, pgtype.Text{
+		String: reference,
+		Valid:  true,
+	})
+}
+
+func (repo *Repository) ListPaymentEvents(
+	ctx context.Context,
+	paymentID string,
+) ([]*db.PaymentEvent, error) {
+	return repo.database.List_Payment_Events(ctx, paymentID)
+}
+
+func (repo *Repository) CreatePaymentEvent(
+	ctx context.Context,
+	arg db.Create_Payment_EventParams,
+) (*db.PaymentEvent, error) {
+	return repo.database.Create_Payment_Event(ctx, arg)
 }
 
-func (repo *Repository) UpdatePaymentStatus(
+func (repo *Repository) ProjectPaymentStatus(
 	ctx context.Context,
-	params db.Update_Payment_StatusParams,
+	arg db.Project_Payment_StatusParams,
 ) (*db.Payment, error) {
-	return repo.database.Update_Payment_Status(ctx, params)
+	return repo.database.Project_Payment_Status(ctx, arg)
 }
 
-func (repo *Repository) GetPaymentByReference(
+func (repo *Repository) ListPaymentRefunds(
 	ctx context.Context,
-	reference string,
+	paymentID string,
+) ([]*db.PaymentRefund, error) {
+	return repo.database.List_Payment_Refunds(ctx, paymentID)
+}
+
+func (repo *Repository) ListPendingProviderRefunds(
+	ctx context.Context,
+	arg db.List_Pending_Provider_RefundsParams,
+) ([]*db.PaymentRefund, error) {
+	return repo.database.List_Pending_Provider_Refunds(ctx, arg)
+}
+
+func (repo *Repository) ListUnreceiptedPayments(
+	ctx context.Context,
+	arg db.List_Unreceipted_PaymentsParams,
+) ([]*db.Payment, error) {
+	return repo.database.List_Unreceipted_Payments(ctx, arg)
+}
+
+func (repo *Repository) ListUnsentProviderRefunds(
+	ctx context.Context,
+	arg db.List_Unsent_Provider_RefundsParams,
+) ([]*db.PaymentRefund, error) {
+	return repo.database.List_Unsent_Provider_Refunds(ctx, arg)
+}
+
+func (repo *Repository) LockPayment(
+	ctx context.Context,
+	id string,
 ) (*db.Payment, error) {
-	return repo.database.GetPaymentByReference(ctx, pgtype.Text{
-		String: reference,
-		Valid:  true,
-	})
+	return repo.database.Lock_Payment(ctx, id)
+}
+
+func (repo *Repository) RecordCentrePayment(
+	ctx context.Context,
+	arg db.Record_Centre_PaymentParams,
+) (*db.Payment, error) {
+	return repo.database.Record_Centre_Payment(ctx, arg)
+}
+
+func (repo *Repository) CreatePaymentRefund(
+	ctx context.Context,
+	arg db.Create_Payment_RefundParams,
+) (*db.PaymentRefund, error) {
+	return repo.database.Create_Payment_Refund(ctx, arg)
+}
+
+func (repo *Repository) CreateAppointmentRefund(
+	ctx context.Context,
+	arg db.Create_Appointment_RefundParams,
+) error {
+	return repo.database.Create_Appointment_Refund(ctx, arg)
+}
+
+func (repo *Repository) SumPaymentRefunds(
+	ctx context.Context,
+	paymentID string,
+) (*db.Sum_Payment_RefundsRow, error) {
+	return repo.database.Sum_Payment_Refunds(ctx, paymentID)
+}
+
+func (repo *Repository) GetPaymentRefundByProviderID(
+	ctx context.Context,
+	arg db.Get_Payment_Refund_By_Provider_IDParams,
+) (*db.PaymentRefund, error) {
+	return repo.database.Get_Payment_Refund_By_Provider_ID(ctx, arg)
+}
+
+func (repo *Repository) GetUnmatchedPaymentRefund(
+	ctx context.Context,
+	arg db.Get_Unmatched_Payment_RefundParams,
+) (*db.PaymentRefund, error) {
+	return repo.database.Get_Unmatched_Payment_Refund(ctx, arg)
+}
+
+func (repo *Repository) SettlePaymentRefund(
+	ctx context.Context,
+	arg db.Settle_Payment_RefundParams,
+) (*db.PaymentRefund, error) {
+	return repo.database.Settle_Payment_Refund(ctx, arg)
+}
+
+func (repo *Repository) MarkPaymentRefundSent(
+	ctx context.Context,
+	id string,
+) (*db.PaymentRefund, error) {
+	return repo.database.Mark_Payment_Refund_Sent(ctx, id)
+}
+
+func (repo *Repository) MarkRefundedAppointments(
+	ctx context.Context,
+	refundID string,
+) error {
+	return repo.database.Mark_Refunded_Appointments(ctx, refundID)
+}
+
+func (repo *Repository) ProjectPaymentRefund(
+	ctx context.Context,
+	arg db.Project_Payment_RefundParams,
+) (*db.Payment, error) {
+	return repo.database.Project_Payment_Refund(ctx, arg)
+}
+
+func (repo *Repository) ExpireAppointmentsByPayment(
+	ctx context.Context,
+	arg db.Expire_Appointments_By_PaymentParams,
+) ([]*db.Appointment, error) {
+	return repo.database.Expire_Appointments_By_Payment(ctx, arg)
+}
+
+func (repo *Repository) ListPaymentsByReferences(
+	ctx context.Context,
+	arg db.List_Payments_By_ReferencesParams,
+) ([]*db.Payment, error) {
+	return repo.database.List_Payments_By_References(ctx, arg)
+}
+
+func (repo *Repository) ListUnpaidAppointmentPayments(
+	ctx context.Context,
+	arg db.List_Unpaid_Appointment_PaymentsParams,
+) ([]*db.Payment, error) {
+	return repo.database.List_Unpaid_Appointment_Payments(ctx, arg)
+}
+
+func (repo *Repository) ListExpiredCentrePayments(
+	ctx context.Context,
+	arg db.List_Expired_Centre_PaymentsParams,
+) ([]*db.Payment, error) {
+	return repo.database.List_Expired_Centre_Payments(ctx, arg)
+}
+
+func (repo *Repository) UpsertPaymentDiscrepancy(
+	ctx context.Context,
+	arg db.Upsert_Payment_DiscrepancyParams,
+) (*db.PaymentDiscrepancy, error) {
+	return repo.database.Upsert_Payment_Discrepancy(ctx, arg)
+}
+
+func (repo *Repository) ListPaymentDiscrepancies(
+	ctx context.Context,
+	arg db.List_Payment_DiscrepanciesParams,
+) ([]*db.PaymentDiscrepancy, error) {
+	return repo.database.List_Payment_Discrepancies(ctx, arg)
 }
 
 func (repo *Repository) BeginWith(
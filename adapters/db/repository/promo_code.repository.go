@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure Repository implements PromoCodeRepository
+var _ ports.PromoCodeRepository = (*Repository)(nil)
+
+func (repo *Repository) CreatePromoCode(
+	ctx context.Context,
+	arg db.Create_Promo_CodeParams,
+) (*db.PromoCode, error) {
+	return repo.database.Create_Promo_Code(ctx, arg)
+}
+
+func (repo *Repository) GetPromoCode(
+	ctx context.Context,
+	id string,
+) (*db.PromoCode, error) {
+	return repo.database.Get_Promo_Code(ctx, id)
+}
+
+func (repo *Repository) GetPromoCodeByCode(
+	ctx context.Context,
+	code string,
+) (*db.PromoCode, error) {
+	return repo.database.Get_Promo_Code_By_Code(ctx, code)
+}
+
+func (repo *Repository) ListPromoCodes(
+	ctx context.Context,
+	arg db.List_Promo_CodesParams,
+) ([]*db.PromoCode, error) {
+	return repo.database.List_Promo_Codes(ctx, arg)
+}
+
+func (repo *Repository) DeactivatePromoCode(
+	ctx context.Context,
+	id string,
+) (*db.PromoCode, error) {
+	return repo.database.Deactivate_Promo_Code(ctx, id)
+}
+
+func (repo *Repository) GetPaymentPromoRedemption(
+	ctx context.Context,
+	paymentID string,
+) (*db.PromoRedemption, error) {
+	return repo.database.Get_Payment_Promo_Redemption(ctx, paymentID)
+}
+
+func (repo *Repository) CountUserPromoRedemptions(
+	ctx context.Context,
+	arg db.Count_User_Promo_RedemptionsParams,
+) (int64, error) {
+	return repo.database.Count_User_Promo_Redemptions(ctx, arg)
+}
+
+func (repo *Repository) CountPatientPayments(
+	ctx context.Context,
+	patientID string,
+) (int64, error) {
+	return repo.database.Count_Patient_Payments(ctx, patientID)
+}
+
+func (repo *Repository) ClaimPromoCode(
+	ctx context.Context,
+	id string,
+) (*db.PromoCode, error) {
+	return repo.database.Claim_Promo_Code(ctx, id)
+}
+
+func (repo *Repository) CreatePromoRedemption(
+	ctx context.Context,
+	arg db.Create_Promo_RedemptionParams,
+) (*db.PromoRedemption, error) {
+	return repo.database.Create_Promo_Redemption(ctx, arg)
+}
+
+func (repo *Repository) ReleasePromoRedemption(
+	ctx context.Context,
+	paymentID string,
+) (int64, error) {
+	return repo.database.Release_Promo_Redemption(ctx, paymentID)
+}
@@ -74,6 +74,60 @@ func NewDiagnosticCentreRepository(
 	return &Repository{database: store, pool: pool}
 }
 
+func NewWaitlistRepository(
+	store *db.Queries,
+) ports.WaitlistRepository {
+	return &Repository{database: store}
+}
+
+func NewAppointmentSeriesRepository(
+	store *db.Queries,
+) ports.AppointmentSeriesRepository {
+	return &Repository{database: store}
+}
+
+func NewLineItemRepository(
+	store *db.Queries,
+) ports.LineItemRepository {
+	return &Repository{database: store}
+}
+
+func NewSettlementRepository(
+	store *db.Queries,
+) ports.SettlementRepository {
+	return &Repository{database: store}
+}
+
+func NewWalletRepository(
+	store *db.Queries,
+) ports.WalletRepository {
+	return &Repository{database: store}
+}
+
+func NewInvoiceRepository(
+	store *db.Queries,
+) ports.InvoiceRepository {
+	return &Repository{database: store}
+}
+
+func NewPromoCodeRepository(
+	store *db.Queries,
+) ports.PromoCodeRepository {
+	return &Repository{database: store}
+}
+
+func NewInsuranceRepository(
+	store *db.Queries,
+) ports.InsuranceRepository {
+	return &Repository{database: store}
+}
+
+func NewEmailOutboxRepository(
+	store *db.Queries,
+) ports.EmailOutboxRepository {
+	return &Repository{database: store}
+}
+
 func NewNotificationRepository(
 	store *db.Queries,
 ) ports.NotificationRepository {
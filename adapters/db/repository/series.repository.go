@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ensure Repository implements AppointmentSeriesRepository
+var _ ports.AppointmentSeriesRepository = (*Repository)(nil)
+
+func (repo *Repository) CreateAppointmentSeries(
+	ctx context.Context,
+	arg db.Create_Appointment_SeriesParams,
+) (*db.AppointmentSeries, error) {
+	return repo.database.Create_Appointment_Series(ctx, arg)
+}
+
+func (repo *Repository) GetAppointmentSeries(
+	ctx context.Context,
+	id string,
+) (*db.AppointmentSeries, error) {
+	return repo.database.Get_Appointment_Series(ctx, id)
+}
+
+func (repo *Repository) CancelAppointmentSeries(
+	ctx context.Context,
+	arg db.Cancel_Appointment_SeriesParams,
+) (*db.AppointmentSeries, error) {
+	return repo.database.Cancel_Appointment_Series(ctx, arg)
+}
+
+func (repo *Repository) ListSeriesAppointments(
+	ctx context.Context,
+	seriesID string,
+) ([]*db.Appointment, error) {
+	return repo.database.List_Series_Appointments(ctx, seriesID)
+}
+
+func (repo *Repository) AddSeriesOccurrence(
+	ctx context.Context,
+	arg db.Add_Series_OccurrenceParams,
+) error {
+	return repo.database.Add_Series_Occurrence(ctx, arg)
+}
+
+func (repo *Repository) MoveSeriesOccurrence(
+	ctx context.Context,
+	arg db.Move_Series_OccurrenceParams,
+) error {
+	return repo.database.Move_Series_Occurrence(ctx, arg)
+}
+
+func (repo *Repository) ConfirmAppointmentsByPayment(
+	ctx context.Context,
+	paymentID pgtype.UUID,
+) ([]*db.Appointment, error) {
+	return repo.database.ConfirmAppointmentsByPayment(ctx, paymentID)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ensure Repository implements SettlementRepository
+var _ ports.SettlementRepository = (*Repository)(nil)
+
+func (repo *Repository) UpsertCentrePayoutAccount(
+	ctx context.Context,
+	arg db.Upsert_Centre_Payout_AccountParams,
+) (*db.CentrePayoutAccount, error) {
+	return repo.database.Upsert_Centre_Payout_Account(ctx, arg)
+}
+
+func (repo *Repository) GetCentrePayoutAccount(
+	ctx context.Context,
+	diagnosticCentreID string,
+) (*db.CentrePayoutAccount, error) {
+	return repo.database.Get_Centre_Payout_Account(ctx, diagnosticCentreID)
+}
+
+func (repo *Repository) ListUnsettledCentres(
+	ctx context.Context,
+	before pgtype.Timestamptz,
+) ([]*db.List_Unsettled_CentresRow, error) {
+	return repo.database.List_Unsettled_Centres(ctx, before)
+}
+
+func (repo *Repository) GetLatestSettlement(
+	ctx context.Context,
+	arg db.Get_Latest_SettlementParams,
+) (*db.Settlement, error) {
+	return repo.database.Get_Latest_Settlement(ctx, arg)
+}
+
+func (repo *Repository) CreateSettlement(
+	ctx context.Context,
+	arg db.Create_SettlementParams,
+) (*db.Settlement, error) {
+	return repo.database.Create_Settlement(ctx, arg)
+}
+
+func (repo *Repository) AddSettlementPaymentEntries(
+	ctx context.Context,
+	settlementID string,
+) (int64, error) {
+	return repo.database.Add_Settlement_Payment_Entries(ctx, settlementID)
+}
+
+func (repo *Repository) AddSettlementRefundEntries(
+	ctx context.Context,
+	settlementID string,
+) (int64, error) {
+	return repo.database.Add_Settlement_Refund_Entries(ctx, settlementID)
+}
+
+func (repo *Repository) TotalSettlement(
+	ctx context.Context,
+	settlementID string,
+) (*db.Settlement, error) {
+	return repo.database.Total_Settlement(ctx, settlementID)
+}
+
+func (repo *Repository) ListPayableSettlements(
+	ctx context.Context,
+	arg db.List_Payable_SettlementsParams,
+) ([]*db.Settlement, error) {
+	return repo.database.List_Payable_Settlements(ctx, arg)
+}
+
+func (repo *Repository) StartSettlementPayout(
+	ctx context.Context,
+	arg db.Start_Settlement_PayoutParams,
+) (*db.Settlement, error) {
+	return repo.database.Start_Settlement_Payout(ctx, arg)
+}
+
+func (repo *Repository) UpdateSettlementPayout(
+	ctx context.Context,
+	arg db.Update_Settlement_PayoutParams,
+) (*db.Settlement, error) {
+	return repo.database.Update_Settlement_Payout(ctx, arg)
+}
+
+func (repo *Repository) GetSettlement(
+	ctx context.Context,
+	id string,
+) (*db.Settlement, error) {
+	return repo.database.Get_Settlement(ctx, id)
+}
+
+func (repo *Repository) GetSettlementByPayoutReference(
+	ctx context.Context,
+	reference string,
+) (*db.Settlement, error) {
+	return repo.database.Get_Settlement_By_Payout_Reference(ctx, pgtype.Text{
+		String: reference,
+		Valid:  true,
+	})
+}
+
+func (repo *Repository) ListCentreSettlements(
+	ctx context.Context,
+	arg db.List_Centre_SettlementsParams,
+) ([]*db.Settlement, error) {
+	return repo.database.List_Centre_Settlements(ctx, arg)
+}
+
+func (repo *Repository) ListSettlementEntries(
+	ctx context.Context,
+	settlementID string,
+) ([]*db.SettlementEntry, error) {
+	return repo.database.List_Settlement_Entries(ctx, settlementID)
+}
@@ -16,3 +16,52 @@ func (repo *Repository) CreateTestPrice(
 ) ([]*db.DiagnosticCentreTestPrice, error) {
 	return repo.database.Create_Test_Price(ctx, price)
 }
+
+func (repo *Repository) GetActiveTestPrice(
+	ctx context.Context,
+	arg db.Get_Active_Test_PriceParams,
+) (*db.DiagnosticCentreTestPrice, error) {
+	return repo.database.Get_Active_Test_Price(ctx, arg)
+}
+
+func (repo *Repository) GetTestPrice(
+	ctx context.Context,
+	arg db.Get_Test_PriceParams,
+) (*db.DiagnosticCentreTestPrice, error) {
+	return repo.database.Get_Test_Price(ctx, arg)
+}
+
+func (repo *Repository) ListTestPrices(
+	ctx context.Context,
+	arg db.List_Test_PricesParams,
+) ([]*db.DiagnosticCentreTestPrice, error) {
+	return repo.database.List_Test_Prices(ctx, arg)
+}
+
+func (repo *Repository) UpsertTestPrice(
+	ctx context.Context,
+	arg db.Upsert_Test_PriceParams,
+) (*db.DiagnosticCentreTestPrice, error) {
+	return repo.database.Upsert_Test_Price(ctx, arg)
+}
+
+func (repo *Repository) UpdateTestPrice(
+	ctx context.Context,
+	arg db.Update_Test_PriceParams,
+) (*db.DiagnosticCentreTestPrice, error) {
+	return repo.database.Update_Test_Price(ctx, arg)
+}
+
+func (repo *Repository) ListTestPriceHistory(
+	ctx context.Context,
+	arg db.List_Test_Price_HistoryParams,
+) ([]*db.DiagnosticCentreTestPriceHistory, error) {
+	return repo.database.List_Test_Price_History(ctx, arg)
+}
+
+func (repo *Repository) GetTestPriceAt(
+	ctx context.Context,
+	arg db.Get_Test_Price_AtParams,
+) (*db.DiagnosticCentreTestPriceHistory, error) {
+	return repo.database.Get_Test_Price_At(ctx, arg)
+}
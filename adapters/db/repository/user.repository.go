@@ -2,15 +2,62 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/core/ports"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // Ensure Repository implements ports.UserRepository
 var _ ports.UserRepository = (*Repository)(nil)
 
+// UserTxRepository represents a transaction-aware repository for account changes
+type UserTxRepository struct {
+	*Repository
+	tx pgx.Tx
+}
+
+// BeginUser starts a new transaction
+func (r *Repository) BeginUser(
+	ctx context.Context,
+) (ports.UserTx, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("database connection is not initialized")
+	}
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	return &UserTxRepository{
+		Repository: &Repository{database: r.database.WithTx(tx)},
+		tx:         tx,
+	}, nil
+}
+
+// Commit commits the transaction
+func (t *UserTxRepository) Commit(
+	ctx context.Context,
+) error {
+	if t.tx == nil {
+		return fmt.Errorf("transaction is nil")
+	}
+	return t.tx.Commit(ctx)
+}
+
+// Rollback rolls back the transaction
+func (t *UserTxRepository) Rollback(
+	ctx context.Context,
+) error {
+	if t.tx == nil {
+		return fmt.Errorf("transaction is nil")
+	}
+	return t.tx.Rollback(ctx)
+}
+
 func (repo *Repository) GetUsers(
 	ctx context.Context,
 	params db.GetUsersParams,
@@ -52,6 +99,24 @@ func (repo *Repository) GetUserByEmail(
 	return response, nil
 }
 
+func (repo *Repository) GetUserByPhoneNumber(
+	ctx context.Context,
+	phoneNumber string,
+) (*db.User, error) {
+	response, err := repo.database.GetUserByPhoneNumber(ctx, pgtype.Text{String: phoneNumber, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (repo *Repository) ResetUnverifiedPhoneUser(
+	ctx context.Context,
+	arg db.ResetUnverifiedPhoneUserParams,
+) (*db.User, error) {
+	return repo.database.ResetUnverifiedPhoneUser(ctx, arg)
+}
+
 func (repo *Repository) ListManagersByadmin(
 	ctx context.Context,
 	arg db.ListUsersByAdminParams,
@@ -149,3 +214,42 @@ func (repo *Repository) MarkEmailAsVerified(
 ) error {
 	return repo.database.MarkEmailAsVerified(ctx, pgtype.Text{String: email, Valid: true})
 }
+
+func (repo *Repository) CreatePhoneVerificationToken(
+	ctx context.Context,
+	arg db.CreatePhoneVerificationTokenParams,
+) (*db.PhoneVerificationToken, error) {
+	return repo.database.CreatePhoneVerificationToken(ctx, arg)
+}
+
+func (repo *Repository) GetLatestPhoneVerificationToken(
+	ctx context.Context,
+	phoneNumber string,
+) (*db.PhoneVerificationToken, error) {
+	return repo.database.GetLatestPhoneVerificationToken(ctx, phoneNumber)
+}
+
+func (repo *Repository) CountPhoneVerificationTokensSince(
+	ctx context.Context,
+	arg db.CountPhoneVerificationTokensSinceParams,
+) (int64, error) {
+	return repo.database.CountPhoneVerificationTokensSince(ctx, arg)
+}
+
+func (repo *Repository) IncrementPhoneVerificationAttempts(
+	ctx context.Context,
+	id string,
+) (*db.PhoneVerificationToken, error) {
+	return repo.database.IncrementPhoneVerificationAttempts(ctx, id)
+}
+
+func (repo *Repository) ConsumePhoneVerificationToken(
+	ctx context.Context,
+	id string,
+) (bool, error) {
+	rows, err := repo.database.ConsumePhoneVerificationToken(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure Repository implements WaitlistRepository
+var _ ports.WaitlistRepository = (*Repository)(nil)
+
+func (repo *Repository) CreateWaitlistEntry(
+	ctx context.Context,
+	arg db.Create_Waitlist_EntryParams,
+) (*db.AppointmentWaitlistEntry, error) {
+	return repo.database.Create_Waitlist_Entry(ctx, arg)
+}
+
+func (repo *Repository) ListPatientWaitlistEntries(
+	ctx context.Context,
+	arg db.List_Patient_Waitlist_EntriesParams,
+) ([]*db.AppointmentWaitlistEntry, error) {
+	return repo.database.List_Patient_Waitlist_Entries(ctx, arg)
+}
+
+func (repo *Repository) CancelWaitlistEntry(
+	ctx context.Context,
+	arg db.Cancel_Waitlist_EntryParams,
+) (*db.AppointmentWaitlistEntry, error) {
+	return repo.database.Cancel_Waitlist_Entry(ctx, arg)
+}
+
+func (repo *Repository) OfferWaitlistSlot(
+	ctx context.Context,
+	arg db.Offer_Waitlist_SlotParams,
+) (*db.AppointmentWaitlistEntry, error) {
+	return repo.database.Offer_Waitlist_Slot(ctx, arg)
+}
+
+func (repo *Repository) ListWaitlistHolds(
+	ctx context.Context,
+	arg db.List_Waitlist_HoldsParams,
+) ([]*db.AppointmentWaitlistEntry, error) {
+	return repo.database.List_Waitlist_Holds(ctx, arg)
+}
+
+func (repo *Repository) MarkWaitlistEntryBooked(
+	ctx context.Context,
+	arg db.Mark_Waitlist_Entry_BookedParams,
+) error {
+	return repo.database.Mark_Waitlist_Entry_Booked(ctx, arg)
+}
+
+func (repo *Repository) ExpireWaitlistEntries(
+	ctx context.Context,
+) ([]*db.AppointmentWaitlistEntry, error) {
+	return repo.database.Expire_Waitlist_Entries(ctx)
+}
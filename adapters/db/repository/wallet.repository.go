@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure Repository implements WalletRepository
+var _ ports.WalletRepository = (*Repository)(nil)
+
+func (repo *Repository) ListUserWallets(
+	ctx context.Context,
+	userID string,
+) ([]*db.Wallet, error) {
+	return repo.database.List_User_Wallets(ctx, userID)
+}
+
+func (repo *Repository) ListWalletTransactions(
+	ctx context.Context,
+	arg db.List_Wallet_TransactionsParams,
+) ([]*db.WalletTransaction, error) {
+	return repo.database.List_Wallet_Transactions(ctx, arg)
+}
+
+func (repo *Repository) LockWallet(
+	ctx context.Context,
+	arg db.Lock_WalletParams,
+) (*db.Wallet, error) {
+	return repo.database.Lock_Wallet(ctx, arg)
+}
+
+func (repo *Repository) CreditWallet(
+	ctx context.Context,
+	arg db.Credit_WalletParams,
+) (*db.Wallet, error) {
+	return repo.database.Credit_Wallet(ctx, arg)
+}
+
+func (repo *Repository) DebitWallet(
+	ctx context.Context,
+	arg db.Debit_WalletParams,
+) (*db.Wallet, error) {
+	return repo.database.Debit_Wallet(ctx, arg)
+}
+
+func (repo *Repository) CreateWalletTransaction(
+	ctx context.Context,
+	arg db.Create_Wallet_TransactionParams,
+) (*db.WalletTransaction, error) {
+	return repo.database.Create_Wallet_Transaction(ctx, arg)
+}
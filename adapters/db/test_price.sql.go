@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const create_Test_Price = `-- name: Create_Test_Price :many
@@ -71,3 +73,264 @@ func (q *Queries) Create_Test_Price(ctx context.Context, arg Create_Test_PricePa
 	}
 	return items, nil
 }
+
+const get_Active_Test_Price = `-- name: Get_Active_Test_Price :one
+SELECT id, diagnostic_centre_id, test_type, price, currency, is_active, created_at, updated_at FROM diagnostic_centre_test_prices
+WHERE diagnostic_centre_id = $1
+    AND test_type = $2
+    AND is_active = true
+`
+
+type Get_Active_Test_PriceParams struct {
+	DiagnosticCentreID string `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	TestType           string `db:"test_type" json:"test_type"`
+}
+
+func (q *Queries) Get_Active_Test_Price(ctx context.Context, arg Get_Active_Test_PriceParams) (*DiagnosticCentreTestPrice, error) {
+	row := q.db.QueryRow(ctx, get_Active_Test_Price, arg.DiagnosticCentreID, arg.TestType)
+	var i DiagnosticCentreTestPrice
+	err := row.Scan(
+		&i.ID,
+		&i.DiagnosticCentreID,
+		&i.TestType,
+		&i.Price,
+		&i.Currency,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const get_Test_Price = `-- name: Get_Test_Price :one
+SELECT id, diagnostic_centre_id, test_type, price, currency, is_active, created_at, updated_at FROM diagnostic_centre_test_prices
+WHERE id = $1 AND diagnostic_centre_id = $2
+`
+
+type Get_Test_PriceParams struct {
+	ID                 string `db:"id" json:"id"`
+	DiagnosticCentreID string `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+}
+
+func (q *Queries) Get_Test_Price(ctx context.Context, arg Get_Test_PriceParams) (*DiagnosticCentreTestPrice, error) {
+	row := q.db.QueryRow(ctx, get_Test_Price, arg.ID, arg.DiagnosticCentreID)
+	var i DiagnosticCentreTestPrice
+	err := row.Scan(
+		&i.ID,
+		&i.DiagnosticCentreID,
+		&i.TestType,
+		&i.Price,
+		&i.Currency,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const get_Test_Price_At = `-- name: Get_Test_Price_At :one
+SELECT id, test_price_id, diagnostic_centre_id, test_type, price, currency, is_active, effective_from, effective_to, created_at FROM diagnostic_centre_test_price_history
+WHERE diagnostic_centre_id = $1
+    AND test_type = $2
+    AND effective_from <= $3
+    AND (effective_to IS NULL OR effective_to > $3)
+ORDER BY effective_from DESC
+LIMIT 1
+`
+
+type Get_Test_Price_AtParams struct {
+	DiagnosticCentreID string             `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	TestType           string             `db:"test_type" json:"test_type"`
+	EffectiveFrom      pgtype.Timestamptz `db:"effective_from" json:"effective_from"`
+}
+
+// Returns the price that was in effect for a test at a given time.
+func (q *Queries) Get_Test_Price_At(ctx context.Context, arg Get_Test_Price_AtParams) (*DiagnosticCentreTestPriceHistory, error) {
+	row := q.db.QueryRow(ctx, get_Test_Price_At, arg.DiagnosticCentreID, arg.TestType, arg.EffectiveFrom)
+	var i DiagnosticCentreTestPriceHistory
+	err := row.Scan(
+		&i.ID,
+		&i.TestPriceID,
+		&i.DiagnosticCentreID,
+		&i.TestType,
+		&i.Price,
+		&i.Currency,
+		&i.IsActive,
+		&i.EffectiveFrom,
+		&i.EffectiveTo,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const list_Test_Price_History = `-- name: List_Test_Price_History :many
+SELECT id, test_price_id, diagnostic_centre_id, test_type, price, currency, is_active, effective_from, effective_to, created_at FROM diagnostic_centre_test_price_history
+WHERE test_price_id = $1 AND diagnostic_centre_id = $2
+ORDER BY effective_from DESC
+`
+
+type List_Test_Price_HistoryParams struct {
+	TestPriceID        string `db:"test_price_id" json:"test_price_id"`
+	DiagnosticCentreID string `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+}
+
+func (q *Queries) List_Test_Price_History(ctx context.Context, arg List_Test_Price_HistoryParams) ([]*DiagnosticCentreTestPriceHistory, error) {
+	rows, err := q.db.Query(ctx, list_Test_Price_History, arg.TestPriceID, arg.DiagnosticCentreID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*DiagnosticCentreTestPriceHistory
+	for rows.Next() {
+		var i DiagnosticCentreTestPriceHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.TestPriceID,
+			&i.DiagnosticCentreID,
+			&i.TestType,
+			&i.Price,
+			&i.Currency,
+			&i.IsActive,
+			&i.EffectiveFrom,
+			&i.EffectiveTo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const list_Test_Prices = `-- name: List_Test_Prices :many
+SELECT id, diagnostic_centre_id, test_type, price, currency, is_active, created_at, updated_at FROM diagnostic_centre_test_prices
+WHERE diagnostic_centre_id = $1
+    AND ($2::boolean = false OR is_active = true)
+ORDER BY test_type ASC
+`
+
+type List_Test_PricesParams struct {
+	DiagnosticCentreID string `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	Column2            bool   `db:"column_2" json:"column_2"`
+}
+
+func (q *Queries) List_Test_Prices(ctx context.Context, arg List_Test_PricesParams) ([]*DiagnosticCentreTestPrice, error) {
+	rows, err := q.db.Query(ctx, list_Test_Prices, arg.DiagnosticCentreID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*DiagnosticCentreTestPrice
+	for rows.Next() {
+		var i DiagnosticCentreTestPrice
+		if err := rows.Scan(
+			&i.ID,
+			&i.DiagnosticCentreID,
+			&i.TestType,
+			&i.Price,
+			&i.Currency,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const update_Test_Price = `-- name: Update_Test_Price :one
+UPDATE diagnostic_centre_test_prices
+SET
+    price = $3,
+    currency = $4,
+    is_active = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND diagnostic_centre_id = $2
+RETURNING id, diagnostic_centre_id, test_type, price, currency, is_active, created_at, updated_at
+`
+
+type Update_Test_PriceParams struct {
+	ID                 string         `db:"id" json:"id"`
+	DiagnosticCentreID string         `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	Price              pgtype.Numeric `db:"price" json:"price"`
+	Currency           string         `db:"currency" json:"currency"`
+	IsActive           bool           `db:"is_active" json:"is_active"`
+}
+
+func (q *Queries) Update_Test_Price(ctx context.Context, arg Update_Test_PriceParams) (*DiagnosticCentreTestPrice, error) {
+	row := q.db.QueryRow(ctx, update_Test_Price,
+		arg.ID,
+		arg.DiagnosticCentreID,
+		arg.Price,
+		arg.Currency,
+		arg.IsActive,
+	)
+	var i DiagnosticCentreTestPrice
+	err := row.Scan(
+		&i.ID,
+		&i.DiagnosticCentreID,
+		&i.TestType,
+		&i.Price,
+		&i.Currency,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const upsert_Test_Price = `-- name: Upsert_Test_Price :one
+INSERT INTO diagnostic_centre_test_prices (
+    diagnostic_centre_id,
+    test_type,
+    price,
+    currency,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, true
+)
+ON CONFLICT (diagnostic_centre_id, test_type) DO UPDATE
+SET
+    price = EXCLUDED.price,
+    currency = EXCLUDED.currency,
+    is_active = true,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id, diagnostic_centre_id, test_type, price, currency, is_active, created_at, updated_at
+`
+
+type Upsert_Test_PriceParams struct {
+	DiagnosticCentreID string         `db:"diagnostic_centre_id" json:"diagnostic_centre_id"`
+	TestType           string         `db:"test_type" json:"test_type"`
+	Price              pgtype.Numeric `db:"price" json:"price"`
+	Currency           string         `db:"currency" json:"currency"`
+}
+
+// Adds a test price, reactivating and repricing it if the test type already exists.
+func (q *Queries) Upsert_Test_Price(ctx context.Context, arg Upsert_Test_PriceParams) (*DiagnosticCentreTestPrice, error) {
+	row := q.db.QueryRow(ctx, upsert_Test_Price,
+		arg.DiagnosticCentreID,
+		arg.TestType,
+		arg.Price,
+		arg.Currency,
+	)
+	var i DiagnosticCentreTestPrice
+	err := row.Scan(
+		&i.ID,
+		&i.DiagnosticCentreID,
+		&i.TestType,
+		&i.Price,
+		&i.Currency,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
@@ -23,7 +23,7 @@ INSERT INTO users (
   created_admin
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin
+) RETURNING id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin, phone_verified_at
 `
 
 type CreateUserParams struct {
@@ -62,12 +62,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (*User,
 		&i.EmailVerifiedAt,
 		&i.PhoneNumber,
 		&i.CreatedAdmin,
+		&i.PhoneVerifiedAt,
 	)
 	return &i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin FROM users where id = $1
+SELECT id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin, phone_verified_at FROM users where id = $1
 `
 
 func (q *Queries) GetUser(ctx context.Context, id string) (*User, error) {
@@ -86,12 +87,13 @@ func (q *Queries) GetUser(ctx context.Context, id string) (*User, error) {
 		&i.EmailVerifiedAt,
 		&i.PhoneNumber,
 		&i.CreatedAdmin,
+		&i.PhoneVerifiedAt,
 	)
 	return &i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin FROM users WHERE email = $1
+SELECT id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin, phone_verified_at FROM users WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email pgtype.Text) (*User, error) {
@@ -110,12 +112,41 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email pgtype.Text) (*User,
 		&i.EmailVerifiedAt,
 		&i.PhoneNumber,
 		&i.CreatedAdmin,
+		&i.PhoneVerifiedAt,
+	)
+	return &i, err
+}
+
+const getUserByPhoneNumber = `-- name: GetUserByPhoneNumber :one
+SELECT id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin, phone_verified_at FROM users
+WHERE phone_number = $1
+ORDER BY phone_verified_at IS NULL, created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetUserByPhoneNumber(ctx context.Context, phoneNumber pgtype.Text) (*User, error) {
+	row := q.db.QueryRow(ctx, getUserByPhoneNumber, phoneNumber)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Nin,
+		&i.Password,
+		&i.UserType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Fullname,
+		&i.EmailVerified,
+		&i.EmailVerifiedAt,
+		&i.PhoneNumber,
+		&i.CreatedAdmin,
+		&i.PhoneVerifiedAt,
 	)
 	return &i, err
 }
 
 const getUsers = `-- name: GetUsers :many
-SELECT id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin FROM users
+SELECT id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin, phone_verified_at FROM users
 ORDER BY id
 LIMIT $1 OFFSET $2
 `
@@ -147,6 +178,7 @@ func (q *Queries) GetUsers(ctx context.Context, arg GetUsersParams) ([]*User, er
 			&i.EmailVerifiedAt,
 			&i.PhoneNumber,
 			&i.CreatedAdmin,
+			&i.PhoneVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -247,12 +279,65 @@ func (q *Queries) ListUsersByAdmin(ctx context.Context, arg ListUsersByAdminPara
 	return items, nil
 }
 
+const resetUnverifiedPhoneUser = `-- name: ResetUnverifiedPhoneUser :one
+UPDATE users
+SET
+  password = $2,
+  user_type = $3,
+  fullname = $4,
+  updated_at = NOW()
+WHERE id = $1
+  AND email IS NULL
+  AND phone_verified_at IS NULL
+  -- Only accounts that phone sign-up sent a code to; older accounts sharing a verified
+  -- number are never handed over
+  AND EXISTS (
+    SELECT 1 FROM phone_verification_tokens
+    WHERE phone_verification_tokens.user_id = users.id
+  )
+RETURNING id, email, nin, password, user_type, created_at, updated_at, fullname, email_verified, email_verified_at, phone_number, created_admin, phone_verified_at
+`
+
+type ResetUnverifiedPhoneUserParams struct {
+	ID       string      `db:"id" json:"id"`
+	Password string      `db:"password" json:"password"`
+	UserType UserEnum    `db:"user_type" json:"user_type"`
+	Fullname pgtype.Text `db:"fullname" json:"fullname"`
+}
+
+func (q *Queries) ResetUnverifiedPhoneUser(ctx context.Context, arg ResetUnverifiedPhoneUserParams) (*User, error) {
+	row := q.db.QueryRow(ctx, resetUnverifiedPhoneUser,
+		arg.ID,
+		arg.Password,
+		arg.UserType,
+		arg.Fullname,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Nin,
+		&i.Password,
+		&i.UserType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Fullname,
+		&i.EmailVerified,
+		&i.EmailVerifiedAt,
+		&i.PhoneNumber,
+		&i.CreatedAdmin,
+		&i.PhoneVerifiedAt,
+	)
+	return &i, err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET
   nin = COALESCE($2, nin),
   fullname = COALESCE($3, fullname),
   phone_number = COALESCE($4, phone_number),
+  phone_verified_at = CASE WHEN $4 IS NULL OR $4 = phone_number THEN phone_verified_at END,
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, email, nin, user_type, fullname, phone_number, email_verified, email_verified_at, created_at, updated_at
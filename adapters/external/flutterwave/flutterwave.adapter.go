@@ -0,0 +1,301 @@
+package flutterwave
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure FlutterwaveAdapter implements PaymentProviderService
+var _ ports.PaymentProviderService = (*FlutterwaveAdapter)(nil)
+
+// Ensure FlutterwaveAdapter reports refund status, as no Flutterwave webhooks are taken
+var _ ports.RefundStatusService = (*FlutterwaveAdapter)(nil)
+
+type (
+	FlutterwaveConfig struct {
+		SecretKey string
+		BaseURL   string
+		// RedirectURL is where Flutterwave sends the patient after checkout
+		RedirectURL string
+		// HTTPClient defaults to a client with a 30 second timeout
+		HTTPClient *http.Client
+	}
+	FlutterwavePaymentResponse struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Data    struct {
+			Link string `json:"link"`
+		} `json:"data"`
+	}
+	Customer struct {
+		ID          int64  `json:"id"`
+		Name        string `json:"name"`
+		Email       string `json:"email"`
+		PhoneNumber string `json:"phone_number"`
+	}
+	Transaction struct {
+		ID          int64                  `json:"id"`
+		TxRef       string                 `json:"tx_ref"`
+		FlwRef      string                 `json:"flw_ref"`
+		Amount      float64                `json:"amount"`
+		Currency    string                 `json:"currency"`
+		Status      string                 `json:"status"`
+		PaymentType string                 `json:"payment_type"`
+		CreatedAt   string                 `json:"created_at"`
+		Customer    Customer               `json:"customer"`
+		Meta        map[string]interface{} `json:"meta"`
+	}
+	FlutterwaveVerificationResponse struct {
+		Status  string      `json:"status"`
+		Message string      `json:"message"`
+		Data    Transaction `json:"data"`
+	}
+	FlutterwaveTransactionListResponse struct {
+		Status  string        `json:"status"`
+		Message string        `json:"message"`
+		Data    []Transaction `json:"data"`
+		Meta    struct {
+			PageInfo struct {
+				CurrentPage int `json:"current_page"`
+				TotalPages  int `json:"total_pages"`
+			} `json:"page_info"`
+		} `json:"meta"`
+	}
+	FlutterwaveRefundResponse struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Data    struct {
+			ID             int64   `json:"id"`
+			AmountRefunded float64 `json:"amount_refunded"`
+			Status         string  `json:"status"`
+		} `json:"data"`
+	}
+	FlutterwaveAdapter struct {
+		config *FlutterwaveConfig
+		client *http.Client
+	}
+)
+
+func NewFlutterwaveAdapter(con *FlutterwaveConfig) *FlutterwaveAdapter {
+	client := con.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &FlutterwaveAdapter{
+		config: con,
+		client: client,
+	}
+}
+
+// Provider identifies Flutterwave in payment records
+func (f *FlutterwaveAdapter) Provider() db.PaymentProvider {
+	return db.PaymentProviderFLUTTERWAVE
+}
+
+// InitializeTransaction creates a Flutterwave standard checkout link for the request
+func (f *FlutterwaveAdapter) InitializeTransaction(request domain.TransactionRequest) (*domain.TransactionCheckout, error) {
+	currency := request.Currency
+	if currency == "" {
+		currency = "NGN"
+	}
+	// Flutterwave takes amounts in major units
+	payload := map[string]interface{}{
+		"tx_ref":       request.Reference,
+		"amount":       request.Amount,
+		"currency":     currency,
+		"redirect_url": f.config.RedirectURL,
+		"customer":     map[string]string{"email": request.Email},
+		"meta":         request.Metadata,
+	}
+
+	var result FlutterwavePaymentResponse
+	if err := f.send(http.MethodPost, "/payments", payload, &result); err != nil {
+		return nil, err
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("transaction rejected: %s", result.Message)
+	}
+
+	return &domain.TransactionCheckout{
+		Provider:         db.PaymentProviderFLUTTERWAVE,
+		Reference:        request.Reference,
+		AuthorizationURL: result.Data.Link,
+	}, nil
+}
+
+// VerifyTransaction verifies a transaction using the reference it was created with
+func (f *FlutterwaveAdapter) VerifyTransaction(reference string) (*domain.TransactionVerification, error) {
+	transaction, err := f.transaction(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return verification(transaction), nil
+}
+
+// ListTransactions lists the transactions created between from and to, following every page.
+// Flutterwave filters by day, so transactions outside the window on its edge days are dropped.
+func (f *FlutterwaveAdapter) ListTransactions(from, to time.Time) ([]*domain.TransactionVerification, error) {
+	var transactions []*domain.TransactionVerification
+	for page := 1; ; page++ {
+		query := url.Values{
+			"from": {from.UTC().Format("2006-01-02")},
+			"to":   {to.UTC().Format("2006-01-02")},
+			"page": {strconv.Itoa(page)},
+		}
+		var result FlutterwaveTransactionListResponse
+		if err := f.send(http.MethodGet, "/transactions?"+query.Encode(), nil, &result); err != nil {
+			return nil, err
+		}
+		if result.Status != "success" {
+			return nil, fmt.Errorf("transaction list rejected: %s", result.Message)
+		}
+		for i := range result.Data {
+			transaction := verification(&result.Data[i])
+			if transaction.PaidAt.IsZero() || (!transaction.PaidAt.Before(from) && !transaction.PaidAt.After(to)) {
+				transactions = append(transactions, transaction)
+			}
+		}
+		if page >= result.Meta.PageInfo.TotalPages || len(result.Data) == 0 {
+			return transactions, nil
+		}
+	}
+}
+
+// RefundTransaction refunds part or all of a transaction. Flutterwave refunds by transaction
+// ID, so the transaction is looked up by its reference first.
+func (f *FlutterwaveAdapter) RefundTransaction(reference string, amount float64) (*domain.TransactionRefund, error) {
+	transaction, err := f.transaction(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	var result FlutterwaveRefundResponse
+	path := fmt.Sprintf("/transactions/%d/refund", transaction.ID)
+	if err := f.send(http.MethodPost, path, map[string]interface{}{"amount": amount}, &result); err != nil {
+		return nil, err
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("refund rejected: %s", result.Message)
+	}
+
+	refund := transactionRefund(&result)
+	refund.Currency = transaction.Currency
+	return refund, nil
+}
+
+// GetRefund fetches a refund by its Flutterwave ID. The refund carries no currency, so the
+// result leaves it unset.
+func (f *FlutterwaveAdapter) GetRefund(refundID string) (*domain.TransactionRefund, error) {
+	var result FlutterwaveRefundResponse
+	if err := f.send(http.MethodGet, "/refunds/"+url.PathEscape(refundID), nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("refund lookup rejected: %s", result.Message)
+	}
+	return transactionRefund(&result), nil
+}
+
+// transactionRefund maps a Flutterwave refund onto the provider-neutral refund
+func transactionRefund(result *FlutterwaveRefundResponse) *domain.TransactionRefund {
+	status := domain.RefundStatusPending
+	switch result.Data.Status {
+	case "completed":
+		status = domain.RefundStatusProcessed
+	case "failed":
+		status = domain.RefundStatusFailed
+	}
+	return &domain.TransactionRefund{
+		Provider: db.PaymentProviderFLUTTERWAVE,
+		RefundID: strconv.FormatInt(result.Data.ID, 10),
+		Status:   status,
+		Amount:   result.Data.AmountRefunded,
+	}
+}
+
+// verification maps a Flutterwave transaction onto the provider-neutral verification
+func verification(transaction *Transaction) *domain.TransactionVerification {
+	status := domain.TransactionStatusPending
+	switch transaction.Status {
+	case "successful":
+		status = domain.TransactionStatusSuccess
+	case "failed", "cancelled":
+		status = domain.TransactionStatusFailed
+	}
+	paidAt, _ := time.Parse(time.RFC3339, transaction.CreatedAt)
+
+	return &domain.TransactionVerification{
+		Provider:      db.PaymentProviderFLUTTERWAVE,
+		TransactionID: strconv.FormatInt(transaction.ID, 10),
+		Reference:     transaction.TxRef,
+		Status:        status,
+		Amount:        transaction.Amount,
+		Currency:      transaction.Currency,
+		Channel:       transaction.PaymentType,
+		PaidAt:        paidAt,
+		Customer: domain.TransactionCustomer{
+			Email:     transaction.Customer.Email,
+			FirstName: transaction.Customer.Name,
+			Phone:     transaction.Customer.PhoneNumber,
+		},
+		Metadata: transaction.Meta,
+	}
+}
+
+// transaction fetches a transaction by the reference it was created with
+func (f *FlutterwaveAdapter) transaction(reference string) (*Transaction, error) {
+	var result FlutterwaveVerificationResponse
+	path := "/transactions/verify_by_reference?tx_ref=" + url.QueryEscape(reference)
+	if err := f.send(http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("verification rejected: %s", result.Message)
+	}
+	return &result.Data, nil
+}
+
+// send calls the Flutterwave API and decodes its JSON response into out. Transport failures
+// and server errors are reported as domain.ErrProviderUnavailable.
+func (f *FlutterwaveAdapter) send(method, path string, payload, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequest(method, f.config.BaseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.config.SecretKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: flutterwave returned status %d", domain.ErrProviderUnavailable, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response (status %d): %v", resp.StatusCode, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package flutterwave
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+)
+
+const verifiedTransaction = `{"status":"success","data":{"id":1234,"tx_ref":"MED-1","amount":5000,"currency":"NGN","status":"successful","payment_type":"card","created_at":"2025-01-02T10:00:00.000Z","customer":{"email":"patient@example.com"}}}`
+
+func newTestAdapter(t *testing.T, handler http.HandlerFunc) *FlutterwaveAdapter {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewFlutterwaveAdapter(&FlutterwaveConfig{
+		SecretKey:   "FLWSECK_TEST",
+		BaseURL:     server.URL,
+		RedirectURL: "https://app/payments/callback",
+	})
+}
+
+func TestInitializeTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/payments" || r.Header.Get("Authorization") != "Bearer FLWSECK_TEST" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["tx_ref"] != "MED-1" || body["amount"] != 5000.0 || body["redirect_url"] != "https://app/payments/callback" {
+			t.Errorf("unexpected payload %v", body)
+		}
+		w.Write([]byte(`{"status":"success","data":{"link":"https://checkout.flutterwave.com/pay/abc"}}`))
+	})
+
+	checkout, err := adapter.InitializeTransaction(domain.TransactionRequest{
+		Email:     "patient@example.com",
+		Reference: "MED-1",
+		Amount:    5000,
+		Currency:  "NGN",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkout.Provider != db.PaymentProviderFLUTTERWAVE || checkout.Reference != "MED-1" ||
+		checkout.AuthorizationURL != "https://checkout.flutterwave.com/pay/abc" {
+		t.Errorf("unexpected checkout %+v", checkout)
+	}
+}
+
+func TestVerifyTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions/verify_by_reference" || r.URL.Query().Get("tx_ref") != "MED-1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(verifiedTransaction))
+	})
+
+	verification, err := adapter.VerifyTransaction("MED-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verification.Status != domain.TransactionStatusSuccess || verification.TransactionID != "1234" ||
+		verification.Amount != 5000 || verification.Channel != "card" {
+		t.Errorf("unexpected verification %+v", verification)
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+	from := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/transactions" || query.Get("from") != "2025-01-02" || query.Get("to") != "2025-01-02" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch query.Get("page") {
+		case "1":
+			w.Write([]byte(`{"status":"success","data":[{"id":1,"tx_ref":"MED-1","amount":5000,"currency":"NGN","status":"successful","created_at":"2025-01-02T10:00:00.000Z"},{"id":2,"tx_ref":"MED-2","amount":5000,"currency":"NGN","status":"successful","created_at":"2025-01-02T08:00:00.000Z"}],"meta":{"page_info":{"current_page":1,"total_pages":2}}}`))
+		case "2":
+			w.Write([]byte(`{"status":"success","data":[{"id":3,"tx_ref":"MED-3","amount":2500,"currency":"NGN","status":"failed","created_at":"2025-01-02T10:30:00.000Z"}],"meta":{"page_info":{"current_page":2,"total_pages":2}}}`))
+		default:
+			t.Errorf("unexpected page %s", query.Get("page"))
+		}
+	})
+
+	transactions, err := adapter.ListTransactions(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// MED-2 was created on the same day but before the window
+	if len(transactions) != 2 || transactions[0].Reference != "MED-1" || transactions[1].Reference != "MED-3" {
+		t.Fatalf("unexpected transactions %+v", transactions)
+	}
+	if transactions[1].Status != domain.TransactionStatusFailed {
+		t.Errorf("status = %s; want failed", transactions[1].Status)
+	}
+}
+
+func TestRefundTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transactions/verify_by_reference":
+			w.Write([]byte(verifiedTransaction))
+		case "/transactions/1234/refund":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["amount"] != 2000.0 {
+				t.Errorf("amount = %v; want 2000", body["amount"])
+			}
+			w.Write([]byte(`{"status":"success","data":{"id":99,"amount_refunded":2000,"status":"completed"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	refund, err := adapter.RefundTransaction("MED-1", 2000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Status != domain.RefundStatusProcessed || refund.RefundID != "99" || refund.Amount != 2000 {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestGetRefund(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/refunds/99" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"status":"success","data":{"id":99,"amount_refunded":2000,"status":"failed"}}`))
+	})
+
+	refund, err := adapter.GetRefund("99")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Status != domain.RefundStatusFailed || refund.RefundID != "99" || refund.Amount != 2000 {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := adapter.InitializeTransaction(domain.TransactionRequest{Reference: "MED-1", Amount: 1})
+	if !errors.Is(err, domain.ErrProviderUnavailable) {
+		t.Fatalf("error = %v; want %v", err, domain.ErrProviderUnavailable)
+	}
+}
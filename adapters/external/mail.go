@@ -1,8 +1,15 @@
 package ex
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"mime"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
+
+	"github.com/diagnoxix/core/domain"
 )
 
 type EmailType string
@@ -44,11 +51,95 @@ func (n *NotificationAdapter) SendEmail(
 	templateName string,
 	data interface{},
 ) error {
-	auth := n.auth()
 	// Generate HTML content from template
+	htmlContent, err := n.RenderEmail(templateName, data)
+	if err != nil {
+		return err
+	}
+	return n.SendHTMLEmail(to, subject, htmlContent, nil)
+}
+
+// SendEmailWithAttachments sends the template as the HTML body of a multipart/mixed message,
+// with each attachment base64 encoded in a part of its own
+func (n *NotificationAdapter) SendEmailWithAttachments(
+	to string,
+	subject string,
+	templateName string,
+	data interface{},
+	attachments []domain.EmailAttachment,
+) error {
+	htmlContent, err := n.RenderEmail(templateName, data)
+	if err != nil {
+		return err
+	}
+	return n.SendHTMLEmail(to, subject, htmlContent, attachments)
+}
+
+// RenderEmail executes an email template into the HTML body of a message
+func (n *NotificationAdapter) RenderEmail(templateName string, data interface{}) (string, error) {
 	htmlContent, err := n.emailTemplate.ExecuteTemplate(templateName, data)
 	if err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return htmlContent, nil
+}
+
+// SendHTMLEmail sends an already rendered HTML body. With attachments the message is
+// multipart/mixed, with each attachment base64 encoded in a part of its own.
+func (n *NotificationAdapter) SendHTMLEmail(
+	to string,
+	subject string,
+	htmlContent string,
+	attachments []domain.EmailAttachment,
+) error {
+	if len(attachments) == 0 {
+		var msg []byte
+		switch n.config.EmailType {
+		case GMAIL, ZOHO:
+			msg = []byte(fmt.Sprintf(
+				"From: DiagnoxixAI <%s>\r\n"+
+					"To: %s\r\n"+
+					"Subject: %s\r\n"+
+					"MIME-Version: 1.0\r\n"+
+					"Content-Type: text/html; charset=utf-8\r\n"+
+					"X-Priority: 1 (Highest)\r\n"+
+					"X-MSMail-Priority: High\r\n"+
+					"Importance: High\r\n"+
+					"\r\n"+
+					"%s\r\n", n.config.From, to, subject, htmlContent),
+			)
+		}
+		return n.send(to, msg)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/html; charset=utf-8"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if _, err := htmlPart.Write([]byte(htmlContent)); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	for _, attachment := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition": {
+				mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write attachment %s: %w", attachment.Filename, err)
+		}
+		if _, err := part.Write(wrapBase64(attachment.Content)); err != nil {
+			return fmt.Errorf("failed to write attachment %s: %w", attachment.Filename, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close email body: %w", err)
 	}
 
 	var msg []byte
@@ -59,27 +150,32 @@ func (n *NotificationAdapter) SendEmail(
 				"To: %s\r\n"+
 				"Subject: %s\r\n"+
 				"MIME-Version: 1.0\r\n"+
-				"Content-Type: text/html; charset=utf-8\r\n"+
-				"X-Priority: 1 (Highest)\r\n"+
-				"X-MSMail-Priority: High\r\n"+
-				"Importance: High\r\n"+
+				"Content-Type: multipart/mixed; boundary=%q\r\n"+
 				"\r\n"+
-				"%s\r\n", n.config.From, to, subject, htmlContent),
+				"%s", n.config.From, to, subject, writer.Boundary(), body.String()),
 		)
 	}
+	return n.send(to, msg)
+}
 
+func (n *NotificationAdapter) send(to string, msg []byte) error {
 	return smtp.SendMail(
 		fmt.Sprintf("%s:%d", n.config.Host, n.config.Port),
-		auth,
+		n.auth(),
 		n.config.From,
 		[]string{to},
 		msg,
 	)
 }
 
-func (n *NotificationAdapter) SendSMS(phone, message string) error {
-	// TODO: Add your actual SMS sending implementation
-	// For now, just log
-	fmt.Printf("Sending SMS to %s\nMessage: %s\n", phone, message)
-	return nil
+// wrapBase64 encodes content in base64 lines of 76 characters, as MIME requires
+func wrapBase64(content []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	var wrapped bytes.Buffer
+	for len(encoded) > 76 {
+		wrapped.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	wrapped.WriteString(encoded + "\r\n")
+	return wrapped.Bytes()
 }
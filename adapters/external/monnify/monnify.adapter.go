@@ -0,0 +1,329 @@
+package monnify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure MonnifyAdapter implements PaymentProviderService
+var _ ports.PaymentProviderService = (*MonnifyAdapter)(nil)
+
+// Ensure MonnifyAdapter reports refund status, as no Monnify webhooks are taken
+var _ ports.RefundStatusService = (*MonnifyAdapter)(nil)
+
+type (
+	MonnifyConfig struct {
+		APIKey       string
+		SecretKey    string
+		ContractCode string
+		BaseURL      string
+		// RedirectURL is where Monnify sends the patient after checkout
+		RedirectURL string
+		// HTTPClient defaults to a client with a 30 second timeout
+		HTTPClient *http.Client
+	}
+	// MonnifyResponse is the envelope every Monnify API response is wrapped in
+	MonnifyResponse struct {
+		RequestSuccessful bool            `json:"requestSuccessful"`
+		ResponseMessage   string          `json:"responseMessage"`
+		ResponseCode      string          `json:"responseCode"`
+		ResponseBody      json.RawMessage `json:"responseBody"`
+	}
+	AccessToken struct {
+		AccessToken string `json:"accessToken"`
+		ExpiresIn   int64  `json:"expiresIn"`
+	}
+	InitTransaction struct {
+		TransactionReference string `json:"transactionReference"`
+		PaymentReference     string `json:"paymentReference"`
+		CheckoutURL          string `json:"checkoutUrl"`
+	}
+	Customer struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	Transaction struct {
+		TransactionReference string                 `json:"transactionReference"`
+		PaymentReference     string                 `json:"paymentReference"`
+		AmountPaid           float64                `json:"amountPaid"`
+		TotalPayable         float64                `json:"totalPayable"`
+		PaidOn               string                 `json:"paidOn"`
+		PaymentStatus        string                 `json:"paymentStatus"`
+		PaymentMethod        string                 `json:"paymentMethod"`
+		Currency             string                 `json:"currency"`
+		Customer             Customer               `json:"customer"`
+		MetaData             map[string]interface{} `json:"metaData"`
+	}
+	TransactionPage struct {
+		Content []Transaction `json:"content"`
+		Last    bool          `json:"last"`
+	}
+	Refund struct {
+		RefundReference      string  `json:"refundReference"`
+		TransactionReference string  `json:"transactionReference"`
+		RefundAmount         float64 `json:"refundAmount"`
+		RefundStatus         string  `json:"refundStatus"`
+	}
+	MonnifyAdapter struct {
+		config *MonnifyConfig
+		client *http.Client
+
+		mu          sync.Mutex
+		token       string
+		tokenExpiry time.Time
+	}
+)
+
+func NewMonnifyAdapter(con *MonnifyConfig) *MonnifyAdapter {
+	client := con.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &MonnifyAdapter{
+		config: con,
+		client: client,
+	}
+}
+
+// Provider identifies Monnify in payment records
+func (m *MonnifyAdapter) Provider() db.PaymentProvider {
+	return db.PaymentProviderMONNIFY
+}
+
+// InitializeTransaction starts a Monnify checkout for the request
+func (m *MonnifyAdapter) InitializeTransaction(request domain.TransactionRequest) (*domain.TransactionCheckout, error) {
+	currency := request.Currency
+	if currency == "" {
+		currency = "NGN"
+	}
+	// Monnify takes amounts in major units
+	payload := map[string]interface{}{
+		"amount":             request.Amount,
+		"customerEmail":      request.Email,
+		"paymentReference":   request.Reference,
+		"paymentDescription": "Diagnostic appointment " + request.Reference,
+		"currencyCode":       currency,
+		"contractCode":       m.config.ContractCode,
+		"redirectUrl":        m.config.RedirectURL,
+		"metaData":           request.Metadata,
+	}
+
+	var result InitTransaction
+	if err := m.send(http.MethodPost, "/api/v1/merchant/transactions/init-transaction", payload, &result); err != nil {
+		return nil, err
+	}
+
+	return &domain.TransactionCheckout{
+		Provider:         db.PaymentProviderMONNIFY,
+		Reference:        result.PaymentReference,
+		AuthorizationURL: result.CheckoutURL,
+		AccessCode:       result.TransactionReference,
+	}, nil
+}
+
+// VerifyTransaction verifies a transaction using the payment reference it was created with
+func (m *MonnifyAdapter) VerifyTransaction(reference string) (*domain.TransactionVerification, error) {
+	transaction, err := m.transaction(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return verification(transaction), nil
+}
+
+// ListTransactions lists the transactions created between from and to, following every page
+func (m *MonnifyAdapter) ListTransactions(from, to time.Time) ([]*domain.TransactionVerification, error) {
+	var transactions []*domain.TransactionVerification
+	for page := 0; ; page++ {
+		query := url.Values{
+			"page": {strconv.Itoa(page)},
+			"size": {"100"},
+			"from": {strconv.FormatInt(from.UnixMilli(), 10)},
+			"to":   {strconv.FormatInt(to.UnixMilli(), 10)},
+		}
+		var result TransactionPage
+		if err := m.send(http.MethodGet, "/api/v1/transactions/search?"+query.Encode(), nil, &result); err != nil {
+			return nil, err
+		}
+		for i := range result.Content {
+			transactions = append(transactions, verification(&result.Content[i]))
+		}
+		if result.Last || len(result.Content) == 0 {
+			return transactions, nil
+		}
+	}
+}
+
+// RefundTransaction refunds part or all of a transaction. Monnify refunds by its own
+// transaction reference, so the transaction is looked up by payment reference first.
+func (m *MonnifyAdapter) RefundTransaction(reference string, amount float64) (*domain.TransactionRefund, error) {
+	transaction, err := m.transaction(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"transactionReference": transaction.TransactionReference,
+		"refundReference":      fmt.Sprintf("%s-RF-%d", reference, time.Now().Unix()),
+		"refundAmount":         amount,
+		"refundReason":         "Appointment refund",
+	}
+	var result Refund
+	if err := m.send(http.MethodPost, "/api/v1/refunds/initiate-refund", payload, &result); err != nil {
+		return nil, err
+	}
+
+	refund := transactionRefund(&result)
+	refund.Currency = transaction.Currency
+	return refund, nil
+}
+
+// GetRefund fetches a refund by its refund reference. The refund carries no currency, so the
+// result leaves it unset.
+func (m *MonnifyAdapter) GetRefund(refundID string) (*domain.TransactionRefund, error) {
+	var result Refund
+	if err := m.send(http.MethodGet, "/api/v1/refunds/"+url.PathEscape(refundID), nil, &result); err != nil {
+		return nil, err
+	}
+	return transactionRefund(&result), nil
+}
+
+// transactionRefund maps a Monnify refund onto the provider-neutral refund
+func transactionRefund(refund *Refund) *domain.TransactionRefund {
+	status := domain.RefundStatusPending
+	switch refund.RefundStatus {
+	case "COMPLETED":
+		status = domain.RefundStatusProcessed
+	case "FAILED":
+		status = domain.RefundStatusFailed
+	}
+	return &domain.TransactionRefund{
+		Provider: db.PaymentProviderMONNIFY,
+		RefundID: refund.RefundReference,
+		Status:   status,
+		Amount:   refund.RefundAmount,
+	}
+}
+
+// verification maps a Monnify transaction onto the provider-neutral verification
+func verification(transaction *Transaction) *domain.TransactionVerification {
+	status := domain.TransactionStatusPending
+	switch transaction.PaymentStatus {
+	case "PAID", "OVERPAID":
+		status = domain.TransactionStatusSuccess
+	case "FAILED", "EXPIRED", "CANCELLED", "REVERSED":
+		status = domain.TransactionStatusFailed
+	}
+	paidAt, _ := time.Parse("2006-01-02 15:04:05", transaction.PaidOn)
+
+	return &domain.TransactionVerification{
+		Provider:      db.PaymentProviderMONNIFY,
+		TransactionID: transaction.TransactionReference,
+		Reference:     transaction.PaymentReference,
+		Status:        status,
+		Amount:        transaction.AmountPaid,
+		Currency:      transaction.Currency,
+		Channel:       transaction.PaymentMethod,
+		PaidAt:        paidAt,
+		Customer: domain.TransactionCustomer{
+			Email:     transaction.Customer.Email,
+			FirstName: transaction.Customer.Name,
+		},
+		Metadata: transaction.MetaData,
+	}
+}
+
+// transaction fetches a transaction by the payment reference it was created with
+func (m *MonnifyAdapter) transaction(reference string) (*Transaction, error) {
+	var result Transaction
+	path := "/api/v2/merchant/transactions/query?paymentReference=" + url.QueryEscape(reference)
+	if err := m.send(http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// accessToken returns a bearer token for the API, logging in again once the cached one expires
+func (m *MonnifyAdapter) accessToken() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token != "" && time.Now().Before(m.tokenExpiry) {
+		return m.token, nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.config.BaseURL+"/api/v1/auth/login", nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %v", err)
+	}
+	req.SetBasicAuth(m.config.APIKey, m.config.SecretKey)
+
+	var token AccessToken
+	if err := m.do(req, &token); err != nil {
+		return "", err
+	}
+	m.token = token.AccessToken
+	// Renew a minute early so a token never expires mid-request
+	m.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return m.token, nil
+}
+
+// send calls the Monnify API with a bearer token and decodes the response body into out
+func (m *MonnifyAdapter) send(method, path string, payload, out interface{}) error {
+	token, err := m.accessToken()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequest(method, m.config.BaseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	return m.do(req, out)
+}
+
+// do sends a request and unwraps the Monnify envelope into out. Transport failures and server
+// errors are reported as domain.ErrProviderUnavailable.
+func (m *MonnifyAdapter) do(req *http.Request, out interface{}) error {
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: monnify returned status %d", domain.ErrProviderUnavailable, resp.StatusCode)
+	}
+	var envelope MonnifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("error decoding response (status %d): %v", resp.StatusCode, err)
+	}
+	if !envelope.RequestSuccessful {
+		return fmt.Errorf("request rejected: %s", envelope.ResponseMessage)
+	}
+	if err := json.Unmarshal(envelope.ResponseBody, out); err != nil {
+		return fmt.Errorf("error decoding response body: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package monnify
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+)
+
+const paidTransaction = `{"requestSuccessful":true,"responseBody":{"transactionReference":"MNFY|1","paymentReference":"MED-1","amountPaid":5000,"paidOn":"2025-01-02 10:00:00","paymentStatus":"PAID","paymentMethod":"CARD","currency":"NGN","customer":{"email":"patient@example.com"}}}`
+
+// newTestAdapter serves the login endpoint and hands every other request to handler once
+// the bearer token is checked. logins counts how often the adapter authenticated.
+func newTestAdapter(t *testing.T, handler http.HandlerFunc) (*MonnifyAdapter, *int) {
+	t.Helper()
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/login" {
+			if user, pass, ok := r.BasicAuth(); !ok || user != "MK_TEST" || pass != "secret" {
+				t.Errorf("unexpected credentials %q %q", user, pass)
+			}
+			logins++
+			w.Write([]byte(`{"requestSuccessful":true,"responseBody":{"accessToken":"token-1","expiresIn":3600}}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return NewMonnifyAdapter(&MonnifyConfig{
+		APIKey:       "MK_TEST",
+		SecretKey:    "secret",
+		ContractCode: "1234567890",
+		BaseURL:      server.URL,
+	}), &logins
+}
+
+func TestInitializeTransaction(t *testing.T) {
+	adapter, _ := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/v1/merchant/transactions/init-transaction" ||
+			body["paymentReference"] != "MED-1" || body["contractCode"] != "1234567890" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, body)
+		}
+		w.Write([]byte(`{"requestSuccessful":true,"responseBody":{"transactionReference":"MNFY|1","paymentReference":"MED-1","checkoutUrl":"https://sandbox.monnify.com/checkout/MNFY|1"}}`))
+	})
+
+	checkout, err := adapter.InitializeTransaction(domain.TransactionRequest{
+		Email:     "patient@example.com",
+		Reference: "MED-1",
+		Amount:    5000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkout.Provider != db.PaymentProviderMONNIFY || checkout.Reference != "MED-1" ||
+		checkout.AuthorizationURL != "https://sandbox.monnify.com/checkout/MNFY|1" {
+		t.Errorf("unexpected checkout %+v", checkout)
+	}
+}
+
+func TestVerifyTransactionReusesToken(t *testing.T) {
+	adapter, logins := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/merchant/transactions/query" || r.URL.Query().Get("paymentReference") != "MED-1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(paidTransaction))
+	})
+
+	for i := 0; i < 2; i++ {
+		verification, err := adapter.VerifyTransaction("MED-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verification.Status != domain.TransactionStatusSuccess || verification.TransactionID != "MNFY|1" ||
+			verification.Amount != 5000 {
+			t.Errorf("unexpected verification %+v", verification)
+		}
+	}
+	if *logins != 1 {
+		t.Errorf("logged in %d times; want 1", *logins)
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+	from := time.UnixMilli(1735689600000)
+	to := from.Add(48 * time.Hour)
+	adapter, _ := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/api/v1/transactions/search" || query.Get("from") != "1735689600000" || query.Get("to") != "1735862400000" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch query.Get("page") {
+		case "0":
+			w.Write([]byte(`{"requestSuccessful":true,"responseBody":{"content":[{"transactionReference":"MNFY|1","paymentReference":"MED-1","amountPaid":5000,"paymentStatus":"PAID","currency":"NGN"}],"last":false}}`))
+		case "1":
+			w.Write([]byte(`{"requestSuccessful":true,"responseBody":{"content":[{"transactionReference":"MNFY|2","paymentReference":"MED-2","paymentStatus":"EXPIRED","currency":"NGN"}],"last":true}}`))
+		default:
+			t.Errorf("unexpected page %s", query.Get("page"))
+		}
+	})
+
+	transactions, err := adapter.ListTransactions(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transactions) != 2 || transactions[0].Reference != "MED-1" || transactions[1].Reference != "MED-2" {
+		t.Fatalf("unexpected transactions %+v", transactions)
+	}
+	if transactions[0].Status != domain.TransactionStatusSuccess || transactions[1].Status != domain.TransactionStatusFailed {
+		t.Errorf("unexpected statuses %s %s", transactions[0].Status, transactions[1].Status)
+	}
+}
+
+func TestRefundTransaction(t *testing.T) {
+	adapter, _ := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/merchant/transactions/query":
+			w.Write([]byte(paidTransaction))
+		case "/api/v1/refunds/initiate-refund":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["transactionReference"] != "MNFY|1" || body["refundAmount"] != 2000.0 {
+				t.Errorf("unexpected refund request %v", body)
+			}
+			w.Write([]byte(`{"requestSuccessful":true,"responseBody":{"refundReference":"MED-1-RF","refundAmount":2000,"refundStatus":"IN_PROGRESS"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	refund, err := adapter.RefundTransaction("MED-1", 2000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Status != domain.RefundStatusPending || refund.RefundID != "MED-1-RF" || refund.Amount != 2000 {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestGetRefund(t *testing.T) {
+	adapter, _ := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/refunds/MED-1-RF" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"requestSuccessful":true,"responseBody":{"refundReference":"MED-1-RF","refundAmount":2000,"refundStatus":"COMPLETED"}}`))
+	})
+
+	refund, err := adapter.GetRefund("MED-1-RF")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Status != domain.RefundStatusProcessed || refund.RefundID != "MED-1-RF" || refund.Amount != 2000 {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	adapter := NewMonnifyAdapter(&MonnifyConfig{BaseURL: server.URL})
+
+	_, err := adapter.VerifyTransaction("MED-1")
+	if !errors.Is(err, domain.ErrProviderUnavailable) {
+		t.Fatalf("error = %v; want %v", err, domain.ErrProviderUnavailable)
+	}
+}
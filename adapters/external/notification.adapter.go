@@ -2,11 +2,13 @@ package ex
 
 import (
 	"github.com/diagnoxix/adapters/external/templates/emails"
+	"github.com/diagnoxix/core/ports"
 )
 
 type NotificationAdapter struct {
 	config        *EmailConfig
 	emailTemplate emails.EmailTemplateHandler
+	sms           ports.SMSProvider
 }
 
 func NewNotificationAdapter(con *EmailConfig) *NotificationAdapter {
@@ -15,3 +17,9 @@ func NewNotificationAdapter(con *EmailConfig) *NotificationAdapter {
 		emailTemplate: *emails.NewEmailTemplateHandler(),
 	}
 }
+
+// WithSMSProvider sends text messages through provider. Without one, messages are only logged.
+func (n *NotificationAdapter) WithSMSProvider(provider ports.SMSProvider) *NotificationAdapter {
+	n.sms = provider
+	return n
+}
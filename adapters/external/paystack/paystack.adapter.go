@@ -4,13 +4,30 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
 )
 
+// Transactions requested per page when listing
+const listPageSize = 100
+
+// Ensure PaystackAdapter implements PaymentProviderService
+var _ ports.PaymentProviderService = (*PaystackAdapter)(nil)
+
 type (
 	PaystackConfig struct {
 		SecretKey string
 		BaseURL   string
+		// HTTPClient defaults to a client with a 30 second timeout
+		HTTPClient *http.Client
 	}
 	PaystackTransactionResponse struct {
 		Status  bool   `json:"status"`
@@ -31,6 +48,7 @@ type (
 		Brand             string `json:"brand"`
 	}
 	Data struct {
+		ID            int64                  `json:"id"`
 		Status        string                 `json:"status"`
 		Reference     string                 `json:"reference"`
 		Amount        float64                `json:"amount"`
@@ -48,6 +66,15 @@ type (
 		Message string `json:"message"`
 		Data    Data   `json:"data"`
 	}
+	PaystackTransactionListResponse struct {
+		Status  bool   `json:"status"`
+		Message string `json:"message"`
+		Data    []Data `json:"data"`
+		Meta    struct {
+			Page      int `json:"page"`
+			PageCount int `json:"pageCount"`
+		} `json:"meta"`
+	}
 	Customer struct {
 		ID           int    `json:"id"`
 		FirstName    string `json:"first_name"`
@@ -56,86 +83,199 @@ type (
 		CustomerCode string `json:"customer_code"`
 		Phone        string `json:"phone"`
 	}
+	PaystackRefundResponse struct {
+		Status  bool   `json:"status"`
+		Message string `json:"message"`
+		Data    struct {
+			ID       int64   `json:"id"`
+			Status   string  `json:"status"`
+			Amount   float64 `json:"amount"`
+			Currency string  `json:"currency"`
+		} `json:"data"`
+	}
 	PaystackAdapter struct {
 		config *PaystackConfig
+		client *http.Client
 	}
 )
 
 func NewPaystackAdapter(con *PaystackConfig) *PaystackAdapter {
+	client := con.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
 	return &PaystackAdapter{
 		config: con,
+		client: client,
 	}
 }
 
-func (p *PaystackAdapter) InitializeTransaction(email, reference string, amount float64, metadata map[string]interface{}) (*PaystackTransactionResponse, error) {
-	url := fmt.Sprintf("%s/transaction/initialize", p.config.BaseURL)
+// Provider identifies Paystack in payment records
+func (p *PaystackAdapter) Provider() db.PaymentProvider {
+	return db.PaymentProviderPAYSTACK
+}
 
+// InitializeTransaction starts a Paystack checkout for the request
+func (p *PaystackAdapter) InitializeTransaction(request domain.TransactionRequest) (*domain.TransactionCheckout, error) {
+	currency := request.Currency
+	if currency == "" {
+		currency = "NGN"
+	}
 	// Paystack expects amount in kobo (multiply by 100)
-	amountInKobo := int64(amount * 100)
-
 	payload := map[string]interface{}{
-		"email":     email,
-		"amount":    amountInKobo,
-		"reference": reference,
-		"metadata":  metadata,
-		"currency":  "NGN",
+		"email":     request.Email,
+		"amount":    int64(math.Round(request.Amount * 100)),
+		"reference": request.Reference,
+		"metadata":  request.Metadata,
+		"currency":  currency,
 	}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
+	var result PaystackTransactionResponse
+	if err := p.send(http.MethodPost, "/transaction/initialize", payload, &result); err != nil {
 		return nil, err
 	}
+	if !result.Status {
+		return nil, fmt.Errorf("transaction rejected: %s", result.Message)
+	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
+	return &domain.TransactionCheckout{
+		Provider:         db.PaymentProviderPAYSTACK,
+		Reference:        result.Data.Reference,
+		AuthorizationURL: result.Data.AuthorizationURL,
+		AccessCode:       result.Data.AccessCode,
+	}, nil
+}
+
+// VerifyTransaction verifies a transaction using its reference
+func (p *PaystackAdapter) VerifyTransaction(reference string) (*domain.TransactionVerification, error) {
+	var result PaystackVerificationResponse
+	if err := p.send(http.MethodGet, "/transaction/verify/"+reference, nil, &result); err != nil {
 		return nil, err
 	}
+	if !result.Status {
+		return nil, fmt.Errorf("verification rejected: %s", result.Message)
+	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.config.SecretKey))
-	req.Header.Set("Content-Type", "application/json")
+	return verification(result.Data), nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+// ListTransactions lists the transactions created between from and to, following every page
+func (p *PaystackAdapter) ListTransactions(from, to time.Time) ([]*domain.TransactionVerification, error) {
+	var transactions []*domain.TransactionVerification
+	for page := 1; ; page++ {
+		query := url.Values{
+			"from":    {from.UTC().Format(time.RFC3339)},
+			"to":      {to.UTC().Format(time.RFC3339)},
+			"perPage": {strconv.Itoa(listPageSize)},
+			"page":    {strconv.Itoa(page)},
+		}
+		var result PaystackTransactionListResponse
+		if err := p.send(http.MethodGet, "/transaction?"+query.Encode(), nil, &result); err != nil {
+			return nil, err
+		}
+		if !result.Status {
+			return nil, fmt.Errorf("transaction list rejected: %s", result.Message)
+		}
+		for _, data := range result.Data {
+			transactions = append(transactions, verification(data))
+		}
+		if page >= result.Meta.PageCount || len(result.Data) == 0 {
+			return transactions, nil
+		}
 	}
-	defer resp.Body.Close()
+}
 
-	var result PaystackTransactionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+// RefundTransaction refunds part or all of a transaction; Paystack processes refunds asynchronously
+func (p *PaystackAdapter) RefundTransaction(reference string, amount float64) (*domain.TransactionRefund, error) {
+	payload := map[string]interface{}{
+		"transaction": reference,
+		"amount":      int64(math.Round(amount * 100)),
+	}
+
+	var result PaystackRefundResponse
+	if err := p.send(http.MethodPost, "/refund", payload, &result); err != nil {
 		return nil, err
 	}
+	if !result.Status {
+		return nil, fmt.Errorf("refund rejected: %s", result.Message)
+	}
 
-	return &result, nil
+	status := domain.RefundStatusPending
+	switch result.Data.Status {
+	case "processed":
+		status = domain.RefundStatusProcessed
+	case "failed":
+		status = domain.RefundStatusFailed
+	}
+	return &domain.TransactionRefund{
+		Provider: db.PaymentProviderPAYSTACK,
+		RefundID: strconv.FormatInt(result.Data.ID, 10),
+		Status:   status,
+		Amount:   result.Data.Amount / 100,
+		Currency: result.Data.Currency,
+	}, nil
 }
 
-// VerifyTransaction verifies a transaction using its reference
-func (p *PaystackAdapter) VerifyTransaction(reference string) (*PaystackVerificationResponse, error) {
-	url := fmt.Sprintf("%s/transaction/verify/%s", p.config.BaseURL, reference)
+// verification maps a Paystack transaction onto the provider-neutral verification
+func verification(data Data) *domain.TransactionVerification {
+	status := domain.TransactionStatusPending
+	switch data.Status {
+	case "success":
+		status = domain.TransactionStatusSuccess
+	case "failed", "abandoned", "reversed":
+		status = domain.TransactionStatusFailed
+	}
+	paidAt, _ := time.Parse(time.RFC3339, data.PaidAt)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
+	return &domain.TransactionVerification{
+		Provider:      db.PaymentProviderPAYSTACK,
+		TransactionID: strconv.FormatInt(data.ID, 10),
+		Reference:     data.Reference,
+		Status:        status,
+		Amount:        data.Amount / 100,
+		Currency:      data.Currency,
+		Channel:       data.Channel,
+		PaidAt:        paidAt,
+		Customer: domain.TransactionCustomer{
+			Email:     data.Customer.Email,
+			FirstName: data.Customer.FirstName,
+			LastName:  data.Customer.LastName,
+			Phone:     data.Customer.Phone,
+		},
+		Metadata: data.Metadata,
+	}
+}
+
+// send calls the Paystack API and decodes its JSON response into out. Transport failures and
+// server errors are reported as domain.ErrProviderUnavailable.
+func (p *PaystackAdapter) send(method, path string, payload, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(jsonData)
 	}
 
+	req, err := http.NewRequest(method, p.config.BaseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.config.SecretKey))
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("%w: %v", domain.ErrProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: paystack returned status %d", domain.ErrProviderUnavailable, resp.StatusCode)
 	}
-
-	var result PaystackVerificationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response (status %d): %v", resp.StatusCode, err)
 	}
-
-	return &result, nil
+	return nil
 }
@@ -0,0 +1,127 @@
+package paystack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+)
+
+func newTestAdapter(t *testing.T, handler http.HandlerFunc) *PaystackAdapter {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewPaystackAdapter(&PaystackConfig{SecretKey: "sk_test", BaseURL: server.URL})
+}
+
+func TestInitializeTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transaction/initialize" || r.Header.Get("Authorization") != "Bearer sk_test" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["amount"] != float64(1250050) || body["currency"] != "GHS" {
+			t.Errorf("amount = %v %v; want 1250050 kobo in GHS", body["amount"], body["currency"])
+		}
+		w.Write([]byte(`{"status":true,"data":{"authorization_url":"https://checkout/abc","access_code":"abc","reference":"MED-1"}}`))
+	})
+
+	checkout, err := adapter.InitializeTransaction(domain.TransactionRequest{
+		Email:     "patient@example.com",
+		Reference: "MED-1",
+		Amount:    12500.50,
+		Currency:  "GHS",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkout.Provider != db.PaymentProviderPAYSTACK || checkout.AuthorizationURL != "https://checkout/abc" {
+		t.Errorf("unexpected checkout %+v", checkout)
+	}
+}
+
+func TestVerifyTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transaction/verify/MED-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"status":true,"data":{"id":42,"status":"success","reference":"MED-1","amount":500000,"currency":"NGN","channel":"card","paid_at":"2025-01-02T10:00:00Z","customer":{"email":"patient@example.com"}}}`))
+	})
+
+	verification, err := adapter.VerifyTransaction("MED-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verification.Status != domain.TransactionStatusSuccess || verification.TransactionID != "42" || verification.Amount != 5000 {
+		t.Errorf("unexpected verification %+v", verification)
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/transaction" || query.Get("from") != "2025-01-01T00:00:00Z" || query.Get("to") != "2025-01-03T00:00:00Z" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch query.Get("page") {
+		case "1":
+			w.Write([]byte(`{"status":true,"data":[{"id":1,"status":"success","reference":"MED-1","amount":500000,"currency":"NGN"}],"meta":{"page":1,"pageCount":2}}`))
+		case "2":
+			w.Write([]byte(`{"status":true,"data":[{"id":2,"status":"abandoned","reference":"MED-2","amount":250000,"currency":"NGN"}],"meta":{"page":2,"pageCount":2}}`))
+		default:
+			t.Errorf("unexpected page %s", query.Get("page"))
+		}
+	})
+
+	transactions, err := adapter.ListTransactions(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("got %d transactions; want 2", len(transactions))
+	}
+	if transactions[0].Reference != "MED-1" || transactions[0].Status != domain.TransactionStatusSuccess || transactions[0].Amount != 5000 {
+		t.Errorf("unexpected transaction %+v", transactions[0])
+	}
+	if transactions[1].Reference != "MED-2" || transactions[1].Status != domain.TransactionStatusFailed {
+		t.Errorf("unexpected transaction %+v", transactions[1])
+	}
+}
+
+func TestRefundTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/refund" || body["transaction"] != "MED-1" || body["amount"] != float64(250000) {
+			t.Errorf("unexpected refund request %s %v", r.URL.Path, body)
+		}
+		w.Write([]byte(`{"status":true,"data":{"id":7,"status":"pending","amount":250000,"currency":"NGN"}}`))
+	})
+
+	refund, err := adapter.RefundTransaction("MED-1", 2500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Status != domain.RefundStatusPending || refund.RefundID != "7" || refund.Amount != 2500 {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := adapter.VerifyTransaction("MED-1")
+	if !errors.Is(err, domain.ErrProviderUnavailable) {
+		t.Fatalf("error = %v; want %v", err, domain.ErrProviderUnavailable)
+	}
+}
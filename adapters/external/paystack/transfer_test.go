@@ -0,0 +1,73 @@
+package paystack
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/diagnoxix/core/domain"
+)
+
+func TestCreateRecipient(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/transferrecipient" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["type"] != "nuban" || body["account_number"] != "0123456789" || body["bank_code"] != "058" {
+			t.Errorf("unexpected recipient %v", body)
+		}
+		w.Write([]byte(`{"status":true,"data":{"recipient_code":"RCP_1","details":{"account_name":"LEKKI DIAGNOSTICS LTD"}}}`))
+	})
+
+	recipient, err := adapter.CreateRecipient(domain.PayoutRecipientRequest{
+		Name:          "Lekki Diagnostics",
+		AccountNumber: "0123456789",
+		BankCode:      "058",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recipient.RecipientCode != "RCP_1" || recipient.AccountName != "LEKKI DIAGNOSTICS LTD" {
+		t.Errorf("unexpected recipient %+v", recipient)
+	}
+}
+
+func TestInitiateTransfer(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/transfer" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["amount"] != float64(4500025) || body["recipient"] != "RCP_1" || body["reference"] != "stl_abc_1" {
+			t.Errorf("unexpected transfer %v", body)
+		}
+		w.Write([]byte(`{"status":true,"data":{"reference":"stl_abc_1","transfer_code":"TRF_1","status":"otp","amount":4500025,"currency":"NGN"}}`))
+	})
+
+	transfer, err := adapter.InitiateTransfer(domain.TransferRequest{
+		Reference:     "stl_abc_1",
+		RecipientCode: "RCP_1",
+		Amount:        45000.25,
+		Currency:      "NGN",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transfer.Status != domain.TransferStatusPending || transfer.TransferCode != "TRF_1" || transfer.Amount != 45000.25 {
+		t.Errorf("unexpected transfer %+v", transfer)
+	}
+}
+
+func TestInitiateTransferRejected(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":false,"message":"Your balance is not enough to fulfil this request"}`))
+	})
+
+	if _, err := adapter.InitiateTransfer(domain.TransferRequest{Reference: "stl_abc_1", Amount: 10}); err == nil {
+		t.Fatal("expected an error for a rejected transfer")
+	}
+}
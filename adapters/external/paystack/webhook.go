@@ -0,0 +1,26 @@
+package paystack
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader carries the HMAC-SHA512 of a webhook body keyed with the secret key
+const SignatureHeader = "x-paystack-signature"
+
+// ValidSignature reports whether signature is the HMAC-SHA512 of body under secretKey
+func ValidSignature(secretKey string, body []byte, signature string) bool {
+	if secretKey == "" || signature == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha512.New, []byte(secretKey))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
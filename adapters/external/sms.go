@@ -0,0 +1,29 @@
+package ex
+
+import (
+	"fmt"
+
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+)
+
+// SendSMS sends a text message through the configured SMS provider. Without a provider it
+// fails with domain.ErrSMSUnavailable. Messages carry one-time codes, so only their length
+// is logged.
+func (n *NotificationAdapter) SendSMS(phone, message string) error {
+	if n.sms == nil {
+		utils.Warn("No SMS provider configured, message not sent",
+			utils.LogField{Key: "phone", Value: phone},
+			utils.LogField{Key: "message_length", Value: len(message)})
+		return fmt.Errorf("%w: no SMS provider configured", domain.ErrSMSUnavailable)
+	}
+
+	id, err := n.sms.SendSMS(phone, message)
+	if err != nil {
+		return fmt.Errorf("%s: %w", n.sms.Name(), err)
+	}
+	utils.Info("SMS sent",
+		utils.LogField{Key: "provider", Value: n.sms.Name()},
+		utils.LogField{Key: "message_id", Value: id})
+	return nil
+}
@@ -0,0 +1,318 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure StripeAdapter implements PaymentProviderService
+var _ ports.PaymentProviderService = (*StripeAdapter)(nil)
+
+// Ensure StripeAdapter reports refund status, as no Stripe webhooks are taken
+var _ ports.RefundStatusService = (*StripeAdapter)(nil)
+
+type (
+	StripeConfig struct {
+		SecretKey string
+		BaseURL   string
+		// RedirectURL is where Stripe sends the patient after checkout
+		RedirectURL string
+		// HTTPClient defaults to a client with a 30 second timeout
+		HTTPClient *http.Client
+	}
+	StripeError struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	CheckoutSession struct {
+		ID                string `json:"id"`
+		URL               string `json:"url"`
+		ClientReferenceID string `json:"client_reference_id"`
+	}
+	PaymentIntent struct {
+		ID                 string            `json:"id"`
+		Amount             int64             `json:"amount"`
+		AmountReceived     int64             `json:"amount_received"`
+		Currency           string            `json:"currency"`
+		Status             string            `json:"status"`
+		Created            int64             `json:"created"`
+		ReceiptEmail       string            `json:"receipt_email"`
+		PaymentMethodTypes []string          `json:"payment_method_types"`
+		Metadata           map[string]string `json:"metadata"`
+	}
+	PaymentIntentSearchResult struct {
+		Data []PaymentIntent `json:"data"`
+	}
+	PaymentIntentList struct {
+		Data    []PaymentIntent `json:"data"`
+		HasMore bool            `json:"has_more"`
+	}
+	Refund struct {
+		ID       string `json:"id"`
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+		Status   string `json:"status"`
+	}
+	StripeAdapter struct {
+		config *StripeConfig
+		client *http.Client
+	}
+)
+
+func NewStripeAdapter(con *StripeConfig) *StripeAdapter {
+	client := con.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &StripeAdapter{
+		config: con,
+		client: client,
+	}
+}
+
+// Provider identifies Stripe in payment records
+func (s *StripeAdapter) Provider() db.PaymentProvider {
+	return db.PaymentProviderSTRIPE
+}
+
+// InitializeTransaction creates a Stripe Checkout session for the request. The reference is
+// copied onto the payment intent so the transaction can be found by it later.
+func (s *StripeAdapter) InitializeTransaction(request domain.TransactionRequest) (*domain.TransactionCheckout, error) {
+	currency := strings.ToLower(request.Currency)
+	if currency == "" {
+		currency = "ngn"
+	}
+	form := url.Values{}
+	form.Set("mode", "payment")
+	form.Set("client_reference_id", request.Reference)
+	form.Set("customer_email", request.Email)
+	form.Set("success_url", s.config.RedirectURL)
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("line_items[0][price_data][currency]", currency)
+	form.Set("line_items[0][price_data][unit_amount]", strconv.FormatInt(minorUnits(request.Amount), 10))
+	form.Set("line_items[0][price_data][product_data][name]", "Diagnostic appointment "+request.Reference)
+	form.Set("metadata[reference]", request.Reference)
+	form.Set("payment_intent_data[metadata][reference]", request.Reference)
+	for key, value := range request.Metadata {
+		form.Set(fmt.Sprintf("payment_intent_data[metadata][%s]", key), fmt.Sprint(value))
+	}
+
+	var session CheckoutSession
+	if err := s.send(http.MethodPost, "/v1/checkout/sessions", form, &session); err != nil {
+		return nil, err
+	}
+
+	return &domain.TransactionCheckout{
+		Provider:         db.PaymentProviderSTRIPE,
+		Reference:        request.Reference,
+		AuthorizationURL: session.URL,
+		AccessCode:       session.ID,
+	}, nil
+}
+
+// VerifyTransaction looks up the payment intent carrying the reference. A checkout that has
+// not been completed yet has no payment intent and is reported as pending.
+func (s *StripeAdapter) VerifyTransaction(reference string) (*domain.TransactionVerification, error) {
+	intent, err := s.paymentIntent(reference)
+	if err != nil {
+		return nil, err
+	}
+	if intent == nil {
+		return &domain.TransactionVerification{
+			Provider:  db.PaymentProviderSTRIPE,
+			Reference: reference,
+			Status:    domain.TransactionStatusPending,
+		}, nil
+	}
+
+	return verification(intent, reference), nil
+}
+
+// ListTransactions lists the payment intents created between from and to, following every
+// page. Intents without a reference were not started by the platform and are left out.
+func (s *StripeAdapter) ListTransactions(from, to time.Time) ([]*domain.TransactionVerification, error) {
+	var transactions []*domain.TransactionVerification
+	var startingAfter string
+	for {
+		query := url.Values{}
+		query.Set("created[gte]", strconv.FormatInt(from.Unix(), 10))
+		query.Set("created[lte]", strconv.FormatInt(to.Unix(), 10))
+		query.Set("limit", "100")
+		if startingAfter != "" {
+			query.Set("starting_after", startingAfter)
+		}
+
+		var result PaymentIntentList
+		if err := s.send(http.MethodGet, "/v1/payment_intents?"+query.Encode(), nil, &result); err != nil {
+			return nil, err
+		}
+		for i := range result.Data {
+			intent := &result.Data[i]
+			if reference := intent.Metadata["reference"]; reference != "" {
+				transactions = append(transactions, verification(intent, reference))
+			}
+		}
+		if !result.HasMore || len(result.Data) == 0 {
+			return transactions, nil
+		}
+		startingAfter = result.Data[len(result.Data)-1].ID
+	}
+}
+
+// RefundTransaction refunds part or all of the payment intent carrying the reference
+func (s *StripeAdapter) RefundTransaction(reference string, amount float64) (*domain.TransactionRefund, error) {
+	intent, err := s.paymentIntent(reference)
+	if err != nil {
+		return nil, err
+	}
+	if intent == nil {
+		return nil, fmt.Errorf("refund rejected: no payment found for reference %s", reference)
+	}
+
+	form := url.Values{}
+	form.Set("payment_intent", intent.ID)
+	form.Set("amount", strconv.FormatInt(minorUnits(amount), 10))
+
+	var refund Refund
+	if err := s.send(http.MethodPost, "/v1/refunds", form, &refund); err != nil {
+		return nil, err
+	}
+	return transactionRefund(&refund), nil
+}
+
+// GetRefund fetches a refund by its Stripe ID
+func (s *StripeAdapter) GetRefund(refundID string) (*domain.TransactionRefund, error) {
+	var refund Refund
+	if err := s.send(http.MethodGet, "/v1/refunds/"+url.PathEscape(refundID), nil, &refund); err != nil {
+		return nil, err
+	}
+	return transactionRefund(&refund), nil
+}
+
+// transactionRefund maps a Stripe refund onto the provider-neutral refund
+func transactionRefund(refund *Refund) *domain.TransactionRefund {
+	status := domain.RefundStatusPending
+	switch refund.Status {
+	case "succeeded":
+		status = domain.RefundStatusProcessed
+	case "failed", "canceled":
+		status = domain.RefundStatusFailed
+	}
+	return &domain.TransactionRefund{
+		Provider: db.PaymentProviderSTRIPE,
+		RefundID: refund.ID,
+		Status:   status,
+		Amount:   majorUnits(refund.Amount),
+		Currency: strings.ToUpper(refund.Currency),
+	}
+}
+
+// verification maps a payment intent onto the provider-neutral verification
+func verification(intent *PaymentIntent, reference string) *domain.TransactionVerification {
+	status := domain.TransactionStatusPending
+	switch intent.Status {
+	case "succeeded":
+		status = domain.TransactionStatusSuccess
+	case "canceled":
+		status = domain.TransactionStatusFailed
+	}
+	var channel string
+	if len(intent.PaymentMethodTypes) > 0 {
+		channel = intent.PaymentMethodTypes[0]
+	}
+	metadata := make(map[string]interface{}, len(intent.Metadata))
+	for key, value := range intent.Metadata {
+		metadata[key] = value
+	}
+
+	return &domain.TransactionVerification{
+		Provider:      db.PaymentProviderSTRIPE,
+		TransactionID: intent.ID,
+		Reference:     reference,
+		Status:        status,
+		Amount:        majorUnits(intent.AmountReceived),
+		Currency:      strings.ToUpper(intent.Currency),
+		Channel:       channel,
+		PaidAt:        time.Unix(intent.Created, 0).UTC(),
+		Customer:      domain.TransactionCustomer{Email: intent.ReceiptEmail},
+		Metadata:      metadata,
+	}
+}
+
+// paymentIntent finds the payment intent created for a reference, or nil when there is none yet
+func (s *StripeAdapter) paymentIntent(reference string) (*PaymentIntent, error) {
+	query := url.Values{}
+	query.Set("query", fmt.Sprintf("metadata['reference']:'%s'", reference))
+
+	var result PaymentIntentSearchResult
+	if err := s.send(http.MethodGet, "/v1/payment_intents/search?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		return nil, nil
+	}
+	return &result.Data[0], nil
+}
+
+// send calls the Stripe API with a form-encoded body and decodes its JSON response into out.
+// Transport failures and server errors are reported as domain.ErrProviderUnavailable.
+func (s *StripeAdapter) send(method, path string, form url.Values, out interface{}) error {
+	var body *strings.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+
+	req, err := http.NewRequest(method, s.config.BaseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.SecretKey))
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: stripe returned status %d", domain.ErrProviderUnavailable, resp.StatusCode)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var stripeErr StripeError
+		if err := json.NewDecoder(resp.Body).Decode(&stripeErr); err != nil {
+			return fmt.Errorf("stripe returned status %d", resp.StatusCode)
+		}
+		return fmt.Errorf("request rejected: %s", stripeErr.Error.Message)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response (status %d): %v", resp.StatusCode, err)
+	}
+	return nil
+}
+
+// minorUnits converts an amount in major units to the currency's minor unit
+func minorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// majorUnits converts an amount in the currency's minor unit to major units
+func majorUnits(amount int64) float64 {
+	return float64(amount) / 100
+}
@@ -0,0 +1,182 @@
+package stripe
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+)
+
+const intentSearch = `{"data":[{"id":"pi_123","amount":500000,"amount_received":500000,"currency":"ngn","status":"succeeded","created":1735812000,"receipt_email":"patient@example.com","payment_method_types":["card"],"metadata":{"reference":"MED-1"}}]}`
+
+func newTestAdapter(t *testing.T, handler http.HandlerFunc) *StripeAdapter {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewStripeAdapter(&StripeConfig{
+		SecretKey:   "sk_test",
+		BaseURL:     server.URL,
+		RedirectURL: "https://app/payments/callback",
+	})
+}
+
+func TestInitializeTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/checkout/sessions" || r.Header.Get("Authorization") != "Bearer sk_test" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		r.ParseForm()
+		if r.PostForm.Get("line_items[0][price_data][unit_amount]") != "1250050" ||
+			r.PostForm.Get("line_items[0][price_data][currency]") != "usd" ||
+			r.PostForm.Get("payment_intent_data[metadata][reference]") != "MED-1" {
+			t.Errorf("unexpected form %v", r.PostForm)
+		}
+		w.Write([]byte(`{"id":"cs_test_1","url":"https://checkout.stripe.com/c/pay/cs_test_1"}`))
+	})
+
+	checkout, err := adapter.InitializeTransaction(domain.TransactionRequest{
+		Email:     "patient@example.com",
+		Reference: "MED-1",
+		Amount:    12500.50,
+		Currency:  "USD",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkout.Provider != db.PaymentProviderSTRIPE || checkout.AccessCode != "cs_test_1" ||
+		checkout.AuthorizationURL != "https://checkout.stripe.com/c/pay/cs_test_1" {
+		t.Errorf("unexpected checkout %+v", checkout)
+	}
+}
+
+func TestVerifyTransaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		wantStatus domain.TransactionStatus
+		wantAmount float64
+	}{
+		{"succeeded", intentSearch, domain.TransactionStatusSuccess, 5000},
+		{"checkout not completed", `{"data":[]}`, domain.TransactionStatusPending, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/payment_intents/search" || r.URL.Query().Get("query") != "metadata['reference']:'MED-1'" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				w.Write([]byte(tt.response))
+			})
+
+			verification, err := adapter.VerifyTransaction("MED-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if verification.Status != tt.wantStatus || verification.Amount != tt.wantAmount {
+				t.Errorf("unexpected verification %+v", verification)
+			}
+		})
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+	from := time.Unix(1735689600, 0)
+	to := from.Add(48 * time.Hour)
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/v1/payment_intents" || query.Get("created[gte]") != "1735689600" || query.Get("created[lte]") != "1735862400" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch query.Get("starting_after") {
+		case "":
+			w.Write([]byte(`{"has_more":true,"data":[{"id":"pi_1","amount_received":500000,"currency":"ngn","status":"succeeded","metadata":{"reference":"MED-1"}},{"id":"pi_2","currency":"usd","status":"succeeded","metadata":{}}]}`))
+		case "pi_2":
+			w.Write([]byte(`{"has_more":false,"data":[{"id":"pi_3","currency":"ngn","status":"canceled","metadata":{"reference":"MED-3"}}]}`))
+		default:
+			t.Errorf("unexpected cursor %s", query.Get("starting_after"))
+		}
+	})
+
+	transactions, err := adapter.ListTransactions(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// pi_2 has no reference, so it was not started by the platform
+	if len(transactions) != 2 || transactions[0].Reference != "MED-1" || transactions[1].Reference != "MED-3" {
+		t.Fatalf("unexpected transactions %+v", transactions)
+	}
+	if transactions[0].Amount != 5000 || transactions[1].Status != domain.TransactionStatusFailed {
+		t.Errorf("unexpected transactions %+v %+v", transactions[0], transactions[1])
+	}
+}
+
+func TestRefundTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/payment_intents/search":
+			w.Write([]byte(intentSearch))
+		case "/v1/refunds":
+			r.ParseForm()
+			if r.PostForm.Get("payment_intent") != "pi_123" || r.PostForm.Get("amount") != "200000" {
+				t.Errorf("unexpected form %v", r.PostForm)
+			}
+			w.Write([]byte(`{"id":"re_1","amount":200000,"currency":"ngn","status":"pending"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	refund, err := adapter.RefundTransaction("MED-1", 2000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Status != domain.RefundStatusPending || refund.RefundID != "re_1" ||
+		refund.Amount != 2000 || refund.Currency != "NGN" {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestGetRefund(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/refunds/re_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"re_1","amount":200000,"currency":"ngn","status":"succeeded"}`))
+	})
+
+	refund, err := adapter.GetRefund("re_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.Status != domain.RefundStatusProcessed || refund.RefundID != "re_1" || refund.Amount != 2000 {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
+
+func TestRejectedRequest(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"Invalid currency"}}`))
+	})
+
+	_, err := adapter.InitializeTransaction(domain.TransactionRequest{Reference: "MED-1", Amount: 1})
+	if err == nil || errors.Is(err, domain.ErrProviderUnavailable) {
+		t.Fatalf("error = %v; want a rejection that is not a provider outage", err)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := adapter.VerifyTransaction("MED-1")
+	if !errors.Is(err, domain.ErrProviderUnavailable) {
+		t.Fatalf("error = %v; want %v", err, domain.ErrProviderUnavailable)
+	}
+}
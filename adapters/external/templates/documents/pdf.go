@@ -0,0 +1,179 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 in points, the unit PDF pages are measured in
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	pageMargin = 50.0
+)
+
+// Fonts are the standard Type 1 fonts every PDF reader ships, so none is embedded
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+	fontMono    = "F3"
+)
+
+var standardFonts = []struct {
+	name     string
+	baseFont string
+}{
+	{fontRegular, "Helvetica"},
+	{fontBold, "Helvetica-Bold"},
+	{fontMono, "Courier"},
+}
+
+// pdfDocument lays out lines of text top to bottom on A4 pages, starting a new page when
+// one is full. It writes just enough of PDF 1.4 for text and rules, and the same lines
+// always give the same bytes.
+type pdfDocument struct {
+	pages [][]byte
+	page  bytes.Buffer
+	y     float64
+}
+
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{y: pageHeight - pageMargin}
+}
+
+// text writes a line in a font, wrapping it at the right margin
+func (d *pdfDocument) text(font string, size float64, line string) {
+	// Courier glyphs are 0.6em wide and the Helvetica ones narrower on average, so wrapping
+	// by character count at that width keeps every font inside the margins
+	maxChars := int((pageWidth - 2*pageMargin) / (0.6 * size))
+	for _, wrapped := range wrapLine(line, maxChars) {
+		d.advance(size * 1.35)
+		fmt.Fprintf(&d.page, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+			font, size, pageMargin, d.y, escapePDFText(wrapped))
+	}
+}
+
+// rule draws a thin line across the page
+func (d *pdfDocument) rule() {
+	d.advance(8)
+	fmt.Fprintf(&d.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n",
+		pageMargin, d.y+3, pageWidth-pageMargin, d.y+3)
+}
+
+// space leaves a gap before the next line
+func (d *pdfDocument) space(height float64) {
+	if d.y-height > pageMargin {
+		d.y -= height
+	}
+}
+
+// advance moves down by height, starting a new page when that would cross the bottom margin
+func (d *pdfDocument) advance(height float64) {
+	if d.y-height < pageMargin {
+		d.pages = append(d.pages, append([]byte(nil), d.page.Bytes()...))
+		d.page.Reset()
+		d.y = pageHeight - pageMargin
+	}
+	d.y -= height
+}
+
+// bytes writes the document out with title as its metadata title
+func (d *pdfDocument) bytes(title string) []byte {
+	pages := append(d.pages, d.page.Bytes())
+
+	// Objects are numbered in the order they are written: the catalog, the page tree, the
+	// document info, the fonts, then a page and its content stream for each page
+	fontObject := 4
+	firstPageObject := fontObject + len(standardFonts)
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Diagnoxix) >>", escapePDFText(title)))
+
+	fonts := make([]string, len(standardFonts))
+	for i, font := range standardFonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.baseFont))
+		fonts[i] = fmt.Sprintf("/%s %d 0 R", font.name, fontObject+i)
+	}
+
+	for i, content := range pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, strings.Join(fonts, " "), firstPageObject+2*i+1,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// winAnsiExtras are the characters WinAnsiEncoding places between 0x80 and 0x9F
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// escapePDFText encodes a string as a PDF literal string in WinAnsiEncoding. Characters the
+// standard fonts cannot show are replaced with a question mark.
+func escapePDFText(s string) string {
+	var out strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			out.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			out.WriteByte(byte(r))
+		case winAnsiExtras[r] != 0:
+			out.WriteByte(winAnsiExtras[r])
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
+
+// wrapLine splits a line into lines of at most maxChars characters, breaking at spaces where
+// it can. Leading spaces are kept so indented lines stay indented.
+func wrapLine(line string, maxChars int) []string {
+	runes := []rune(line)
+	if len(runes) <= maxChars {
+		return []string{line}
+	}
+
+	var lines []string
+	for len(runes) > maxChars {
+		cut := maxChars
+		for i := maxChars; i > maxChars/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
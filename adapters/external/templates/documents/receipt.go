@@ -0,0 +1,152 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ReceiptData contains everything printed on a payment's invoice and receipt. Amounts are in
+// major currency units and include tax.
+type ReceiptData struct {
+	InvoiceNumber    string
+	IssuedAt         time.Time
+	PaymentReference string
+	PaymentMethod    string
+	PaidAt           time.Time
+	Currency         string
+	Centre           ReceiptParty
+	BilledTo         ReceiptParty
+	Items            []ReceiptLine
+	Total            float64
+	// TaxRate is the percentage of tax included in the total
+	TaxRate   float64
+	TaxAmount float64
+	// Adjustments are the refunds made against the payment, as negative amounts
+	Adjustments []ReceiptLine
+	NetPaid     float64
+}
+
+// ReceiptParty is a name followed by its address and contact lines
+type ReceiptParty struct {
+	Name  string
+	Lines []string
+}
+
+// ReceiptLine is an amount with what it is for, and an optional detail printed under it
+type ReceiptLine struct {
+	Description string
+	Detail      string
+	Amount      float64
+}
+
+// receiptWidth is how many monospaced characters a receipt row takes
+const receiptWidth = 80
+
+// ReceiptTemplate lays the receipt out as lines of text: "# " starts the title, "## " a
+// heading and "---" draws a rule. Every other line is printed in a monospaced font, so
+// amounts line up in columns.
+const ReceiptTemplate = `
+{{- define "receipt" -}}
+# INVOICE / RECEIPT
+## {{.Centre.Name}}
+{{range .Centre.Lines}}{{.}}
+{{end}}
+{{field "Invoice number" .InvoiceNumber}}
+{{field "Issued" (date .IssuedAt)}}
+{{field "Payment reference" .PaymentReference}}
+{{field "Payment method" .PaymentMethod}}
+{{field "Paid on" (date .PaidAt)}}
+
+## Billed to
+{{.BilledTo.Name}}
+{{range .BilledTo.Lines}}{{.}}
+{{end}}
+## Items
+{{heading "Description" (printf "Amount (%s)" .Currency)}}
+---
+{{range .Items}}{{row .Description .Amount}}
+{{if .Detail}}  {{.Detail}}
+{{end}}{{end -}}
+---
+{{row "Total" .Total}}
+{{if .TaxRate}}{{row (printf "Includes tax at %s%%" (percent .TaxRate)) .TaxAmount}}
+{{end}}
+{{- if .Adjustments}}
+## Refund adjustments
+{{range .Adjustments}}{{row .Description .Amount}}
+{{if .Detail}}  {{.Detail}}
+{{end}}{{end -}}
+---
+{{end -}}
+{{row "Net paid" .NetPaid}}
+
+Thank you for choosing {{.Centre.Name}}. Please keep this receipt for your records.
+{{end}}`
+
+var receiptTemplate = template.Must(template.New("documents").Funcs(template.FuncMap{
+	"field": func(label, value string) string {
+		return fmt.Sprintf("%-20s %s", label+":", value)
+	},
+	"heading": func(description, amount string) string {
+		return fmt.Sprintf("%-*s%*s", receiptWidth-20, description, 20, amount)
+	},
+	"row": func(description string, amount float64) string {
+		return fmt.Sprintf("%-*.*s%*s", receiptWidth-20, receiptWidth-22, description, 20, FormatAmount(amount))
+	},
+	"date": func(t time.Time) string {
+		return t.Format("2 January 2006, 3:04 PM")
+	},
+	"percent": func(rate float64) string {
+		return strconv.FormatFloat(rate, 'f', -1, 64)
+	},
+}).Parse(ReceiptTemplate))
+
+// RenderReceipt renders a payment's invoice and receipt as a PDF
+func RenderReceipt(data *ReceiptData) ([]byte, error) {
+	var text bytes.Buffer
+	if err := receiptTemplate.ExecuteTemplate(&text, "receipt", data); err != nil {
+		return nil, fmt.Errorf("failed to execute receipt template: %w", err)
+	}
+
+	doc := newPDFDocument()
+	for _, line := range strings.Split(strings.TrimRight(text.String(), "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "# "):
+			doc.text(fontBold, 16, line[2:])
+			doc.space(6)
+		case strings.HasPrefix(line, "## "):
+			doc.space(4)
+			doc.text(fontBold, 11, line[3:])
+		case line == "---":
+			doc.rule()
+		case strings.TrimSpace(line) == "":
+			doc.space(8)
+		default:
+			doc.text(fontMono, 9, line)
+		}
+	}
+	return doc.bytes("Invoice " + data.InvoiceNumber), nil
+}
+
+// FormatAmount formats an amount with thousands separators and two decimal places
+func FormatAmount(amount float64) string {
+	formatted := strconv.FormatFloat(amount, 'f', 2, 64)
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+
+	whole, fraction := formatted[:len(formatted)-3], formatted[len(formatted)-3:]
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + grouped.String() + fraction
+}
@@ -0,0 +1,120 @@
+package documents
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReceipt() *ReceiptData {
+	paid := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	return &ReceiptData{
+		InvoiceNumber:    "INV-5F0C6D1E-000042",
+		IssuedAt:         paid,
+		PaymentReference: "APT-ref (1)",
+		PaymentMethod:    "card",
+		PaidAt:           paid,
+		Currency:         "NGN",
+		Centre: ReceiptParty{
+			Name:  "Lagos Diagnostics",
+			Lines: []string{"12 Marina Road, Lagos, Lagos State, Nigeria", "+234 800 000 0000"},
+		},
+		BilledTo: ReceiptParty{Name: "Ada Obi", Lines: []string{"ada@example.com"}},
+		Items: []ReceiptLine{
+			{Description: "Full Blood Count", Detail: "Appointment on 5 March 2026, 09:00", Amount: 14500},
+			{Description: "Booking fee", Amount: 500},
+		},
+		Total:       15000,
+		TaxRate:     7.5,
+		TaxAmount:   1046.51,
+		Adjustments: []ReceiptLine{{Description: "Refund", Detail: "Cancelled by patient", Amount: -5000}},
+		NetPaid:     10000,
+	}
+}
+
+func TestReceiptTemplate(t *testing.T) {
+	var text bytes.Buffer
+	if err := receiptTemplate.ExecuteTemplate(&text, "receipt", testReceipt()); err != nil {
+		t.Fatalf("ExecuteTemplate() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# INVOICE / RECEIPT\n## Lagos Diagnostics\n",
+		"Invoice number:      INV-5F0C6D1E-000042\n",
+		"  Appointment on 5 March 2026, 09:00\n",
+		"## Refund adjustments\n",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("receipt does not contain %q:\n%s", want, text.String())
+		}
+	}
+
+	// Amounts are right aligned in the last column
+	rows := map[string]string{
+		"Full Blood Count":     "14,500.00",
+		"Includes tax at 7.5%": "1,046.51",
+		"Refund":               "-5,000.00",
+		"Net paid":             "10,000.00",
+	}
+	for _, line := range strings.Split(text.String(), "\n") {
+		for description, amount := range rows {
+			if strings.HasPrefix(line, description+" ") {
+				if len(line) != receiptWidth || !strings.HasSuffix(line, " "+amount) {
+					t.Errorf("row %q; want %s in the last column", line, amount)
+				}
+				delete(rows, description)
+			}
+		}
+	}
+	if len(rows) > 0 {
+		t.Errorf("receipt has no rows for %v", rows)
+	}
+}
+
+func TestRenderReceipt(t *testing.T) {
+	content, err := RenderReceipt(testReceipt())
+	if err != nil {
+		t.Fatalf("RenderReceipt() error = %v", err)
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Fatal("RenderReceipt() did not write a complete PDF")
+	}
+	if !bytes.Contains(content, []byte(`(Payment reference:   APT-ref \(1\))`)) {
+		t.Error("RenderReceipt() did not escape parentheses")
+	}
+	if again, _ := RenderReceipt(testReceipt()); !bytes.Equal(content, again) {
+		t.Error("RenderReceipt() is not deterministic")
+	}
+}
+
+func TestPDFDocumentPages(t *testing.T) {
+	doc := newPDFDocument()
+	for i := 0; i < 100; i++ {
+		doc.text(fontMono, 9, "line")
+	}
+	content := doc.bytes("Pages")
+	if !bytes.Contains(content, []byte("/Count 2 >>")) {
+		t.Error("100 lines did not take two pages")
+	}
+}
+
+func TestEscapePDFText(t *testing.T) {
+	if got, want := escapePDFText(`a(b)\c – ₦é`), "a\\(b\\)\\\\c \x96 ?\xe9"; got != want {
+		t.Errorf("escapePDFText() = %q; want %q", got, want)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := map[float64]string{
+		0:          "0.00",
+		999.5:      "999.50",
+		1000:       "1,000.00",
+		-1234567.8: "-1,234,567.80",
+	}
+	for amount, want := range tests {
+		if got := FormatAmount(amount); got != want {
+			t.Errorf("FormatAmount(%v) = %q; want %q", amount, got, want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package emails
+
+const AppointmentDisruptionTemplate = `
+{{define "appointment_disruption"}}
+    <p><strong>Dear {{.PatientName}},</strong></p>
+
+    <p>{{.CentreName}} has changed its opening hours on the day of your appointment, and your booking is affected.</p>
+
+    <div class="details">
+        <ul>
+            <li><strong>Appointment ID:</strong> {{.AppointmentID}}</li>
+            <li><strong>Date:</strong> {{.AppointmentDate | formatDate}}</li>
+            <li><strong>Time:</strong> {{.TimeSlot}}</li>
+            <li><strong>Diagnostic Centre:</strong> {{.CentreName}}</li>
+            {{if .Notes}}<li><strong>Reason:</strong> {{.Notes}}</li>{{end}}
+        </ul>
+    </div>
+
+    <div class="note">
+        <p>Please log into your DiagnoxixAI account to reschedule or cancel this appointment.</p>
+    </div>
+{{end}}
+`
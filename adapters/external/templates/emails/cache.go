@@ -56,7 +56,10 @@ func (c *TemplateCache) Compile() error {
 		AppointmentCancellationTemplate +
 		AppointmentReminderTemplate +
 		AppointmentRescheduleTemplate +
+		AppointmentDisruptionTemplate +
+		WaitlistOfferTemplate +
 		PaymentConfirmationTemplate +
+		PaymentDiscrepancyReportTemplate +
 		TestResultsTemplate +
 		StaffNotificationTemplate +
 		PolicyUpdateTemplate +
@@ -75,7 +78,10 @@ func (c *TemplateCache) Compile() error {
 	c.Set(TemplateAppointmentCancelled, base.Lookup(TemplateAppointmentCancelled))
 	c.Set(TemplateAppointmentReminder, base.Lookup(TemplateAppointmentReminder))
 	c.Set(TemplateAppointmentReschedule, base.Lookup(TemplateAppointmentReschedule))
+	c.Set(TemplateAppointmentDisrupted, base.Lookup(TemplateAppointmentDisrupted))
+	c.Set(TemplateWaitlistOffer, base.Lookup(TemplateWaitlistOffer))
 	c.Set(TemplatePaymentConfirmation, base.Lookup(TemplatePaymentConfirmation))
+	c.Set(TemplatePaymentDiscrepancyReport, base.Lookup(TemplatePaymentDiscrepancyReport))
 	c.Set(TemplateTestResults, base.Lookup(TemplateTestResults))
 	c.Set(TemplatePolicyUpdate, base.Lookup(TemplatePolicyUpdate))
 	c.Set(TemplateStaffNotification, base.Lookup(TemplateStaffNotification))
@@ -6,6 +6,9 @@ const (
 	TitleAppointmentCancelled       = "Appointment Cancelled"
 	TitleAppointmentReminder        = "Appointment Reminder"
 	TitleAppointmentReschedule      = "Appointment Rescheduled"
+	TitleAppointmentDisrupted       = "Appointment Affected By Closure"
+	TitleWaitlistOffer              = "A Slot Has Opened Up"
+	TitlePaymentDiscrepancyReport   = "Payment Discrepancy Report"
 	TitlePaymentConfirmed           = "Payment Confirmation"
 	TitleTestResults                = "Test Results Available"
 	TitleStaffNotification          = "New Appointment"
@@ -24,6 +27,8 @@ const (
 	TemplateEmailVerification   = "email_verification"
 	TemplatePaymentConfirmation = "payment_confirmation"
 
+	TemplatePaymentDiscrepancyReport = "payment_discrepancy_report"
+
 	TemplateDiagnosticCentreManagement = "dc_management_notification"
 	TemplateDiagnosticCentreManager    = "dc_manager_notification"
 
@@ -31,6 +36,8 @@ const (
 	TemplateAppointmentCancelled  = "appointment_cancellation"
 	TemplateAppointmentReminder   = "appointment_reminder"
 	TemplateAppointmentReschedule = "appointment_reschedule"
+	TemplateAppointmentDisrupted  = "appointment_disruption"
+	TemplateWaitlistOffer         = "waitlist_offer"
 )
 
 const (
@@ -46,7 +53,10 @@ const (
 	IconCancelled         = "❌"
 	IconReminder          = "🔔"
 	IconReschedule        = "🔄"
+	IconDisrupted         = "⚠️"
+	IconWaitlistOffer     = "⏳"
 	IconPayment           = "💳"
+	IconReconciliation    = "📊"
 	IconTestResults       = "🔬"
 	IconStaff             = "👥"
 	IconPolicy            = "📋"
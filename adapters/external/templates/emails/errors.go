@@ -69,6 +69,26 @@ func ValidateTemplateData(data interface{}) error {
 		if v.AppointmentDate.IsZero() {
 			return NewValidationError("AppointmentDate", "cannot be zero")
 		}
+	case *WaitlistOfferData:
+		if v == nil {
+			return NewValidationError("WaitlistOfferData", "data cannot be nil")
+		}
+		if v.PatientName == "" {
+			return NewValidationError("PatientName", "cannot be empty")
+		}
+		if v.SlotStart.IsZero() {
+			return NewValidationError("SlotStart", "cannot be zero")
+		}
+		if v.HoldExpiresAt.IsZero() {
+			return NewValidationError("HoldExpiresAt", "cannot be zero")
+		}
+	case *PaymentDiscrepancyReportData:
+		if v == nil {
+			return NewValidationError("PaymentDiscrepancyReportData", "data cannot be nil")
+		}
+		if v.Date.IsZero() {
+			return NewValidationError("Date", "cannot be zero")
+		}
 	case *PaymentData:
 		if v == nil {
 			return NewValidationError("PaymentData", "data cannot be nil")
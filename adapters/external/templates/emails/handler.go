@@ -29,8 +29,14 @@ func (h *EmailTemplateHandler) ExecuteTemplate(templateName string, data interfa
 		return h.renderAppointmentCancellation(data.(*AppointmentData))
 	case TemplateAppointmentReminder:
 		return h.renderAppointmentReminder(data.(*AppointmentData))
+	case TemplateAppointmentDisrupted:
+		return h.renderAppointmentDisruption(data.(*AppointmentData))
+	case TemplateWaitlistOffer:
+		return h.renderWaitlistOffer(data.(*WaitlistOfferData))
 	case TemplatePaymentConfirmation:
 		return h.renderPaymentConfirmation(data.(*PaymentData))
+	case TemplatePaymentDiscrepancyReport:
+		return h.renderPaymentDiscrepancyReport(data.(*PaymentDiscrepancyReportData))
 	case TemplateEmailVerification:
 		return h.renderEmailVerification(data.(*EmailVerificationData)) // Working
 	case TemplateStaffNotification:
@@ -98,6 +104,38 @@ func (h *EmailTemplateHandler) renderAppointmentReminder(data *AppointmentData)
 		})
 }
 
+// renderAppointmentDisruption renders the email sent when a closure affects a booking
+func (h *EmailTemplateHandler) renderAppointmentDisruption(data *AppointmentData) (string, error) {
+	if err := ValidateTemplateData(data); err != nil {
+		return "", err
+	}
+	return h.renderTemplate(
+		TemplateAppointmentDisrupted,
+		data,
+		EmailData{
+			Title:         TitleAppointmentDisrupted,
+			Icon:          IconDisrupted,
+			FooterContent: FooterChanges,
+			Type:          TemplateAppointmentDisrupted,
+		})
+}
+
+// renderWaitlistOffer renders the email offering a held slot to a waitlisted patient
+func (h *EmailTemplateHandler) renderWaitlistOffer(data *WaitlistOfferData) (string, error) {
+	if err := ValidateTemplateData(data); err != nil {
+		return "", err
+	}
+	return h.renderTemplate(
+		TemplateWaitlistOffer,
+		data,
+		EmailData{
+			Title:         TitleWaitlistOffer,
+			Icon:          IconWaitlistOffer,
+			FooterContent: FooterSupport,
+			Type:          TemplateWaitlistOffer,
+		})
+}
+
 // RenderPaymentConfirmation renders the payment confirmation email
 func (h *EmailTemplateHandler) renderPaymentConfirmation(data *PaymentData) (string, error) {
 	if err := ValidateTemplateData(data); err != nil {
@@ -114,6 +152,22 @@ func (h *EmailTemplateHandler) renderPaymentConfirmation(data *PaymentData) (str
 		})
 }
 
+// renderPaymentDiscrepancyReport renders the daily payment reconciliation report sent to finance
+func (h *EmailTemplateHandler) renderPaymentDiscrepancyReport(data *PaymentDiscrepancyReportData) (string, error) {
+	if err := ValidateTemplateData(data); err != nil {
+		return "", err
+	}
+	return h.renderTemplate(
+		TemplatePaymentDiscrepancyReport,
+		data,
+		EmailData{
+			Title:         TitlePaymentDiscrepancyReport,
+			Icon:          IconReconciliation,
+			FooterContent: FooterPayment,
+			Type:          TemplatePaymentDiscrepancyReport,
+		})
+}
+
 // RenderTestResults renders the test results available email
 func (h *EmailTemplateHandler) renderTestResults(data *TestResultsData) (string, error) {
 	if err := ValidateTemplateData(data); err != nil {
@@ -0,0 +1,38 @@
+package emails
+
+const PaymentDiscrepancyReportTemplate = `
+{{define "payment_discrepancy_report"}}
+    <p><strong>Payment reconciliation for {{.Date | formatDate}}</strong></p>
+
+    <p>The reconciliation job found {{.Total}} difference(s) between payment provider transactions and payments, {{.Unresolved}} of which need follow-up.</p>
+
+    {{if .Discrepancies}}
+    <div class="details">
+        <table style="width: 100%; border-collapse: collapse;">
+            <tr>
+                <th align="left">Provider</th>
+                <th align="left">Reference</th>
+                <th align="left">Kind</th>
+                <th align="left">Payment</th>
+                <th align="left">Provider</th>
+                <th align="left">Status</th>
+            </tr>
+            {{range .Discrepancies}}
+            <tr>
+                <td>{{.Provider}}</td>
+                <td>{{.Reference}}</td>
+                <td>{{.Kind}}</td>
+                <td>{{if .PaymentStatus}}{{.PaymentStatus}} {{.PaymentAmount}}{{else}}none{{end}}</td>
+                <td>{{.ProviderStatus}} {{.ProviderAmount}} {{.Currency}}</td>
+                <td>{{if .Resolved}}Corrected{{else}}Open{{end}}</td>
+            </tr>
+            {{end}}
+        </table>
+    </div>
+    {{end}}
+
+    <div class="note">
+        <p>Corrected differences were fixed automatically by verifying the payment with its provider. Open differences, such as money captured after an appointment expired, need to be settled by hand.</p>
+    </div>
+{{end}}
+`
@@ -37,6 +37,39 @@ type AppointmentData struct {
 	Notes           string
 }
 
+// WaitlistOfferData contains fields for the email offering a freed slot to a waitlisted patient
+type WaitlistOfferData struct {
+	EmailData
+	PatientName   string
+	CentreName    string
+	TestType      string
+	SlotStart     time.Time
+	TimeSlot      string
+	HoldExpiresAt time.Time
+}
+
+// PaymentDiscrepancyReportData contains fields for the daily payment reconciliation report
+type PaymentDiscrepancyReportData struct {
+	EmailData
+	Date          time.Time
+	Total         int
+	Unresolved    int
+	Discrepancies []PaymentDiscrepancyRow
+}
+
+// PaymentDiscrepancyRow is one difference listed in the reconciliation report; amounts are preformatted
+type PaymentDiscrepancyRow struct {
+	Provider       string
+	Reference      string
+	Kind           string
+	PaymentStatus  string
+	PaymentAmount  string
+	ProviderStatus string
+	ProviderAmount string
+	Currency       string
+	Resolved       bool
+}
+
 // PaymentData contains fields for payment-related emails
 type PaymentData struct {
 	EmailData
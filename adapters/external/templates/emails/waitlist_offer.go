@@ -0,0 +1,22 @@
+package emails
+
+const WaitlistOfferTemplate = `
+{{define "waitlist_offer"}}
+    <p><strong>Dear {{.PatientName}},</strong></p>
+
+    <p>A slot has opened up at {{.CentreName}} and we are holding it for you.</p>
+
+    <div class="details">
+        <ul>
+            <li><strong>Date:</strong> {{.SlotStart | formatDate}}</li>
+            <li><strong>Time:</strong> {{.TimeSlot}}</li>
+            <li><strong>Diagnostic Centre:</strong> {{.CentreName}}</li>
+            {{if .TestType}}<li><strong>Test Type:</strong> {{.TestType}}</li>{{end}}
+        </ul>
+    </div>
+
+    <div class="note">
+        <p>The slot is held for you until {{.HoldExpiresAt | formatTime}} (UTC). Log into your DiagnoxixAI account and book it before then, after which it will be offered to the next patient on the waitlist.</p>
+    </div>
+{{end}}
+`
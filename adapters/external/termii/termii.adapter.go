@@ -0,0 +1,111 @@
+package termii
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure TermiiAdapter implements SMSProvider
+var _ ports.SMSProvider = (*TermiiAdapter)(nil)
+
+const defaultBaseURL = "https://api.ng.termii.com"
+
+type (
+	TermiiConfig struct {
+		APIKey string
+		// SenderID is the registered sender name messages come from
+		SenderID string
+		// Channel defaults to dnd, which also reaches numbers on the do-not-disturb list
+		Channel string
+		// BaseURL defaults to Termii's Nigerian endpoint
+		BaseURL string
+		// HTTPClient defaults to a client with a 30 second timeout
+		HTTPClient *http.Client
+	}
+	SendRequest struct {
+		To      string `json:"to"`
+		From    string `json:"from"`
+		SMS     string `json:"sms"`
+		Type    string `json:"type"`
+		Channel string `json:"channel"`
+		APIKey  string `json:"api_key"`
+	}
+	SendResponse struct {
+		MessageID string `json:"message_id"`
+		Message   string `json:"message"`
+	}
+	TermiiAdapter struct {
+		config *TermiiConfig
+		client *http.Client
+	}
+)
+
+func NewTermiiAdapter(con *TermiiConfig) *TermiiAdapter {
+	client := con.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &TermiiAdapter{
+		config: con,
+		client: client,
+	}
+}
+
+// Name identifies Termii in logs
+func (t *TermiiAdapter) Name() string {
+	return "termii"
+}
+
+// SendSMS sends a plain text message. Termii takes numbers without the leading plus.
+func (t *TermiiAdapter) SendSMS(phone, message string) (string, error) {
+	channel := t.config.Channel
+	if channel == "" {
+		channel = "dnd"
+	}
+	baseURL := t.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	jsonData, err := json.Marshal(SendRequest{
+		To:      strings.TrimPrefix(phone, "+"),
+		From:    t.config.SenderID,
+		SMS:     message,
+		Type:    "plain",
+		Channel: channel,
+		APIKey:  t.config.APIKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/api/sms/send", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrSMSUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return "", fmt.Errorf("%w: termii returned status %d", domain.ErrSMSUnavailable, resp.StatusCode)
+	}
+	var result SendResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error decoding response (status %d): %v", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || result.MessageID == "" {
+		return "", fmt.Errorf("message rejected: %s", result.Message)
+	}
+	return result.MessageID, nil
+}
@@ -0,0 +1,67 @@
+package termii
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/diagnoxix/core/domain"
+)
+
+func newTestAdapter(t *testing.T, handler http.HandlerFunc) *TermiiAdapter {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewTermiiAdapter(&TermiiConfig{
+		APIKey:   "TL_TEST",
+		SenderID: "Diagnoxix",
+		BaseURL:  server.URL,
+	})
+}
+
+func TestSendSMS(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		var body SendRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != http.MethodPost || r.URL.Path != "/api/sms/send" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if body.To != "2348012345678" || body.From != "Diagnoxix" || body.SMS != "Your code is 123456" ||
+			body.APIKey != "TL_TEST" || body.Channel != "dnd" || body.Type != "plain" {
+			t.Errorf("unexpected body %+v", body)
+		}
+		w.Write([]byte(`{"message_id":"9122821270554876574","message":"Successfully Sent","balance":9,"user":"Diagnoxix"}`))
+	})
+
+	id, err := adapter.SendSMS("+2348012345678", "Your code is 123456")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "9122821270554876574" {
+		t.Errorf("message id = %q", id)
+	}
+}
+
+func TestSendSMSRejected(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"ApiKey Not found"}`))
+	})
+
+	_, err := adapter.SendSMS("+2348012345678", "Your code is 123456")
+	if err == nil || errors.Is(err, domain.ErrSMSUnavailable) {
+		t.Fatalf("error = %v; want a rejection", err)
+	}
+}
+
+func TestSendSMSUnavailable(t *testing.T) {
+	adapter := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	if _, err := adapter.SendSMS("+2348012345678", "Your code is 123456"); !errors.Is(err, domain.ErrSMSUnavailable) {
+		t.Fatalf("error = %v; want %v", err, domain.ErrSMSUnavailable)
+	}
+}
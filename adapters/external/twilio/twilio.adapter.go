@@ -0,0 +1,103 @@
+package twilio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+)
+
+// Ensure TwilioAdapter implements SMSProvider
+var _ ports.SMSProvider = (*TwilioAdapter)(nil)
+
+const defaultBaseURL = "https://api.twilio.com"
+
+type (
+	TwilioConfig struct {
+		AccountSID string
+		AuthToken  string
+		// From is the sending number. MessagingServiceSID is used instead when set.
+		From                string
+		MessagingServiceSID string
+		// BaseURL defaults to Twilio's REST API
+		BaseURL string
+		// HTTPClient defaults to a client with a 30 second timeout
+		HTTPClient *http.Client
+	}
+	// Message is the part of Twilio's message resource the adapter reads. Errors come back
+	// with a code and message instead.
+	Message struct {
+		SID     string `json:"sid"`
+		Status  string `json:"status"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	TwilioAdapter struct {
+		config *TwilioConfig
+		client *http.Client
+	}
+)
+
+func NewTwilioAdapter(con *TwilioConfig) *TwilioAdapter {
+	client := con.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &TwilioAdapter{
+		config: con,
+		client: client,
+	}
+}
+
+// Name identifies Twilio in logs
+func (t *TwilioAdapter) Name() string {
+	return "twilio"
+}
+
+// SendSMS creates a message resource, which Twilio queues for delivery
+func (t *TwilioAdapter) SendSMS(phone, message string) (string, error) {
+	baseURL := t.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	form := url.Values{}
+	form.Set("To", phone)
+	form.Set("Body", message)
+	if t.config.MessagingServiceSID != "" {
+		form.Set("MessagingServiceSid", t.config.MessagingServiceSID)
+	} else {
+		form.Set("From", t.config.From)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", baseURL, url.PathEscape(t.config.AccountSID))
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %v", err)
+	}
+	req.SetBasicAuth(t.config.AccountSID, t.config.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrSMSUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return "", fmt.Errorf("%w: twilio returned status %d", domain.ErrSMSUnavailable, resp.StatusCode)
+	}
+	var result Message
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error decoding response (status %d): %v", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusCreated || result.SID == "" {
+		return "", fmt.Errorf("message rejected (code %d): %s", result.Code, result.Message)
+	}
+	return result.SID, nil
+}
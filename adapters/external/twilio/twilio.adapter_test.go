@@ -0,0 +1,85 @@
+package twilio
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/diagnoxix/core/domain"
+)
+
+func newTestAdapter(t *testing.T, config TwilioConfig, handler http.HandlerFunc) *TwilioAdapter {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "AC123" || pass != "secret" {
+			t.Errorf("unexpected credentials %q %q", user, pass)
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	config.AccountSID = "AC123"
+	config.AuthToken = "secret"
+	config.BaseURL = server.URL
+	return NewTwilioAdapter(&config)
+}
+
+func TestSendSMS(t *testing.T) {
+	adapter := newTestAdapter(t, TwilioConfig{From: "+15005550006"}, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Method != http.MethodPost || r.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.PostForm.Get("To") != "+2348012345678" || r.PostForm.Get("From") != "+15005550006" ||
+			r.PostForm.Get("Body") != "Your code is 123456" {
+			t.Errorf("unexpected form %v", r.PostForm)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid":"SM87105da94bff44b999e4e6eb90d8eb6a","status":"queued"}`))
+	})
+
+	id, err := adapter.SendSMS("+2348012345678", "Your code is 123456")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "SM87105da94bff44b999e4e6eb90d8eb6a" {
+		t.Errorf("message id = %q", id)
+	}
+}
+
+func TestSendSMSWithMessagingService(t *testing.T) {
+	adapter := newTestAdapter(t, TwilioConfig{From: "+15005550006", MessagingServiceSID: "MG123"}, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("MessagingServiceSid") != "MG123" || r.PostForm.Has("From") {
+			t.Errorf("unexpected form %v", r.PostForm)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid":"SM1","status":"accepted"}`))
+	})
+
+	if _, err := adapter.SendSMS("+2348012345678", "Your code is 123456"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendSMSRejected(t *testing.T) {
+	adapter := newTestAdapter(t, TwilioConfig{From: "+15005550006"}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":21211,"message":"The 'To' number +234 is not a valid phone number.","status":400}`))
+	})
+
+	_, err := adapter.SendSMS("+234", "Your code is 123456")
+	if err == nil || errors.Is(err, domain.ErrSMSUnavailable) {
+		t.Fatalf("error = %v; want a rejection", err)
+	}
+}
+
+func TestSendSMSUnavailable(t *testing.T) {
+	adapter := newTestAdapter(t, TwilioConfig{From: "+15005550006"}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	if _, err := adapter.SendSMS("+2348012345678", "Your code is 123456"); !errors.Is(err, domain.ErrSMSUnavailable) {
+		t.Fatalf("error = %v; want %v", err, domain.ErrSMSUnavailable)
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 // CreateAppointment creates a new appointment for a diagnostic centre
 // @Summary Create appointment
-// @Description Create a new appointment for a diagnostic test
+// @Description Create a new appointment for a diagnostic test. Further tests listed in test_types are booked as line items of the same appointment, each priced from the centre's price list, under one combined payment. With use_wallet the patient's wallet balance pays first and only the rest is charged; a booking the wallet covers in full is confirmed without checkout. With pay_at_centre no checkout is opened: the slot is held until shortly after the appointment starts for the centre to record payment in cash or by transfer. A promo_code is taken off the tests it covers and is used up only if the booking is created; it is given back if the payment fails or is cancelled. A policy_id instead takes the share the patient's insurance plan covers off the tests; plans that require it need an authorization_code, and a booking the insurer covers in full is confirmed without payment
 // @Tags Appointments
 // @Accept json
 // @Produce json
@@ -17,6 +17,7 @@ import (
 // @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
 // @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
 // @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
 // @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
 // @Router /v1/appointments [post]
 func (h *HTTPHandler) CreateAppointment(c echo.Context) error {
@@ -24,6 +25,29 @@ func (h *HTTPHandler) CreateAppointment(c echo.Context) error {
 	return h.service.CreateAppointment(c)
 }
 
+// GetAppointmentQuote returns the server-side price of an appointment
+// @Summary Quote appointment
+// @Description Get the active price, currency and fees of a test at a diagnostic centre before booking. Further tests in test_types are priced as line items of one appointment and the booking fee is charged once
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id query string true "Diagnostic Centre ID" format(uuid)
+// @Param test_type query string true "Test type" default(BLOOD_TEST)
+// @Param test_types query []string false "Further tests booked under the same appointment" collectionFormat(multi)
+// @Param promo_code query string false "Promo code to take off the tests"
+// @Param policy_id query string false "Insurance policy of the patient that covers the tests" format(uuid)
+// @Success 200 {object} domain.AppointmentQuote "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/appointments/quote [get]
+func (h *HTTPHandler) GetAppointmentQuote(c echo.Context) error {
+	return h.service.GetAppointmentQuote(c)
+}
+
 // GetAppointment retrieves an appointment by ID
 // @Summary Get appointment
 // @Description Get an appointment by its ID
@@ -88,7 +112,7 @@ func (h *HTTPHandler) UpdateAppointment(c echo.Context) error {
 
 // CancelAppointment cancels an existing appointment
 // @Summary Cancel appointment
-// @Description Cancel an existing appointment
+// @Description Cancel an existing appointment. A fee is kept according to the centre's cancellation policy and the rest of a successful payment is refunded, to the patient's wallet at once when refund_to is wallet
 // @Tags Appointments
 // @Accept json
 // @Produce json
@@ -96,10 +120,11 @@ func (h *HTTPHandler) UpdateAppointment(c echo.Context) error {
 // @Param Authorization header string true "Bearer token"
 // @Param appointment_id path string true "Appointment ID" default(123e4567-e89b-12d3-a456-426614174000)
 // @Param RequestBody body domain.CancelAppointmentDTO true "Cancellation details"
-// @Success 200 {object} map[string]string "Cancellation success message"
+// @Success 200 {object} domain.AppointmentCancellation "Cancelled appointment with fee and refund"
 // @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
 // @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
 // @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
 // @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
 // @Router /v1/appointments/{appointment_id}/cancel [post]
 func (h *HTTPHandler) CancelAppointment(c echo.Context) error {
@@ -109,7 +134,7 @@ func (h *HTTPHandler) CancelAppointment(c echo.Context) error {
 
 // RescheduleAppointment reschedules an existing appointment
 // @Summary Reschedule appointment
-// @Description Reschedule an existing appointment to a new time
+// @Description Move an appointment to another free slot of the same centre. The new date must be the start of a slot returned by the slots endpoint; a configured rescheduling fee is charged with the reschedule, from the wallet first when use_wallet is set and through a checkout in reference for the rest, and the number of reschedules per booking is limited
 // @Tags Appointments
 // @Accept json
 // @Produce json
@@ -121,6 +146,8 @@ func (h *HTTPHandler) CancelAppointment(c echo.Context) error {
 // @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
 // @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
 // @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
 // @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
 // @Router /v1/appointments/{appointment_id}/reschedule [post]
 func (h *HTTPHandler) RescheduleAppointment(c echo.Context) error {
@@ -128,6 +155,45 @@ func (h *HTTPHandler) RescheduleAppointment(c echo.Context) error {
 	return h.service.RescheduleAppointment(c)
 }
 
+// ListAppointmentLineItems lists the tests booked under an appointment
+// @Summary List appointment tests
+// @Description List the tests booked under one of the patient's appointments with their price, result status and attached medical records
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Success 200 {array} domain.AppointmentLineItem "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/appointments/{appointment_id}/line_items [get]
+func (h *HTTPHandler) ListAppointmentLineItems(c echo.Context) error {
+	return h.service.ListAppointmentLineItems(c)
+}
+
+// InitializeAppointmentPayment starts payment for a pending appointment
+// @Summary Pay for appointment
+// @Description Open a payment for a pending appointment that has none yet, such as an occurrence of a series paid per visit. Confirm the appointment with the returned reference once paid
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Success 201 {object} handlers.AppointmentSwagger "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/appointments/{appointment_id}/pay [post]
+func (h *HTTPHandler) InitializeAppointmentPayment(c echo.Context) error {
+	return h.service.InitializeAppointmentPayment(c)
+}
+
 // ConfirmAppointment confirms an appointment
 // @Summary Confirm appointment
 // @Description Confirm an appointment by its ID
@@ -145,3 +211,264 @@ func (h *HTTPHandler) RescheduleAppointment(c echo.Context) error {
 func (h *HTTPHandler) ConfirmAppointment(c echo.Context) error {
 	return h.service.ConfirmAppointment(c)
 }
+
+// ListCentreAppointments lists a diagnostic centre's appointments for a day
+// @Summary List centre appointments
+// @Description List the appointments of a diagnostic centre for a day, today by default. Cancelled and rescheduled appointments are left out unless requested by status (owner or manager)
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param date query string false "Day to list (YYYY-MM-DD)"
+// @Param status query string false "Filter by status" Enums(pending,confirmed,checked_in,in_progress,completed,no_show,cancelled,rescheduled)
+// @Success 200 {array} db.GetCentreAppointmentsRow "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/appointments [get]
+func (h *HTTPHandler) ListCentreAppointments(c echo.Context) error {
+	return h.service.ListCentreAppointments(c)
+}
+
+// CheckInAppointment moves an appointment along the centre-side lifecycle
+// @Summary Check in appointment
+// @Description Record the patient's arrival. Only confirmed appointments booked for today can be checked in (owner or manager)
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Success 200 {object} handlers.AppointmentSwagger "SUCCESS_RESPONSE"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/appointments/{appointment_id}/check_in [post]
+func (h *HTTPHandler) CheckInAppointment(c echo.Context) error {
+	return h.service.CheckInAppointment(c)
+}
+
+// StartAppointment moves an appointment along the centre-side lifecycle
+// @Summary Start appointment
+// @Description Move a checked-in appointment to in progress (owner or manager)
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Success 200 {object} handlers.AppointmentSwagger "SUCCESS_RESPONSE"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/appointments/{appointment_id}/start [post]
+func (h *HTTPHandler) StartAppointment(c echo.Context) error {
+	return h.service.StartAppointment(c)
+}
+
+// CompleteAppointment moves an appointment along the centre-side lifecycle
+// @Summary Complete appointment
+// @Description Move an in-progress appointment to completed (owner or manager)
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Success 200 {object} handlers.AppointmentSwagger "SUCCESS_RESPONSE"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/appointments/{appointment_id}/complete [post]
+func (h *HTTPHandler) CompleteAppointment(c echo.Context) error {
+	return h.service.CompleteAppointment(c)
+}
+
+// MarkAppointmentNoShow moves an appointment along the centre-side lifecycle
+// @Summary Mark appointment as no-show
+// @Description Close a confirmed appointment the patient did not turn up for, once its start time has passed (owner or manager)
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Success 200 {object} handlers.AppointmentSwagger "SUCCESS_RESPONSE"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/appointments/{appointment_id}/no_show [post]
+func (h *HTTPHandler) MarkAppointmentNoShow(c echo.Context) error {
+	return h.service.MarkAppointmentNoShow(c)
+}
+
+// CreateAppointmentSeries books a recurring appointment series
+// @Summary Create appointment series
+// @Description Book a recurring series of appointments for one test: weekly, every N weeks or monthly from a start date, or on an explicit list of dates. Every occurrence must fall on a free slot. Paid upfront, one payment covers the whole series; paid per visit, each appointment is paid through the pay endpoint
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param RequestBody body domain.CreateAppointmentSeriesDTO true "Recurrence rule and payment mode"
+// @Success 201 {object} domain.AppointmentSeriesDetail "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/appointment_series [post]
+func (h *HTTPHandler) CreateAppointmentSeries(c echo.Context) error {
+	return h.service.CreateAppointmentSeries(c)
+}
+
+// GetAppointmentSeries returns an appointment series
+// @Summary Get appointment series
+// @Description Get a series with its appointments in occurrence order. Rescheduled occurrences show the appointment that replaced them
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param series_id path string true "Series ID" format(uuid)
+// @Success 200 {object} domain.AppointmentSeriesDetail "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/appointment_series/{series_id} [get]
+func (h *HTTPHandler) GetAppointmentSeries(c echo.Context) error {
+	return h.service.GetAppointmentSeries(c)
+}
+
+// CancelAppointmentSeries cancels a whole appointment series
+// @Summary Cancel appointment series
+// @Description Cancel every pending or confirmed appointment of a series. The centre's cancellation fee applies to each appointment on its own, and the rest of each payment is refunded once. Single appointments of a series are cancelled or rescheduled through the appointment endpoints
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param series_id path string true "Series ID" format(uuid)
+// @Param RequestBody body domain.CancelAppointmentSeriesDTO true "Cancellation details"
+// @Success 200 {object} domain.AppointmentSeriesCancellation "Cancelled appointments with fees and refunds"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/appointment_series/{series_id}/cancel [post]
+func (h *HTTPHandler) CancelAppointmentSeries(c echo.Context) error {
+	return h.service.CancelAppointmentSeries(c)
+}
+
+// ListCentreAppointmentLineItems lists the tests booked under an appointment at a centre
+// @Summary List appointment tests at a centre
+// @Description List the tests booked under an appointment with their result status and attached medical records (owner or manager)
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Success 200 {array} domain.AppointmentLineItem "SUCCESS_RESPONSE"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/appointments/{appointment_id}/line_items [get]
+func (h *HTTPHandler) ListCentreAppointmentLineItems(c echo.Context) error {
+	return h.service.ListCentreAppointmentLineItems(c)
+}
+
+// UpdateLineItemResultStatus records the progress of one test's result
+// @Summary Update test result status
+// @Description Move the result of one test of an appointment from pending to processing or ready (owner or manager). Uploading a medical record for the test marks it ready as well
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param appointment_id path string true "Appointment ID" format(uuid)
+// @Param line_item_id path string true "Line item ID" format(uuid)
+// @Param RequestBody body domain.UpdateLineItemResultDTO true "New result status"
+// @Success 200 {object} db.AppointmentLineItem "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/appointments/{appointment_id}/line_items/{line_item_id} [put]
+func (h *HTTPHandler) UpdateLineItemResultStatus(c echo.Context) error {
+	return h.service.UpdateLineItemResultStatus(c)
+}
+
+// JoinWaitlist puts the patient on a centre's waitlist
+// @Summary Join waitlist
+// @Description Wait for a slot at a fully booked diagnostic centre within a date window. When a booking is cancelled the first waiting patient is offered a time-limited hold on the freed slot
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param RequestBody body domain.JoinWaitlistDTO true "Centre, test type and date window"
+// @Success 201 {object} db.AppointmentWaitlistEntry "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/waitlist [post]
+func (h *HTTPHandler) JoinWaitlist(c echo.Context) error {
+	return h.service.JoinWaitlist(c)
+}
+
+// ListWaitlist lists the patient's waitlist entries
+// @Summary List waitlist entries
+// @Description List the patient's waitlist entries, newest first, including slots currently held for them
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(50)
+// @Success 200 {array} db.AppointmentWaitlistEntry "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/waitlist [get]
+func (h *HTTPHandler) ListWaitlist(c echo.Context) error {
+	return h.service.ListWaitlist(c)
+}
+
+// LeaveWaitlist takes the patient off a waitlist
+// @Summary Leave waitlist
+// @Description Leave a waitlist. A slot held for the patient is offered to the next patient waiting
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param waitlist_id path string true "Waitlist entry ID" format(uuid)
+// @Success 200 {object} db.AppointmentWaitlistEntry "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/waitlist/{waitlist_id} [delete]
+func (h *HTTPHandler) LeaveWaitlist(c echo.Context) error {
+	return h.service.LeaveWaitlist(c)
+}
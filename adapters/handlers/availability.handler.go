@@ -98,3 +98,65 @@ func (handler *HTTPHandler) UpdateManyAvailability(context echo.Context) error {
 func (h *HTTPHandler) DeleteAvailability(c echo.Context) error {
 	return nil
 }
+
+// ListAvailabilityExceptions godoc
+// @Summary List availability exceptions
+// @Description List the closures and overrides of a diagnostic centre between two dates, from today for a year by default (owner or manager)
+// @Tags Availability
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} db.DiagnosticCentreAvailabilityException "List of availability exceptions"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/availability_exceptions [get]
+func (handler *HTTPHandler) ListAvailabilityExceptions(context echo.Context) error {
+	return handler.service.ListAvailabilityExceptions(context)
+}
+
+// CreateAvailabilityException godoc
+// @Summary Add an availability exception
+// @Description Close a diagnostic centre for a day, or override its hours or capacity, replacing any exception already set for that day. Patients booked outside the new hours are notified (owner or manager)
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param RequestBody body domain.CreateAvailabilityExceptionDTO true "Request body"
+// @Success 201 {object} domain.AvailabilityExceptionResponse "Availability exception created"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/availability_exceptions [post]
+func (handler *HTTPHandler) CreateAvailabilityException(context echo.Context) error {
+	return handler.service.CreateAvailabilityException(context)
+}
+
+// DeleteAvailabilityException godoc
+// @Summary Delete an availability exception
+// @Description Reopen a day on the centre's regular weekly hours (owner or manager)
+// @Tags Availability
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param exception_id path string true "Availability Exception ID" format(uuid)
+// @Success 200 {object} handlers.SUCCESS_RESPONSE "Availability exception deleted"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/availability_exceptions/{exception_id} [delete]
+func (handler *HTTPHandler) DeleteAvailabilityException(context echo.Context) error {
+	return handler.service.DeleteAvailabilityException(context)
+}
@@ -65,7 +65,8 @@ func (handler *HTTPHandler) GetDiagnosticCentre(context echo.Context) error {
 // @Produce json
 // @Param latitude query number true "Latitude (-90 to 90)" minimum(-90) maximum(90) default(25.06)
 // @Param longitude query number true "Longitude (-180 to 180)" minimum(-180) maximum(180) default(56.67)
-// @Param day_of_week query string true "Filter by day" Enums(monday,tuesday,wednesday,thursday,friday,saturday,sunday)
+// @Param day_of_week query string false "Filter by day, required without date" Enums(monday,tuesday,wednesday,thursday,friday,saturday,sunday)
+// @Param date query string false "Exclude centres closed on this date (YYYY-MM-DD); defaults to the next day_of_week"
 // @Param doctor query string false "Filter by doctor specialization" Enums(Male,Female)
 // @Param test query string true "Filter by available test type" Enums(BLOOD_TEST,URINE_TEST,X_RAY,MRI,CT_SCAN,ULTRASOUND,ECG,EEG,BIOPSY,SKIN_TEST,ALLERGY_TEST,GENETIC_TEST,IMMUNOLOGY_TEST,HORMONE_TEST,VIRAL_TEST,BACTERIAL_TEST,PARASITIC_TEST,FUNGAL_TEST,MOLECULAR_TEST,TOXICOLOGY_TEST,ECHO,COVID_19_TEST,OTHER,BLOOD_SUGAR_TEST,LIPID_PROFILE,HEMOGLOBIN_TEST,THYROID_TEST,LIVER_FUNCTION_TEST,KIDNEY_FUNCTION_TEST,URIC_ACID_TEST,VITAMIN_D_TEST,VITAMIN_B12_TEST,HEMOGRAM,COMPLETE_BLOOD_COUNT,BLOOD_GROUPING,HEPATITIS_B_TEST,HEPATITIS_C_TEST,HIV_TEST,MALARIA_TEST,DENGUE_TEST,TYPHOID_TEST,COVID_19_ANTIBODY_TEST,COVID_19_RAPID_ANTIGEN_TEST,COVID_19_RT_PCR_TEST,PREGNANCY_TEST)
 // @Param page query integer false "Page number for pagination" default(1) minimum(1)
@@ -196,6 +197,253 @@ func (handler *HTTPHandler) GetDiagnosticCentreSchedules(context echo.Context) e
 	return handler.service.GetDiagnosticCentreSchedules(context)
 }
 
+// GetAvailableSlots godoc
+// @Summary Get available slots of a diagnostic centre
+// @Description Expand the centre's weekly availability into concrete bookable slots, excluding fully booked ones
+// @Tags DiagnosticCentre
+// @Produce json
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param from query string false "First day of the range (YYYY-MM-DD), defaults to today" format(date)
+// @Param to query string false "Last day of the range (YYYY-MM-DD), defaults to a week from the first day" format(date)
+// @Param test_type query string false "Only return slots if the centre offers this test type"
+// @Success 200 {array} domain.AvailableSlot "List of free slots"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/slots [get]
+func (handler *HTTPHandler) GetAvailableSlots(context echo.Context) error {
+	return handler.service.GetAvailableSlots(context)
+}
+
+// ListTestPrices godoc
+// @Summary List test prices
+// @Description List the test prices of a diagnostic centre, including inactive ones (owner or manager)
+// @Tags DiagnosticCentre
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param active_only query boolean false "Only return active prices"
+// @Success 200 {array} db.DiagnosticCentreTestPrice "List of test prices"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/test_prices [get]
+func (handler *HTTPHandler) ListTestPrices(context echo.Context) error {
+	return handler.service.ListTestPrices(context)
+}
+
+// CreateTestPrice godoc
+// @Summary Add a test price
+// @Description Add a test to the centre's price list, reactivating and repricing it if it already exists (owner only)
+// @Tags DiagnosticCentre
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param RequestBody body domain.CreateTestPriceDTO true "Request body"
+// @Success 201 {object} db.DiagnosticCentreTestPrice "Test price created"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/test_prices [post]
+func (handler *HTTPHandler) CreateTestPrice(context echo.Context) error {
+	return handler.service.CreateTestPrice(context)
+}
+
+// UpdateTestPrice godoc
+// @Summary Update a test price
+// @Description Change the price, currency or active status of a test price; previous prices are kept in the history (owner only)
+// @Tags DiagnosticCentre
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param test_price_id path string true "Test Price ID" format(uuid)
+// @Param RequestBody body domain.UpdateTestPriceDTO true "Request body"
+// @Success 200 {object} db.DiagnosticCentreTestPrice "Test price updated"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/test_prices/{test_price_id} [put]
+func (handler *HTTPHandler) UpdateTestPrice(context echo.Context) error {
+	return handler.service.UpdateTestPrice(context)
+}
+
+// DeactivateTestPrice godoc
+// @Summary Deactivate a test price
+// @Description Remove a test from the bookable price list while keeping its history (owner only)
+// @Tags DiagnosticCentre
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param test_price_id path string true "Test Price ID" format(uuid)
+// @Success 200 {object} db.DiagnosticCentreTestPrice "Test price deactivated"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/test_prices/{test_price_id} [delete]
+func (handler *HTTPHandler) DeactivateTestPrice(context echo.Context) error {
+	return handler.service.DeactivateTestPrice(context)
+}
+
+// GetTestPriceHistory godoc
+// @Summary Get test price history
+// @Description List every price a test has had at the centre with effective-from dates, newest first
+// @Tags DiagnosticCentre
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param test_price_id path string true "Test Price ID" format(uuid)
+// @Success 200 {array} db.DiagnosticCentreTestPriceHistory "Price history"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/test_prices/{test_price_id}/history [get]
+func (handler *HTTPHandler) GetTestPriceHistory(context echo.Context) error {
+	return handler.service.GetTestPriceHistory(context)
+}
+
+// GetCancellationPolicy godoc
+// @Summary Get cancellation policy
+// @Description Get the cancellation policy of a diagnostic centre; centres without one use the default of free cancellation up to 24 hours before and 50% after (owner or manager)
+// @Tags DiagnosticCentre
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Success 200 {object} domain.CancellationPolicy "Cancellation policy"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/cancellation_policy [get]
+func (handler *HTTPHandler) GetCancellationPolicy(context echo.Context) error {
+	return handler.service.GetCancellationPolicy(context)
+}
+
+// UpdateCancellationPolicy godoc
+// @Summary Set cancellation policy
+// @Description Set how many hours before an appointment cancelling is free and the percentage of the payment kept on later cancellations (owner only)
+// @Tags DiagnosticCentre
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param RequestBody body domain.UpdateCancellationPolicyDTO true "Request body"
+// @Success 200 {object} domain.CancellationPolicy "Cancellation policy updated"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/cancellation_policy [put]
+func (handler *HTTPHandler) UpdateCancellationPolicy(context echo.Context) error {
+	return handler.service.UpdateCancellationPolicy(context)
+}
+
+// GetPaymentProviderSetting godoc
+// @Summary Get payment provider
+// @Description Get the payment provider a diagnostic centre collects payments with and the providers available; centres without a choice use Paystack with fallback (owner or manager)
+// @Tags DiagnosticCentre
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Success 200 {object} domain.PaymentProviderSetting "Payment provider setting"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/payment_provider [get]
+func (handler *HTTPHandler) GetPaymentProviderSetting(context echo.Context) error {
+	return handler.service.GetPaymentProviderSetting(context)
+}
+
+// UpdatePaymentProviderSetting godoc
+// @Summary Set payment provider
+// @Description Set the payment provider a diagnostic centre collects payments with and whether payments may start with another provider while it is unavailable (owner only)
+// @Tags DiagnosticCentre
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param RequestBody body domain.UpdatePaymentProviderDTO true "Request body"
+// @Success 200 {object} domain.PaymentProviderSetting "Payment provider updated"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/payment_provider [put]
+func (handler *HTTPHandler) UpdatePaymentProviderSetting(context echo.Context) error {
+	return handler.service.UpdatePaymentProviderSetting(context)
+}
+
+// UpdatePayoutAccount godoc
+// @Summary Set payout account
+// @Description Set the bank account a diagnostic centre's settlements are paid into; the account is registered with Paystack as a transfer recipient (owner only)
+// @Tags DiagnosticCentre
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param RequestBody body domain.UpdatePayoutAccountDTO true "Request body"
+// @Success 200 {object} db.CentrePayoutAccount "Payout account updated"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "Bank account rejected by the payout provider"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Failure 502 {object} handlers.INTERNAL_SERVER_ERROR "Payout provider unavailable"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/payout_account [put]
+func (handler *HTTPHandler) UpdatePayoutAccount(context echo.Context) error {
+	return handler.service.UpdatePayoutAccount(context)
+}
+
+// ListSettlements godoc
+// @Summary List settlements
+// @Description List a diagnostic centre's weekly settlement statements, newest first, with their commission, net amount and payout status (owner only)
+// @Tags DiagnosticCentre
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid) default(123e4567-e89b-12d3-a456-426614174000)
+// @Param page query integer false "Page number" minimum(1) default(1)
+// @Param per_page query integer false "Items per page" minimum(1) maximum(100) default(10)
+// @Success 200 {array} db.Settlement "Settlement statements"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/settlements [get]
+func (handler *HTTPHandler) ListSettlements(context echo.Context) error {
+	return handler.service.ListSettlements(context)
+}
+
 // GetDiagnosticCentreRecords godoc
 // @Summary Get diagnostic centre medical records
 // @Description Get all medical records uploaded by a diagnostic centre
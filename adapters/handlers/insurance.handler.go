@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// CreateInsurer registers an HMO or insurer
+// @Summary Create insurer
+// @Description Register an HMO or insurer whose plans cover patients' tests (admin only). The code prefixes the insurer's claim numbers
+// @Tags Insurance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param insurer body domain.CreateInsurerDTO true "Insurer details"
+// @Success 201 {object} db.Insurer "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurers [post]
+func (h *HTTPHandler) CreateInsurer(c echo.Context) error {
+	return h.service.CreateInsurer(c)
+}
+
+// ListInsurers lists the active insurers
+// @Summary List insurers
+// @Description List the active insurers by name
+// @Tags Insurance
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param per_page query int false "Items per page" minimum(1) maximum(50) default(10)
+// @Success 200 {array} db.Insurer "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurers [get]
+func (h *HTTPHandler) ListInsurers(c echo.Context) error {
+	return h.service.ListInsurers(c)
+}
+
+// CreateInsurancePlan adds a plan to an insurer
+// @Summary Create insurance plan
+// @Description Add a plan to an insurer (admin only). Plans cover nothing until coverage rules are set
+// @Tags Insurance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param insurer_id path string true "Insurer ID" format(uuid)
+// @Param plan body domain.CreateInsurancePlanDTO true "Plan details"
+// @Success 201 {object} db.InsurancePlan "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurers/{insurer_id}/plans [post]
+func (h *HTTPHandler) CreateInsurancePlan(c echo.Context) error {
+	return h.service.CreateInsurancePlan(c)
+}
+
+// ListInsurancePlans lists the active plans of an insurer
+// @Summary List insurance plans
+// @Description List the active plans of an insurer
+// @Tags Insurance
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param insurer_id path string true "Insurer ID" format(uuid)
+// @Success 200 {array} db.InsurancePlan "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurers/{insurer_id}/plans [get]
+func (h *HTTPHandler) ListInsurancePlans(c echo.Context) error {
+	return h.service.ListInsurancePlans(c)
+}
+
+// SetCoverageRule sets how a plan covers a test type
+// @Summary Set coverage rule
+// @Description Set how a plan covers a test type: the copay is taken off the price and the plan pays its covered percentage of the rest. Tests without a rule are not covered (admin only)
+// @Tags Insurance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param plan_id path string true "Insurance plan ID" format(uuid)
+// @Param rule body domain.SetCoverageRuleDTO true "Coverage rule"
+// @Success 200 {object} db.InsuranceCoverageRule "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-plans/{plan_id}/coverage [put]
+func (h *HTTPHandler) SetCoverageRule(c echo.Context) error {
+	return h.service.SetCoverageRule(c)
+}
+
+// ListCoverageRules lists the coverage rules of a plan
+// @Summary List coverage rules
+// @Description List how a plan covers each test type
+// @Tags Insurance
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param plan_id path string true "Insurance plan ID" format(uuid)
+// @Success 200 {array} db.InsuranceCoverageRule "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-plans/{plan_id}/coverage [get]
+func (h *HTTPHandler) ListCoverageRules(c echo.Context) error {
+	return h.service.ListCoverageRules(c)
+}
+
+// EnrolPolicy records the current patient's membership of a plan
+// @Summary Enrol insurance policy
+// @Description Record the current patient's membership of an insurance plan so bookings can be covered by it
+// @Tags Insurance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param policy body domain.EnrolPolicyDTO true "Policy details"
+// @Success 201 {object} db.PatientPolicy "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-policies [post]
+func (h *HTTPHandler) EnrolPolicy(c echo.Context) error {
+	return h.service.EnrolPolicy(c)
+}
+
+// ListPolicies lists the current patient's insurance policies
+// @Summary List insurance policies
+// @Description List the current patient's insurance policies, active ones first
+// @Tags Insurance
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} db.PatientPolicy "SUCCESS_RESPONSE"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-policies [get]
+func (h *HTTPHandler) ListPolicies(c echo.Context) error {
+	return h.service.ListPolicies(c)
+}
+
+// EndPolicy ends one of the current patient's policies
+// @Summary End insurance policy
+// @Description End one of the current patient's insurance policies. Bookings it already covers stay covered
+// @Tags Insurance
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param policy_id path string true "Policy ID" format(uuid)
+// @Success 200 {object} db.PatientPolicy "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-policies/{policy_id}/end [post]
+func (h *HTTPHandler) EndPolicy(c echo.Context) error {
+	return h.service.EndPolicy(c)
+}
+
+// CreateInsuranceClaim batches covered appointments into a claim
+// @Summary Create insurance claim
+// @Description Batch the centre's completed appointments covered by an insurer that have not been claimed yet into a draft claim (owner or manager of the centre)
+// @Tags Insurance Claims
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param claim body domain.CreateInsuranceClaimDTO true "Insurer and period of the claim"
+// @Success 201 {object} db.InsuranceClaim "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/insurance-claims [post]
+func (h *HTTPHandler) CreateInsuranceClaim(c echo.Context) error {
+	return h.service.CreateInsuranceClaim(c)
+}
+
+// ListInsuranceClaims lists a centre's insurance claims
+// @Summary List insurance claims
+// @Description List a centre's insurance claims, newest first (owner or manager of the centre)
+// @Tags Insurance Claims
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param diagnostic_centre_id path string true "Diagnostic Centre ID" format(uuid)
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param per_page query int false "Items per page" minimum(1) maximum(50) default(10)
+// @Success 200 {array} db.InsuranceClaim "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/diagnostic_centres/{diagnostic_centre_id}/insurance-claims [get]
+func (h *HTTPHandler) ListInsuranceClaims(c echo.Context) error {
+	return h.service.ListInsuranceClaims(c)
+}
+
+// GetInsuranceClaim returns a claim with its entries
+// @Summary Get insurance claim
+// @Description Get an insurance claim with the covered appointments it claims for (owner or manager of the claim's centre)
+// @Tags Insurance Claims
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param claim_id path string true "Insurance claim ID" format(uuid)
+// @Success 200 {object} domain.InsuranceClaimStatement "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-claims/{claim_id} [get]
+func (h *HTTPHandler) GetInsuranceClaim(c echo.Context) error {
+	return h.service.GetInsuranceClaim(c)
+}
+
+// ExportInsuranceClaim returns a claim as a CSV or JSON file
+// @Summary Export insurance claim
+// @Description Download an insurance claim to send to the insurer: CSV with one row per covered test, or JSON (owner or manager of the claim's centre)
+// @Tags Insurance Claims
+// @Produce text/csv,application/json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param claim_id path string true "Insurance claim ID" format(uuid)
+// @Param format query string false "File format" Enums(csv, json) default(csv)
+// @Success 200 {file} file "Insurance claim file"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-claims/{claim_id}/export [get]
+func (h *HTTPHandler) ExportInsuranceClaim(c echo.Context) error {
+	return h.service.ExportInsuranceClaim(c)
+}
+
+// UpdateInsuranceClaimStatus records a claim's progress with the insurer
+// @Summary Update insurance claim status
+// @Description Record a claim's progress: draft claims are submitted, submitted claims approved or rejected and approved claims settled once the insurer pays. An approval without approved_amount approves the full claim
+// @Tags Insurance Claims
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param claim_id path string true "Insurance claim ID" format(uuid)
+// @Param status body domain.UpdateInsuranceClaimStatusDTO true "New status"
+// @Success 200 {object} db.InsuranceClaim "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "UNPROCESSED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/insurance-claims/{claim_id}/status [post]
+func (h *HTTPHandler) UpdateInsuranceClaimStatus(c echo.Context) error {
+	return h.service.UpdateInsuranceClaimStatus(c)
+}
@@ -38,3 +38,108 @@ func (h *HTTPHandler) GetNotifications(c echo.Context) error {
 func (h *HTTPHandler) MarkNotificationRead(c echo.Context) error {
 	return h.service.MarkNotificationRead(c)
 }
+
+// ListOutboxEmails lists queued outbound email by delivery status
+// @Summary List queued emails
+// @Description List outbound emails in the outbox by delivery status, dead-lettered ones by default. Admins only.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param status query string false "Delivery status" Enums(pending, sending, sent, dead) default(dead)
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {array} domain.OutboxEmail
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/admin/emails [get]
+func (h *HTTPHandler) ListOutboxEmails(c echo.Context) error {
+	return h.service.ListOutboxEmails(c)
+}
+
+// ReplayOutboxEmail queues a dead-lettered email again
+// @Summary Replay a dead-lettered email
+// @Description Queue a dead-lettered email for delivery again with a fresh set of attempts. Admins only.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param email_id path string true "Email ID"
+// @Success 200 {object} domain.OutboxEmail
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/admin/emails/{email_id}/replay [post]
+func (h *HTTPHandler) ReplayOutboxEmail(c echo.Context) error {
+	return h.service.ReplayOutboxEmail(c)
+}
+
+// GetNotificationPreferences returns the signed in user's notification preferences
+// @Summary Get notification preferences
+// @Description Get the channels every notification type goes out on for the signed in user, with the defaults for the types they have not set, and their quiet hours
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} domain.NotificationPreferences
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/notifications/preferences [get]
+func (h *HTTPHandler) GetNotificationPreferences(c echo.Context) error {
+	return h.service.GetNotificationPreferences(c)
+}
+
+// UpdateNotificationPreference sets the channels of a notification type
+// @Summary Update a notification preference
+// @Description Choose whether a notification type goes out by email, SMS, in-app and over WebSocket for the signed in user
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param body body domain.UpdateNotificationPreferenceDTO true "Notification preference"
+// @Success 200 {object} domain.NotificationPreference
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/notifications/preferences [put]
+func (h *HTTPHandler) UpdateNotificationPreference(c echo.Context) error {
+	return h.service.UpdateNotificationPreference(c)
+}
+
+// SetQuietHours sets the signed in user's quiet hours
+// @Summary Set quiet hours
+// @Description Set a daily window, in the given time zone, when nothing is sent by SMS or pushed over WebSocket. A window that ends before it starts runs past midnight.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param body body domain.SetQuietHoursDTO true "Quiet hours"
+// @Success 200 {object} domain.QuietHours
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/notifications/quiet_hours [put]
+func (h *HTTPHandler) SetQuietHours(c echo.Context) error {
+	return h.service.SetQuietHours(c)
+}
+
+// ClearQuietHours removes the signed in user's quiet hours
+// @Summary Clear quiet hours
+// @Description Remove the signed in user's quiet hours
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/notifications/quiet_hours [delete]
+func (h *HTTPHandler) ClearQuietHours(c echo.Context) error {
+	return h.service.ClearQuietHours(c)
+}
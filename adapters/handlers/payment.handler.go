@@ -23,6 +23,25 @@ func (h *HTTPHandler) GetPayment(c echo.Context) error {
 	return h.service.GetPayment(c)
 }
 
+// GetPaymentTimeline lists the event ledger of a payment
+// @Summary Get payment timeline
+// @Description List every provider event and internal transition recorded against a payment, oldest first, for dispute handling (owner or manager of the payment's centre)
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param payment_id path string true "Payment ID" format(uuid)
+// @Success 200 {object} domain.PaymentTimeline "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/payments/{payment_id}/events [get]
+func (h *HTTPHandler) GetPaymentTimeline(c echo.Context) error {
+	return h.service.GetPaymentTimeline(c)
+}
+
 // ListPayments retrieves a paginated list of payments
 // @Summary List payments
 // @Description Get a paginated list of all payments
@@ -47,38 +66,248 @@ func (h *HTTPHandler) ListPayments(c echo.Context) error {
 
 // RefundPayment refunds a specific payment
 // @Summary Refund payment
-// @Description Issue a refund for a specific payment by its ID
+// @Description Refund part or all of a successful payment through the provider it was made with (owner or manager of the payment's centre). Refunds may be repeated until the captured amount is used up. The refund stays pending until the provider reports it processed or failed. With refund_to set to wallet, and always for payments the wallet paid part of, the amount is credited to the patient's wallet at once instead. Payments collected at the centre are refunded by the centre and recorded as processed at once
 // @Tags Payments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string true "Bearer token"
 // @Param payment_id path uuid true "Payment ID to refund"
-// @Param refund_request body domain.RefundPaymentDTO true "Refund details (amount, reason, etc.)"
-// @Success 200 {object} domain.GetPaymentDTO "Refunded payment details"
+// @Param refund_request body domain.RefundPaymentDTO true "Refund amount and reason"
+// @Success 202 {object} db.PaymentRefund "Refund requested from the provider"
 // @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
 // @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
 // @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND"
+// @Failure 422 {object} handlers.UNPROCESSED_ERROR "Payment not refundable or amount exceeds what is left to refund"
+// @Failure 502 {object} handlers.INTERNAL_SERVER_ERROR "Payment provider rejected the refund"
 // @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
 // @Router /v1/payments/{payment_id}/refund [post]
 func (h *HTTPHandler) RefundPayment(c echo.Context) error {
 	return h.service.RPayment(c)
 }
 
-// PaymentWebhook handles incoming payment gateway webhooks
-// @Summary Payment gateway webhook
-// @Description Receives asynchronous payment status updates from the payment provider
+// ListPaymentRefunds lists the refunds of a payment
+// @Summary List payment refunds
+// @Description List every refund requested against a payment with its provider status, oldest first (owner or manager of the payment's centre)
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param payment_id path string true "Payment ID" format(uuid)
+// @Success 200 {array} db.PaymentRefund "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/payments/{payment_id}/refunds [get]
+func (h *HTTPHandler) ListPaymentRefunds(c echo.Context) error {
+	return h.service.ListPaymentRefunds(c)
+}
+
+// GetPaymentReceipt returns the invoice and receipt of a payment as a PDF
+// @Summary Download payment receipt
+// @Description Download the invoice and receipt of a successful payment as a PDF: the centre's details, a line per test, the tax included and any refunds made since. The invoice is numbered in the centre's sequence when first issued (patient who made the payment, or owner or manager of its centre)
+// @Tags Payments
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param payment_id path string true "Payment ID" format(uuid)
+// @Success 200 {file} file "Invoice and receipt PDF"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "Payment was not successful"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/payments/{payment_id}/receipt [get]
+func (h *HTTPHandler) GetPaymentReceipt(c echo.Context) error {
+	return h.service.GetPaymentReceipt(c)
+}
+
+// GetPaymentDiscrepancyReport returns a day's payment reconciliation discrepancies
+// @Summary Get payment discrepancy report
+// @Description Get the differences between provider transactions and payments the reconciliation job found on a day, yesterday by default (admin only)
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param date query string false "Day the report covers (YYYY-MM-DD)"
+// @Success 200 {object} domain.PaymentDiscrepancyReport "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/payments/reconciliation/report [get]
+func (h *HTTPHandler) GetPaymentDiscrepancyReport(c echo.Context) error {
+	return h.service.GetPaymentDiscrepancyReport(c)
+}
+
+// GetSettlement returns a settlement statement
+// @Summary Get settlement statement
+// @Description Get a settlement statement with every payment and refund it settled and the commission taken from each (owner of the statement's centre)
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param settlement_id path string true "Settlement ID" format(uuid)
+// @Success 200 {object} domain.SettlementStatement "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/settlements/{settlement_id} [get]
+func (h *HTTPHandler) GetSettlement(c echo.Context) error {
+	return h.service.GetSettlement(c)
+}
+
+// ExportSettlement returns a settlement statement as CSV
+// @Summary Export settlement statement
+// @Description Download a settlement statement as CSV: the opening balance carried into it, one row per payment or refund, then its totals (owner of the statement's centre)
 // @Tags Payments
+// @Produce text/csv
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param settlement_id path string true "Settlement ID" format(uuid)
+// @Success 200 {file} file "Settlement statement CSV"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/settlements/{settlement_id}/export [get]
+func (h *HTTPHandler) ExportSettlement(c echo.Context) error {
+	return h.service.ExportSettlement(c)
+}
+
+// RecordCentrePayment records a payment received at the centre
+// @Summary Record payment received at the centre
+// @Description Record that the patient of a pay-at-centre booking paid in cash or by bank transfer, under the receipt the centre issued (owner or manager of the payment's centre). The payment becomes successful and its appointments are confirmed as for a verified card payment. Each receipt number can be recorded once per centre
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param payment_id path string true "Payment ID" format(uuid)
+// @Param receipt body domain.RecordCentrePaymentDTO true "Payment method and receipt number"
+// @Success 200 {object} domain.CentrePaymentReceipt "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "Payment not awaiting payment at the centre or receipt already recorded"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/payments/{payment_id}/receive [post]
+func (h *HTTPHandler) RecordCentrePayment(c echo.Context) error {
+	return h.service.RecordCentrePayment(c)
+}
+
+// GetWallet returns the current patient's wallet balances
+// @Summary Get wallet
+// @Description Get the current patient's wallet balances, one per currency. Refunds sent to the wallet are credited at once and can pay for later bookings
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} db.Wallet "SUCCESS_RESPONSE"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/wallet [get]
+func (h *HTTPHandler) GetWallet(c echo.Context) error {
+	return h.service.GetWallet(c)
+}
+
+// ListWalletTransactions lists the current patient's wallet transactions
+// @Summary List wallet transactions
+// @Description List the credits and debits of the current patient's wallets, newest first, each with the balance it left
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param per_page query int false "Items per page" minimum(1) maximum(50) default(10)
+// @Success 200 {array} db.WalletTransaction "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/wallet/transactions [get]
+func (h *HTTPHandler) ListWalletTransactions(c echo.Context) error {
+	return h.service.ListWalletTransactions(c)
+}
+
+// CreatePromoCode creates a promo code
+// @Summary Create promo code
+// @Description Create a percentage or fixed promo code with a validity window and usage limits, optionally restricted to centres and test types. Centre owners must restrict codes to centres they own; admins may create codes for every centre
+// @Tags Promo Codes
 // @Accept json
 // @Produce json
-// @Param X-Signature header string false "Optional signature header for webhook verification"
-// @Param webhook body domain.PaymentWebhookDTO true "Webhook payload from the payment gateway"
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param promo_code body domain.CreatePromoCodeDTO true "Promo code terms"
+// @Success 201 {object} db.PromoCode "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "CONFLICT_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/promo-codes [post]
+func (h *HTTPHandler) CreatePromoCode(c echo.Context) error {
+	return h.service.CreatePromoCode(c)
+}
+
+// ListPromoCodes lists the promo codes the current user created
+// @Summary List promo codes
+// @Description List the promo codes the current user created, newest first, with how often each was redeemed
+// @Tags Promo Codes
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param per_page query int false "Items per page" minimum(1) maximum(50) default(10)
+// @Success 200 {array} db.PromoCode "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/promo-codes [get]
+func (h *HTTPHandler) ListPromoCodes(c echo.Context) error {
+	return h.service.ListPromoCodes(c)
+}
+
+// DeactivatePromoCode withdraws a promo code
+// @Summary Deactivate promo code
+// @Description Withdraw a promo code so it can no longer be applied. Bookings already made with it keep their discount
+// @Tags Promo Codes
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer token"
+// @Param promo_code_id path string true "Promo code ID" format(uuid)
+// @Success 200 {object} db.PromoCode "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "FORBIDDEN_ERROR"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/promo-codes/{promo_code_id}/deactivate [post]
+func (h *HTTPHandler) DeactivatePromoCode(c echo.Context) error {
+	return h.service.DeactivatePromoCode(c)
+}
+
+// PaystackWebhook handles signed Paystack webhook events
+// @Summary Paystack webhook
+// @Description Receives Paystack events signed with x-paystack-signature (HMAC-SHA512 of the body keyed with the secret key). charge.success marks the payment successful and confirms its appointments, refund.processed and refund.failed settle the matching refund, recording refunds started from the Paystack dashboard, and transfer.success, transfer.failed and transfer.reversed settle the payout of the matching settlement. Events that do not apply are acknowledged and ignored
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param x-paystack-signature header string true "HMAC-SHA512 signature of the request body"
+// @Param webhook body domain.PaystackWebhookDTO true "Paystack event"
 // @Success 200 {object} object "Acknowledgement of webhook received"
 // @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
 // @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
-// @Router /v1/payments/webhook [post]
-func (h *HTTPHandler) PaymentWebhook(c echo.Context) error {
-	return h.service.HandlePaymentWebhook(c)
+// @Router /v1/payments/webhook/paystack [post]
+func (h *HTTPHandler) PaystackWebhook(c echo.Context) error {
+	return h.service.HandlePaystackWebhook(c)
 }
 
 // VerifyPayment verifies the status of a specific payment
@@ -19,6 +19,7 @@ import (
 // @Param document_type formData string true "Type of medical document" Enums(LAB_REPORT, PRESCRIPTION, DISCHARGE_SUMMARY, IMAGING, VACCINATION, ALLERGY, SURGERY, CHRONIC_CONDITION, FAMILY_HISTORY, OTHER)
 // @Param document_date formData string true "Date of the document" format(date)
 // @Param file formData file true "Medical record file (PDF/Image)"
+// @Param line_item_id formData string false "Test of a bundled appointment the record reports on; its result is marked ready" format(uuid)
 // @Success 201 {object} handlers.MedicalRecordSwagger "Medical record created successfully"
 // @Failure 400 {object} handlers.BAD_REQUEST "Invalid input data/file format"
 // @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
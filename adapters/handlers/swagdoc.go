@@ -114,6 +114,20 @@ type UNAUTHORIZED_ERROR struct {
 	} `json:"error"`
 }
 
+// FORBIDDEN_ERROR is used for Swagger documentation only
+// @Description FORBIDDEN_ERROR response for Swagger
+// @name FORBIDDEN_ERROR
+// @property code string "FORBIDDEN_ERROR" example:"FORBIDDEN_ERROR"
+// @property message string "Error message" example:"Permission denied"
+type FORBIDDEN_ERROR struct {
+	Status  int64 `json:"status" example:"403"`
+	Success bool  `json:"success" example:"false"`
+	Error   struct {
+		Code    string `json:"code" example:"FORBIDDEN_ERROR"`
+		Message string `json:"message" example:"Permission denied"`
+	} `json:"error"`
+}
+
 // NOT_FOUND_ERROR is used for Swagger documentation only
 // @Description NOT_FOUND_ERROR response for Swagger
 // @name NOT_FOUND_ERROR
@@ -142,6 +156,20 @@ type DUPLICATE_ERROR struct {
 	} `json:"error"`
 }
 
+// CONFLICT_ERROR is used for Swagger documentation only
+// @Description CONFLICT_ERROR response for Swagger
+// @name CONFLICT_ERROR
+// @property code string "CONFLICT_ERROR" example:"CONFLICT_ERROR"
+// @property message string "Error message" example:"Conflict error"
+type CONFLICT_ERROR struct {
+	Status  int64 `json:"status" example:"409"`
+	Success bool  `json:"success" example:"false"`
+	Error   struct {
+		Code    string `json:"code" example:"CONFLICT_ERROR"`
+		Message string `json:"message" example:"appointment cannot make this transition from its current status"`
+	} `json:"error"`
+}
+
 // LOGIN_RESPONSE is used for Swagger documentation only
 // @Description LOGIN_RESPONSE response for Swagger
 // @name LOGIN_RESPONSE
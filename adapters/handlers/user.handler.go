@@ -22,7 +22,7 @@ func (handler *HTTPHandler) Register(context echo.Context) error {
 
 // SignIn godoc
 // @Summary User login
-// @Description Authenticate a user and return a JWT token for API access
+// @Description Authenticate a user by email, or by phone number once it is verified, and return a JWT token for API access
 // @Tags User
 // @Accept json
 // @Produce json
@@ -30,6 +30,7 @@ func (handler *HTTPHandler) Register(context echo.Context) error {
 // @Success 200 {object} handlers.LOGIN_RESPONSE "SUCCESS_RESPONSE"
 // @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
 // @Failure 401 {object} handlers.UNAUTHORIZED_ERROR "UNAUTHORIZED_ERROR"
+// @Failure 403 {object} handlers.FORBIDDEN_ERROR "Phone number not verified"
 // @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
 // @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
 // @Router /v1/login [post]
@@ -175,10 +176,53 @@ func (handler *HTTPHandler) GoogleLogin(context echo.Context) error {
 	return handler.service.GoogleLogin(context)
 }
 
+// VerifyPhoneDTO godoc
+// @Summary Verify phone number
+// @Description Verify a phone number with the six digit code sent to it by SMS. A code expires after 10 minutes and allows 5 attempts.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param RequestBody body domain.VerifyPhoneDTO true "Phone number and code"
+// @Success 200 {object} handlers.SUCCESS_RESPONSE "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "Invalid or expired code"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "Number verified by another account"
+// @Failure 429 {object} handlers.BAD_REQUEST "Too many attempts"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/verify_phone [post]
 func (handler *HTTPHandler) VerifyPhoneDTO(context echo.Context) error {
 	return handler.service.VerifyPhoneNumber(context)
 }
 
+// CreateWithPhoneNumber godoc
+// @Summary Register with phone number
+// @Description Register a patient or diagnostic centre owner by phone number and send a verification code by SMS. The account can sign in once the number is verified.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param RequestBody body domain.PhoneRegisterDTO true "User registration details"
+// @Success 201 {object} handlers.SUCCESS_RESPONSE "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 409 {object} handlers.DUPLICATE_ERROR "Phone number already registered"
+// @Failure 500 {object} handlers.INTERNAL_SERVER_ERROR "INTERNAL_SERVER_ERROR"
+// @Router /v1/register_with_phone [post]
 func (handler *HTTPHandler) CreateWithPhoneNumber(context echo.Context) error {
 	return handler.service.CreateWithPhoneNumber(context)
 }
+
+// ResendPhoneVerification godoc
+// @Summary Resend phone verification code
+// @Description Send a new verification code to a phone number that is not verified yet. Codes are at least a minute apart and at most 5 an hour.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param RequestBody body domain.ResendPhoneVerificationDTO true "Phone number"
+// @Success 200 {object} handlers.SUCCESS_RESPONSE "SUCCESS_RESPONSE"
+// @Failure 400 {object} handlers.BAD_REQUEST "BAD_REQUEST"
+// @Failure 404 {object} handlers.NOT_FOUND_ERROR "NOT_FOUND_ERROR"
+// @Failure 409 {object} handlers.CONFLICT_ERROR "Phone number already verified"
+// @Failure 429 {object} handlers.BAD_REQUEST "Code sent too recently"
+// @Failure 503 {object} handlers.INTERNAL_SERVER_ERROR "SMS gateway unavailable"
+// @Router /v1/resend_phone_verification [post]
+func (handler *HTTPHandler) ResendPhoneVerification(context echo.Context) error {
+	return handler.service.ResendPhoneVerification(context)
+}
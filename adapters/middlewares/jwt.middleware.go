@@ -106,6 +106,7 @@ func ConditionalJWTMiddleware(jwtSecret string) echo.MiddlewareFunc {
 		"GET /v1/ai/capabilities":                          true,
 		"GET /v1/diagnostic_centres":                       true,
 		"GET /v1/diagnostic_centres/:diagnostic_centre_id": true,
+		"GET /v1/diagnostic_centres/:diagnostic_centre_id/slots": true,
     
 		"POST /v1/login":                  true,
 		"POST /v1/register":               true,
@@ -114,7 +115,10 @@ func ConditionalJWTMiddleware(jwtSecret string) echo.MiddlewareFunc {
 		"POST /v1/reset_password":         true,
 		"POST /v1/register_with_phone":    true,
 		"POST /v1/resend_verification":    true,
+		"POST /v1/resend_phone_verification": true,
 		"POST /v1/request_password_reset": true,
+
+		"POST /v1/payments/webhook/paystack": true,
 	}
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -258,6 +258,12 @@ func handleJSONRequest(c echo.Context) func(interface{}) error {
 	c.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 
 	return func(dto interface{}) error {
-		return json.Unmarshal(bodyBytes, dto)
+		if len(bytes.TrimSpace(bodyBytes)) > 0 {
+			if err := json.Unmarshal(bodyBytes, dto); err != nil {
+				return err
+			}
+		}
+		// Path params such as :appointment_id are not part of the JSON body
+		return (&echo.DefaultBinder{}).BindPathParams(c, dto)
 	}
 }
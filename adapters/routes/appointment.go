@@ -18,6 +18,13 @@ func AppointmentRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
 			factory:     func() interface{} { return &domain.CreateAppointmentDTO{} },
 			description: "Create a new appointment",
 		},
+		{
+			method:      http.MethodGet,
+			path:        "/appointments/quote",
+			handler:     handler.GetAppointmentQuote,
+			factory:     func() interface{} { return &domain.GetAppointmentQuoteDTO{} },
+			description: "Get the price of an appointment before booking",
+		},
 		{
 			method:  http.MethodGet,
 			path:    "/appointments/:appointment_id",
@@ -52,6 +59,20 @@ func AppointmentRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
 			factory:     func() interface{} { return &domain.RescheduleAppointmentDTO{} },
 			description: "Reschedule an appointment",
 		},
+		{
+			method:      http.MethodGet,
+			path:        "/appointments/:appointment_id/line_items",
+			handler:     handler.ListAppointmentLineItems,
+			factory:     func() interface{} { return &domain.GetAppointmentDTO{} },
+			description: "List the tests booked under an appointment",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/appointments/:appointment_id/pay",
+			handler:     handler.InitializeAppointmentPayment,
+			factory:     func() interface{} { return &domain.InitializeAppointmentPaymentDTO{} },
+			description: "Start payment for a pending appointment",
+		},
 		{
 			method:      http.MethodPost,
 			path:        "/appointments/confirm_appointment",
@@ -59,6 +80,97 @@ func AppointmentRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
 			factory:     func() interface{} { return &domain.ConfirmAppointmentDTO{} },
 			description: "Confirm an appointment",
 		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/appointments",
+			handler:     handler.ListCentreAppointments,
+			factory:     func() interface{} { return &domain.ListCentreAppointmentsDTO{} },
+			description: "List a diagnostic centre's appointments for a day",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/appointments/:appointment_id/check_in",
+			handler:     handler.CheckInAppointment,
+			factory:     func() interface{} { return &domain.AppointmentTransitionDTO{} },
+			description: "Check a patient in for an appointment",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/appointments/:appointment_id/start",
+			handler:     handler.StartAppointment,
+			factory:     func() interface{} { return &domain.AppointmentTransitionDTO{} },
+			description: "Start an appointment",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/appointments/:appointment_id/complete",
+			handler:     handler.CompleteAppointment,
+			factory:     func() interface{} { return &domain.AppointmentTransitionDTO{} },
+			description: "Complete an appointment",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/appointments/:appointment_id/no_show",
+			handler:     handler.MarkAppointmentNoShow,
+			factory:     func() interface{} { return &domain.AppointmentTransitionDTO{} },
+			description: "Mark an appointment as a no-show",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/appointments/:appointment_id/line_items",
+			handler:     handler.ListCentreAppointmentLineItems,
+			factory:     func() interface{} { return &domain.AppointmentTransitionDTO{} },
+			description: "List the tests booked under an appointment at a centre",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/appointments/:appointment_id/line_items/:line_item_id",
+			handler:     handler.UpdateLineItemResultStatus,
+			factory:     func() interface{} { return &domain.UpdateLineItemResultDTO{} },
+			description: "Update the result status of a test booked under an appointment",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/appointment_series",
+			handler:     handler.CreateAppointmentSeries,
+			factory:     func() interface{} { return &domain.CreateAppointmentSeriesDTO{} },
+			description: "Book a recurring appointment series",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/appointment_series/:series_id",
+			handler:     handler.GetAppointmentSeries,
+			factory:     func() interface{} { return &domain.GetAppointmentSeriesDTO{} },
+			description: "Get an appointment series with its appointments",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/appointment_series/:series_id/cancel",
+			handler:     handler.CancelAppointmentSeries,
+			factory:     func() interface{} { return &domain.CancelAppointmentSeriesDTO{} },
+			description: "Cancel every remaining appointment of a series",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/waitlist",
+			handler:     handler.JoinWaitlist,
+			factory:     func() interface{} { return &domain.JoinWaitlistDTO{} },
+			description: "Join a diagnostic centre's waitlist",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/waitlist",
+			handler:     handler.ListWaitlist,
+			factory:     func() interface{} { return &domain.ListWaitlistDTO{} },
+			description: "List the patient's waitlist entries",
+		},
+		{
+			method:      http.MethodDelete,
+			path:        "/waitlist/:waitlist_id",
+			handler:     handler.LeaveWaitlist,
+			factory:     func() interface{} { return &domain.LeaveWaitlistDTO{} },
+			description: "Leave a waitlist",
+		},
 	}
 
 	registerRoutes(group, appointmentGroup)
@@ -32,6 +32,13 @@ func AuthRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
 			factory:     func() interface{} { return &domain.VerifyPhoneDTO{} },
 			description: "Phone number verification",
 		},
+		{
+			method:      http.MethodPost,
+			path:        "/resend_phone_verification",
+			handler:     handler.ResendPhoneVerification,
+			factory:     func() interface{} { return &domain.ResendPhoneVerificationDTO{} },
+			description: "Resend phone verification code",
+		},
 		{
 			method:      http.MethodPost,
 			path:        "/login",
@@ -104,6 +104,111 @@ func DiagnosticRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
 			factory:     func() interface{} { return &domain.GetDiagnosticSchedulesByCentreParamDTO{} },
 			description: "Get Diagnostic centre schedules",
 		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/slots",
+			handler:     handler.GetAvailableSlots,
+			factory:     func() interface{} { return &domain.GetAvailableSlotsDTO{} },
+			description: "Get available slots of a diagnostic centre",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/test_prices",
+			handler:     handler.ListTestPrices,
+			factory:     func() interface{} { return &domain.ListTestPricesDTO{} },
+			description: "List test prices of a diagnostic centre",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/test_prices",
+			handler:     handler.CreateTestPrice,
+			factory:     func() interface{} { return &domain.CreateTestPriceDTO{} },
+			description: "Add a test price to a diagnostic centre",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/test_prices/:test_price_id",
+			handler:     handler.UpdateTestPrice,
+			factory:     func() interface{} { return &domain.UpdateTestPriceDTO{} },
+			description: "Update a test price",
+		},
+		{
+			method:      http.MethodDelete,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/test_prices/:test_price_id",
+			handler:     handler.DeactivateTestPrice,
+			factory:     func() interface{} { return &domain.TestPriceParamDTO{} },
+			description: "Deactivate a test price",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/test_prices/:test_price_id/history",
+			handler:     handler.GetTestPriceHistory,
+			factory:     func() interface{} { return &domain.TestPriceParamDTO{} },
+			description: "Get the price history of a test",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/cancellation_policy",
+			handler:     handler.GetCancellationPolicy,
+			factory:     func() interface{} { return &domain.GetDiagnosticParamDTO{} },
+			description: "Get the cancellation policy of a diagnostic centre",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/cancellation_policy",
+			handler:     handler.UpdateCancellationPolicy,
+			factory:     func() interface{} { return &domain.UpdateCancellationPolicyDTO{} },
+			description: "Set the cancellation policy of a diagnostic centre",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/payment_provider",
+			handler:     handler.GetPaymentProviderSetting,
+			factory:     func() interface{} { return &domain.GetDiagnosticParamDTO{} },
+			description: "Get the payment provider of a diagnostic centre",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/payment_provider",
+			handler:     handler.UpdatePaymentProviderSetting,
+			factory:     func() interface{} { return &domain.UpdatePaymentProviderDTO{} },
+			description: "Set the payment provider of a diagnostic centre",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/payout_account",
+			handler:     handler.UpdatePayoutAccount,
+			factory:     func() interface{} { return &domain.UpdatePayoutAccountDTO{} },
+			description: "Set the payout account of a diagnostic centre",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/settlements",
+			handler:     handler.ListSettlements,
+			factory:     func() interface{} { return &domain.ListSettlementsDTO{} },
+			description: "List the settlement statements of a diagnostic centre",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/availability_exceptions",
+			handler:     handler.ListAvailabilityExceptions,
+			factory:     func() interface{} { return &domain.ListAvailabilityExceptionsDTO{} },
+			description: "List closures and overrides of a diagnostic centre",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/availability_exceptions",
+			handler:     handler.CreateAvailabilityException,
+			factory:     func() interface{} { return &domain.CreateAvailabilityExceptionDTO{} },
+			description: "Close a diagnostic centre or override its hours for a day",
+		},
+		{
+			method:      http.MethodDelete,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/availability_exceptions/:exception_id",
+			handler:     handler.DeleteAvailabilityException,
+			factory:     func() interface{} { return &domain.AvailabilityExceptionParamDTO{} },
+			description: "Delete an availability exception",
+		},
 		{
 			method:      http.MethodPost,
 			path:        "/diagnostic_centres_owner/kyc",
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/diagnoxix/adapters/handlers"
+	"github.com/diagnoxix/core/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// EmailOutboxRoutes registers the admin routes over queued outbound email
+func EmailOutboxRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
+	emailOutboxGroup := []routeConfig{
+		{
+			method:      http.MethodGet,
+			path:        "/admin/emails",
+			handler:     handler.ListOutboxEmails,
+			factory:     func() interface{} { return &domain.ListOutboxEmailsDTO{} },
+			description: "List queued emails by delivery status",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/admin/emails/:email_id/replay",
+			handler:     handler.ReplayOutboxEmail,
+			factory:     func() interface{} { return &domain.ReplayOutboxEmailDTO{} },
+			description: "Queue a dead-lettered email again",
+		},
+	}
+
+	registerRoutes(group, emailOutboxGroup)
+}
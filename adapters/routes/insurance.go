@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/diagnoxix/adapters/handlers"
+	"github.com/diagnoxix/core/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// InsuranceRoutes registers insurer, policy and insurance claim routes
+func InsuranceRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
+	insuranceGroup := []routeConfig{
+		{
+			method:      http.MethodPost,
+			path:        "/insurers",
+			handler:     handler.CreateInsurer,
+			factory:     func() interface{} { return &domain.CreateInsurerDTO{} },
+			description: "Register an insurer",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/insurers",
+			handler:     handler.ListInsurers,
+			factory:     func() interface{} { return &domain.ListInsurersDTO{} },
+			description: "List active insurers",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/insurers/:insurer_id/plans",
+			handler:     handler.CreateInsurancePlan,
+			factory:     func() interface{} { return &domain.CreateInsurancePlanDTO{} },
+			description: "Add a plan to an insurer",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/insurers/:insurer_id/plans",
+			handler:     handler.ListInsurancePlans,
+			factory:     func() interface{} { return &domain.ListInsurancePlansDTO{} },
+			description: "List the active plans of an insurer",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/insurance-plans/:plan_id/coverage",
+			handler:     handler.SetCoverageRule,
+			factory:     func() interface{} { return &domain.SetCoverageRuleDTO{} },
+			description: "Set how a plan covers a test type",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/insurance-plans/:plan_id/coverage",
+			handler:     handler.ListCoverageRules,
+			factory:     func() interface{} { return &domain.ListCoverageRulesDTO{} },
+			description: "List the coverage rules of a plan",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/insurance-policies",
+			handler:     handler.EnrolPolicy,
+			factory:     func() interface{} { return &domain.EnrolPolicyDTO{} },
+			description: "Enrol the current patient on an insurance plan",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/insurance-policies",
+			handler:     handler.ListPolicies,
+			factory:     func() interface{} { return &domain.ListPoliciesDTO{} },
+			description: "List the current patient's insurance policies",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/insurance-policies/:policy_id/end",
+			handler:     handler.EndPolicy,
+			factory:     func() interface{} { return &domain.EndPolicyDTO{} },
+			description: "End an insurance policy",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/insurance-claims",
+			handler:     handler.CreateInsuranceClaim,
+			factory:     func() interface{} { return &domain.CreateInsuranceClaimDTO{} },
+			description: "Batch covered appointments into an insurance claim",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/diagnostic_centres/:diagnostic_centre_id/insurance-claims",
+			handler:     handler.ListInsuranceClaims,
+			factory:     func() interface{} { return &domain.ListInsuranceClaimsDTO{} },
+			description: "List a centre's insurance claims",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/insurance-claims/:claim_id",
+			handler:     handler.GetInsuranceClaim,
+			factory:     func() interface{} { return &domain.GetInsuranceClaimDTO{} },
+			description: "Get an insurance claim",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/insurance-claims/:claim_id/export",
+			handler:     handler.ExportInsuranceClaim,
+			factory:     func() interface{} { return &domain.ExportInsuranceClaimDTO{} },
+			description: "Export an insurance claim as CSV or JSON",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/insurance-claims/:claim_id/status",
+			handler:     handler.UpdateInsuranceClaimStatus,
+			factory:     func() interface{} { return &domain.UpdateInsuranceClaimStatusDTO{} },
+			description: "Update the status of an insurance claim",
+		},
+	}
+
+	registerRoutes(group, insuranceGroup)
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/diagnoxix/adapters/handlers"
+	"github.com/diagnoxix/core/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// NotificationRoutes registers the routes over a user's notification preferences
+func NotificationRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
+	notificationGroup := []routeConfig{
+		{
+			method:      http.MethodGet,
+			path:        "/notifications/preferences",
+			handler:     handler.GetNotificationPreferences,
+			factory:     func() interface{} { return &domain.GetNotificationPreferencesDTO{} },
+			description: "Get notification channel preferences and quiet hours",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/notifications/preferences",
+			handler:     handler.UpdateNotificationPreference,
+			factory:     func() interface{} { return &domain.UpdateNotificationPreferenceDTO{} },
+			description: "Set the channels of a notification type",
+		},
+		{
+			method:      http.MethodPut,
+			path:        "/notifications/quiet_hours",
+			handler:     handler.SetQuietHours,
+			factory:     func() interface{} { return &domain.SetQuietHoursDTO{} },
+			description: "Set notification quiet hours",
+		},
+		{
+			method:      http.MethodDelete,
+			path:        "/notifications/quiet_hours",
+			handler:     handler.ClearQuietHours,
+			factory:     func() interface{} { return &domain.ClearQuietHoursDTO{} },
+			description: "Clear notification quiet hours",
+		},
+	}
+
+	registerRoutes(group, notificationGroup)
+}
@@ -18,6 +18,13 @@ func PaymentRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
 			factory:     func() interface{} { return &domain.GetPaymentDTO{} },
 			description: "Get a payment details",
 		},
+		{
+			method:      http.MethodGet,
+			path:        "/payments/:payment_id/events",
+			handler:     handler.GetPaymentTimeline,
+			factory:     func() interface{} { return &domain.GetPaymentDTO{} },
+			description: "Get the event timeline of a payment",
+		},
 		{
 			method:      http.MethodGet,
 			path:        "/payments",
@@ -32,12 +39,89 @@ func PaymentRoutes(group *echo.Group, handler *handlers.HTTPHandler) {
 			factory:     func() interface{} { return &domain.RefundPaymentDTO{} },
 			description: "Process payment refund",
 		},
+		{
+			method:      http.MethodGet,
+			path:        "/payments/:payment_id/refunds",
+			handler:     handler.ListPaymentRefunds,
+			factory:     func() interface{} { return &domain.GetPaymentDTO{} },
+			description: "List the refunds of a payment",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/payments/:payment_id/receipt",
+			handler:     handler.GetPaymentReceipt,
+			factory:     func() interface{} { return &domain.GetPaymentDTO{} },
+			description: "Download the invoice and receipt of a payment as PDF",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/payments/:payment_id/receive",
+			handler:     handler.RecordCentrePayment,
+			factory:     func() interface{} { return &domain.RecordCentrePaymentDTO{} },
+			description: "Record a payment received at the centre",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/payments/reconciliation/report",
+			handler:     handler.GetPaymentDiscrepancyReport,
+			factory:     func() interface{} { return &domain.GetPaymentDiscrepancyReportDTO{} },
+			description: "Get the payment discrepancy report of a day",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/settlements/:settlement_id",
+			handler:     handler.GetSettlement,
+			factory:     func() interface{} { return &domain.GetSettlementDTO{} },
+			description: "Get a settlement statement",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/settlements/:settlement_id/export",
+			handler:     handler.ExportSettlement,
+			factory:     func() interface{} { return &domain.GetSettlementDTO{} },
+			description: "Export a settlement statement as CSV",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/wallet",
+			handler:     handler.GetWallet,
+			factory:     func() interface{} { return &domain.GetWalletDTO{} },
+			description: "Get the current patient's wallet balances",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/wallet/transactions",
+			handler:     handler.ListWalletTransactions,
+			factory:     func() interface{} { return &domain.ListWalletTransactionsDTO{} },
+			description: "List the current patient's wallet transactions",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/promo-codes",
+			handler:     handler.CreatePromoCode,
+			factory:     func() interface{} { return &domain.CreatePromoCodeDTO{} },
+			description: "Create a promo code",
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/promo-codes",
+			handler:     handler.ListPromoCodes,
+			factory:     func() interface{} { return &domain.ListPromoCodesDTO{} },
+			description: "List the promo codes the current user created",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/promo-codes/:promo_code_id/deactivate",
+			handler:     handler.DeactivatePromoCode,
+			factory:     func() interface{} { return &domain.DeactivatePromoCodeDTO{} },
+			description: "Deactivate a promo code",
+		},
 		{
 			method:      http.MethodPost,
-			path:        "/payments/webhook",
-			handler:     handler.PaymentWebhook,
-			factory:     func() interface{} { return &domain.PaymentWebhookDTO{} },
-			description: "Handle payment provider webhook",
+			path:        "/payments/webhook/paystack",
+			handler:     handler.PaystackWebhook,
+			factory:     func() interface{} { return &domain.PaystackWebhookDTO{} },
+			description: "Handle signed Paystack webhook events",
 		},
 		{
 			method:      http.MethodGet,
@@ -17,6 +17,9 @@ func RoutesAdaptor(public *echo.Group, handler *handlers.HTTPHandler) *echo.Grou
 	MedicalRecordRoutes(public, handler)
 	AppointmentRoutes(public, handler)
 	PaymentRoutes(public, handler)
+	InsuranceRoutes(public, handler)
+	EmailOutboxRoutes(public, handler)
+	NotificationRoutes(public, handler)
 	AvailabilityRoutes(public, handler)
 	AIRoutes(public, handler)
 	CacheRoutes(public, handler)
@@ -3,29 +3,45 @@ package domain
 import (
 	"time"
 
+	"github.com/diagnoxix/adapters/db"
 	"github.com/google/uuid"
 )
 
 const (
 	AppointmentStatusPending     AppointmentStatus = "pending"
 	AppointmentStatusConfirmed   AppointmentStatus = "confirmed"
+	AppointmentStatusCheckedIn   AppointmentStatus = "checked_in"
 	AppointmentStatusInProgress  AppointmentStatus = "in_progress"
 	AppointmentStatusCompleted   AppointmentStatus = "completed"
+	AppointmentStatusNoShow      AppointmentStatus = "no_show"
 	AppointmentStatusCancelled   AppointmentStatus = "cancelled"
 	AppointmentStatusRescheduled AppointmentStatus = "rescheduled"
 )
 
+const (
+	RefundStatusNone      RefundStatus = "none"
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusProcessed RefundStatus = "processed"
+	RefundStatusFailed    RefundStatus = "failed"
+)
+
 type (
 	// AppointmentStatus represents the status of an appointment
 	AppointmentStatus    string
 	CreateAppointmentDTO struct {
 		DiagnosticCentreID uuid.UUID `json:"diagnostic_centre_id" validate:"required,uuid"`
 		TestType           string    `json:"test_type" validate:"required,oneof=BLOOD_TEST URINE_TEST X_RAY MRI CT_SCAN ULTRASOUND ECG EEG BIOPSY SKIN_TEST IMMUNOLOGY_TEST HORMONE_TEST VIRAL_TEST BACTERIAL_TEST PARASITIC_TEST FUNGAL_TEST MOLECULAR_TEST TOXICOLOGY_TEST ECHO COVID_19_TEST BLOOD_SUGAR_TEST LIPID_PROFILE HEMOGLOBIN_TEST THYROID_TEST LIVER_FUNCTION_TEST KIDNEY_FUNCTION_TEST URIC_ACID_TEST VITAMIN_D_TEST VITAMIN_B12_TEST HEMOGRAM COMPLETE_BLOOD_COUNT BLOOD_GROUPING HEPATITIS_B_TEST HEPATITIS_C_TEST HIV_TEST MALARIA_TEST DENGUE_TEST TYPHOID_TEST COVID_19_ANTIBODY_TEST COVID_19_RAPID_ANTIGEN_TEST COVID_19_RT_PCR_TEST PREGNANCY_TEST ALLERGY_TEST GENETIC_TEST OTHER"`
+		TestTypes          []string  `json:"test_types" validate:"omitempty,max=10,dive,required"` // further tests booked with TestType under one payment
 		AppointmentDate    time.Time `json:"appointment_date" validate:"required"`
-		Amount             float64   `json:"amount" validate:"required"`
+		Amount             float64   `json:"amount" validate:"omitempty,gt=0"` // optional, must match the quoted total when provided
 		PreferredDoctor    string    `json:"preferred_doctor" validate:"omitempty,oneof=Male Female"`
 		PaymentProvider    string    `json:"payment_provider" validate:"oneof=PAYSTACK FLUTTERWAVE STRIPE MONNIFY"`
 		Notes              string    `json:"notes" validate:"max=500"`
+		UseWallet          bool      `json:"use_wallet"` // pay what the wallet balance covers, the rest through the provider
+		PayAtCentre        bool      `json:"pay_at_centre" validate:"excluded_with=UseWallet"` // pay in cash or by transfer at the centre instead of online
+		PromoCode          string    `json:"promo_code" validate:"omitempty,max=32"`
+		PolicyID           string    `json:"policy_id" validate:"omitempty,uuid,excluded_with=PromoCode"` // insurance policy that covers the tests
+		AuthorizationCode  string    `json:"authorization_code" validate:"max=64"`                        // insurer pre-authorization, when the plan requires it
 	}
 	// CreatePaymentDTO represents the request body for creating a payment
 	ConfirmAppointmentDTO struct {
@@ -43,7 +59,7 @@ type (
 	}
 	// ListAppointmentsDTO represents the query parameters for listing appointments
 	ListAppointmentsDTO struct {
-		Status             AppointmentStatus `query:"status" validate:"omitempty,oneof=pending confirmed in_progress completed cancelled rescheduled"`
+		Status             AppointmentStatus `query:"status" validate:"omitempty,oneof=pending confirmed checked_in in_progress completed no_show cancelled rescheduled"`
 		FromDate           time.Time         `query:"from_date" validate:"omitempty"`
 		ToDate             time.Time         `query:"to_date" validate:"omitempty,gtefield=FromDate"`
 		PaginationQueryDTO
@@ -52,7 +68,7 @@ type (
 	// UpdateAppointmentDTO represents the request body for updating an appointment
 	UpdateAppointmentDTO struct {
 		AppointmentID string            `param:"appointment_id" validate:"required,uuid"`
-		Status        AppointmentStatus `json:"status" validate:"required,oneof=pending confirmed in_progress completed cancelled rescheduled"`
+		Status        AppointmentStatus `json:"status" validate:"required,oneof=pending confirmed checked_in in_progress completed no_show cancelled rescheduled"`
 		Notes         string            `json:"notes" validate:"max=500"`
 	}
 
@@ -60,17 +76,167 @@ type (
 	CancelAppointmentDTO struct {
 		AppointmentID string `param:"appointment_id" validate:"required,uuid"`
 		Reason        string `json:"reason" validate:"required,max=500"`
+		RefundTo      string `json:"refund_to" validate:"omitempty,oneof=provider wallet" example:"wallet"` // provider by default
+	}
+
+	// RefundStatus is the state of the refund issued when an appointment is cancelled
+	RefundStatus string
+
+	// AppointmentCancellation is the outcome of a cancellation: the fee kept and the refund issued
+	AppointmentCancellation struct {
+		Appointment       *db.Appointment      `json:"appointment"`
+		CancellationFee   float64              `json:"cancellation_fee" example:"2500"`
+		ReschedulingFees  float64              `json:"rescheduling_fees" example:"0"` // of earlier reschedules left unpaid, kept from the refund
+		RefundAmount      float64              `json:"refund_amount" example:"2600"`
+		RefundStatus      RefundStatus         `json:"refund_status" example:"pending"`
+		RefundDestination db.RefundDestination `json:"refund_destination,omitempty" example:"wallet"`
 	}
 
 	// RescheduleAppointmentDTO represents the request body for rescheduling an appointment
 	RescheduleAppointmentDTO struct {
 		AppointmentID    string    `param:"appointment_id" validate:"required,uuid"`
-		NewScheduleID    string    `json:"new_schedule_id" validate:"required,uuid"`
-		NewDate          time.Time `json:"new_date" validate:"required"`
-		NewTimeSlot      string    `json:"new_time_slot" validate:"required"`
+		NewDate          time.Time `json:"new_date" validate:"required"` // must be the start of a free slot
 		RescheduleReason string    `json:"reschedule_reason" validate:"required,max=500"`
+		UseWallet        bool      `json:"use_wallet"` // pay the rescheduling fee from the wallet balance first
 	}
 	GetNotificationsDTO struct {
 		PaginationQueryDTO
 	}
+
+	// GetAppointmentQuoteDTO represents the query for pricing an appointment before booking
+	GetAppointmentQuoteDTO struct {
+		DiagnosticCentreID string   `query:"diagnostic_centre_id" validate:"required,uuid"`
+		TestType           string   `query:"test_type" validate:"required"`
+		TestTypes          []string `query:"test_types" validate:"omitempty,max=10,dive,required"`
+		PromoCode          string   `query:"promo_code" validate:"omitempty,max=32"`
+		PolicyID           string   `query:"policy_id" validate:"omitempty,uuid,excluded_with=PromoCode"`
+	}
+
+	// QuoteFee is a single fee charged on top of the test price
+	QuoteFee struct {
+		Name   string  `json:"name" example:"booking_fee"`
+		Amount float64 `json:"amount" example:"100"`
+	}
+
+	// QuoteItem is the price of one test booked under an appointment
+	QuoteItem struct {
+		TestType    string  `json:"test_type" example:"LIPID_PROFILE"`
+		TestPriceID string  `json:"test_price_id"`
+		Price       float64 `json:"price" example:"5000"`
+	}
+
+	// AppointmentQuote is the server-side price of an appointment. When several tests
+	// are booked together Price is the sum of the items and TestType is the first test
+	AppointmentQuote struct {
+		DiagnosticCentreID string         `json:"diagnostic_centre_id"`
+		TestType           string         `json:"test_type" example:"BLOOD_TEST"`
+		TestPriceID        string         `json:"test_price_id"`
+		Price              float64        `json:"price" example:"5000"`
+		Currency           string         `json:"currency" example:"NGN"`
+		Items              []QuoteItem    `json:"items"`
+		Fees               []QuoteFee     `json:"fees"`
+		Discount           *QuoteDiscount `json:"discount,omitempty"`
+		Coverage           *QuoteCoverage `json:"coverage,omitempty"`
+		Total              float64        `json:"total" example:"5100"`
+	}
+
+	// QuoteDiscount is a promo code applied to the tests of an appointment; it is
+	// taken off the total and never off the fees
+	QuoteDiscount struct {
+		Code        string  `json:"code" example:"OCTLIPID20"`
+		Description string  `json:"description,omitempty"`
+		Amount      float64 `json:"amount" example:"1000"`
+	}
+
+	// ListCentreAppointmentsDTO represents the query for a centre's appointments on a day
+	ListCentreAppointmentsDTO struct {
+		DiagnosticCentreID string            `param:"diagnostic_centre_id" validate:"required,uuid"`
+		Date               string            `query:"date" validate:"omitempty,datetime=2006-01-02"`
+		Status             AppointmentStatus `query:"status" validate:"omitempty,oneof=pending confirmed checked_in in_progress completed no_show cancelled rescheduled"`
+	}
+
+	// AppointmentTransitionDTO identifies the appointment a centre is moving to its next status
+	AppointmentTransitionDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		AppointmentID      string `param:"appointment_id" validate:"required,uuid"`
+	}
+
+	// JoinWaitlistDTO puts a patient on a centre's waitlist for a test within a date window
+	JoinWaitlistDTO struct {
+		DiagnosticCentreID string `json:"diagnostic_centre_id" validate:"required,uuid"`
+		TestType           string `json:"test_type" validate:"required" example:"BLOOD_TEST"`
+		DateFrom           string `json:"date_from" validate:"required,datetime=2006-01-02" example:"2025-09-01"`
+		DateTo             string `json:"date_to" validate:"required,datetime=2006-01-02" example:"2025-09-07"`
+	}
+
+	// ListWaitlistDTO represents the query for the caller's waitlist entries
+	ListWaitlistDTO struct {
+		PaginationQueryDTO
+	}
+
+	// LeaveWaitlistDTO identifies the waitlist entry a patient is leaving
+	LeaveWaitlistDTO struct {
+		WaitlistID string `param:"waitlist_id" validate:"required,uuid"`
+	}
+
+	// CreateAppointmentSeriesDTO books a recurring series of appointments for the same test.
+	// Recurring rules start at StartDate and book Occurrences appointments; the dates rule books Dates
+	CreateAppointmentSeriesDTO struct {
+		DiagnosticCentreID string      `json:"diagnostic_centre_id" validate:"required,uuid"`
+		TestType           string      `json:"test_type" validate:"required" example:"BLOOD_SUGAR_TEST"`
+		Recurrence         string      `json:"recurrence" validate:"required,oneof=weekly every_n_weeks monthly dates"`
+		StartDate          time.Time   `json:"start_date"` // must be the start of a free slot
+		IntervalWeeks      int         `json:"interval_weeks" validate:"omitempty,min=2,max=12" example:"4"`
+		Occurrences        int         `json:"occurrences" validate:"omitempty,min=2,max=12" example:"6"`
+		Dates              []time.Time `json:"dates" validate:"omitempty,max=12"`
+		PaymentMode        string      `json:"payment_mode" validate:"required,oneof=upfront per_visit"`
+		PreferredDoctor    string      `json:"preferred_doctor" validate:"omitempty,oneof=Male Female"`
+		Notes              string      `json:"notes" validate:"max=500"`
+	}
+
+	// GetAppointmentSeriesDTO identifies an appointment series
+	GetAppointmentSeriesDTO struct {
+		SeriesID string `param:"series_id" validate:"required,uuid"`
+	}
+
+	// CancelAppointmentSeriesDTO represents the request body for cancelling a whole series
+	CancelAppointmentSeriesDTO struct {
+		SeriesID string `param:"series_id" validate:"required,uuid"`
+		Reason   string `json:"reason" validate:"required,max=500"`
+		RefundTo string `json:"refund_to" validate:"omitempty,oneof=provider wallet" example:"wallet"` // provider by default
+	}
+
+	// AppointmentSeriesDetail is a series with its appointments in occurrence order
+	AppointmentSeriesDetail struct {
+		Series       *db.AppointmentSeries `json:"series"`
+		Appointments []*db.Appointment     `json:"appointments"`
+	}
+
+	// AppointmentSeriesCancellation is the outcome of cancelling every remaining occurrence of a series
+	AppointmentSeriesCancellation struct {
+		Series          *db.AppointmentSeries      `json:"series"`
+		Occurrences     []*AppointmentCancellation `json:"occurrences"`
+		CancellationFee float64                    `json:"cancellation_fee" example:"0"`
+		RefundAmount    float64                    `json:"refund_amount" example:"30600"`
+	}
+
+	// InitializeAppointmentPaymentDTO identifies a pending appointment to be paid on its own
+	InitializeAppointmentPaymentDTO struct {
+		AppointmentID string `param:"appointment_id" validate:"required,uuid"`
+	}
+
+	// AppointmentLineItem is a test booked under an appointment with the medical records
+	// attached to its result
+	AppointmentLineItem struct {
+		*db.AppointmentLineItem
+		RecordIDs []string `json:"record_ids"`
+	}
+
+	// UpdateLineItemResultDTO moves the result of one test of an appointment on
+	UpdateLineItemResultDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		AppointmentID      string `param:"appointment_id" validate:"required,uuid"`
+		LineItemID         string `param:"line_item_id" validate:"required,uuid"`
+		ResultStatus       string `json:"result_status" validate:"required,oneof=processing ready" example:"processing"`
+	}
 )
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/diagnoxix/adapters/db"
 	"github.com/google/uuid"
 )
 
@@ -70,6 +71,50 @@ type (
 	UpdateManyAvailabilityDTO struct {
 		Slots []UpdateManyAvailabilitySlot `json:"slots" validate:"required,min=1,dive"`
 	}
+
+	// GetAvailableSlotsDTO represents the query for bookable slots of a diagnostic centre
+	GetAvailableSlotsDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		From               string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+		To                 string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+		TestType           string `query:"test_type" validate:"omitempty"`
+	}
+
+	// CreateAvailabilityExceptionDTO closes a centre for a day, or overrides its hours or capacity
+	CreateAvailabilityExceptionDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		Date               string `json:"date" validate:"required,datetime=2006-01-02" example:"2025-12-25"`
+		IsClosed           bool   `json:"is_closed" example:"false"`
+		StartTime          string `json:"start_time,omitempty" validate:"required_with=EndTime,omitempty,datetime=15:04" example:"10:00"`
+		EndTime            string `json:"end_time,omitempty" validate:"required_with=StartTime,omitempty,datetime=15:04" example:"14:00"`
+		MaxAppointments    *int32 `json:"max_appointments,omitempty" validate:"omitempty,min=1" example:"2"`
+		Reason             string `json:"reason" validate:"omitempty,max=255" example:"Equipment maintenance"`
+	}
+	ListAvailabilityExceptionsDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		From               string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+		To                 string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	}
+	AvailabilityExceptionParamDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		ExceptionID        string `param:"exception_id" validate:"required,uuid"`
+	}
+	// AvailabilityExceptionResponse is an exception together with the bookings it disrupted
+	AvailabilityExceptionResponse struct {
+		Exception            *db.DiagnosticCentreAvailabilityException `json:"exception"`
+		AffectedAppointments int                                       `json:"affected_appointments" example:"3"`
+	}
+
+	// AvailableSlot is a concrete bookable slot expanded from weekly availability
+	AvailableSlot struct {
+		Date      string    `json:"date" example:"2025-08-27"`
+		TimeSlot  string    `json:"time_slot" example:"09:00-09:30"`
+		StartTime time.Time `json:"start_time" example:"2025-08-27T09:00:00Z"`
+		EndTime   time.Time `json:"end_time" example:"2025-08-27T09:30:00Z"`
+		Capacity  int32     `json:"capacity" example:"4"`
+		Booked    int32     `json:"booked" example:"1"`
+		Remaining int32     `json:"remaining" example:"3"`
+	}
 )
 
 // UnmarshalJSON implements custom JSON unmarshaling for Slots
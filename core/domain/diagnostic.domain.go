@@ -46,6 +46,42 @@ type (
 		TestType string  `json:"test_type" validate:"required,oneof=BLOOD_TEST URINE_TEST X_RAY MRI CT_SCAN ULTRASOUND ECG EEG BIOPSY SKIN_TEST ALLERGY_TEST GENETIC_TEST IMMUNOLOGY_TEST HORMONE_TEST VIRAL_TEST BACTERIAL_TEST PARASITIC_TEST FUNGAL_TEST MOLECULAR_TEST TOXICOLOGY_TEST ECHO COVID_19_TEST OTHER BLOOD_SUGAR_TEST LIPID_PROFILE HEMOGLOBIN_TEST THYROID_TEST LIVER_FUNCTION_TEST KIDNEY_FUNCTION_TEST URIC_ACID_TEST VITAMIN_D_TEST VITAMIN_B12_TEST HEMOGRAM COMPLETE_BLOOD_COUNT BLOOD_GROUPING HEPATITIS_B_TEST HEPATITIS_C_TEST HIV_TEST MALARIA_TEST DENGUE_TEST TYPHOID_TEST COVID_19_ANTIBODY_TEST COVID_19_RAPID_ANTIGEN_TEST COVID_19_RT_PCR_TEST PREGNANCY_TEST"`
 		Price    float64 `json:"price" validate:"required,min=500.00"`
 	}
+	// CreateTestPriceDTO adds a test to a centre's price list, or reprices an existing one
+	CreateTestPriceDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		TestPrices
+		Currency string `json:"currency" validate:"omitempty,len=3"`
+	}
+	// UpdateTestPriceDTO updates the price, currency or status of a test price
+	UpdateTestPriceDTO struct {
+		DiagnosticCentreID string   `param:"diagnostic_centre_id" validate:"required,uuid"`
+		TestPriceID        string   `param:"test_price_id" validate:"required,uuid"`
+		Price              *float64 `json:"price,omitempty" validate:"omitempty,min=500.00"`
+		Currency           *string  `json:"currency,omitempty" validate:"omitempty,len=3"`
+		IsActive           *bool    `json:"is_active,omitempty"`
+	}
+	ListTestPricesDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		ActiveOnly         bool   `query:"active_only"`
+	}
+	TestPriceParamDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		TestPriceID        string `param:"test_price_id" validate:"required,uuid"`
+	}
+	// UpdateCancellationPolicyDTO sets how long before an appointment cancelling is free
+	// and the share of the payment kept on later cancellations
+	UpdateCancellationPolicyDTO struct {
+		DiagnosticCentreID         string  `param:"diagnostic_centre_id" validate:"required,uuid"`
+		FreeCancellationHours      int32   `json:"free_cancellation_hours" validate:"min=0,max=720" example:"24"`
+		LateCancellationFeePercent float64 `json:"late_cancellation_fee_percent" validate:"min=0,max=100" example:"50"`
+	}
+	// CancellationPolicy is the cancellation policy in force at a centre
+	CancellationPolicy struct {
+		DiagnosticCentreID         string  `json:"diagnostic_centre_id"`
+		FreeCancellationHours      int32   `json:"free_cancellation_hours" example:"24"`
+		LateCancellationFeePercent float64 `json:"late_cancellation_fee_percent" example:"50"`
+		IsDefault                  bool    `json:"is_default"`
+	}
 	GetDiagnosticParamDTO struct {
 		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
 	}
@@ -55,7 +91,9 @@ type (
 		Doctor    string  `query:"doctor"`
 		Test      string  `query:"test" validate:"omitempty,oneof=BLOOD_TEST URINE_TEST X_RAY MRI CT_SCAN ULTRASOUND ECG EEG BIOPSY SKIN_TEST ALLERGY_TEST GENETIC_TEST IMMUNOLOGY_TEST HORMONE_TEST VIRAL_TEST BACTERIAL_TEST PARASITIC_TEST FUNGAL_TEST MOLECULAR_TEST TOXICOLOGY_TEST ECHO COVID_19_TEST OTHER BLOOD_SUGAR_TEST LIPID_PROFILE HEMOGLOBIN_TEST THYROID_TEST LIVER_FUNCTION_TEST KIDNEY_FUNCTION_TEST URIC_ACID_TEST VITAMIN_D_TEST VITAMIN_B12_TEST HEMOGRAM COMPLETE_BLOOD_COUNT BLOOD_GROUPING HEPATITIS_B_TEST HEPATITIS_C_TEST HIV_TEST MALARIA_TEST DENGUE_TEST TYPHOID_TEST COVID_19_ANTIBODY_TEST COVID_19_RAPID_ANTIGEN_TEST COVID_19_RT_PCR_TEST PREGNANCY_TEST"`
 		// Get Availability
-		DayOfWeek string `query:"day_of_week" validate:"oneof=monday tuesday wednesday thursday friday saturday sunday"`
+		DayOfWeek string `query:"day_of_week" validate:"required_without=Date,omitempty,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+		// Date excludes centres closed that day; defaults to the next occurrence of DayOfWeek
+		Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
 		PaginationQueryDTO
 	}
 	UpdateDiagnosticParamDTO struct {
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type (
+	// ListOutboxEmailsDTO pages through queued email by delivery status, newest first
+	ListOutboxEmailsDTO struct {
+		Status string `query:"status" validate:"omitempty,oneof=pending sending sent dead" example:"dead"` // defaults to dead
+		PaginationQueryDTO
+	}
+
+	// ReplayOutboxEmailDTO identifies a dead-lettered email to send again
+	ReplayOutboxEmailDTO struct {
+		EmailID string `param:"email_id" validate:"required,uuid"`
+	}
+
+	// OutboxEmail is a queued email without its body and attachments
+	OutboxEmail struct {
+		ID            string     `json:"id"`
+		Recipient     string     `json:"recipient" example:"patient@example.com"`
+		Subject       string     `json:"subject" example:"Appointment Confirmed"`
+		Template      string     `json:"template" example:"appointment_confirmation"`
+		Status        string     `json:"status" example:"dead"`
+		Attempts      int32      `json:"attempts" example:"8"`
+		MaxAttempts   int32      `json:"max_attempts" example:"8"`
+		NextAttemptAt time.Time  `json:"next_attempt_at"`
+		LastError     string     `json:"last_error,omitempty" example:"dial tcp: connection refused"`
+		SentAt        *time.Time `json:"sent_at,omitempty"`
+		CreatedAt     time.Time  `json:"created_at"`
+	}
+)
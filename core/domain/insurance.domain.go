@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+type (
+	// CreateInsurerDTO registers an HMO or insurer that covers patients' tests
+	CreateInsurerDTO struct {
+		Name  string `json:"name" validate:"required,max=255" example:"Hygeia HMO"`
+		Code  string `json:"code" validate:"required,min=2,max=16,alphanum" example:"HYGEIA"` // prefixes the insurer's claim numbers
+		Email string `json:"email" validate:"omitempty,email" example:"claims@hygeiahmo.com"`
+		Phone string `json:"phone" validate:"omitempty,max=20" example:"+2348000000000"`
+	}
+
+	// ListInsurersDTO pages through the active insurers by name
+	ListInsurersDTO struct {
+		PaginationQueryDTO
+	}
+
+	// CreateInsurancePlanDTO adds a plan to an insurer
+	CreateInsurancePlanDTO struct {
+		InsurerID string `param:"insurer_id" validate:"required,uuid"`
+		Name      string `json:"name" validate:"required,max=255" example:"Prestige"`
+		Code      string `json:"code" validate:"required,min=2,max=32,alphanum" example:"PRESTIGE"`
+		Currency  string `json:"currency" validate:"omitempty,len=3,alpha" example:"NGN"` // defaults to NGN
+	}
+
+	// ListInsurancePlansDTO lists the active plans of an insurer
+	ListInsurancePlansDTO struct {
+		InsurerID string `param:"insurer_id" validate:"required,uuid"`
+	}
+
+	// SetCoverageRuleDTO sets how a plan covers one test type. The copay is taken off the
+	// test price before the covered percentage is applied.
+	SetCoverageRuleDTO struct {
+		PlanID                   string  `param:"plan_id" validate:"required,uuid"`
+		TestType                 string  `json:"test_type" validate:"required" example:"LIPID_PROFILE"`
+		CoveredPercent           float64 `json:"covered_percent" validate:"required,gt=0,lte=100" example:"80"`
+		Copay                    float64 `json:"copay" validate:"gte=0" example:"1000"`
+		RequiresPreauthorization bool    `json:"requires_preauthorization"`
+	}
+
+	// ListCoverageRulesDTO lists the coverage rules of a plan
+	ListCoverageRulesDTO struct {
+		PlanID string `param:"plan_id" validate:"required,uuid"`
+	}
+
+	// EnrolPolicyDTO records the current patient's membership of an insurance plan
+	EnrolPolicyDTO struct {
+		PlanID       string     `json:"plan_id" validate:"required,uuid"`
+		MemberNumber string     `json:"member_number" validate:"required,max=64" example:"HYG/12345/A"`
+		ValidFrom    *time.Time `json:"valid_from"` // defaults to now
+		ValidUntil   *time.Time `json:"valid_until"`
+	}
+
+	// ListPoliciesDTO lists the current patient's insurance policies
+	ListPoliciesDTO struct{}
+
+	// EndPolicyDTO identifies a policy of the current patient to end
+	EndPolicyDTO struct {
+		PolicyID string `param:"policy_id" validate:"required,uuid"`
+	}
+
+	// CoverageLine is the split of one test's price between the insurer and the patient
+	CoverageLine struct {
+		TestType       string  `json:"test_type" example:"LIPID_PROFILE"`
+		Price          float64 `json:"price" example:"10000"`
+		CoveredPercent float64 `json:"covered_percent" example:"80"`
+		Copay          float64 `json:"copay" example:"1000"`
+		InsurerAmount  float64 `json:"insurer_amount" example:"7200"`
+		PatientAmount  float64 `json:"patient_amount" example:"2800"`
+	}
+
+	// QuoteCoverage is an insurance policy applied to the tests of an appointment. The
+	// insurer's share is taken off the total and claimed from the insurer later; fees are
+	// always paid by the patient.
+	QuoteCoverage struct {
+		PolicyID                 string         `json:"policy_id"`
+		InsurerID                string         `json:"insurer_id"`
+		Insurer                  string         `json:"insurer" example:"Hygeia HMO"`
+		PlanID                   string         `json:"plan_id"`
+		Plan                     string         `json:"plan" example:"Prestige"`
+		MemberNumber             string         `json:"member_number" example:"HYG/12345/A"`
+		InsurerAmount            float64        `json:"insurer_amount" example:"7200"`
+		PatientAmount            float64        `json:"patient_amount" example:"2800"` // of the tests, before fees
+		PreauthorizationRequired bool           `json:"preauthorization_required"`
+		Lines                    []CoverageLine `json:"lines"`
+	}
+
+	// CreateInsuranceClaimDTO batches a centre's unclaimed, completed appointments covered
+	// by an insurer into a draft claim
+	CreateInsuranceClaimDTO struct {
+		DiagnosticCentreID string     `param:"diagnostic_centre_id" validate:"required,uuid"`
+		InsurerID          string     `json:"insurer_id" validate:"required,uuid"`
+		Currency           string     `json:"currency" validate:"omitempty,len=3,alpha" example:"NGN"` // defaults to NGN
+		Until              *time.Time `json:"until"`                                                   // appointments before this time, defaults to now
+	}
+
+	// ListInsuranceClaimsDTO pages through a centre's insurance claims, newest first
+	ListInsuranceClaimsDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		PaginationQueryDTO
+	}
+
+	// GetInsuranceClaimDTO identifies an insurance claim
+	GetInsuranceClaimDTO struct {
+		ClaimID string `param:"claim_id" validate:"required,uuid"`
+	}
+
+	// ExportInsuranceClaimDTO asks for an insurance claim as a file to send to the insurer
+	ExportInsuranceClaimDTO struct {
+		ClaimID string `param:"claim_id" validate:"required,uuid"`
+		Format  string `query:"format" validate:"omitempty,oneof=csv json" example:"csv"` // defaults to csv
+	}
+
+	// UpdateInsuranceClaimStatusDTO moves an insurance claim on. A claim is submitted to the
+	// insurer, then approved or rejected, and an approved claim is settled once paid.
+	UpdateInsuranceClaimStatusDTO struct {
+		ClaimID          string  `param:"claim_id" validate:"required,uuid"`
+		Status           string  `json:"status" validate:"required,oneof=submitted approved rejected settled" example:"approved"`
+		ApprovedAmount   float64 `json:"approved_amount" validate:"omitempty,gt=0" example:"72000"` // defaults to the claimed amount
+		InsurerReference string  `json:"insurer_reference" validate:"max=100"`
+		Notes            string  `json:"notes" validate:"max=500"`
+	}
+
+	// InsuranceClaimEntry is one covered appointment of an insurance claim
+	InsuranceClaimEntry struct {
+		AppointmentID        string         `json:"appointment_id"`
+		AppointmentDate      time.Time      `json:"appointment_date"`
+		PatientName          string         `json:"patient_name"`
+		MemberNumber         string         `json:"member_number"`
+		PreauthorizationCode string         `json:"preauthorization_code,omitempty"`
+		TotalAmount          float64        `json:"total_amount"`
+		InsurerAmount        float64        `json:"insurer_amount"`
+		PatientAmount        float64        `json:"patient_amount"`
+		Lines                []CoverageLine `json:"lines"`
+	}
+
+	// InsuranceClaimStatement is an insurance claim with the appointments it claims for
+	InsuranceClaimStatement struct {
+		Claim   *db.InsuranceClaim    `json:"claim"`
+		Insurer *db.Insurer           `json:"insurer"`
+		Entries []InsuranceClaimEntry `json:"entries"`
+	}
+)
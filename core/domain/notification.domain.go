@@ -1,11 +1,16 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrSMSUnavailable is wrapped by SMS gateway adapters when the gateway cannot be reached or
+// fails on its side
+var ErrSMSUnavailable = errors.New("sms gateway is unavailable")
+
 type NotificationType string
 
 const (
@@ -14,6 +19,15 @@ const (
 	AppointmentRescheduled NotificationType = "APPOINTMENT_RESCHEDULED"
 	AppointmentCancelled   NotificationType = "APPOINTMENT_CANCELLED"
 	AppointmentReminder    NotificationType = "APPOINTMENT_REMINDER"
+	AppointmentDisrupted   NotificationType = "APPOINTMENT_DISRUPTED"
+	AppointmentCheckedIn   NotificationType = "APPOINTMENT_CHECKED_IN"
+	AppointmentStarted     NotificationType = "APPOINTMENT_STARTED"
+	AppointmentCompleted   NotificationType = "APPOINTMENT_COMPLETED"
+	AppointmentNoShow      NotificationType = "APPOINTMENT_NO_SHOW"
+	WaitlistSlotOffered    NotificationType = "WAITLIST_SLOT_OFFERED"
+	LabResultReady         NotificationType = "LAB_RESULT_READY"
+	AIAnalysisComplete     NotificationType = "AI_ANALYSIS_COMPLETE"
+	SystemNotification     NotificationType = "SYSTEM_NOTIFICATION"
 )
 
 type (
@@ -28,7 +42,59 @@ type (
 		CreatedAt time.Time              `json:"created_at"`
 		Metadata  map[string]interface{} `json:"metadata,omitempty"`
 	}
+	// EmailAttachment is a file sent along with an email
+	EmailAttachment struct {
+		Filename    string `json:"filename"`
+		ContentType string `json:"content_type"`
+		Content     []byte `json:"content"`
+	}
 	MarkNotificationReadDTO struct {
 		NotificationID uuid.UUID `param:"notification_id" json:"notification_id" validate:"uuid,required"`
 	}
+
+	// GetNotificationPreferencesDTO lists the signed in user's notification preferences
+	GetNotificationPreferencesDTO struct{}
+
+	// UpdateNotificationPreferenceDTO sets the channels one type of notification goes out on
+	UpdateNotificationPreferenceDTO struct {
+		Type      string `json:"type" validate:"required" example:"APPOINTMENT_REMINDER"`
+		Email     *bool  `json:"email" validate:"required" example:"true"`
+		SMS       *bool  `json:"sms" validate:"required" example:"true"`
+		InApp     *bool  `json:"in_app" validate:"required" example:"true"`
+		WebSocket *bool  `json:"websocket" validate:"required" example:"false"`
+	}
+
+	// SetQuietHoursDTO sets the hours when nothing is sent by SMS or pushed over WebSocket
+	SetQuietHoursDTO struct {
+		Start    string `json:"start" validate:"required" example:"22:00"`
+		End      string `json:"end" validate:"required" example:"07:00"`
+		Timezone string `json:"timezone" validate:"omitempty,timezone" example:"Africa/Lagos"`
+	}
+
+	// ClearQuietHoursDTO removes the signed in user's quiet hours
+	ClearQuietHoursDTO struct{}
+
+	// NotificationPreference is the channels one type of notification goes out on
+	NotificationPreference struct {
+		Type      NotificationType `json:"type" example:"APPOINTMENT_REMINDER"`
+		Email     bool             `json:"email" example:"true"`
+		SMS       bool             `json:"sms" example:"false"`
+		InApp     bool             `json:"in_app" example:"true"`
+		WebSocket bool             `json:"websocket" example:"true"`
+		// Default is true when the user has not chosen channels for the type
+		Default bool `json:"default" example:"true"`
+	}
+
+	// QuietHours is a daily window when nothing is sent by SMS or pushed over WebSocket
+	QuietHours struct {
+		Start    string `json:"start" example:"22:00"`
+		End      string `json:"end" example:"07:00"`
+		Timezone string `json:"timezone" example:"Africa/Lagos"`
+	}
+
+	// NotificationPreferences is a user's channels for every type of notification
+	NotificationPreferences struct {
+		Preferences []NotificationPreference `json:"preferences"`
+		QuietHours  *QuietHours              `json:"quiet_hours,omitempty"`
+	}
 )
@@ -51,20 +51,32 @@ type (
 		PaginationQueryDTO
 	}
 
-	// RefundPaymentDTO represents the request body for refunding a payment
+	// RefundPaymentDTO represents the request body for refunding part or all of a payment;
+	// the refund is attributed to the user making the request
 	RefundPaymentDTO struct {
 		PaymentID    string  `param:"payment_id" validate:"required,uuid"`
 		RefundAmount float64 `json:"refund_amount" validate:"required,gt=0"`
 		RefundReason string  `json:"refund_reason" validate:"required,max=500"`
-		RefundedBy   string  `json:"refunded_by"`
+		RefundTo     string  `json:"refund_to" validate:"omitempty,oneof=provider wallet" example:"provider"` // provider by default
 	}
 
-	// PaymentWebhookDTO represents the request body for payment webhook
-	PaymentWebhookDTO struct {
-		PaymentID     string           `json:"payment_id" validate:"required"`
-		Status        db.PaymentStatus `json:"status" validate:"required,oneof=pending success failed refunded cancelled"`
-		TransactionID string           `json:"transaction_id" validate:"required"`
-		Metadata      interface{}      `json:"metadata,omitempty"`
+	// RecordCentrePaymentDTO records a pay-at-centre payment the centre has received
+	RecordCentrePaymentDTO struct {
+		PaymentID     string `param:"payment_id" validate:"required,uuid"`
+		PaymentMethod string `json:"payment_method" validate:"required,oneof=cash transfer" example:"cash"`
+		ReceiptNumber string `json:"receipt_number" validate:"required,max=64" example:"RCT-000123"`
+	}
+
+	// CentrePaymentReceipt is a pay-at-centre payment once received, with the appointments it confirmed
+	CentrePaymentReceipt struct {
+		Payment      *db.Payment       `json:"payment"`
+		Appointments []*db.Appointment `json:"appointments"`
+	}
+
+	// PaymentTimeline is a payment together with every event recorded against it
+	PaymentTimeline struct {
+		Payment *db.Payment        `json:"payment"`
+		Events  []*db.PaymentEvent `json:"events"`
 	}
 
 	VerifyPaymentDTO struct {
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+// ErrProviderUnavailable is wrapped by payment provider adapters when the provider cannot be
+// reached or fails on its side, so callers can fall back to another provider
+var ErrProviderUnavailable = errors.New("payment provider is unavailable")
+
+const (
+	TransactionStatusSuccess TransactionStatus = "success"
+	TransactionStatusPending TransactionStatus = "pending"
+	TransactionStatusFailed  TransactionStatus = "failed"
+)
+
+type (
+	// TransactionStatus is the outcome of a transaction as reported by its provider
+	TransactionStatus string
+
+	// TransactionRequest asks a payment provider to start collecting a payment; amounts
+	// are in major currency units
+	TransactionRequest struct {
+		Email     string
+		Reference string
+		Amount    float64
+		Currency  string
+		Metadata  map[string]interface{}
+	}
+
+	// TransactionCheckout tells the patient where to complete a payment
+	TransactionCheckout struct {
+		Provider         db.PaymentProvider `json:"provider"`
+		Reference        string             `json:"reference"`
+		AuthorizationURL string             `json:"authorization_url"`
+		AccessCode       string             `json:"access_code,omitempty"`
+	}
+
+	// TransactionVerification is a provider's account of a transaction; amounts are in
+	// major currency units
+	TransactionVerification struct {
+		Provider      db.PaymentProvider     `json:"provider"`
+		TransactionID string                 `json:"transaction_id"`
+		Reference     string                 `json:"reference"`
+		Status        TransactionStatus      `json:"status"`
+		Amount        float64                `json:"amount"`
+		Currency      string                 `json:"currency"`
+		Channel       string                 `json:"channel"`
+		PaidAt        time.Time              `json:"paid_at"`
+		Customer      TransactionCustomer    `json:"customer"`
+		Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	}
+
+	// TransactionCustomer is the payer of a transaction
+	TransactionCustomer struct {
+		Email     string `json:"email"`
+		FirstName string `json:"first_name,omitempty"`
+		LastName  string `json:"last_name,omitempty"`
+		Phone     string `json:"phone,omitempty"`
+	}
+
+	// TransactionRefund is a refund accepted by a provider; amounts are in major currency units
+	TransactionRefund struct {
+		Provider db.PaymentProvider `json:"provider"`
+		RefundID string             `json:"refund_id"`
+		Status   RefundStatus       `json:"status"`
+		Amount   float64            `json:"amount"`
+		Currency string             `json:"currency"`
+	}
+
+	// UpdatePaymentProviderDTO picks the provider a centre collects payments with
+	UpdatePaymentProviderDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		PaymentProvider    string `json:"payment_provider" validate:"required,oneof=PAYSTACK FLUTTERWAVE STRIPE MONNIFY" example:"PAYSTACK"`
+		AllowFallback      bool   `json:"allow_fallback" example:"true"`
+	}
+
+	// PaymentProviderSetting is the provider a centre collects payments with. When fallback
+	// is allowed, payments start with the next available provider while the chosen one is down.
+	PaymentProviderSetting struct {
+		DiagnosticCentreID string               `json:"diagnostic_centre_id"`
+		PaymentProvider    db.PaymentProvider   `json:"payment_provider" example:"PAYSTACK"`
+		AllowFallback      bool                 `json:"allow_fallback"`
+		IsDefault          bool                 `json:"is_default"`
+		Available          []db.PaymentProvider `json:"available_providers"`
+	}
+)
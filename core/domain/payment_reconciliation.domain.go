@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+type (
+	// GetPaymentDiscrepancyReportDTO selects the day a discrepancy report covers, yesterday when unset
+	GetPaymentDiscrepancyReportDTO struct {
+		Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+	}
+
+	// PaymentDiscrepancyReport lists the differences between provider transactions and
+	// payments the reconciliation job found on a day
+	PaymentDiscrepancyReport struct {
+		Date          time.Time                         `json:"date"`
+		Total         int                               `json:"total"`
+		Unresolved    int                               `json:"unresolved"`
+		ByKind        map[db.PaymentDiscrepancyKind]int `json:"by_kind"`
+		Discrepancies []*db.PaymentDiscrepancy          `json:"discrepancies"`
+	}
+)
@@ -0,0 +1,53 @@
+package domain
+
+import "encoding/json"
+
+// Paystack webhook events handled by the payment service
+const (
+	PaystackEventChargeSuccess    = "charge.success"
+	PaystackEventRefundProcessed  = "refund.processed"
+	PaystackEventRefundFailed     = "refund.failed"
+	PaystackEventTransferSuccess  = "transfer.success"
+	PaystackEventTransferFailed   = "transfer.failed"
+	PaystackEventTransferReversed = "transfer.reversed"
+)
+
+// PaystackWebhookDTO is the envelope Paystack posts for every webhook event
+type PaystackWebhookDTO struct {
+	Event string          `json:"event" validate:"required"`
+	Data  json.RawMessage `json:"data" validate:"required" swaggertype:"object"`
+}
+
+// PaystackChargeEvent is the data of a charge.success event; amounts are in kobo
+type PaystackChargeEvent struct {
+	ID              int64  `json:"id"`
+	Status          string `json:"status"`
+	Reference       string `json:"reference"`
+	Amount          int64  `json:"amount"`
+	Currency        string `json:"currency"`
+	Channel         string `json:"channel"`
+	GatewayResponse string `json:"gateway_response"`
+	PaidAt          string `json:"paid_at"`
+}
+
+// PaystackRefundEvent is the data of a refund.* event; amounts are in kobo
+type PaystackRefundEvent struct {
+	ID                   int64  `json:"id"`
+	Status               string `json:"status"`
+	TransactionReference string `json:"transaction_reference"`
+	RefundReference      string `json:"refund_reference"`
+	Amount               int64  `json:"amount"`
+	Currency             string `json:"currency"`
+	MerchantNote         string `json:"merchant_note"`
+}
+
+// PaystackTransferEvent is the data of a transfer.* event; amounts are in kobo
+type PaystackTransferEvent struct {
+	ID           int64  `json:"id"`
+	Status       string `json:"status"`
+	Reference    string `json:"reference"`
+	TransferCode string `json:"transfer_code"`
+	Amount       int64  `json:"amount"`
+	Currency     string `json:"currency"`
+	Reason       string `json:"reason"`
+}
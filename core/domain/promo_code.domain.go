@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type (
+	// CreatePromoCodeDTO creates a promo code. Empty centre and test type lists allow any
+	// centre or test; centre owners must restrict their codes to centres they own.
+	CreatePromoCodeDTO struct {
+		Code                  string     `json:"code" validate:"required,min=3,max=32,alphanum" example:"OCTLIPID20"`
+		Description           string     `json:"description" validate:"max=255" example:"20% off lipid profiles in October"`
+		DiscountType          string     `json:"discount_type" validate:"required,oneof=percentage fixed" example:"percentage"`
+		DiscountValue         float64    `json:"discount_value" validate:"required,gt=0" example:"20"`
+		MaxDiscount           float64    `json:"max_discount" validate:"omitempty,gt=0" example:"5000"` // caps a percentage discount
+		Currency              string     `json:"currency" validate:"omitempty,len=3,alpha" example:"NGN"`
+		DiagnosticCentreIDs   []string   `json:"diagnostic_centre_ids" validate:"omitempty,max=50,dive,uuid"`
+		TestTypes             []string   `json:"test_types" validate:"omitempty,max=50,dive,required"`
+		FirstBookingOnly      bool       `json:"first_booking_only"`
+		StartsAt              *time.Time `json:"starts_at"` // defaults to now
+		EndsAt                *time.Time `json:"ends_at"`
+		MaxRedemptions        int32      `json:"max_redemptions" validate:"omitempty,gt=0" example:"500"`
+		MaxRedemptionsPerUser int32      `json:"max_redemptions_per_user" validate:"omitempty,gt=0" example:"1"` // defaults to 1
+	}
+
+	// ListPromoCodesDTO pages through the promo codes the current user created, newest first
+	ListPromoCodesDTO struct {
+		PaginationQueryDTO
+	}
+
+	// DeactivatePromoCodeDTO identifies a promo code to withdraw
+	DeactivatePromoCodeDTO struct {
+		PromoCodeID string `param:"promo_code_id" validate:"required,uuid"`
+	}
+)
@@ -28,6 +28,7 @@ type (
 		Specialty       string `json:"specialty"`
 		IsShared        bool   `json:"is_shared"`
 		SharedUntil     string `json:"shared_until" validate:"omitempty,datetime=2006-01-02"`
+		LineItemID      string `json:"line_item_id" form:"line_item_id" validate:"omitempty,uuid"` // test of the appointment this record reports on
 	}
 	GetMedicalRecordParamsDTO struct {
 		RecordID uuid.UUID `json:"record_id" validate:"required,uuid" param:"record_id"`
@@ -0,0 +1,73 @@
+package domain
+
+import "github.com/diagnoxix/adapters/db"
+
+const (
+	TransferStatusPending TransferStatus = "pending"
+	TransferStatusSuccess TransferStatus = "success"
+	TransferStatusFailed  TransferStatus = "failed"
+)
+
+type (
+	// TransferStatus is the outcome of a payout transfer as reported by its provider
+	TransferStatus string
+
+	// PayoutRecipientRequest registers a bank account to receive payouts
+	PayoutRecipientRequest struct {
+		Name          string
+		AccountNumber string
+		BankCode      string
+		Currency      string
+	}
+
+	// PayoutRecipient is a bank account registered with the payout provider
+	PayoutRecipient struct {
+		RecipientCode string `json:"recipient_code"`
+		AccountName   string `json:"account_name"`
+	}
+
+	// TransferRequest pays a registered recipient; amounts are in major currency units
+	TransferRequest struct {
+		Reference     string
+		RecipientCode string
+		Amount        float64
+		Currency      string
+		Reason        string
+	}
+
+	// Transfer is a payout accepted by the provider. Transfers usually complete
+	// asynchronously and are settled by webhook.
+	Transfer struct {
+		TransferCode string         `json:"transfer_code"`
+		Reference    string         `json:"reference"`
+		Status       TransferStatus `json:"status"`
+		Amount       float64        `json:"amount"`
+		Currency     string         `json:"currency"`
+	}
+
+	// UpdatePayoutAccountDTO sets the bank account a centre's settlements are paid into
+	UpdatePayoutAccountDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		BankCode           string `json:"bank_code" validate:"required,numeric,max=20" example:"058"`
+		AccountNumber      string `json:"account_number" validate:"required,numeric,min=6,max=20" example:"0123456789"`
+		AccountName        string `json:"account_name" validate:"required,max=255" example:"Lekki Diagnostics Ltd"`
+		Currency           string `json:"currency" validate:"omitempty,len=3,alpha" example:"NGN"`
+	}
+
+	// ListSettlementsDTO pages through a centre's settlement statements, newest first
+	ListSettlementsDTO struct {
+		DiagnosticCentreID string `param:"diagnostic_centre_id" validate:"required,uuid"`
+		PaginationQueryDTO
+	}
+
+	// GetSettlementDTO identifies a settlement statement
+	GetSettlementDTO struct {
+		SettlementID string `param:"settlement_id" validate:"required,uuid"`
+	}
+
+	// SettlementStatement is a settlement with the payments and refunds it settled
+	SettlementStatement struct {
+		Settlement *db.Settlement        `json:"settlement"`
+		Entries    []*db.SettlementEntry `json:"entries"`
+	}
+)
@@ -51,7 +51,11 @@ type (
 		UserType        db.UserEnum `json:"user_type" validate:"required,oneof=PATIENT DIAGNOSTIC_CENTRE_OWNER" example:"PATIENT"`
 	}
 	VerifyPhoneDTO struct {
-		Token       string `json:"token" validate:"required"`
+		Token       string `json:"token" validate:"required,len=6,numeric" example:"123456"`
+		PhoneNumber string `json:"phone_number" validate:"required,e164" example:"+23470311787767"`
+	}
+	// ResendPhoneVerificationDTO asks for a new code for a phone number not yet verified
+	ResendPhoneVerificationDTO struct {
 		PhoneNumber string `json:"phone_number" validate:"required,e164" example:"+23470311787767"`
 	}
 	DiagnosticCentreManagerRegisterDTO struct {
@@ -60,9 +64,12 @@ type (
 		FirstName string      `json:"first_name" validate:"gte=3,required"`
 		UserType  db.UserEnum `json:"user_type" validate:"required,oneof=DIAGNOSTIC_CENTRE_MANAGER" example:"DIAGNOSTIC_CENTRE_MANAGER"`
 	}
+	// UserSignInDTO signs in with an email address, or with the phone number of an account
+	// registered by phone once the number is verified
 	UserSignInDTO struct {
-		Email    string `json:"email" validate:"email,required" example:"example@diagnoxix.com"`
-		Password string `json:"password" validate:"gte=6,lte=20,required" example:"Diagnoxix12345"`
+		Email       string `json:"email" validate:"required_without=PhoneNumber,omitempty,email" example:"example@diagnoxix.com"`
+		PhoneNumber string `json:"phone_number" validate:"required_without=Email,omitempty,e164" example:"+23470311787767"`
+		Password    string `json:"password" validate:"gte=6,lte=20,required" example:"Diagnoxix12345"`
 	}
 	CurrentUserDTO struct {
 		UserID       uuid.UUID   `json:"user_id"`
@@ -0,0 +1,11 @@
+package domain
+
+type (
+	// GetWalletDTO requests the wallets of the current patient
+	GetWalletDTO struct{}
+
+	// ListWalletTransactionsDTO pages through the current patient's wallet transactions, newest first
+	ListWalletTransactionsDTO struct {
+		PaginationQueryDTO
+	}
+)
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/diagnoxix/core/utils"
+	"github.com/go-co-op/gocron"
+)
+
+// EmailOutboxJob delivers the email queued in the outbox and queues the payment receipts that
+// never made it there. The work itself lives with the services package and is passed in.
+type EmailOutboxJob struct {
+	deliver   func()
+	receipts  func()
+	scheduler *gocron.Scheduler
+}
+
+func NewEmailOutboxJob(deliver, receipts func()) *EmailOutboxJob {
+	return &EmailOutboxJob{
+		deliver:   deliver,
+		receipts:  receipts,
+		scheduler: gocron.NewScheduler(time.UTC),
+	}
+}
+
+func (job *EmailOutboxJob) Start() {
+	job.scheduler.SingletonModeAll()
+
+	// Email should go out promptly, so the outbox is polled every few seconds; a run
+	// that is still delivering is not started again
+	job.scheduler.Every(5).Seconds().Do(job.deliver)
+	job.scheduler.Every(5).Minutes().Do(job.receipts)
+	job.scheduler.StartAsync()
+
+	utils.Info("Email outbox job manager started")
+}
+
+func (job *EmailOutboxJob) Stop() {
+	job.scheduler.Stop()
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/diagnoxix/core/utils"
+	"github.com/go-co-op/gocron"
+)
+
+// ReconciliationJob keeps payments in step with the payment providers: it reconciles recent
+// provider transactions, expires appointments left unpaid and sends finance a daily report.
+// The work lives with the payment service in the services package and is passed in.
+type ReconciliationJob struct {
+	reconcile func()
+	expire    func()
+	report    func()
+	scheduler *gocron.Scheduler
+}
+
+func NewReconciliationJob(reconcile, expire, report func()) *ReconciliationJob {
+	return &ReconciliationJob{
+		reconcile: reconcile,
+		expire:    expire,
+		report:    report,
+		scheduler: gocron.NewScheduler(time.UTC),
+	}
+}
+
+func (job *ReconciliationJob) Start() {
+	// Provider calls can outlast the interval, so a run is never started over one still going
+	job.scheduler.SingletonModeAll()
+
+	job.scheduler.Every(15).Minutes().Do(job.reconcile)
+	job.scheduler.Every(5).Minutes().Do(job.expire)
+	// The report covers the previous UTC day
+	job.scheduler.Every(1).Day().At("06:00").Do(job.report)
+	job.scheduler.StartAsync()
+
+	utils.Info("Reconciliation job manager started")
+}
+
+func (job *ReconciliationJob) Stop() {
+	job.scheduler.Stop()
+}
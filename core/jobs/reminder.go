@@ -3,29 +3,27 @@ package jobs
 import (
 	"time"
 
+	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/core/ports"
 	"github.com/go-co-op/gocron"
 )
 
+// ReminderJob reminds patients of their confirmed appointments a day ahead. The reminder
+// itself is sent by the services package, on the channels the patient chose, and is
+// passed in.
 type ReminderJob struct {
 	appointmentRepo ports.AppointmentRepository
-	diagnosticRepo  ports.DiagnosticRepository
-	notificationSvc ports.NotificationService
-	userRepo        ports.UserRepository
+	remind          func(*db.GetUpComingAppointmentsRow) error
 	scheduler       *gocron.Scheduler
 }
 
 func NewReminderJob(
 	appointmentRepo ports.AppointmentRepository,
-	notificationSvc ports.NotificationService,
-	userRepo ports.UserRepository,
-	diagnosticRepo ports.DiagnosticRepository,
+	remind func(*db.GetUpComingAppointmentsRow) error,
 ) *ReminderJob {
 	return &ReminderJob{
 		appointmentRepo: appointmentRepo,
-		notificationSvc: notificationSvc,
-		userRepo:        userRepo,
-		diagnosticRepo:  diagnosticRepo,
+		remind:          remind,
 		scheduler:       gocron.NewScheduler(time.UTC),
 	}
 }
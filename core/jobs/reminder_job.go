@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/diagnoxix/adapters/db"
-	"github.com/diagnoxix/adapters/external/templates/emails"
 	"github.com/diagnoxix/core/utils"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -44,9 +43,9 @@ func (job *ReminderJob) sendAppointmentReminders() {
 		if appointment.ReminderSent.Valid {
 			continue
 		}           
-		// Send reminder email
-		if err := job.SendReminderEmail(appointment); err != nil {
-			utils.Error("Failed to send reminder email",
+		// Send reminder
+		if err := job.remind(appointment); err != nil {
+			utils.Error("Failed to send reminder",
 				utils.LogField{Key: "error", Value: err.Error()},
 				utils.LogField{Key: "appointment_id", Value: appointment.ID})
 			continue
@@ -62,21 +61,3 @@ func (job *ReminderJob) sendAppointmentReminders() {
 
 	utils.Info("Finished reminder job for the next 1 hour...")
 }
-
-func (job *ReminderJob) SendReminderEmail(param *db.GetUpComingAppointmentsRow) error {
-
-	
-	data := emails.AppointmentEmailData{
-		EmailData: emails.EmailData{
-			AppName: "Diagnoxix",
-		},
-		PatientName:     param.FullName.String,
-		AppointmentID:   param.ID,
-		AppointmentDate: param.AppointmentDate.Time,
-		TimeSlot:        param.TimeSlot,
-		CentreName:      param.DiagnosticCentreName.String,
-		Status:          string(param.Status),
-	}
-
-	return job.notificationSvc.SendEmail(param.Email.String, "Appointment Reminder", emails.TemplateAppointmentReminder, data)
-}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/diagnoxix/core/utils"
+	"github.com/go-co-op/gocron"
+)
+
+// SettlementJob closes the settlement statements of diagnostic centres and pays them out.
+// The settlement itself lives with the payment service in the services package and is passed in.
+type SettlementJob struct {
+	settle    func()
+	scheduler *gocron.Scheduler
+}
+
+func NewSettlementJob(settle func()) *SettlementJob {
+	return &SettlementJob{
+		settle:    settle,
+		scheduler: gocron.NewScheduler(time.UTC),
+	}
+}
+
+func (job *SettlementJob) Start() {
+	job.scheduler.SingletonModeAll()
+
+	// Periods close weekly, so a daily run picks each one up the morning it ends and
+	// retries payouts that have not gone through
+	job.scheduler.Every(1).Day().At("02:00").Do(job.settle)
+	job.scheduler.StartAsync()
+
+	utils.Info("Settlement job manager started")
+}
+
+func (job *SettlementJob) Stop() {
+	job.scheduler.Stop()
+}
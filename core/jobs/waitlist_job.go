@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/diagnoxix/core/utils"
+	"github.com/go-co-op/gocron"
+)
+
+// WaitlistJob releases waitlist holds that were not booked in time. The release
+// itself lives with the slot engine in the services package and is passed in.
+type WaitlistJob struct {
+	release   func()
+	scheduler *gocron.Scheduler
+}
+
+func NewWaitlistJob(release func()) *WaitlistJob {
+	return &WaitlistJob{
+		release:   release,
+		scheduler: gocron.NewScheduler(time.UTC),
+	}
+}
+
+func (job *WaitlistJob) Start() {
+	// A slow run must not overlap the next one, or the same holds are released and
+	// re-offered twice
+	job.scheduler.SingletonModeAll()
+
+	// Holds last minutes, so lapsed ones are picked up every minute
+	job.scheduler.Every(1).Minute().Do(job.release)
+	job.scheduler.StartAsync()
+
+	utils.Info("Waitlist job manager started")
+}
+
+func (job *WaitlistJob) Stop() {
+	job.scheduler.Stop()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/diagnoxix/adapters/db"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // AppointmentRepository defines the interface for appointment data operations
@@ -35,21 +36,39 @@ type AppointmentRepository interface {
 		params db.GetPatientAppointmentsParams,
 	) ([]*db.GetPatientAppointmentsRow, error)
 
+	GetCentreAppointments(
+		ctx context.Context,
+		params db.GetCentreAppointmentsParams,
+	) ([]*db.GetCentreAppointmentsRow, error)
+
 	UpdateAppointment(
 		ctx context.Context,
 		params db.UpdateAppointmentPaymentParams,
 	) (*db.Appointment, error)
 
+	// UpdateAppointmentStatus moves an appointment from Status_2 to Status;
+	// it returns pgx.ErrNoRows when the appointment is no longer in Status_2
+	UpdateAppointmentStatus(
+		ctx context.Context,
+		params db.UpdateAppointmentStatusParams,
+	) (*db.Appointment, error)
+
+	// CancelAppointment returns pgx.ErrNoRows when the appointment is no longer pending or confirmed
 	CancelAppointment(
 		ctx context.Context,
-		id string,
-	) error
+		params db.CancelAppointmentParams,
+	) (*db.Appointment, error)
 
 	RescheduleAppointment(
 		ctx context.Context,
 		params db.RescheduleAppointmentParams,
 	) (*db.Appointment, error)
 
+	GetAppointmentRescheduleSummary(
+		ctx context.Context,
+		id string,
+	) (*db.GetAppointmentRescheduleSummaryRow, error)
+
 	MarkReminderSent(
 		ctx context.Context,
 		id string,
@@ -89,4 +108,261 @@ type AppointmentTx interface {
 		ctx context.Context,
 		update db.UpdateAppointmentPaymentParams,
 	) (*db.Appointment, error)
+
+	// CancelAppointment returns pgx.ErrNoRows when the appointment is no longer pending or confirmed
+	CancelAppointment(
+		ctx context.Context,
+		params db.CancelAppointmentParams,
+	) (*db.Appointment, error)
+
+	// RescheduleAppointment returns pgx.ErrNoRows when the appointment is no longer pending or confirmed
+	RescheduleAppointment(
+		ctx context.Context,
+		params db.RescheduleAppointmentParams,
+	) (*db.Appointment, error)
+
+	CreateAppointmentSeries(
+		ctx context.Context,
+		arg db.Create_Appointment_SeriesParams,
+	) (*db.AppointmentSeries, error)
+
+	// CancelAppointmentSeries returns pgx.ErrNoRows when the series is no longer active
+	CancelAppointmentSeries(
+		ctx context.Context,
+		arg db.Cancel_Appointment_SeriesParams,
+	) (*db.AppointmentSeries, error)
+
+	ListSeriesAppointments(
+		ctx context.Context,
+		seriesID string,
+	) ([]*db.Appointment, error)
+
+	AddSeriesOccurrence(
+		ctx context.Context,
+		arg db.Add_Series_OccurrenceParams,
+	) error
+
+	// MoveSeriesOccurrence points a series occurrence at the appointment that replaced it
+	MoveSeriesOccurrence(
+		ctx context.Context,
+		arg db.Move_Series_OccurrenceParams,
+	) error
+
+	CreateAppointmentLineItem(
+		ctx context.Context,
+		arg db.Create_Appointment_Line_ItemParams,
+	) (*db.AppointmentLineItem, error)
+
+	// MoveAppointmentLineItems carries the line items of a rescheduled appointment over to its replacement
+	MoveAppointmentLineItems(
+		ctx context.Context,
+		arg db.Move_Appointment_Line_ItemsParams,
+	) error
+
+	// ConfirmAppointmentsByPayment confirms every pending appointment settled by one payment
+	ConfirmAppointmentsByPayment(
+		ctx context.Context,
+		paymentID pgtype.UUID,
+	) ([]*db.Appointment, error)
+
+	// CreatePaymentEvent appends to a payment's ledger; a provider event that was already
+	// recorded yields pgx.ErrNoRows
+	CreatePaymentEvent(
+		ctx context.Context,
+		arg db.Create_Payment_EventParams,
+	) (*db.PaymentEvent, error)
+
+	// ProjectPaymentStatus applies a ledger event to the payment row when the payment is
+	// still in the status the event moved it from
+	ProjectPaymentStatus(
+		ctx context.Context,
+		arg db.Project_Payment_StatusParams,
+	) (*db.Payment, error)
+
+	// LockPayment reads a payment and holds it until the transaction ends, so refunds
+	// against it are checked one at a time
+	LockPayment(
+		ctx context.Context,
+		id string,
+	) (*db.Payment, error)
+
+	// RecordCentrePayment stores the receipt of a pay-at-centre payment that is still pending
+	RecordCentrePayment(
+		ctx context.Context,
+		arg db.Record_Centre_PaymentParams,
+	) (*db.Payment, error)
+
+	CreatePaymentRefund(
+		ctx context.Context,
+		arg db.Create_Payment_RefundParams,
+	) (*db.PaymentRefund, error)
+
+	// SumPaymentRefunds totals a payment's refunds that have not failed, those processed, and the
+	// provider refunds that have not failed
+	SumPaymentRefunds(
+		ctx context.Context,
+		paymentID string,
+	) (*db.Sum_Payment_RefundsRow, error)
+
+	// CreateAppointmentRefund links a refund to an appointment it pays back
+	CreateAppointmentRefund(
+		ctx context.Context,
+		arg db.Create_Appointment_RefundParams,
+	) error
+
+	// MarkRefundedAppointments marks the appointments a processed refund pays back as refunded
+	MarkRefundedAppointments(
+		ctx context.Context,
+		refundID string,
+	) error
+
+	// SettlePaymentRefund moves a pending refund on; a refund that is no longer pending
+	// yields pgx.ErrNoRows
+	SettlePaymentRefund(
+		ctx context.Context,
+		arg db.Settle_Payment_RefundParams,
+	) (*db.PaymentRefund, error)
+
+	// ProjectPaymentRefund records the total refunded on a payment that stays successful
+	ProjectPaymentRefund(
+		ctx context.Context,
+		arg db.Project_Payment_RefundParams,
+	) (*db.Payment, error)
+
+	// ExpireAppointmentsByPayment cancels the appointments still waiting on an unpaid payment
+	ExpireAppointmentsByPayment(
+		ctx context.Context,
+		arg db.Expire_Appointments_By_PaymentParams,
+	) ([]*db.Appointment, error)
+
+	// GetLatestSettlement finds a centre's most recent statement in a currency
+	GetLatestSettlement(
+		ctx context.Context,
+		arg db.Get_Latest_SettlementParams,
+	) (*db.Settlement, error)
+
+	// CreateSettlement opens a statement for a period; pgx.ErrNoRows means it is already closed
+	CreateSettlement(
+		ctx context.Context,
+		arg db.Create_SettlementParams,
+	) (*db.Settlement, error)
+
+	// AddSettlementPaymentEntries settles the centre's captured payments no statement has
+	// settled before the statement's period end
+	AddSettlementPaymentEntries(
+		ctx context.Context,
+		settlementID string,
+	) (int64, error)
+
+	// AddSettlementRefundEntries settles the centre's processed refunds no statement has
+	// settled before the statement's period end
+	AddSettlementRefundEntries(
+		ctx context.Context,
+		settlementID string,
+	) (int64, error)
+
+	// TotalSettlement totals a statement's entries and marks it payable or carried forward
+	TotalSettlement(
+		ctx context.Context,
+		settlementID string,
+	) (*db.Settlement, error)
+
+	// LockWallet reads a patient's wallet in a currency and holds it until the transaction ends
+	LockWallet(
+		ctx context.Context,
+		arg db.Lock_WalletParams,
+	) (*db.Wallet, error)
+
+	// CreditWallet adds to a patient's wallet, opening it on the first credit
+	CreditWallet(
+		ctx context.Context,
+		arg db.Credit_WalletParams,
+	) (*db.Wallet, error)
+
+	// DebitWallet takes from a wallet; pgx.ErrNoRows means the balance does not cover it
+	DebitWallet(
+		ctx context.Context,
+		arg db.Debit_WalletParams,
+	) (*db.Wallet, error)
+
+	CreateWalletTransaction(
+		ctx context.Context,
+		arg db.Create_Wallet_TransactionParams,
+	) (*db.WalletTransaction, error)
+
+	// NextInvoiceNumber takes the next number in a centre's invoice sequence
+	NextInvoiceNumber(
+		ctx context.Context,
+		diagnosticCentreID string,
+	) (int64, error)
+
+	// CreatePaymentInvoice issues a payment's invoice; pgx.ErrNoRows means it was already issued
+	CreatePaymentInvoice(
+		ctx context.Context,
+		arg db.Create_Payment_InvoiceParams,
+	) (*db.PaymentInvoice, error)
+
+	// ClaimPromoCode takes one use of a promo code and holds it until the transaction ends;
+	// pgx.ErrNoRows means the code is inactive or used up
+	ClaimPromoCode(
+		ctx context.Context,
+		id string,
+	) (*db.PromoCode, error)
+
+	CountUserPromoRedemptions(
+		ctx context.Context,
+		arg db.Count_User_Promo_RedemptionsParams,
+	) (int64, error)
+
+	CountPatientPayments(
+		ctx context.Context,
+		patientID string,
+	) (int64, error)
+
+	CreatePromoRedemption(
+		ctx context.Context,
+		arg db.Create_Promo_RedemptionParams,
+	) (*db.PromoRedemption, error)
+
+	// ReleasePromoRedemption gives back the promo code use of a payment that did not complete,
+	// returning 0 when there was none
+	ReleasePromoRedemption(
+		ctx context.Context,
+		paymentID string,
+	) (int64, error)
+
+	CreateAppointmentCoverage(
+		ctx context.Context,
+		arg db.Create_Appointment_CoverageParams,
+	) (*db.AppointmentCoverage, error)
+
+	CreateInsuranceClaim(
+		ctx context.Context,
+		arg db.Create_Insurance_ClaimParams,
+	) (*db.InsuranceClaim, error)
+
+	// AttachClaimCoverages moves an insurer's unclaimed, completed appointments at a
+	// centre into a claim
+	AttachClaimCoverages(
+		ctx context.Context,
+		arg db.Attach_Claim_CoveragesParams,
+	) ([]*db.AppointmentCoverage, error)
+
+	// TotalInsuranceClaim sums the coverages attached to a claim
+	TotalInsuranceClaim(
+		ctx context.Context,
+		id string,
+	) (*db.InsuranceClaim, error)
+
+	// EnqueueEmail queues an email with the rest of the transaction, so it goes out only
+	// if the transaction commits
+	EnqueueEmail(
+		ctx context.Context,
+		arg db.Enqueue_EmailParams,
+	) (*db.EmailOutbox, error)
+
+	MarkInvoiceEmailed(
+		ctx context.Context,
+		id string,
+	) (*db.PaymentInvoice, error)
 }
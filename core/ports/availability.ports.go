@@ -42,4 +42,25 @@ type AvailabilityRepository interface {
 		ctx context.Context,
 		params db.Create_Single_AvailabilityParams,
 	) (*db.DiagnosticCentreAvailability, error)
+
+	// Date-specific closures and overrides of the weekly availability
+	UpsertAvailabilityException(
+		ctx context.Context,
+		params db.Upsert_Availability_ExceptionParams,
+	) (*db.DiagnosticCentreAvailabilityException, error)
+
+	GetAvailabilityException(
+		ctx context.Context,
+		params db.Get_Availability_ExceptionParams,
+	) (*db.DiagnosticCentreAvailabilityException, error)
+
+	ListAvailabilityExceptions(
+		ctx context.Context,
+		params db.List_Availability_ExceptionsParams,
+	) ([]*db.DiagnosticCentreAvailabilityException, error)
+
+	DeleteAvailabilityException(
+		ctx context.Context,
+		params db.Delete_Availability_ExceptionParams,
+	) error
 }
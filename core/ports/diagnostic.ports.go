@@ -73,6 +73,26 @@ type DiagnosticRepository interface {
 		ctx context.Context,
 		arg db.UnassignAdminParams,
 	) (*db.DiagnosticCentre, error)
+
+	GetCancellationPolicy(
+		ctx context.Context,
+		diagnosticCentreID string,
+	) (*db.DiagnosticCentreCancellationPolicy, error)
+
+	UpsertCancellationPolicy(
+		ctx context.Context,
+		arg db.Upsert_Cancellation_PolicyParams,
+	) (*db.DiagnosticCentreCancellationPolicy, error)
+
+	GetPaymentSettings(
+		ctx context.Context,
+		diagnosticCentreID string,
+	) (*db.DiagnosticCentrePaymentSetting, error)
+
+	UpsertPaymentSettings(
+		ctx context.Context,
+		arg db.Upsert_Payment_SettingsParams,
+	) (*db.DiagnosticCentrePaymentSetting, error)
 }
 
 type TestPriceRepository interface {
@@ -80,6 +100,41 @@ type TestPriceRepository interface {
 		ctx context.Context,
 		test_price db.Create_Test_PriceParams,
 	) ([]*db.DiagnosticCentreTestPrice, error)
+
+	GetActiveTestPrice(
+		ctx context.Context,
+		arg db.Get_Active_Test_PriceParams,
+	) (*db.DiagnosticCentreTestPrice, error)
+
+	GetTestPrice(
+		ctx context.Context,
+		arg db.Get_Test_PriceParams,
+	) (*db.DiagnosticCentreTestPrice, error)
+
+	ListTestPrices(
+		ctx context.Context,
+		arg db.List_Test_PricesParams,
+	) ([]*db.DiagnosticCentreTestPrice, error)
+
+	UpsertTestPrice(
+		ctx context.Context,
+		arg db.Upsert_Test_PriceParams,
+	) (*db.DiagnosticCentreTestPrice, error)
+
+	UpdateTestPrice(
+		ctx context.Context,
+		arg db.Update_Test_PriceParams,
+	) (*db.DiagnosticCentreTestPrice, error)
+
+	ListTestPriceHistory(
+		ctx context.Context,
+		arg db.List_Test_Price_HistoryParams,
+	) ([]*db.DiagnosticCentreTestPriceHistory, error)
+
+	GetTestPriceAt(
+		ctx context.Context,
+		arg db.Get_Test_Price_AtParams,
+	) (*db.DiagnosticCentreTestPriceHistory, error)
 }
 
 type DiagnosticTx interface {
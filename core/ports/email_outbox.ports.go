@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+// EmailOutboxRepository stores outbound email until it is delivered
+type EmailOutboxRepository interface {
+	EnqueueEmail(
+		ctx context.Context,
+		arg db.Enqueue_EmailParams,
+	) (*db.EmailOutbox, error)
+
+	// ClaimOutboxEmails takes due messages, and messages whose worker lost them, for
+	// delivery and counts the attempt
+	ClaimOutboxEmails(
+		ctx context.Context,
+		arg db.Claim_Outbox_EmailsParams,
+	) ([]*db.EmailOutbox, error)
+
+	MarkEmailSent(
+		ctx context.Context,
+		id string,
+	) error
+
+	// RetryEmail puts a message that failed back in the queue for a later attempt
+	RetryEmail(
+		ctx context.Context,
+		arg db.Retry_EmailParams,
+	) error
+
+	// DeadLetterEmail gives up on a message that used all its attempts
+	DeadLetterEmail(
+		ctx context.Context,
+		arg db.Dead_Letter_EmailParams,
+	) error
+
+	ListOutboxEmails(
+		ctx context.Context,
+		arg db.List_Outbox_EmailsParams,
+	) ([]*db.EmailOutbox, error)
+
+	// ReplayEmail queues a dead-lettered message again with fresh attempts; pgx.ErrNoRows
+	// means there is no such dead-lettered message
+	ReplayEmail(
+		ctx context.Context,
+		id string,
+	) (*db.EmailOutbox, error)
+}
@@ -0,0 +1,103 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// InsuranceRepository defines the interface for HMO and insurance data operations.
+// Coverage is recorded and claims are batched within an AppointmentTx.
+type InsuranceRepository interface {
+	CreateInsurer(
+		ctx context.Context,
+		arg db.Create_InsurerParams,
+	) (*db.Insurer, error)
+
+	GetInsurer(
+		ctx context.Context,
+		id string,
+	) (*db.Insurer, error)
+
+	// ListInsurers pages through active insurers by name
+	ListInsurers(
+		ctx context.Context,
+		arg db.List_InsurersParams,
+	) ([]*db.Insurer, error)
+
+	CreateInsurancePlan(
+		ctx context.Context,
+		arg db.Create_Insurance_PlanParams,
+	) (*db.InsurancePlan, error)
+
+	GetInsurancePlan(
+		ctx context.Context,
+		id string,
+	) (*db.InsurancePlan, error)
+
+	ListInsurancePlans(
+		ctx context.Context,
+		insurerID string,
+	) ([]*db.InsurancePlan, error)
+
+	// UpsertCoverageRule sets how a plan covers one test type, replacing any earlier rule
+	UpsertCoverageRule(
+		ctx context.Context,
+		arg db.Upsert_Coverage_RuleParams,
+	) (*db.InsuranceCoverageRule, error)
+
+	ListCoverageRules(
+		ctx context.Context,
+		planID string,
+	) ([]*db.InsuranceCoverageRule, error)
+
+	CreatePatientPolicy(
+		ctx context.Context,
+		arg db.Create_Patient_PolicyParams,
+	) (*db.PatientPolicy, error)
+
+	// GetPatientPolicy only finds policies held by the given patient
+	GetPatientPolicy(
+		ctx context.Context,
+		arg db.Get_Patient_PolicyParams,
+	) (*db.PatientPolicy, error)
+
+	ListPatientPolicies(
+		ctx context.Context,
+		patientID string,
+	) ([]*db.PatientPolicy, error)
+
+	EndPatientPolicy(
+		ctx context.Context,
+		arg db.End_Patient_PolicyParams,
+	) (*db.PatientPolicy, error)
+
+	// ListPaymentCoverages returns the insurer's share of each appointment a payment covers
+	ListPaymentCoverages(
+		ctx context.Context,
+		paymentID pgtype.UUID,
+	) ([]*db.AppointmentCoverage, error)
+
+	GetInsuranceClaim(
+		ctx context.Context,
+		id string,
+	) (*db.InsuranceClaim, error)
+
+	ListInsuranceClaims(
+		ctx context.Context,
+		arg db.List_Insurance_ClaimsParams,
+	) ([]*db.InsuranceClaim, error)
+
+	ListClaimEntries(
+		ctx context.Context,
+		claimID pgtype.UUID,
+	) ([]*db.List_Claim_EntriesRow, error)
+
+	// UpdateInsuranceClaimStatus moves a claim on from the status in Status_2. It returns
+	// pgx.ErrNoRows when the claim has moved on in the meantime.
+	UpdateInsuranceClaimStatus(
+		ctx context.Context,
+		arg db.Update_Insurance_Claim_StatusParams,
+	) (*db.InsuranceClaim, error)
+}
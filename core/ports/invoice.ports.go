@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// InvoiceRepository defines the interface for reading payment invoices. Invoices are issued
+// within an AppointmentTx, so their numbers are taken together with the invoice.
+type InvoiceRepository interface {
+	GetPaymentInvoice(
+		ctx context.Context,
+		paymentID string,
+	) (*db.PaymentInvoice, error)
+
+	// MarkInvoiceEmailed claims an invoice for the confirmation email; pgx.ErrNoRows means it
+	// was already sent
+	MarkInvoiceEmailed(
+		ctx context.Context,
+		id string,
+	) (*db.PaymentInvoice, error)
+
+	// ListPaymentAppointments lists the appointments a payment paid for, earliest first
+	ListPaymentAppointments(
+		ctx context.Context,
+		paymentID pgtype.UUID,
+	) ([]*db.Appointment, error)
+}
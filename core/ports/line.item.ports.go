@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+// LineItemRepository defines the interface for the tests booked under an appointment
+type LineItemRepository interface {
+	GetAppointmentLineItem(
+		ctx context.Context,
+		id string,
+	) (*db.AppointmentLineItem, error)
+
+	ListAppointmentLineItems(
+		ctx context.Context,
+		appointmentID string,
+	) ([]*db.AppointmentLineItem, error)
+
+	ListAppointmentLineItemRecords(
+		ctx context.Context,
+		appointmentID string,
+	) ([]*db.AppointmentLineItemRecord, error)
+
+	// UpdateLineItemResultStatus moves a line item from ResultStatus_2 to ResultStatus;
+	// it returns pgx.ErrNoRows when the line item is no longer in ResultStatus_2
+	UpdateLineItemResultStatus(
+		ctx context.Context,
+		arg db.Update_Line_Item_Result_StatusParams,
+	) (*db.AppointmentLineItem, error)
+
+	AttachLineItemRecord(
+		ctx context.Context,
+		arg db.Attach_Line_Item_RecordParams,
+	) error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
 )
 // NotificationService is the interface that wraps notification operations
 type NotificationService interface {
@@ -14,6 +15,26 @@ type NotificationService interface {
 		templateName string,
 		data interface{},
 	) error
+	// SendEmailWithAttachments sends an email to a recipient with files attached
+	SendEmailWithAttachments(
+		to,
+		subject,
+		templateName string,
+		data interface{},
+		attachments []domain.EmailAttachment,
+	) error
+	// RenderEmail executes an email template into the HTML body of a message
+	RenderEmail(
+		templateName string,
+		data interface{},
+	) (string, error)
+	// SendHTMLEmail sends an already rendered HTML body, with any attachments
+	SendHTMLEmail(
+		to,
+		subject,
+		htmlContent string,
+		attachments []domain.EmailAttachment,
+	) error
 	// SendSMS sends an SMS message to a phone number
 	SendSMS(
 		phone,
@@ -41,4 +62,50 @@ type NotificationRepository interface {
 		ctx context.Context,
 		arg string,
 	) error
+
+	// ListNotificationPreferences returns the types a user has set channels for
+	ListNotificationPreferences(
+		ctx context.Context,
+		userID string,
+	) ([]*db.NotificationPreference, error)
+
+	// GetNotificationPreference returns the channels a user set for a type; pgx.ErrNoRows
+	// means the defaults apply
+	GetNotificationPreference(
+		ctx context.Context,
+		arg db.Get_Notification_PreferenceParams,
+	) (*db.NotificationPreference, error)
+
+	UpsertNotificationPreference(
+		ctx context.Context,
+		arg db.Upsert_Notification_PreferenceParams,
+	) (*db.NotificationPreference, error)
+
+	// GetNotificationQuietHours returns a user's quiet hours; pgx.ErrNoRows means none are set
+	GetNotificationQuietHours(
+		ctx context.Context,
+		userID string,
+	) (*db.NotificationQuietHour, error)
+
+	UpsertNotificationQuietHours(
+		ctx context.Context,
+		arg db.Upsert_Notification_Quiet_HoursParams,
+	) (*db.NotificationQuietHour, error)
+
+	DeleteNotificationQuietHours(
+		ctx context.Context,
+		userID string,
+	) error
+
+	// RecordNotificationDelivery stores the channels a notification went out on
+	RecordNotificationDelivery(
+		ctx context.Context,
+		arg db.Record_Notification_DeliveryParams,
+	) (*db.NotificationDelivery, error)
+
+	// EnqueueEmail queues the email of a notification sent outside a transaction
+	EnqueueEmail(
+		ctx context.Context,
+		arg db.Enqueue_EmailParams,
+	) (*db.EmailOutbox, error)
 }
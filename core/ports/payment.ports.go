@@ -2,9 +2,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/diagnoxix/adapters/db"
-	"github.com/diagnoxix/adapters/external/paystack"
+	"github.com/diagnoxix/core/domain"
 )
 
 // DBTX represents a database transaction interface
@@ -35,26 +36,118 @@ type PaymentRepository interface {
 		params db.List_PaymentsParams,
 	) ([]*db.Payment, error)
 
-	RefundPayment(
+	// ListPaymentEvents returns a payment's ledger, oldest event first
+	ListPaymentEvents(
 		ctx context.Context,
-		params db.Refund_PaymentParams,
-	) (*db.Payment, error)
+		paymentID string,
+	) ([]*db.PaymentEvent, error)
 
-	UpdatePaymentStatus(
+	// ListPaymentRefunds returns a payment's refunds, oldest first
+	ListPaymentRefunds(
 		ctx context.Context,
-		params db.Update_Payment_StatusParams,
-	) (*db.Payment, error)
+		paymentID string,
+	) ([]*db.PaymentRefund, error)
+
+	GetPaymentRefundByProviderID(
+		ctx context.Context,
+		arg db.Get_Payment_Refund_By_Provider_IDParams,
+	) (*db.PaymentRefund, error)
+
+	// GetUnmatchedPaymentRefund finds the oldest pending refund of an amount that has no
+	// provider refund ID yet, for provider events that arrive before the ID was stored
+	GetUnmatchedPaymentRefund(
+		ctx context.Context,
+		arg db.Get_Unmatched_Payment_RefundParams,
+	) (*db.PaymentRefund, error)
+
+	// ListPendingProviderRefunds lists refunds created before a cutoff that a provider has
+	// accepted but not yet settled, oldest first
+	ListPendingProviderRefunds(
+		ctx context.Context,
+		arg db.List_Pending_Provider_RefundsParams,
+	) ([]*db.PaymentRefund, error)
+
+	// ListUnsentProviderRefunds lists refunds opened before a cutoff whose request never
+	// reached the provider, oldest first
+	ListUnsentProviderRefunds(
+		ctx context.Context,
+		arg db.List_Unsent_Provider_RefundsParams,
+	) ([]*db.PaymentRefund, error)
+
+	// MarkPaymentRefundSent claims a pending refund for sending to its provider; a refund
+	// already sent yields pgx.ErrNoRows
+	MarkPaymentRefundSent(
+		ctx context.Context,
+		id string,
+	) (*db.PaymentRefund, error)
+
+	// ListPaymentsByReferences finds the payments made with a provider under any of the references
+	ListPaymentsByReferences(
+		ctx context.Context,
+		arg db.List_Payments_By_ReferencesParams,
+	) ([]*db.Payment, error)
+
+	// ListUnpaidAppointmentPayments lists pending payments created before a cutoff whose
+	// appointments are still waiting on them, oldest first
+	ListUnpaidAppointmentPayments(
+		ctx context.Context,
+		arg db.List_Unpaid_Appointment_PaymentsParams,
+	) ([]*db.Payment, error)
+
+	// ListExpiredCentrePayments lists pay-at-centre payments whose hold ended before a cutoff
+	// while their appointments still wait on them, oldest hold first
+	ListExpiredCentrePayments(
+		ctx context.Context,
+		arg db.List_Expired_Centre_PaymentsParams,
+	) ([]*db.Payment, error)
+
+	// ListUnreceiptedPayments lists successful payments last updated within a window whose
+	// receipt has not been emailed, oldest first
+	ListUnreceiptedPayments(
+		ctx context.Context,
+		arg db.List_Unreceipted_PaymentsParams,
+	) ([]*db.Payment, error)
+
+	UpsertPaymentDiscrepancy(
+		ctx context.Context,
+		arg db.Upsert_Payment_DiscrepancyParams,
+	) (*db.PaymentDiscrepancy, error)
+
+	ListPaymentDiscrepancies(
+		ctx context.Context,
+		arg db.List_Payment_DiscrepanciesParams,
+	) ([]*db.PaymentDiscrepancy, error)
 
 	BeginWith(ctx context.Context) (DBTX, error)
 }
 
-// PaymentProviderService defines the interface for payment provider operations
+// PaymentProviderService defines the interface for payment provider operations. Amounts are in
+// major currency units; adapters convert them to the provider's own units and wrap outages in
+// domain.ErrProviderUnavailable.
 type PaymentProviderService interface {
+	Provider() db.PaymentProvider
+
 	InitializeTransaction(
-		email,
+		request domain.TransactionRequest,
+	) (*domain.TransactionCheckout, error)
+
+	VerifyTransaction(reference string) (*domain.TransactionVerification, error)
+
+	// ListTransactions lists the transactions created at the provider between from and to
+	ListTransactions(
+		from time.Time,
+		to time.Time,
+	) ([]*domain.TransactionVerification, error)
+
+	RefundTransaction(
 		reference string,
 		amount float64,
-		metadata map[string]interface{},
-	) (*paystack.PaystackTransactionResponse, error)
-	VerifyTransaction(reference string) (*paystack.PaystackVerificationResponse, error)
+	) (*domain.TransactionRefund, error)
+}
+
+// RefundStatusService is implemented by payment providers that do not report refund
+// outcomes by webhook, so pending refunds can be settled by asking after them
+type RefundStatusService interface {
+	// GetRefund fetches a refund by the ID the provider gave it
+	GetRefund(refundID string) (*domain.TransactionRefund, error)
 }
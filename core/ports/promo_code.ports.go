@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+// PromoCodeRepository defines the interface for promo code data operations. Codes are
+// claimed and redeemed within an AppointmentTx, together with the payment they discount.
+type PromoCodeRepository interface {
+	CreatePromoCode(
+		ctx context.Context,
+		arg db.Create_Promo_CodeParams,
+	) (*db.PromoCode, error)
+
+	GetPromoCode(
+		ctx context.Context,
+		id string,
+	) (*db.PromoCode, error)
+
+	// GetPromoCodeByCode looks a code up by its upper-case text
+	GetPromoCodeByCode(
+		ctx context.Context,
+		code string,
+	) (*db.PromoCode, error)
+
+	ListPromoCodes(
+		ctx context.Context,
+		arg db.List_Promo_CodesParams,
+	) ([]*db.PromoCode, error)
+
+	DeactivatePromoCode(
+		ctx context.Context,
+		id string,
+	) (*db.PromoCode, error)
+
+	GetPaymentPromoRedemption(
+		ctx context.Context,
+		paymentID string,
+	) (*db.PromoRedemption, error)
+
+	CountUserPromoRedemptions(
+		ctx context.Context,
+		arg db.Count_User_Promo_RedemptionsParams,
+	) (int64, error)
+
+	// CountPatientPayments counts the payments a patient has made or still owes
+	CountPatientPayments(
+		ctx context.Context,
+		patientID string,
+	) (int64, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+// AppointmentSeriesRepository defines the interface for recurring appointment series data operations
+type AppointmentSeriesRepository interface {
+	GetAppointmentSeries(
+		ctx context.Context,
+		id string,
+	) (*db.AppointmentSeries, error)
+
+	// CancelAppointmentSeries returns pgx.ErrNoRows when the series is no longer active
+	CancelAppointmentSeries(
+		ctx context.Context,
+		arg db.Cancel_Appointment_SeriesParams,
+	) (*db.AppointmentSeries, error)
+
+	ListSeriesAppointments(
+		ctx context.Context,
+		seriesID string,
+	) ([]*db.Appointment, error)
+}
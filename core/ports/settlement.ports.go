@@ -0,0 +1,84 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SettlementRepository defines the interface for centre settlement data operations.
+// Statements are closed within an AppointmentTx.
+type SettlementRepository interface {
+	UpsertCentrePayoutAccount(
+		ctx context.Context,
+		arg db.Upsert_Centre_Payout_AccountParams,
+	) (*db.CentrePayoutAccount, error)
+
+	GetCentrePayoutAccount(
+		ctx context.Context,
+		diagnosticCentreID string,
+	) (*db.CentrePayoutAccount, error)
+
+	// ListUnsettledCentres lists the centres and currencies with captured payments or
+	// processed refunds before a cutoff that no statement has settled
+	ListUnsettledCentres(
+		ctx context.Context,
+		before pgtype.Timestamptz,
+	) ([]*db.List_Unsettled_CentresRow, error)
+
+	// ListPayableSettlements lists statements waiting to be paid out, including failed
+	// payouts that have attempts left, oldest period first
+	ListPayableSettlements(
+		ctx context.Context,
+		arg db.List_Payable_SettlementsParams,
+	) ([]*db.Settlement, error)
+
+	// StartSettlementPayout claims a payable statement for a transfer under a reference
+	StartSettlementPayout(
+		ctx context.Context,
+		arg db.Start_Settlement_PayoutParams,
+	) (*db.Settlement, error)
+
+	// UpdateSettlementPayout records what became of the transfer of a statement being paid out
+	UpdateSettlementPayout(
+		ctx context.Context,
+		arg db.Update_Settlement_PayoutParams,
+	) (*db.Settlement, error)
+
+	GetSettlement(
+		ctx context.Context,
+		id string,
+	) (*db.Settlement, error)
+
+	GetSettlementByPayoutReference(
+		ctx context.Context,
+		reference string,
+	) (*db.Settlement, error)
+
+	ListCentreSettlements(
+		ctx context.Context,
+		arg db.List_Centre_SettlementsParams,
+	) ([]*db.Settlement, error)
+
+	ListSettlementEntries(
+		ctx context.Context,
+		settlementID string,
+	) ([]*db.SettlementEntry, error)
+}
+
+// PayoutService defines the interface for paying diagnostic centres through a payment
+// provider. Amounts are in major currency units.
+type PayoutService interface {
+	// CreateRecipient registers a bank account to receive transfers
+	CreateRecipient(
+		recipient domain.PayoutRecipientRequest,
+	) (*domain.PayoutRecipient, error)
+
+	// InitiateTransfer pays a registered recipient. Reusing the reference of a transfer
+	// the provider already accepted does not pay twice.
+	InitiateTransfer(
+		transfer domain.TransferRequest,
+	) (*domain.Transfer, error)
+}
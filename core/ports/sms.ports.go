@@ -0,0 +1,15 @@
+package ports
+
+// SMSProvider sends text messages through an SMS gateway. Phone numbers are in E.164
+// format; adapters convert them to the gateway's own format and wrap outages in
+// domain.ErrSMSUnavailable.
+type SMSProvider interface {
+	// Name identifies the gateway in logs
+	Name() string
+
+	// SendSMS sends message to phone and returns the gateway's message ID
+	SendSMS(
+		phone,
+		message string,
+	) (string, error)
+}
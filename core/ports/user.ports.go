@@ -8,6 +8,9 @@ import (
 )
 
 type UserRepository interface {
+	// BeginUser starts a transaction for a change to an account and the email reporting it
+	BeginUser(ctx context.Context) (UserTx, error)
+
 	// User operations
 	GetUser(
 		ctx context.Context,
@@ -24,6 +27,21 @@ type UserRepository interface {
 		email pgtype.Text,
 	) (*db.User, error)
 
+	// GetUserByPhoneNumber prefers the account that verified the number, then the newest.
+	// Phone sign-ups reuse an unverified phone-only account they sent a code to, so other
+	// accounts only share a number when they predate verification or were never sent a code.
+	GetUserByPhoneNumber(
+		ctx context.Context,
+		phoneNumber string,
+	) (*db.User, error)
+
+	// ResetUnverifiedPhoneUser hands a phone-only account that was sent a code but never
+	// verified its number to a new sign-up with that number
+	ResetUnverifiedPhoneUser(
+		ctx context.Context,
+		arg db.ResetUnverifiedPhoneUserParams,
+	) (*db.User, error)
+
 	GetUsers(
 		ctx context.Context,
 		arg db.GetUsersParams,
@@ -84,4 +102,59 @@ type UserRepository interface {
 		ctx context.Context,
 		email string,
 	) error
+
+	// Phone verification operations
+	CreatePhoneVerificationToken(
+		ctx context.Context,
+		arg db.CreatePhoneVerificationTokenParams,
+	) (*db.PhoneVerificationToken, error)
+
+	GetLatestPhoneVerificationToken(
+		ctx context.Context,
+		phoneNumber string,
+	) (*db.PhoneVerificationToken, error)
+
+	CountPhoneVerificationTokensSince(
+		ctx context.Context,
+		arg db.CountPhoneVerificationTokensSinceParams,
+	) (int64, error)
+
+	IncrementPhoneVerificationAttempts(
+		ctx context.Context,
+		id string,
+	) (*db.PhoneVerificationToken, error)
+
+	// ConsumePhoneVerificationToken uses up an unexpired code and marks its number as
+	// verified on the account, reporting whether it did
+	ConsumePhoneVerificationToken(
+		ctx context.Context,
+		id string,
+	) (bool, error)
+}
+
+// UserTx represents a transaction for account changes that send email
+type UserTx interface {
+	DBTX
+
+	CreateUser(
+		ctx context.Context,
+		arg db.CreateUserParams,
+	) (*db.User, error)
+
+	CreatePasswordResetToken(
+		ctx context.Context,
+		arg db.CreatePasswordResetTokenParams,
+	) error
+
+	CreateEmailVerificationToken(
+		ctx context.Context,
+		arg db.CreateEmailVerificationTokenParams,
+	) (*db.EmailVerificationToken, error)
+
+	// EnqueueEmail queues an email with the rest of the transaction, so it goes out only
+	// if the transaction commits
+	EnqueueEmail(
+		ctx context.Context,
+		arg db.Enqueue_EmailParams,
+	) (*db.EmailOutbox, error)
 }
@@ -0,0 +1,44 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+// WaitlistRepository defines the interface for appointment waitlist data operations
+type WaitlistRepository interface {
+	CreateWaitlistEntry(
+		ctx context.Context,
+		arg db.Create_Waitlist_EntryParams,
+	) (*db.AppointmentWaitlistEntry, error)
+
+	ListPatientWaitlistEntries(
+		ctx context.Context,
+		arg db.List_Patient_Waitlist_EntriesParams,
+	) ([]*db.AppointmentWaitlistEntry, error)
+
+	CancelWaitlistEntry(
+		ctx context.Context,
+		arg db.Cancel_Waitlist_EntryParams,
+	) (*db.AppointmentWaitlistEntry, error)
+
+	OfferWaitlistSlot(
+		ctx context.Context,
+		arg db.Offer_Waitlist_SlotParams,
+	) (*db.AppointmentWaitlistEntry, error)
+
+	ListWaitlistHolds(
+		ctx context.Context,
+		arg db.List_Waitlist_HoldsParams,
+	) ([]*db.AppointmentWaitlistEntry, error)
+
+	MarkWaitlistEntryBooked(
+		ctx context.Context,
+		arg db.Mark_Waitlist_Entry_BookedParams,
+	) error
+
+	ExpireWaitlistEntries(
+		ctx context.Context,
+	) ([]*db.AppointmentWaitlistEntry, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+// WalletRepository defines the interface for reading patient wallets. Balances only move
+// within an AppointmentTx, together with the transaction that records the movement.
+type WalletRepository interface {
+	ListUserWallets(
+		ctx context.Context,
+		userID string,
+	) ([]*db.Wallet, error)
+
+	// ListWalletTransactions lists the transactions of every wallet of a user, newest first
+	ListWalletTransactions(
+		ctx context.Context,
+		arg db.List_Wallet_TransactionsParams,
+	) ([]*db.WalletTransaction, error)
+}
@@ -5,14 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/adapters/external/templates/emails"
 	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
 	"github.com/diagnoxix/core/utils"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 )
@@ -41,22 +44,94 @@ func (service *ServicesHandler) CreateAppointment(context echo.Context) error {
 	}
 	defer tx.Rollback(ctx)
 
-	// Normalize test type format
-	testType := strings.ToUpper(strings.ReplaceAll(string(dto.TestType), " ", "_"))
-	if !service.appointmentPort.IsValidTestType(ctx, testType) {
-		utils.Error("Failed to validate test_type",
-			utils.LogField{Key: "error", Value: "Invalid test type"})
-		return utils.ErrorResponse(
-			http.StatusBadRequest,
-			fmt.Errorf("invalid test type: %s", testType),
-			context,
-		)
+	// Normalize test type format; further tests are booked as line items of the same appointment
+	testTypes, err := bundleTestTypes(dto.TestType, dto.TestTypes)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, context)
+	}
+	for _, testType := range testTypes {
+		if !service.appointmentPort.IsValidTestType(ctx, testType) {
+			utils.Error("Failed to validate test_type",
+				utils.LogField{Key: "error", Value: "Invalid test type"})
+			return utils.ErrorResponse(
+				http.StatusBadRequest,
+				fmt.Errorf("invalid test type: %s", testType),
+				context,
+			)
+		}
+	}
+	testType := testTypes[0]
+	// The requested time must match a free slot of the centre
+	slot, err := service.findFreeSlot(
+		ctx,
+		dto.DiagnosticCentreID.String(),
+		dto.AppointmentDate.UTC(),
+		currentUser.UserID.String(),
+	)
+	if err != nil {
+		utils.Error("Failed to match appointment slot",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID.String()},
+			utils.LogField{Key: "appointment_date", Value: dto.AppointmentDate})
+		if errors.Is(err, ErrSlotUnavailable) || errors.Is(err, ErrSlotFullyBooked) {
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
+
+	// Price the appointment from the centre's active price list, never from the client
+	quote, err := service.quoteAppointmentTests(ctx, dto.DiagnosticCentreID.String(), testTypes)
+	if err != nil {
+		utils.Error("Failed to price appointment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID.String()},
+			utils.LogField{Key: "test_types", Value: testTypes})
+		if errors.Is(err, ErrNoActivePrice) || errors.Is(err, ErrMixedCurrencies) {
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	// A promo code is taken off the quote before the amount is checked, so the client confirms
+	// the discounted total
+	var promo *db.PromoCode
+	if dto.PromoCode != "" {
+		promo, err = service.applyPromoCode(ctx, quote, dto.PromoCode, currentUser.UserID.String())
+		if err != nil {
+			utils.Error("Failed to apply promo code",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "patient_id", Value: currentUser.UserID.String()})
+			if isPromoCodeError(err) {
+				return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+			}
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+	}
+	// An insurance policy takes the insurer's share off the quote in the same way; that share is
+	// claimed from the insurer once the tests are done
+	if dto.PolicyID != "" {
+		err = service.applyInsuranceCoverage(ctx, quote, dto.PolicyID, currentUser.UserID.String())
+		if err == nil && quote.Coverage.PreauthorizationRequired && strings.TrimSpace(dto.AuthorizationCode) == "" {
+			err = ErrPreauthorizationRequired
+		}
+		if err != nil {
+			utils.Error("Failed to apply insurance coverage",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "patient_id", Value: currentUser.UserID.String()})
+			if isCoverageError(err) {
+				return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+			}
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+	}
+	if dto.Amount > 0 && roundAmount(dto.Amount) != quote.Total {
+		return utils.ErrorResponse(http.StatusConflict, ErrPriceMismatch, context)
+	}
+
 	// Use the validated test type for schedule creation
 	scheduleParams := db.Create_Diagnostic_ScheduleParams{
 		UserID:             currentUser.UserID.String(),
 		DiagnosticCentreID: dto.DiagnosticCentreID.String(),
-		ScheduleTime:       toTimestamptz(dto.AppointmentDate),
+		ScheduleTime:       toTimestamptz(slot.StartTime),
 		Doctor:             string(dto.PreferredDoctor),
 		TestType:           testType,
 		Notes:              toText(dto.Notes),
@@ -80,12 +155,10 @@ func (service *ServicesHandler) CreateAppointment(context echo.Context) error {
 		PatientID:          currentUser.UserID.String(),
 		ScheduleID:         schedule.ID,
 		DiagnosticCentreID: dto.DiagnosticCentreID.String(),
-		AppointmentDate:    toTimestamptz(dto.AppointmentDate),
-		TimeSlot: fmt.Sprintf("%s-%s",
-			dto.AppointmentDate.Format("15:04"),
-			dto.AppointmentDate.Add(30*time.Minute).Format("15:04")),
-		Status: db.AppointmentStatusPending,
-		Notes:  toText(dto.Notes),
+		AppointmentDate:    toTimestamptz(slot.StartTime),
+		TimeSlot:           slot.TimeSlot,
+		Status:             db.AppointmentStatusPending,
+		Notes:              toText(dto.Notes),
 	}
 
 	appointment, err := tx.CreateAppointment(ctx, appointmentParams)
@@ -96,125 +169,147 @@ func (service *ServicesHandler) CreateAppointment(context echo.Context) error {
 		return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
 	}
 
-	// Create payment record
-	var pgAmount pgtype.Numeric
-	// Format amount with exactly 2 decimal places and apply proper rounding
-	amount := fmt.Sprintf("%.2f", dto.Amount)
-	if err := pgAmount.Scan(amount); err != nil {
-		utils.Error("Failed to convert amount to numeric",
+	// Every test keeps its own price so its result can be tracked on its own
+	if err := createLineItems(ctx, tx, appointment.ID, quote); err != nil {
+		utils.Error("Failed to create appointment line items",
 			utils.LogField{Key: "error", Value: err.Error()},
-			utils.LogField{Key: "amount", Value: amount})
-		return utils.ErrorResponse(
-			http.StatusBadRequest,
-			errors.New("invalid amount format"),
-			context,
-		)
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if quote.Coverage != nil {
+		if err := recordCoverage(ctx, tx, appointment, quote, dto.AuthorizationCode); err != nil {
+			utils.Error("Failed to record insurance coverage",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+	}
+
+	// Take a use of the promo code before the payment is created; the code stays locked until
+	// the booking commits and its use is recorded against the payment below
+	if promo != nil {
+		if err := claimPromoCode(ctx, tx, promo, currentUser.UserID.String()); err != nil {
+			utils.Error("Failed to claim promo code",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "promo_code_id", Value: promo.ID})
+			if isPromoCodeError(err) {
+				return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+			}
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+	}
+
+	// Spend what the patient's wallet covers first. The wallet stays locked until the booking
+	// commits, so concurrent bookings cannot spend the same balance.
+	var wallet *db.Wallet
+	var walletAmount float64
+	if dto.UseWallet && quote.Total > 0 {
+		var balance float64
+		wallet, balance, err = lockWallet(ctx, tx, currentUser.UserID.String(), quote.Currency)
+		if err != nil {
+			utils.Error("Failed to read wallet balance",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "patient_id", Value: currentUser.UserID.String()})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+		walletAmount = walletShare(balance, quote.Total)
 	}
 
-	// Generate unique reference for this payment
-	paymentReference := fmt.Sprintf("MED-%s-%s", appointment.ID, time.Now().Format("20060102150405"))
 	// Initialize Paystack transaction
 	metadata := map[string]interface{}{
 		"appointment_id":       appointment.ID,
 		"patient_id":           currentUser.UserID.String(),
 		"diagnostic_centre_id": dto.DiagnosticCentreID.String(),
 		"test_type":            testType,
+		"test_types":           testTypes,
 		"scheduled_time":       schedule.ScheduleTime,
+		"test_price_id":        quote.TestPriceID,
+		"price":                quote.Price,
+		"fees":                 quote.Fees,
+		"wallet_amount":        walletAmount,
+	}
+	if quote.Discount != nil {
+		metadata["promo_code"] = quote.Discount.Code
+		metadata["discount"] = quote.Discount.Amount
+	}
+	if quote.Coverage != nil {
+		metadata["policy_id"] = quote.Coverage.PolicyID
+		metadata["insurer_amount"] = quote.Coverage.InsurerAmount
+	}
+
+	// Paying at the centre skips the provider; the slot is held until the centre records payment.
+	// A booking the insurer covers in full has nothing left to pay.
+	var payment *db.Payment
+	var checkout *domain.TransactionCheckout
+	switch {
+	case quote.Total == 0:
+	case dto.PayAtCentre:
+		payment, err = service.holdAppointmentForCentrePayment(
+			ctx,
+			tx,
+			appointment,
+			quote.Total,
+			quote.Currency,
+			metadata,
+		)
+	default:
+		payment, checkout, err = service.initializeAppointmentPayment(
+			ctx,
+			tx,
+			appointment,
+			quote.Total,
+			walletAmount,
+			quote.Currency,
+			metadata,
+		)
 	}
-
-	// Get User Email
-	user, err := service.userPort.GetUser(ctx, currentUser.UserID.String())
-
-	if err != nil {
-		utils.Error("Failed to get user email",
-			utils.LogField{Key: "error", Value: err.Error()},
-			utils.LogField{Key: "appointment_id", Value: appointment.ID})
-		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
-	}
-
-	paystackResponse, err := service.paymentService.InitializeTransaction(
-		user.Email.String,
-		paymentReference,
-		dto.Amount,
-		metadata,
-	)
-
 	if err != nil {
 		utils.Error("Failed to initialize payment",
 			utils.LogField{Key: "error", Value: err.Error()},
 			utils.LogField{Key: "appointment_id", Value: appointment.ID})
 		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
-
-	utils.Info("Payment initialized successfully",
-		utils.LogField{Key: "paystack_response", Value: paystackResponse},
-		utils.LogField{Key: "appointment_id", Value: appointment.ID},
-	)
-
-	metadataBytes, err := utils.MarshalJSONField(paystackResponse)
-	if err != nil {
-		utils.Error("Failed to marshal payment metadata",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
-	}
-
-	payment, err := tx.CreatePayment(ctx, db.Create_PaymentParams{
-		AppointmentID:      appointment.ID,
-		PatientID:          currentUser.UserID.String(),
-		DiagnosticCentreID: dto.DiagnosticCentreID.String(),
-		Amount:             pgAmount,
-		Currency:           "NGN",
-		PaymentMethod:      db.PaymentMethodCard,
-		PaymentProvider:    db.PaymentProviderPAYSTACK,
-		PaymentMetadata:    metadataBytes,
-		ProviderMetadata:   metadataBytes,
-		ProviderReference:  pgtype.Text{String: paymentReference, Valid: true},
-		TransactionID:      pgtype.Text{String: paystackResponse.Data.Reference, Valid: true},
-	})
-
-	if err != nil {
-		utils.Error("Failed to create payment",
-			utils.LogField{Key: "error", Value: err.Error()},
-			utils.LogField{Key: "appointment_id", Value: appointment.ID},
-		)
-		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	if walletAmount > 0 {
+		if err := debitWallet(ctx, tx, wallet, payment, walletAmount); err != nil {
+			utils.Error("Failed to debit wallet",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
 	}
 
-	paymentUUID, err := uuid.Parse(payment.ID)
-	if err != nil {
-		utils.Error("Failed to parse payment UUID",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	if promo != nil {
+		if err := redeemPromoCode(ctx, tx, promo, quote.Discount, payment); err != nil {
+			utils.Error("Failed to redeem promo code",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
 	}
 
-	metadataJSON, err := utils.MarshalJSONField(metadata)
-	if err != nil {
-		utils.Error("Failed to marshal notification metadata",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	// A booking the wallet or the insurer paid in full needs no checkout and is confirmed
+	// straight away
+	update := db.UpdateAppointmentPaymentParams{
+		ID:            appointment.ID,
+		PaymentAmount: toNumeric(0),
+		Status:        db.AppointmentStatusConfirmed,
 	}
-
-	_, err = tx.CreateNotification(ctx, db.CreateNotificationParams{
-		UserID:   currentUser.UserID.String(),
-		Type:     db.NotificationTypeAPPOINTMENTCREATED,
-		Title:    dto.TestType,
-		Message:  dto.TestType,
-		Metadata: metadataJSON,
-	})
-	if err != nil {
-		utils.Error("Failed to create appointment notification",
-			utils.LogField{Key: "error", Value: err.Error()},
-			utils.LogField{Key: "appointment_id", Value: appointment.ID},
-		)
+	if payment != nil {
+		paymentUUID, err := uuid.Parse(payment.ID)
+		if err != nil {
+			utils.Error("Failed to parse payment UUID",
+				utils.LogField{Key: "error", Value: err.Error()})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+		update.PaymentID = pgtype.UUID{Bytes: paymentUUID, Valid: true}
+		update.PaymentStatus = db.NullPaymentStatus{PaymentStatus: payment.PaymentStatus, Valid: true}
+		update.PaymentAmount = payment.Amount
+		if payment.PaymentStatus != db.PaymentStatusSuccess {
+			update.Status = db.AppointmentStatusPending
+		}
 	}
-
-	_, err = tx.UpdateAppointment(ctx, db.UpdateAppointmentPaymentParams{
-		ID:            appointment.ID,
-		PaymentID:     pgtype.UUID{Bytes: paymentUUID, Valid: true},
-		PaymentStatus: db.NullPaymentStatus{PaymentStatus: db.PaymentStatusPending, Valid: true},
-		PaymentAmount: pgAmount,
-		Status:        db.AppointmentStatusPending,
-	})
+	status := update.Status
+	appointment, err = tx.UpdateAppointment(ctx, update)
 	if err != nil {
 		utils.Error("Failed to update appointment",
 			utils.LogField{Key: "error", Value: err.Error()},
@@ -227,6 +322,19 @@ func (service *ServicesHandler) CreateAppointment(context echo.Context) error {
 		)
 	}
 
+	// A booking paid in full is confirmed outright and the patient hears only that
+	notificationType := db.NotificationTypeAPPOINTMENTCREATED
+	if status == db.AppointmentStatusConfirmed {
+		notificationType = db.NotificationTypeAPPOINTMENTCONFIRMED
+	}
+	notification, err := service.dispatchAppointmentNotification(ctx, tx, appointment, notificationType, metadata)
+	if err != nil {
+		utils.Error("Failed to dispatch appointment notification",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
 	// Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		utils.Error("Failed to commit appointment transaction",
@@ -238,10 +346,29 @@ func (service *ServicesHandler) CreateAppointment(context echo.Context) error {
 		)
 	}
 
+	notification.push(ctx)
+
+	// Booking a slot held for the patient closes their waitlist hold
+	service.markWaitlistHoldBooked(ctx, appointment)
+
+	if status == db.AppointmentStatusConfirmed {
+		if payment != nil {
+			go service.sendPaymentReceiptEmail(payment.ID)
+		}
+	}
+
+	var payAtCentreBy pgtype.Timestamptz
+	if payment != nil {
+		payAtCentreBy = payment.HoldExpiresAt
+	}
+
 	return utils.ResponseMessage(http.StatusCreated, map[string]interface{}{
-		"message":     "Appointment created successfully",
-		"appointment": appointment,
-		"reference":   paystackResponse.Data,
+		"message":          "Appointment created successfully",
+		"appointment":      appointment,
+		"reference":        checkout,
+		"quote":            quote,
+		"wallet_amount":    walletAmount,
+		"pay_at_centre_by": payAtCentreBy,
 	}, context)
 }
 
@@ -300,6 +427,22 @@ func (service *ServicesHandler) ConfirmAppointment(context echo.Context) error {
 		return utils.ErrorResponse(http.StatusForbidden, errors.New("not authorized to confirm this appointment"), context)
 	}
 
+	// Only the payment the appointment was booked with confirms it
+	if appointment.PaymentID != toUUID(payment.ID) {
+		utils.Error("Payment does not belong to appointment",
+			utils.LogField{Key: "appointment_id", Value: appointment.ID},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("payment does not belong to this appointment"), context)
+	}
+
+	// The Paystack webhook may have confirmed the appointment before the patient returned
+	if appointment.Status == db.AppointmentStatusConfirmed {
+		return utils.ResponseMessage(http.StatusOK, map[string]interface{}{
+			"message":     "Appointment confirmed successfully",
+			"appointment": appointment,
+		}, context)
+	}
+
 	// Verify appointment status
 	if appointment.Status != db.AppointmentStatusPending {
 		utils.Error("Invalid appointment status for confirmation",
@@ -335,12 +478,18 @@ func (service *ServicesHandler) ConfirmAppointment(context echo.Context) error {
 		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
+	// An upfront series payment covers several appointments, each carrying its own share
+	paymentAmount := payment.Amount
+	if appointment.PaymentAmount.Valid {
+		paymentAmount = appointment.PaymentAmount
+	}
+
 	// Update appointment status
 	confirmedAppointment, err := tx.UpdateAppointment(ctx, db.UpdateAppointmentPaymentParams{
 		ID:            appointment.ID,
 		PaymentID:     pgtype.UUID{Bytes: paymentUUID, Valid: true},
 		PaymentStatus: db.NullPaymentStatus{PaymentStatus: db.PaymentStatusSuccess, Valid: true},
-		PaymentAmount: payment.Amount,
+		PaymentAmount: paymentAmount,
 		Status:        db.AppointmentStatusConfirmed,
 	})
 	if err != nil {
@@ -350,31 +499,26 @@ func (service *ServicesHandler) ConfirmAppointment(context echo.Context) error {
 		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
+	// Confirm the remaining occurrences of a series paid upfront
+	if _, err := tx.ConfirmAppointmentsByPayment(ctx, pgtype.UUID{Bytes: paymentUUID, Valid: true}); err != nil {
+		utils.Error("Failed to confirm appointments covered by payment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
 	metadata := map[string]interface{}{
 		"appointment_id":       appointment.ID,
 		"patient_id":           currentUser.UserID.String(),
 		"diagnostic_centre_id": appointment.DiagnosticCentreID,
 	}
 
-	metadataJSON, err := utils.MarshalJSONField(metadata)
-	if err != nil {
-		utils.Error("Failed to marshal notification metadata",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
-	}
-
-	_, err = tx.CreateNotification(ctx, db.CreateNotificationParams{
-		UserID:   currentUser.UserID.String(),
-		Type:     db.NotificationTypeAPPOINTMENTCONFIRMED,
-		Title:    dto.PaymentMethod,
-		Message:  dto.ProviderReference,
-		Metadata: metadataJSON,
-	})
+	notification, err := service.dispatchAppointmentNotification(ctx, tx, confirmedAppointment, db.NotificationTypeAPPOINTMENTCONFIRMED, metadata)
 	if err != nil {
-		utils.Error("Failed to create confirm notification",
+		utils.Error("Failed to dispatch confirm notification",
 			utils.LogField{Key: "error", Value: err.Error()},
-			utils.LogField{Key: "appointment_id", Value: appointment.ID},
-		)
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
 	// Commit transaction
@@ -383,9 +527,7 @@ func (service *ServicesHandler) ConfirmAppointment(context echo.Context) error {
 			utils.LogField{Key: "error", Value: err.Error()})
 		return utils.ErrorResponse(http.StatusInternalServerError, errors.New("failed to commit transaction"), context)
 	}
-
-	// Send confirmation email asynchronously
-	go service.sendAppointmentConfirmationEmail(confirmedAppointment)
+	notification.push(ctx)
 
 	utils.Info("Appointment confirmed successfully",
 		utils.LogField{Key: "appointment_id", Value: appointment.ID},
@@ -521,68 +663,154 @@ func (service *ServicesHandler) CancelAppointment(context echo.Context) error {
 		return utils.ErrorResponse(http.StatusBadRequest, errors.New("appointment cannot be cancelled in its current state"), context)
 	}
 
-	// Cancel appointment
-	_ = db.CancelAppointmentParams{
-		ID:                 dto.AppointmentID,
-		CancellationReason: toText(dto.Reason),
-		CancelledBy:        toUUID(currentUser.UserID.String()),
-		CancellationFee:    toNumeric(0), // Fee could be configured based on business rules
+	policy, err := service.centreCancellationPolicy(ctx, appointment.DiagnosticCentreID)
+	if err != nil {
+		utils.Error("Failed to get cancellation policy",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: appointment.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
-	err = service.appointmentPort.CancelAppointment(ctx, dto.AppointmentID)
+	// The fee only applies to money actually paid
+	payment, err := service.successfulAppointmentPayment(ctx, appointment)
 	if err != nil {
-		return err
+		utils.Error("Failed to get appointment payment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	var amountPaid, fee, reschedulingFees float64
+	if payment != nil {
+		amountPaid, err = appointmentAmountPaid(appointment, payment)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusInternalServerError, fmt.Errorf("invalid payment amount: %w", err), context)
+		}
+		fee = cancellationFee(policy, amountPaid, appointment.AppointmentDate.Time, time.Now())
+		reschedulingFees, err = service.reschedulingFeesOwed(ctx, appointment.ID)
+		if err != nil {
+			utils.Error("Failed to get rescheduling fees",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
 	}
 
-	cancelledAppointment, err := service.appointmentPort.GetAppointment(ctx, dto.AppointmentID)
+	// The refund is opened with the cancellation, so neither commits without the other
+	tx, err := service.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		utils.Error("Failed to begin cancellation transaction",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(ctx)
+
+	// Cancel appointment
+	cancelledAppointment, err := tx.CancelAppointment(ctx, db.CancelAppointmentParams{
+		ID:                 appointment.ID,
+		CancellationReason: toText(dto.Reason),
+		CancelledBy:        toUUID(currentUser.UserID.String()),
+		CancellationFee:    toNumeric(fee),
+	})
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Another request moved the appointment on since it was read
+			return utils.ErrorResponse(http.StatusConflict, errors.New("appointment cannot be cancelled in its current state"), context)
+		}
 		utils.Error("Failed to cancel appointment",
 			utils.LogField{Key: "error", Value: err.Error()},
 			utils.LogField{Key: "appointment_id", Value: dto.AppointmentID})
 		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
+	cancellation := &domain.AppointmentCancellation{
+		Appointment:      cancelledAppointment,
+		CancellationFee:  fee,
+		ReschedulingFees: reschedulingFees,
+		RefundStatus:     domain.RefundStatusNone,
+	}
+	var refund *db.PaymentRefund
+	refundAmount := roundAmount(amountPaid - fee - reschedulingFees)
+	if payment != nil && refundAmount > 0 {
+		cancellation.RefundAmount = refundAmount
+		cancellation.RefundDestination = refundDestination(payment, dto.RefundTo)
+		refund, err = service.openCancellationRefund(
+			ctx,
+			tx,
+			payment,
+			refundAmount,
+			dto.Reason,
+			currentUser.UserID.String(),
+			cancellation.RefundDestination,
+			cancelledAppointment,
+		)
+		if err != nil {
+			utils.Error("Failed to open refund of cancelled appointment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID},
+				utils.LogField{Key: "payment_id", Value: payment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+		if refund != nil {
+			cancellation.RefundDestination = refund.Destination
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		utils.Error("Failed to commit cancellation",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if payment != nil && refundAmount > 0 {
+		cancellation.RefundStatus = service.sendCancellationRefund(ctx, payment, refund)
+	}
+
+	// An unpaid booking gives back what the wallet put towards it and drops its pay-at-centre hold
+	if payment == nil {
+		released, err := service.cancelHeldPayment(ctx, cancelledAppointment)
+		if err != nil {
+			utils.Error("Failed to release payment of cancelled appointment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		}
+		if released > 0 {
+			cancellation.RefundAmount = released
+			cancellation.RefundStatus = domain.RefundStatusProcessed
+			cancellation.RefundDestination = db.RefundDestinationWallet
+		}
+	}
+
 	metadata := map[string]interface{}{
 		"appointment_id":       appointment.ID,
 		"patient_id":           currentUser.UserID.String(),
 		"diagnostic_centre_id": appointment.DiagnosticCentreID,
 		"reason":               dto.Reason,
+		"cancellation_fee":     cancellation.CancellationFee,
+		"refund_amount":        cancellation.RefundAmount,
+		"refund_status":        cancellation.RefundStatus,
 	}
 
-	metadataJSON, err := utils.MarshalJSONField(metadata)
-	if err != nil {
-		utils.Error("Failed to marshal notification metadata",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
-	}
-
-	_, err = service.notificationRepo.CreateNotification(
-		ctx,
-		db.CreateNotificationParams{
-			UserID:   currentUser.UserID.String(),
-			Type:     db.NotificationTypeAPPOINTMENTCANCELLED,
-			Title:    dto.Reason,
-			Message:  dto.Reason,
-			Metadata: metadataJSON,
-		},
-	)
-	if err != nil {
-		utils.Error("Failed to create cancel notification",
+	if err := service.notifyAppointment(ctx, cancelledAppointment, db.NotificationTypeAPPOINTMENTCANCELLED, metadata); err != nil {
+		utils.Error("Failed to send cancel notification",
 			utils.LogField{Key: "error", Value: err.Error()},
 			utils.LogField{Key: "appointment_id", Value: appointment.ID},
 		)
 	}
 
-	// Send cancellation email asynchronously
-	go service.sendAppointmentCancellationEmail(cancelledAppointment)
+	// The freed slot goes to the next patient on the centre's waitlist
+	go service.offerFreedSlot(cancelledAppointment.DiagnosticCentreID, cancelledAppointment.AppointmentDate.Time)
 
-	return utils.ResponseMessage(
-		http.StatusOK,
-		map[string]string{"message": "Appointment cancelled successfully"},
-		context,
-	)
+	return utils.ResponseMessage(http.StatusOK, cancellation, context)
 }
 
+const defaultMaxReschedules = 2
+
+var (
+	ErrRescheduleLimitReached = errors.New("appointment has been rescheduled the maximum number of times")
+	ErrSameAppointmentTime    = errors.New("appointment is already booked for this time")
+)
+
 // RescheduleAppointment reschedules an appointment to a new time
 func (service *ServicesHandler) RescheduleAppointment(context echo.Context) error {
 
@@ -626,53 +854,366 @@ func (service *ServicesHandler) RescheduleAppointment(context echo.Context) erro
 		)
 	}
 
-	// Verify new schedule exists and is valid
-	newSchedule, err := service.schedulePort.GetDiagnosticScheduleByCentre(
+	summary, err := service.appointmentPort.GetAppointmentRescheduleSummary(ctx, appointment.ID)
+	if err != nil {
+		utils.Error("Failed to count appointment reschedules",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	// The requested time must match a free slot of the same centre
+	slot, err := service.findFreeSlot(ctx, appointment.DiagnosticCentreID, dto.NewDate.UTC(), currentUser.UserID.String())
+	if err != nil {
+		utils.Error("Failed to match reschedule slot",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID},
+			utils.LogField{Key: "new_date", Value: dto.NewDate})
+		if errors.Is(err, ErrSlotUnavailable) || errors.Is(err, ErrSlotFullyBooked) {
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if err := validateReschedule(appointment, slot.StartTime, int(summary.RescheduleCount), service.maxReschedules()); err != nil {
+		return utils.ErrorResponse(http.StatusConflict, err, context)
+	}
+
+	// The new schedule keeps the test and doctor of the original booking
+	oldSchedule, err := service.schedulePort.GetDiagnosticScheduleByCentre(
 		ctx,
 		db.Get_Diagnsotic_Schedule_By_CentreParams{
-			ID:                 dto.NewScheduleID,
+			ID:                 appointment.ScheduleID,
 			DiagnosticCentreID: appointment.DiagnosticCentreID,
 		},
 	)
 	if err != nil {
-		return utils.ErrorResponse(
-			http.StatusNotFound,
-			errors.New("new schedule not found"),
-			context,
-		)
+		utils.Error("Failed to get appointment schedule",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "schedule_id", Value: appointment.ScheduleID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
-	if newSchedule.AcceptanceStatus != db.ScheduleAcceptanceStatusACCEPTED {
-		return utils.ErrorResponse(
-			http.StatusBadRequest,
-			errors.New("new schedule is not accepted"),
-			context,
-		)
+	tx, err := service.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		utils.Error("Failed to start transaction",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(ctx)
+
+	newSchedule, err := tx.CreateSchedule(ctx, db.Create_Diagnostic_ScheduleParams{
+		UserID:             currentUser.UserID.String(),
+		DiagnosticCentreID: appointment.DiagnosticCentreID,
+		ScheduleTime:       toTimestamptz(slot.StartTime),
+		Doctor:             oldSchedule.Doctor,
+		TestType:           oldSchedule.TestType,
+		Notes:              oldSchedule.Notes,
+		AcceptanceStatus:   db.ScheduleAcceptanceStatusACCEPTED,
+	})
+	if err != nil {
+		utils.Error("Failed to create schedule",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
 	}
 
 	// Reschedule appointment
+	fee := roundAmount(service.reschedulingFee())
 	params := db.RescheduleAppointmentParams{
-		ID:                 dto.AppointmentID,
+		ID:                 appointment.ID,
 		ReschedulingReason: toText(dto.RescheduleReason),
 		RescheduledBy:      toUUID(currentUser.UserID.String()),
-		ReschedulingFee:    toNumeric(0), // Fee could be configured based on business rules
-		ScheduleID:         dto.NewScheduleID,
-		AppointmentDate:    toTimestamptz(dto.NewDate),
-		TimeSlot:           dto.NewTimeSlot,
+		ReschedulingFee:    toNumeric(fee),
+		ScheduleID:         newSchedule.ID,
+		AppointmentDate:    toTimestamptz(slot.StartTime),
+		TimeSlot:           slot.TimeSlot,
 	}
 
-	rescheduledAppointment, err := service.appointmentPort.RescheduleAppointment(ctx, params)
+	rescheduledAppointment, err := tx.RescheduleAppointment(ctx, params)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Another request moved the appointment on since it was read
+			return utils.ErrorResponse(http.StatusConflict, errors.New("appointment cannot be rescheduled in its current state"), context)
+		}
 		utils.Error("Failed to reschedule appointment",
 			utils.LogField{Key: "error", Value: err.Error()},
 			utils.LogField{Key: "appointment_id", Value: dto.AppointmentID})
 		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
-	// Send reschedule email asynchronously
-	go service.sendAppointmentRescheduleEmail(rescheduledAppointment)
+	if err := tx.MoveAppointmentLineItems(ctx, db.Move_Appointment_Line_ItemsParams{
+		AppointmentID:   appointment.ID,
+		AppointmentID_2: rescheduledAppointment.ID,
+	}); err != nil {
+		utils.Error("Failed to move appointment line items",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	// A rescheduled series occurrence lives on in the appointment that replaced it
+	if err := tx.MoveSeriesOccurrence(ctx, db.Move_Series_OccurrenceParams{
+		AppointmentID:   appointment.ID,
+		AppointmentID_2: rescheduledAppointment.ID,
+	}); err != nil {
+		utils.Error("Failed to move series occurrence",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	var feePayment *db.Payment
+	var checkout *domain.TransactionCheckout
+	var walletAmount float64
+	if fee > 0 {
+		feePayment, checkout, walletAmount, err = service.chargeReschedulingFee(ctx, tx, rescheduledAppointment, fee, dto.UseWallet)
+		if err != nil {
+			utils.Error("Failed to charge rescheduling fee",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+	}
+
+	notification, err := service.dispatchAppointmentNotification(ctx, tx, rescheduledAppointment, db.NotificationTypeAPPOINTMENTRESCHEDULED, nil)
+	if err != nil {
+		utils.Error("Failed to dispatch reschedule notification",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		utils.Error("Failed to commit reschedule transaction",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, errors.New("failed to commit reschedule transaction"), context)
+	}
+
+	notification.push(ctx)
+
+	service.markWaitlistHoldBooked(ctx, rescheduledAppointment)
+
+	// The slot moved away from goes to the next patient on the centre's waitlist
+	go service.offerFreedSlot(appointment.DiagnosticCentreID, appointment.AppointmentDate.Time)
+
+	if feePayment != nil && feePayment.PaymentStatus == db.PaymentStatusSuccess {
+		go service.sendPaymentReceiptEmail(feePayment.ID)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, map[string]interface{}{
+		"message":          "Appointment rescheduled successfully",
+		"appointment":      rescheduledAppointment,
+		"rescheduling_fee": fee,
+		"reference":        checkout,
+		"wallet_amount":    walletAmount,
+	}, context)
+}
+
+// chargeReschedulingFee charges the fee of a reschedule within tx, against the appointment that
+// replaced the rescheduled one and in the currency the booking was paid in. The patient's wallet
+// covers what it can first when asked to, and the centre's payment provider the rest.
+func (service *ServicesHandler) chargeReschedulingFee(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	appointment *db.Appointment,
+	fee float64,
+	useWallet bool,
+) (*db.Payment, *domain.TransactionCheckout, float64, error) {
+	currency := "NGN"
+	if appointment.PaymentID.Valid {
+		booking, err := service.paymentPort.GetPayment(ctx, uuidString(appointment.PaymentID))
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, 0, fmt.Errorf("failed to get booking payment: %w", err)
+		}
+		if err == nil {
+			currency = booking.Currency
+		}
+	}
+
+	var wallet *db.Wallet
+	var walletAmount float64
+	if useWallet {
+		var balance float64
+		var err error
+		wallet, balance, err = lockWallet(ctx, tx, appointment.PatientID, currency)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to read wallet balance: %w", err)
+		}
+		walletAmount = walletShare(balance, fee)
+	}
+
+	payment, checkout, err := service.initializeAppointmentPayment(ctx, tx, appointment, fee, walletAmount, currency, map[string]interface{}{
+		"appointment_id":       appointment.ID,
+		"patient_id":           appointment.PatientID,
+		"diagnostic_centre_id": appointment.DiagnosticCentreID,
+		"rescheduling_fee":     fee,
+		"wallet_amount":        walletAmount,
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if walletAmount > 0 {
+		if err := debitWallet(ctx, tx, wallet, payment, walletAmount); err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to debit wallet: %w", err)
+		}
+	}
+	return payment, checkout, walletAmount, nil
+}
+
+// validateReschedule checks the reschedule limit and that the appointment actually moves
+func validateReschedule(appointment *db.Appointment, newStart time.Time, rescheduleCount, maxReschedules int) error {
+	if rescheduleCount >= maxReschedules {
+		return fmt.Errorf("%w (%d)", ErrRescheduleLimitReached, maxReschedules)
+	}
+	if appointment.AppointmentDate.Time.Equal(newStart) {
+		return ErrSameAppointmentTime
+	}
+	return nil
+}
+
+// maxReschedules reads how often a booking may be rescheduled from configuration
+func (service *ServicesHandler) maxReschedules() int {
+	if service.Config.MAX_RESCHEDULES == "" {
+		return defaultMaxReschedules
+	}
+	limit, err := strconv.Atoi(service.Config.MAX_RESCHEDULES)
+	if err != nil || limit < 0 {
+		utils.Warn("Invalid MAX_RESCHEDULES configuration, using default",
+			utils.LogField{Key: "max_reschedules", Value: service.Config.MAX_RESCHEDULES})
+		return defaultMaxReschedules
+	}
+	return limit
+}
+
+// initializeAppointmentPayment opens a transaction with the centre's payment provider for the
+// patient of an appointment and records the pending payment against it within tx. The provider
+// is only charged what walletAmount, taken from the patient's wallet, leaves; a payment the
+// wallet covers in full is recorded as successful without a provider transaction and has no
+// checkout.
+func (service *ServicesHandler) initializeAppointmentPayment(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	appointment *db.Appointment,
+	amount float64,
+	walletAmount float64,
+	currency string,
+	metadata map[string]interface{},
+) (*db.Payment, *domain.TransactionCheckout, error) {
+	// Format amount with exactly 2 decimal places and apply proper rounding
+	var pgAmount pgtype.Numeric
+	if err := pgAmount.Scan(fmt.Sprintf("%.2f", amount)); err != nil {
+		return nil, nil, fmt.Errorf("invalid amount format: %w", err)
+	}
+	if roundAmount(amount-walletAmount) == 0 {
+		return service.payAppointmentFromWallet(ctx, tx, appointment, pgAmount, currency, metadata)
+	}
+
+	// Get User Email
+	user, err := service.userPort.GetUser(ctx, appointment.PatientID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user email: %w", err)
+	}
+
+	// Generate unique reference for this payment
+	paymentReference := fmt.Sprintf("MED-%s-%s", appointment.ID, time.Now().Format("20060102150405"))
+	checkout, err := service.initializeCentreTransaction(ctx, appointment.DiagnosticCentreID, domain.TransactionRequest{
+		Email:     user.Email.String,
+		Reference: paymentReference,
+		Amount:    roundAmount(amount - walletAmount),
+		Currency:  currency,
+		Metadata:  metadata,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	utils.Info("Payment initialized successfully",
+		utils.LogField{Key: "checkout", Value: checkout},
+		utils.LogField{Key: "appointment_id", Value: appointment.ID},
+	)
 
-	return utils.ResponseMessage(http.StatusOK, rescheduledAppointment, context)
+	metadataBytes, err := utils.MarshalJSONField(checkout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal payment metadata: %w", err)
+	}
+
+	payment, err := tx.CreatePayment(ctx, db.Create_PaymentParams{
+		AppointmentID:      appointment.ID,
+		PatientID:          appointment.PatientID,
+		DiagnosticCentreID: appointment.DiagnosticCentreID,
+		Amount:             pgAmount,
+		Currency:           currency,
+		PaymentMethod:      db.PaymentMethodCard,
+		PaymentProvider:    checkout.Provider,
+		PaymentMetadata:    metadataBytes,
+		ProviderMetadata:   metadataBytes,
+		ProviderReference:  pgtype.Text{String: paymentReference, Valid: true},
+		TransactionID:      pgtype.Text{String: checkout.Reference, Valid: true},
+		WalletAmount:       toNumeric(walletAmount),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+	if err := recordPaymentInitialized(ctx, tx, payment); err != nil {
+		return nil, nil, fmt.Errorf("failed to record payment event: %w", err)
+	}
+	return payment, checkout, nil
+}
+
+// payAppointmentFromWallet records an appointment payment the patient's wallet covers in full
+// within tx. It is successful as soon as it is recorded; the centre's provider is kept on it so
+// it settles like any other payment to the centre.
+func (service *ServicesHandler) payAppointmentFromWallet(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	appointment *db.Appointment,
+	amount pgtype.Numeric,
+	currency string,
+	metadata map[string]interface{},
+) (*db.Payment, *domain.TransactionCheckout, error) {
+	setting, err := service.centrePaymentSetting(ctx, appointment.DiagnosticCentreID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get payment provider setting: %w", err)
+	}
+	metadataBytes, err := utils.MarshalJSONField(metadata)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal payment metadata: %w", err)
+	}
+
+	paymentReference := fmt.Sprintf("WAL-%s-%s", appointment.ID, time.Now().Format("20060102150405"))
+	payment, err := tx.CreatePayment(ctx, db.Create_PaymentParams{
+		AppointmentID:      appointment.ID,
+		PatientID:          appointment.PatientID,
+		DiagnosticCentreID: appointment.DiagnosticCentreID,
+		Amount:             amount,
+		Currency:           currency,
+		PaymentMethod:      db.PaymentMethodWallet,
+		PaymentProvider:    setting.PaymentProvider,
+		PaymentMetadata:    metadataBytes,
+		ProviderReference:  pgtype.Text{String: paymentReference, Valid: true},
+		TransactionID:      pgtype.Text{String: paymentReference, Valid: true},
+		WalletAmount:       amount,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+	if err := recordPaymentInitialized(ctx, tx, payment); err != nil {
+		return nil, nil, fmt.Errorf("failed to record payment event: %w", err)
+	}
+	payment, err = service.applyPaymentEvent(ctx, tx, payment, paymentEvent{
+		Source:        db.PaymentEventSourceInternal,
+		Type:          PaymentEventWalletCharged,
+		To:            db.PaymentStatusSuccess,
+		Amount:        amount,
+		TransactionID: paymentReference,
+		Payload:       metadataBytes,
+		ActorID:       appointment.PatientID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return payment, nil, nil
 }
 
 // Helper function to verify and update payment
@@ -680,8 +1221,21 @@ func (service *ServicesHandler) verifyAndUpdatePayment(
 	ctx context.Context,
 	providerReference string,
 ) (*db.Payment, error) {
-	// Verify payment with provider
-	verificationResponse, err := service.paymentService.VerifyTransaction(providerReference)
+	// Get payment by provider reference
+	payment, err := service.paymentPort.GetPaymentByReference(ctx, providerReference)
+	if err != nil {
+		utils.Error("Failed to get payment by reference",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "reference", Value: providerReference})
+		return nil, fmt.Errorf("payment not found: %w", err)
+	}
+
+	// Verify payment with the provider that holds it
+	provider, err := service.paymentProvider(payment.PaymentProvider)
+	if err != nil {
+		return nil, err
+	}
+	verification, err := provider.VerifyTransaction(providerReference)
 	if err != nil {
 		utils.Error("Failed to verify transaction",
 			utils.LogField{Key: "error", Value: err.Error()},
@@ -689,15 +1243,15 @@ func (service *ServicesHandler) verifyAndUpdatePayment(
 		return nil, fmt.Errorf("payment verification failed: %w", err)
 	}
 
-	if !verificationResponse.Status || verificationResponse.Data.Status != "success" {
+	if verification.Status != domain.TransactionStatusSuccess {
 		utils.Error("Payment verification returned unsuccessful status",
 			utils.LogField{Key: "reference", Value: providerReference},
-			utils.LogField{Key: "status", Value: verificationResponse.Data.Status})
+			utils.LogField{Key: "status", Value: verification.Status})
 		return nil, errors.New("payment verification unsuccessful")
 	}
 
 	// Convert payment metadata to JSON with error recovery
-	metadataBytes, err := utils.MarshalJSONField(verificationResponse.Data)
+	metadataBytes, err := utils.MarshalJSONField(verification)
 	if err != nil {
 		utils.Error("Failed to marshal payment metadata, using fallback",
 			utils.LogField{Key: "error", Value: err.Error()})
@@ -705,27 +1259,17 @@ func (service *ServicesHandler) verifyAndUpdatePayment(
 		metadataBytes = []byte(`{"reference":"` + providerReference + `"}`)
 	}
 
-	// Get payment by provider reference
-	payment, err := service.paymentPort.GetPaymentByReference(ctx, providerReference)
-	if err != nil {
-		utils.Error("Failed to get payment by reference",
-			utils.LogField{Key: "error", Value: err.Error()},
-			utils.LogField{Key: "reference", Value: providerReference})
-		return nil, fmt.Errorf("payment not found: %w", err)
-	}
-
 	// Update payment status with retries
 	var updatedPayment *db.Payment
 	for retries := 0; retries < 3; retries++ {
-		updatedPayment, err = service.paymentPort.UpdatePaymentStatus(ctx, db.Update_Payment_StatusParams{
-			ID:              payment.ID,
-			PaymentStatus:   db.PaymentStatus(db.PaymentStatusSuccess),
-			TransactionID:   pgtype.Text{String: verificationResponse.Data.Reference, Valid: true},
-			PaymentMetadata: metadataBytes,
-		})
+		updatedPayment, err = service.applyVerifiedCharge(ctx, payment, verification, metadataBytes)
 		if err == nil {
 			break
 		}
+		var pErr *PaymentError
+		if errors.As(err, &pErr) {
+			break
+		}
 		utils.Warn("Failed to update payment status, retrying...",
 			utils.LogField{Key: "error", Value: err.Error()},
 			utils.LogField{Key: "retry", Value: retries + 1})
@@ -744,140 +1288,116 @@ func (service *ServicesHandler) verifyAndUpdatePayment(
 	return updatedPayment, nil
 }
 
-// Helper functions to send appointment emails
-func (service *ServicesHandler) sendAppointmentConfirmationEmail(appointment *db.Appointment) {
-
-	ctx := context.Background()
-	// Get patient details by email
-	patient, err := service.userPort.GetUser(
-		ctx,
-		appointment.PatientID,
-	)
-	if err != nil {
-		utils.Error("Failed to get patient details for confirmation email",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return
-	}
-
-	// Get centre details
-	centre, err := service.diagnosticPort.GetDiagnosticCentre(
-		ctx,
-		appointment.DiagnosticCentreID,
-	)
+// notifyAppointment sends the patient the notification of an appointment change that was not
+// made in a transaction
+func (service *ServicesHandler) notifyAppointment(
+	ctx context.Context,
+	appointment *db.Appointment,
+	notificationType db.NotificationType,
+	metadata map[string]interface{},
+) error {
+	pending, err := service.dispatchAppointmentNotification(ctx, service.notificationRepo, appointment, notificationType, metadata)
 	if err != nil {
-		utils.Error("Failed to get centre details for confirmation email",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return
+		return err
 	}
+	pending.push(ctx)
+	return nil
+}
 
-	// Get schedule details for test type
-	schedule, err := service.schedulePort.GetDiagnosticScheduleByCentre(
-		ctx,
-		db.Get_Diagnsotic_Schedule_By_CentreParams{
-			ID:                 appointment.ScheduleID,
-			DiagnosticCentreID: appointment.DiagnosticCentreID,
-		},
-	)
+// dispatchAppointmentNotification dispatches the notification of an appointment change on store.
+// Where the change is made in a transaction the notification is dispatched on it, so its email
+// goes out exactly when the change lands.
+func (service *ServicesHandler) dispatchAppointmentNotification(
+	ctx context.Context,
+	store notificationStore,
+	appointment *db.Appointment,
+	notificationType db.NotificationType,
+	metadata map[string]interface{},
+) (*pendingNotification, error) {
+	notification, err := service.appointmentNotification(ctx, appointment, notificationType, metadata)
 	if err != nil {
-		utils.Error("Failed to get schedule details for confirmation email",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return
-	}
-
-	data := emails.AppointmentEmailData{
-		EmailData: emails.EmailData{
-			AppName: "Diagnoxix",
-		},
-		PatientName:     patient.Fullname.String,
-		AppointmentID:   appointment.ID,
-		AppointmentDate: appointment.AppointmentDate.Time,
-		TimeSlot:        appointment.TimeSlot,
-		CentreName:      centre.DiagnosticCentreName,
-		TestType:        string(schedule.TestType),
-		Notes:           appointment.Notes.String,
+		return nil, err
 	}
+	return service.dispatchNotification(ctx, store, notification)
+}
 
-	// Send email
-	if err := service.notificationPort.SendEmail(
-		patient.Email.String,
-		emails.TitleAppointmentConfirmed,
-		emails.TemplateAppointmentConfirmed,
-		&data,
-	); err != nil {
-		utils.Error("Failed to send confirmation email",
-			utils.LogField{Key: "error", Value: err.Error()})
+// dispatchAppointmentConfirmations dispatches the confirmation of every appointment a payment
+// confirmed on store
+func (service *ServicesHandler) dispatchAppointmentConfirmations(
+	ctx context.Context,
+	store notificationStore,
+	appointments []*db.Appointment,
+) ([]*pendingNotification, error) {
+	notifications := make([]*pendingNotification, 0, len(appointments))
+	for _, appointment := range appointments {
+		notification, err := service.dispatchAppointmentNotification(ctx, store, appointment, db.NotificationTypeAPPOINTMENTCONFIRMED, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dispatch confirmation of appointment %s: %w", appointment.ID, err)
+		}
+		notifications = append(notifications, notification)
 	}
-
-	utils.Info("Appointment email sent successfully",
-		utils.LogField{Key: "appointment_id", Value: appointment.ID},
-	)
+	return notifications, nil
 }
 
-func (service *ServicesHandler) sendAppointmentCancellationEmail(appointment *db.Appointment) {
-	// Get patient details by email
-	patient, err := service.userPort.GetUser(context.Background(), appointment.PatientID)
-	if err != nil {
-		utils.Error("Failed to get patient details for cancellation email",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return
+// appointmentNotification builds the notification of an appointment's patient, with the email of
+// its type when it has one. Without metadata the notification names the appointment and centre.
+func (service *ServicesHandler) appointmentNotification(
+	ctx context.Context,
+	appointment *db.Appointment,
+	notificationType db.NotificationType,
+	metadata map[string]interface{},
+) (userNotification, error) {
+	if metadata == nil {
+		metadata = map[string]interface{}{
+			"appointment_id":       appointment.ID,
+			"diagnostic_centre_id": appointment.DiagnosticCentreID,
+			"status":               string(appointment.Status),
+		}
 	}
-
-	// Get centre details
-	centre, err := service.diagnosticPort.GetDiagnosticCentre(
-		context.Background(),
-		appointment.DiagnosticCentreID,
-	)
+	title, message := appointmentNotificationText(domain.NotificationType(notificationType))
+	email, err := service.appointmentEmail(ctx, appointment, notificationType)
 	if err != nil {
-		utils.Error("Failed to get centre details for cancellation email",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return
-	}
-
-	data := emails.AppointmentEmailData{
-		EmailData: emails.EmailData{
-			AppName: "Diagnoxix",
-		},
-		PatientName:     patient.Fullname.String,
-		AppointmentID:   appointment.ID,
-		AppointmentDate: appointment.AppointmentDate.Time,
-		TimeSlot:        appointment.TimeSlot,
-		CentreName:      centre.DiagnosticCentreName,
-		Status:          string(appointment.Status),
-	}
-
-	
-	if err := service.notificationPort.SendEmail(
-		patient.Email.String,
-		emails.TitleAppointmentCancelled,
-		emails.AppointmentCancellationTemplate,
-		&data,
-	); err != nil {
-		utils.Error("Failed to send cancellation email",
-			utils.LogField{Key: "error", Value: err.Error()})
-	}
+		return userNotification{}, err
+	}
+	return userNotification{
+		UserID:   appointment.PatientID,
+		Type:     notificationType,
+		Title:    title,
+		Message:  message,
+		Metadata: metadata,
+		Email:    email,
+	}, nil
 }
 
-func (service *ServicesHandler) sendAppointmentRescheduleEmail(appointment *db.Appointment) {
-	// Get patient details by email
-	patient, err := service.userPort.GetUser(context.Background(), appointment.PatientID)
+// appointmentEmail builds the email an appointment notification sends, if its type has one
+func (service *ServicesHandler) appointmentEmail(
+	ctx context.Context,
+	appointment *db.Appointment,
+	notificationType db.NotificationType,
+) (*outboxEmail, error) {
+	var subject, template string
+	switch notificationType {
+	case db.NotificationTypeAPPOINTMENTCONFIRMED:
+		subject, template = emails.TitleAppointmentConfirmed, emails.TemplateAppointmentConfirmed
+	case db.NotificationTypeAPPOINTMENTCANCELLED:
+		subject, template = emails.TitleAppointmentCancelled, emails.AppointmentCancellationTemplate
+	case db.NotificationTypeAPPOINTMENTRESCHEDULED:
+		subject, template = emails.TitleAppointmentReschedule, emails.TemplateAppointmentReschedule
+	default:
+		return nil, nil
+	}
+
+	patient, err := service.userPort.GetUser(ctx, appointment.PatientID)
 	if err != nil {
-		utils.Error("Failed to get patient details for reschedule email",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return
+		return nil, fmt.Errorf("failed to get patient details for %s email: %w", template, err)
 	}
 
-	// Get centre details
-	centre, err := service.diagnosticPort.GetDiagnosticCentre(
-		context.Background(),
-		appointment.DiagnosticCentreID,
-	)
+	centre, err := service.diagnosticPort.GetDiagnosticCentre(ctx, appointment.DiagnosticCentreID)
 	if err != nil {
-		utils.Error("Failed to get centre details for reschedule email",
-			utils.LogField{Key: "error", Value: err.Error()})
-		return
+		return nil, fmt.Errorf("failed to get centre details for %s email: %w", template, err)
 	}
 
-	data := emails.AppointmentEmailData{
+	data := &emails.AppointmentEmailData{
 		EmailData: emails.EmailData{
 			AppName: "Diagnoxix",
 		},
@@ -888,17 +1408,63 @@ func (service *ServicesHandler) sendAppointmentRescheduleEmail(appointment *db.A
 		CentreName:      centre.DiagnosticCentreName,
 		Status:          string(appointment.Status),
 	}
+	if notificationType == db.NotificationTypeAPPOINTMENTCONFIRMED {
+		// Get schedule details for test type
+		schedule, err := service.schedulePort.GetDiagnosticScheduleByCentre(
+			ctx,
+			db.Get_Diagnsotic_Schedule_By_CentreParams{
+				ID:                 appointment.ScheduleID,
+				DiagnosticCentreID: appointment.DiagnosticCentreID,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schedule details for confirmation email: %w", err)
+		}
+		data.Status = ""
+		data.TestType = string(schedule.TestType)
+		data.Notes = appointment.Notes.String
+	}
 
+	return &outboxEmail{
+		To:       patient.Email.String,
+		Subject:  subject,
+		Template: template,
+		Data:     data,
+	}, nil
+}
 
-	if err := service.notificationPort.SendEmail(
-		patient.Email.String,
-		emails.TitleAppointmentReschedule,
-		emails.TemplateAppointmentReschedule,
-		&data,
-	); err != nil {
-		utils.Error("Failed to send reschedule email",
-			utils.LogField{Key: "error", Value: err.Error()})
-	}
+// sendAppointmentReminder reminds the patient of an upcoming appointment, on the channels they
+// chose for reminders
+func (service *ServicesHandler) sendAppointmentReminder(row *db.GetUpComingAppointmentsRow) error {
+	title, message := appointmentNotificationText(domain.AppointmentReminder)
+	return service.notify(context.Background(), userNotification{
+		UserID:  row.PatientID,
+		Type:    db.NotificationTypeAPPOINTMENTREMINDER,
+		Title:   title,
+		Message: message,
+		Metadata: map[string]interface{}{
+			"appointment_id":       row.ID,
+			"diagnostic_centre_id": row.DiagnosticCentreID,
+			"appointment_date":     row.AppointmentDate.Time,
+			"time_slot":            row.TimeSlot,
+		},
+		Email: &outboxEmail{
+			To:       row.Email.String,
+			Subject:  "Appointment Reminder",
+			Template: emails.TemplateAppointmentReminder,
+			Data: &emails.AppointmentEmailData{
+				EmailData: emails.EmailData{
+					AppName: "Diagnoxix",
+				},
+				PatientName:     row.FullName.String,
+				AppointmentID:   row.ID,
+				AppointmentDate: row.AppointmentDate.Time,
+				TimeSlot:        row.TimeSlot,
+				CentreName:      row.DiagnosticCentreName.String,
+				Status:          string(row.Status),
+			},
+		},
+	})
 }
 
 // notifyDiagnosticCentreOfNewAppointment notifies the diagnostic centre about a new appointment
@@ -956,13 +1522,13 @@ func (service *ServicesHandler) NotifyDiagnosticCentreOfNewAppointment(
 	}
 
 	// Send email to diagnostic centre's primary email
-	if err := service.notificationPort.SendEmail(
-		contact.Email,
-		emails.TitleStaffNotification,
-		emails.TemplateStaffNotification,
-		&data,
-	); err != nil {
-		utils.Error("Failed to send centre notification email",
+	if err := service.queueEmail(ctx, service.outboxPort, outboxEmail{
+		To:       contact.Email,
+		Subject:  emails.TitleStaffNotification,
+		Template: emails.TemplateStaffNotification,
+		Data:     &data,
+	}); err != nil {
+		utils.Error("Failed to queue centre notification email",
 			utils.LogField{Key: "error", Value: err.Error()})
 	}
 
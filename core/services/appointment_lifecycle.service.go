@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// appointmentAction is a step a centre takes to move an appointment through its visit
+type appointmentAction string
+
+const (
+	actionCheckIn  appointmentAction = "check_in"
+	actionStart    appointmentAction = "start"
+	actionComplete appointmentAction = "complete"
+	actionNoShow   appointmentAction = "no_show"
+)
+
+var (
+	ErrIllegalTransition = errors.New("appointment cannot make this transition from its current status")
+	ErrNotAppointmentDay = errors.New("appointment can only be checked in on the day it is booked for")
+	ErrNoShowTooEarly    = errors.New("appointment cannot be marked as a no-show before its start time")
+)
+
+// appointmentTransition is one edge of the centre-side appointment state machine
+type appointmentTransition struct {
+	from         []db.AppointmentStatus
+	to           db.AppointmentStatus
+	notification db.NotificationType
+}
+
+// appointmentTransitions is the centre-side state machine:
+//
+//	confirmed -> checked_in -> in_progress -> completed
+//	confirmed -> no_show
+var appointmentTransitions = map[appointmentAction]appointmentTransition{
+	actionCheckIn: {
+		from:         []db.AppointmentStatus{db.AppointmentStatusConfirmed},
+		to:           db.AppointmentStatusCheckedIn,
+		notification: db.NotificationTypeAPPOINTMENTCHECKEDIN,
+	},
+	actionStart: {
+		from:         []db.AppointmentStatus{db.AppointmentStatusCheckedIn},
+		to:           db.AppointmentStatusInProgress,
+		notification: db.NotificationTypeAPPOINTMENTSTARTED,
+	},
+	actionComplete: {
+		from:         []db.AppointmentStatus{db.AppointmentStatusInProgress},
+		to:           db.AppointmentStatusCompleted,
+		notification: db.NotificationTypeAPPOINTMENTCOMPLETED,
+	},
+	actionNoShow: {
+		from:         []db.AppointmentStatus{db.AppointmentStatusConfirmed},
+		to:           db.AppointmentStatusNoShow,
+		notification: db.NotificationTypeAPPOINTMENTNOSHOW,
+	},
+}
+
+// ListCentreAppointments lists a diagnostic centre's appointments for a day, today by default
+func (service *ServicesHandler) ListCentreAppointments(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.ListCentreAppointmentsDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid query parameters"), context)
+	}
+
+	day := truncateToDay(time.Now())
+	if dto.Date != "" {
+		day, err = time.Parse("2006-01-02", dto.Date)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusBadRequest, fmt.Errorf("invalid date: %w", err), context)
+		}
+	}
+
+	statuses := make([]string, 0, len(db.AllAppointmentStatusValues()))
+	if dto.Status != "" {
+		statuses = append(statuses, string(dto.Status))
+	} else {
+		for _, status := range db.AllAppointmentStatusValues() {
+			if status != db.AppointmentStatusCancelled && status != db.AppointmentStatusRescheduled {
+				statuses = append(statuses, string(status))
+			}
+		}
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	appointments, err := service.appointmentPort.GetCentreAppointments(ctx, db.GetCentreAppointmentsParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		Column2:            statuses,
+		AppointmentDate:    toTimestamptz(day),
+		AppointmentDate_2:  toTimestamptz(day.AddDate(0, 0, 1)),
+		Limit:              maxBookingsPerRange,
+		Offset:             0,
+	})
+	if err != nil {
+		utils.Error("Failed to list centre appointments",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if len(appointments) == 0 {
+		return utils.ResponseMessage(http.StatusOK, []*db.GetCentreAppointmentsRow{}, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, appointments, context)
+}
+
+// CheckInAppointment records the patient's arrival at the centre
+func (service *ServicesHandler) CheckInAppointment(context echo.Context) error {
+	return service.transitionAppointment(context, actionCheckIn)
+}
+
+// StartAppointment marks the test as under way
+func (service *ServicesHandler) StartAppointment(context echo.Context) error {
+	return service.transitionAppointment(context, actionStart)
+}
+
+// CompleteAppointment marks the test as done
+func (service *ServicesHandler) CompleteAppointment(context echo.Context) error {
+	return service.transitionAppointment(context, actionComplete)
+}
+
+// MarkAppointmentNoShow closes an appointment the patient did not turn up for
+func (service *ServicesHandler) MarkAppointmentNoShow(context echo.Context) error {
+	return service.transitionAppointment(context, actionNoShow)
+}
+
+// transitionAppointment applies a state machine action to an appointment of the caller's centre
+func (service *ServicesHandler) transitionAppointment(context echo.Context, action appointmentAction) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.AppointmentTransitionDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	appointment, err := service.appointmentPort.GetAppointment(ctx, dto.AppointmentID)
+	if err != nil || appointment.DiagnosticCentreID != dto.DiagnosticCentreID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("appointment not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	next, err := nextAppointmentStatus(appointment, action, time.Now().UTC())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusConflict, err, context)
+	}
+
+	updated, err := service.appointmentPort.UpdateAppointmentStatus(ctx, db.UpdateAppointmentStatusParams{
+		ID:       appointment.ID,
+		Status:   next,
+		Status_2: appointment.Status,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Another request moved the appointment on since it was read
+			return utils.ErrorResponse(http.StatusConflict, ErrIllegalTransition, context)
+		}
+		utils.Error("Failed to update appointment status",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID},
+			utils.LogField{Key: "action", Value: string(action)})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	utils.Info("Appointment status updated",
+		utils.LogField{Key: "appointment_id", Value: updated.ID},
+		utils.LogField{Key: "from", Value: string(appointment.Status)},
+		utils.LogField{Key: "to", Value: string(updated.Status)},
+		utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+
+	go service.notifyAppointmentTransition(updated, appointmentTransitions[action].notification)
+
+	return utils.ResponseMessage(http.StatusOK, updated, context)
+}
+
+// nextAppointmentStatus validates an action against the state machine and the appointment time
+func nextAppointmentStatus(
+	appointment *db.Appointment,
+	action appointmentAction,
+	now time.Time,
+) (db.AppointmentStatus, error) {
+	transition, ok := appointmentTransitions[action]
+	if !ok || !slices.Contains(transition.from, appointment.Status) {
+		return "", fmt.Errorf("%w: %s -> %s", ErrIllegalTransition, appointment.Status, action)
+	}
+
+	switch action {
+	case actionCheckIn:
+		if !truncateToDay(appointment.AppointmentDate.Time).Equal(truncateToDay(now)) {
+			return "", ErrNotAppointmentDay
+		}
+	case actionNoShow:
+		if now.Before(appointment.AppointmentDate.Time) {
+			return "", ErrNoShowTooEarly
+		}
+	}
+	return transition.to, nil
+}
+
+// notifyAppointmentTransition tells the patient their appointment moved on
+func (service *ServicesHandler) notifyAppointmentTransition(
+	appointment *db.Appointment,
+	notificationType db.NotificationType,
+) {
+	title, message := appointmentTransitionMessage(notificationType)
+	if err := service.notify(context.Background(), userNotification{
+		UserID:  appointment.PatientID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		Metadata: map[string]interface{}{
+			"appointment_id":       appointment.ID,
+			"diagnostic_centre_id": appointment.DiagnosticCentreID,
+			"status":               string(appointment.Status),
+		},
+	}); err != nil {
+		utils.Error("Failed to send appointment status notification",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+	}
+}
+
+func appointmentTransitionMessage(notificationType db.NotificationType) (string, string) {
+	switch notificationType {
+	case db.NotificationTypeAPPOINTMENTCHECKEDIN:
+		return "Checked In", "You have been checked in. Please wait to be called."
+	case db.NotificationTypeAPPOINTMENTSTARTED:
+		return "Appointment Started", "Your test is now in progress."
+	case db.NotificationTypeAPPOINTMENTCOMPLETED:
+		return "Appointment Completed", "Your test is complete. You will be notified when your results are ready."
+	case db.NotificationTypeAPPOINTMENTNOSHOW:
+		return "Missed Appointment", "You missed your appointment. Please book a new one if you still need the test."
+	default:
+		return "Appointment Update", "Your appointment status has been updated."
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestNextAppointmentStatus(t *testing.T) {
+	start := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	sameDay := start.Add(-time.Hour)
+	dayBefore := start.AddDate(0, 0, -1)
+	later := start.Add(2 * time.Hour)
+
+	appointment := func(status db.AppointmentStatus) *db.Appointment {
+		return &db.Appointment{
+			Status:          status,
+			AppointmentDate: pgtype.Timestamptz{Time: start, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		status  db.AppointmentStatus
+		action  appointmentAction
+		now     time.Time
+		want    db.AppointmentStatus
+		wantErr error
+	}{
+		{"check in", db.AppointmentStatusConfirmed, actionCheckIn, sameDay, db.AppointmentStatusCheckedIn, nil},
+		{"start", db.AppointmentStatusCheckedIn, actionStart, later, db.AppointmentStatusInProgress, nil},
+		{"complete", db.AppointmentStatusInProgress, actionComplete, later, db.AppointmentStatusCompleted, nil},
+		{"no show", db.AppointmentStatusConfirmed, actionNoShow, later, db.AppointmentStatusNoShow, nil},
+		{"check in unpaid", db.AppointmentStatusPending, actionCheckIn, sameDay, "", ErrIllegalTransition},
+		{"start completed", db.AppointmentStatusCompleted, actionStart, later, "", ErrIllegalTransition},
+		{"complete without start", db.AppointmentStatusConfirmed, actionComplete, later, "", ErrIllegalTransition},
+		{"no show after check in", db.AppointmentStatusCheckedIn, actionNoShow, later, "", ErrIllegalTransition},
+		{"check in a day early", db.AppointmentStatusConfirmed, actionCheckIn, dayBefore, "", ErrNotAppointmentDay},
+		{"no show before start", db.AppointmentStatusConfirmed, actionNoShow, sameDay, "", ErrNoShowTooEarly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextAppointmentStatus(appointment(tt.status), tt.action, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v; want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("nextAppointmentStatus() = %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
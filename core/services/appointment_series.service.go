@@ -0,0 +1,718 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Bounds on the number of appointments a single series may book
+const (
+	minSeriesOccurrences = 2
+	maxSeriesOccurrences = 12
+)
+
+var (
+	ErrInvalidSeriesRule  = errors.New("invalid recurrence rule")
+	ErrSeriesDatePast     = errors.New("series occurrences must be in the future")
+	ErrAppointmentPaidFor = errors.New("appointment already has a payment")
+	ErrSeriesCancelled    = errors.New("appointment series is already cancelled")
+)
+
+// CreateAppointmentSeries books every occurrence of a recurring series in one transaction
+func (service *ServicesHandler) CreateAppointmentSeries(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.CreateAppointmentSeriesDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	ctx := context.Request().Context()
+	testType := strings.ToUpper(strings.ReplaceAll(dto.TestType, " ", "_"))
+	if !service.appointmentPort.IsValidTestType(ctx, testType) {
+		return utils.ErrorResponse(http.StatusBadRequest, fmt.Errorf("invalid test type: %s", testType), context)
+	}
+
+	recurrence := db.SeriesRecurrence(dto.Recurrence)
+	dates, err := seriesOccurrences(
+		recurrence,
+		dto.StartDate.UTC(),
+		dto.IntervalWeeks,
+		dto.Occurrences,
+		dto.Dates,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, context)
+	}
+
+	// Every occurrence must match a free slot before anything is booked
+	patientID := currentUser.UserID.String()
+	slots := make([]*domain.AvailableSlot, 0, len(dates))
+	for _, date := range dates {
+		slot, err := service.findFreeSlot(ctx, dto.DiagnosticCentreID, date, patientID)
+		if err != nil {
+			utils.Error("Failed to match series slot",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID},
+				utils.LogField{Key: "appointment_date", Value: date})
+			if errors.Is(err, ErrSlotUnavailable) || errors.Is(err, ErrSlotFullyBooked) {
+				return utils.ErrorResponse(
+					http.StatusUnprocessableEntity,
+					fmt.Errorf("%w: %s", err, date.Format(time.RFC3339)),
+					context,
+				)
+			}
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+		slots = append(slots, slot)
+	}
+
+	// Every occurrence is charged the price quoted today
+	quote, err := service.quoteAppointment(ctx, dto.DiagnosticCentreID, testType)
+	if err != nil {
+		utils.Error("Failed to price appointment series",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID},
+			utils.LogField{Key: "test_type", Value: testType})
+		if errors.Is(err, ErrNoActivePrice) {
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	tx, err := service.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		utils.Error("Failed to start transaction",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(ctx)
+
+	var intervalWeeks pgtype.Int4
+	if recurrence == db.SeriesRecurrenceEveryNWeeks {
+		intervalWeeks = pgtype.Int4{Int32: int32(dto.IntervalWeeks), Valid: true}
+	}
+	series, err := tx.CreateAppointmentSeries(ctx, db.Create_Appointment_SeriesParams{
+		PatientID:          patientID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		TestType:           testType,
+		Recurrence:         recurrence,
+		IntervalWeeks:      intervalWeeks,
+		PaymentMode:        db.SeriesPaymentMode(dto.PaymentMode),
+		Notes:              toText(dto.Notes),
+	})
+	if err != nil {
+		utils.Error("Failed to create appointment series",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "patient_id", Value: patientID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	appointments := make([]*db.Appointment, 0, len(slots))
+	for i, slot := range slots {
+		schedule, err := tx.CreateSchedule(ctx, db.Create_Diagnostic_ScheduleParams{
+			UserID:             patientID,
+			DiagnosticCentreID: dto.DiagnosticCentreID,
+			ScheduleTime:       toTimestamptz(slot.StartTime),
+			Doctor:             dto.PreferredDoctor,
+			TestType:           testType,
+			Notes:              toText(dto.Notes),
+			AcceptanceStatus:   db.ScheduleAcceptanceStatusACCEPTED,
+		})
+		if err != nil {
+			utils.Error("Failed to create series schedule",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "series_id", Value: series.ID})
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+
+		appointment, err := tx.CreateAppointment(ctx, db.CreateAppointmentParams{
+			PatientID:          patientID,
+			ScheduleID:         schedule.ID,
+			DiagnosticCentreID: dto.DiagnosticCentreID,
+			AppointmentDate:    toTimestamptz(slot.StartTime),
+			TimeSlot:           slot.TimeSlot,
+			Status:             db.AppointmentStatusPending,
+			Notes:              toText(dto.Notes),
+		})
+		if err != nil {
+			utils.Error("Failed to create series appointment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "series_id", Value: series.ID})
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+
+		if err := createLineItems(ctx, tx, appointment.ID, quote); err != nil {
+			utils.Error("Failed to create series line items",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "series_id", Value: series.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+
+		if err := tx.AddSeriesOccurrence(ctx, db.Add_Series_OccurrenceParams{
+			SeriesID:         series.ID,
+			AppointmentID:    appointment.ID,
+			OccurrenceNumber: int32(i + 1),
+		}); err != nil {
+			utils.Error("Failed to add series occurrence",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "series_id", Value: series.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	metadata := map[string]interface{}{
+		"series_id":            series.ID,
+		"appointment_id":       appointments[0].ID,
+		"patient_id":           patientID,
+		"diagnostic_centre_id": dto.DiagnosticCentreID,
+		"test_type":            testType,
+		"occurrences":          len(appointments),
+		"test_price_id":        quote.TestPriceID,
+		"price":                quote.Price,
+		"fees":                 quote.Fees,
+	}
+
+	// Paid upfront, one payment settles the whole series and each occurrence carries its share.
+	// Paid per visit, each occurrence is paid on its own before the visit
+	var reference interface{}
+	if series.PaymentMode == db.SeriesPaymentModeUpfront {
+		payment, checkout, err := service.initializeAppointmentPayment(
+			ctx,
+			tx,
+			appointments[0],
+			roundAmount(quote.Total*float64(len(appointments))),
+			0,
+			quote.Currency,
+			metadata,
+		)
+		if err != nil {
+			utils.Error("Failed to initialize series payment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "series_id", Value: series.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+
+		for i, appointment := range appointments {
+			appointments[i], err = tx.UpdateAppointment(ctx, db.UpdateAppointmentPaymentParams{
+				ID:            appointment.ID,
+				PaymentID:     toUUID(payment.ID),
+				PaymentStatus: db.NullPaymentStatus{PaymentStatus: db.PaymentStatusPending, Valid: true},
+				PaymentAmount: toNumeric(quote.Total),
+				Status:        db.AppointmentStatusPending,
+			})
+			if err != nil {
+				utils.Error("Failed to attach series payment",
+					utils.LogField{Key: "error", Value: err.Error()},
+					utils.LogField{Key: "appointment_id", Value: appointment.ID})
+				return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+			}
+		}
+		reference = checkout
+	}
+
+	notification, err := service.dispatchNotification(ctx, tx, userNotification{
+		UserID:   patientID,
+		Type:     db.NotificationTypeAPPOINTMENTCREATED,
+		Title:    testType,
+		Message:  fmt.Sprintf("%d recurring %s appointments booked", len(appointments), testType),
+		Metadata: metadata,
+	})
+	if err != nil {
+		utils.Error("Failed to create series notification",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "series_id", Value: series.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		utils.Error("Failed to commit appointment series transaction",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(
+			http.StatusInternalServerError,
+			errors.New("failed to commit appointment series transaction"),
+			context,
+		)
+	}
+	notification.push(ctx)
+
+	for _, appointment := range appointments {
+		service.markWaitlistHoldBooked(ctx, appointment)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, map[string]interface{}{
+		"message":      "Appointment series created successfully",
+		"series":       series,
+		"appointments": appointments,
+		"reference":    reference,
+		"quote":        quote,
+	}, context)
+}
+
+// GetAppointmentSeries returns a patient's series with its appointments
+func (service *ServicesHandler) GetAppointmentSeries(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.GetAppointmentSeriesDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	series, err := service.seriesPort.GetAppointmentSeries(ctx, dto.SeriesID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("appointment series not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if series.PatientID != currentUser.UserID.String() {
+		return utils.ErrorResponse(http.StatusForbidden, errors.New("not authorized to view this appointment series"), context)
+	}
+
+	appointments, err := service.seriesPort.ListSeriesAppointments(ctx, series.ID)
+	if err != nil {
+		utils.Error("Failed to list series appointments",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "series_id", Value: series.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if appointments == nil {
+		appointments = []*db.Appointment{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, &domain.AppointmentSeriesDetail{
+		Series:       series,
+		Appointments: appointments,
+	}, context)
+}
+
+// CancelAppointmentSeries cancels every remaining occurrence of a series. Each occurrence
+// is charged the centre's cancellation fee on its own share, and each payment is refunded once
+func (service *ServicesHandler) CancelAppointmentSeries(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.CancelAppointmentSeriesDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	ctx := context.Request().Context()
+	patientID := currentUser.UserID.String()
+	series, err := service.seriesPort.GetAppointmentSeries(ctx, dto.SeriesID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("appointment series not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if series.PatientID != patientID {
+		return utils.ErrorResponse(http.StatusForbidden, errors.New("not authorized to cancel this appointment series"), context)
+	}
+
+	policy, err := service.centreCancellationPolicy(ctx, series.DiagnosticCentreID)
+	if err != nil {
+		utils.Error("Failed to get cancellation policy",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: series.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	// The series, its occurrences and their refunds are cancelled together, so none of them
+	// commits without the others
+	tx, err := service.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		utils.Error("Failed to begin series cancellation transaction",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "series_id", Value: dto.SeriesID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(ctx)
+
+	// Claiming the series first stops two cancellations from refunding twice
+	series, err = tx.CancelAppointmentSeries(ctx, db.Cancel_Appointment_SeriesParams{
+		ID:                 series.ID,
+		PatientID:          patientID,
+		CancellationReason: toText(dto.Reason),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusConflict, ErrSeriesCancelled, context)
+		}
+		utils.Error("Failed to cancel appointment series",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "series_id", Value: dto.SeriesID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	appointments, err := tx.ListSeriesAppointments(ctx, series.ID)
+	if err != nil {
+		utils.Error("Failed to list series appointments",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "series_id", Value: series.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	type seriesRefund struct {
+		payment      *db.Payment
+		amount       float64
+		appointments []*db.Appointment
+		cancellation []*domain.AppointmentCancellation
+		refund       *db.PaymentRefund
+	}
+	refunds := map[string]*seriesRefund{}
+	result := &domain.AppointmentSeriesCancellation{
+		Series:      series,
+		Occurrences: []*domain.AppointmentCancellation{},
+	}
+	now := time.Now()
+	for _, appointment := range appointments {
+		if appointment.Status != db.AppointmentStatusPending && appointment.Status != db.AppointmentStatusConfirmed {
+			continue
+		}
+
+		payment, err := service.successfulAppointmentPayment(ctx, appointment)
+		if err != nil {
+			utils.Error("Failed to get appointment payment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+		var amountPaid, fee, reschedulingFees float64
+		if payment != nil {
+			amountPaid, err = appointmentAmountPaid(appointment, payment)
+			if err != nil {
+				return utils.ErrorResponse(http.StatusInternalServerError, fmt.Errorf("invalid payment amount: %w", err), context)
+			}
+			fee = cancellationFee(policy, amountPaid, appointment.AppointmentDate.Time, now)
+			reschedulingFees, err = service.reschedulingFeesOwed(ctx, appointment.ID)
+			if err != nil {
+				utils.Error("Failed to get rescheduling fees",
+					utils.LogField{Key: "error", Value: err.Error()},
+					utils.LogField{Key: "appointment_id", Value: appointment.ID})
+				return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+			}
+		}
+
+		cancelledAppointment, err := tx.CancelAppointment(ctx, db.CancelAppointmentParams{
+			ID:                 appointment.ID,
+			CancellationReason: toText(dto.Reason),
+			CancelledBy:        toUUID(patientID),
+			CancellationFee:    toNumeric(fee),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				// The occurrence moved on since it was read; leave it be
+				continue
+			}
+			utils.Error("Failed to cancel series appointment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+
+		cancellation := &domain.AppointmentCancellation{
+			Appointment:      cancelledAppointment,
+			CancellationFee:  fee,
+			ReschedulingFees: reschedulingFees,
+			RefundStatus:     domain.RefundStatusNone,
+		}
+		result.Occurrences = append(result.Occurrences, cancellation)
+		result.CancellationFee = roundAmount(result.CancellationFee + fee)
+
+		if refund := roundAmount(amountPaid - fee - reschedulingFees); payment != nil && refund > 0 {
+			cancellation.RefundAmount = refund
+			entry, ok := refunds[payment.ID]
+			if !ok {
+				entry = &seriesRefund{payment: payment}
+				refunds[payment.ID] = entry
+			}
+			entry.amount = roundAmount(entry.amount + refund)
+			entry.appointments = append(entry.appointments, cancelledAppointment)
+			entry.cancellation = append(entry.cancellation, cancellation)
+		}
+	}
+
+	// An upfront payment is refunded once for all of its cancelled occurrences
+	for _, entry := range refunds {
+		destination := refundDestination(entry.payment, dto.RefundTo)
+		entry.refund, err = service.openCancellationRefund(
+			ctx,
+			tx,
+			entry.payment,
+			entry.amount,
+			dto.Reason,
+			patientID,
+			destination,
+			entry.appointments...,
+		)
+		if err != nil {
+			utils.Error("Failed to open refund of cancelled series",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "series_id", Value: series.ID},
+				utils.LogField{Key: "payment_id", Value: entry.payment.ID})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+		if entry.refund != nil {
+			destination = entry.refund.Destination
+		}
+		for _, cancellation := range entry.cancellation {
+			cancellation.RefundDestination = destination
+		}
+		result.RefundAmount = roundAmount(result.RefundAmount + entry.amount)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		utils.Error("Failed to commit series cancellation",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "series_id", Value: series.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	for _, entry := range refunds {
+		status := service.sendCancellationRefund(ctx, entry.payment, entry.refund)
+		for _, cancellation := range entry.cancellation {
+			cancellation.RefundStatus = status
+		}
+	}
+
+	for _, cancellation := range result.Occurrences {
+		if err := service.notifyAppointment(ctx, cancellation.Appointment, db.NotificationTypeAPPOINTMENTCANCELLED, map[string]interface{}{
+			"appointment_id":       cancellation.Appointment.ID,
+			"series_id":            series.ID,
+			"diagnostic_centre_id": series.DiagnosticCentreID,
+			"reason":               dto.Reason,
+			"cancellation_fee":     cancellation.CancellationFee,
+			"refund_amount":        cancellation.RefundAmount,
+		}); err != nil {
+			utils.Error("Failed to send series cancel notification",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: cancellation.Appointment.ID})
+		}
+		go service.offerFreedSlot(cancellation.Appointment.DiagnosticCentreID, cancellation.Appointment.AppointmentDate.Time)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, result, context)
+}
+
+// InitializeAppointmentPayment opens a payment for a single pending appointment, such as
+// an occurrence of a series paid per visit
+func (service *ServicesHandler) InitializeAppointmentPayment(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.InitializeAppointmentPaymentDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	appointment, err := service.appointmentPort.GetAppointment(ctx, dto.AppointmentID)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusNotFound, errors.New("appointment not found"), context)
+	}
+	if appointment.PatientID != currentUser.UserID.String() {
+		return utils.ErrorResponse(http.StatusForbidden, errors.New("not authorized to pay for this appointment"), context)
+	}
+	if appointment.Status != db.AppointmentStatusPending {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("appointment cannot be paid in its current state"), context)
+	}
+	if appointment.PaymentID.Valid {
+		return utils.ErrorResponse(http.StatusConflict, ErrAppointmentPaidFor, context)
+	}
+
+	schedule, err := service.schedulePort.GetDiagnosticScheduleByCentre(
+		ctx,
+		db.Get_Diagnsotic_Schedule_By_CentreParams{
+			ID:                 appointment.ScheduleID,
+			DiagnosticCentreID: appointment.DiagnosticCentreID,
+		},
+	)
+	if err != nil {
+		utils.Error("Failed to get appointment schedule",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "schedule_id", Value: appointment.ScheduleID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	// The visit is charged the centre's prices on the day it is paid for
+	testTypes := []string{schedule.TestType}
+	items, err := service.lineItemPort.ListAppointmentLineItems(ctx, appointment.ID)
+	if err != nil {
+		utils.Error("Failed to list appointment line items",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if len(items) > 0 {
+		testTypes = testTypes[:0]
+		for _, item := range items {
+			testTypes = append(testTypes, item.TestType)
+		}
+	}
+	quote, err := service.quoteAppointmentTests(ctx, appointment.DiagnosticCentreID, testTypes)
+	if err != nil {
+		utils.Error("Failed to price appointment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		if errors.Is(err, ErrNoActivePrice) {
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	tx, err := service.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		utils.Error("Failed to start transaction",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(ctx)
+
+	payment, checkout, err := service.initializeAppointmentPayment(
+		ctx,
+		tx,
+		appointment,
+		quote.Total,
+		0,
+		quote.Currency,
+		map[string]interface{}{
+			"appointment_id":       appointment.ID,
+			"patient_id":           appointment.PatientID,
+			"diagnostic_centre_id": appointment.DiagnosticCentreID,
+			"test_type":            schedule.TestType,
+			"test_types":           testTypes,
+			"scheduled_time":       schedule.ScheduleTime,
+			"test_price_id":        quote.TestPriceID,
+			"price":                quote.Price,
+			"fees":                 quote.Fees,
+		},
+	)
+	if err != nil {
+		utils.Error("Failed to initialize payment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	updatedAppointment, err := tx.UpdateAppointment(ctx, db.UpdateAppointmentPaymentParams{
+		ID:            appointment.ID,
+		PaymentID:     toUUID(payment.ID),
+		PaymentStatus: db.NullPaymentStatus{PaymentStatus: db.PaymentStatusPending, Valid: true},
+		PaymentAmount: payment.Amount,
+		Status:        db.AppointmentStatusPending,
+	})
+	if err != nil {
+		utils.Error("Failed to update appointment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		utils.Error("Failed to commit payment transaction",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, errors.New("failed to commit payment transaction"), context)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, map[string]interface{}{
+		"message":     "Payment initialized successfully",
+		"appointment": updatedAppointment,
+		"reference":   checkout,
+		"quote":       quote,
+	}, context)
+}
+
+// seriesOccurrences expands a recurrence rule into the start times of its appointments.
+// Monthly occurrences keep the day of the month, falling back to the last day of shorter months
+func seriesOccurrences(
+	recurrence db.SeriesRecurrence,
+	start time.Time,
+	intervalWeeks int,
+	occurrences int,
+	dates []time.Time,
+	now time.Time,
+) ([]time.Time, error) {
+	var result []time.Time
+	switch recurrence {
+	case db.SeriesRecurrenceDates:
+		result = make([]time.Time, 0, len(dates))
+		for _, date := range dates {
+			result = append(result, date.UTC())
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+		for i := 1; i < len(result); i++ {
+			if result[i].Equal(result[i-1]) {
+				return nil, fmt.Errorf("%w: duplicate date %s", ErrInvalidSeriesRule, result[i].Format(time.RFC3339))
+			}
+		}
+	case db.SeriesRecurrenceWeekly, db.SeriesRecurrenceEveryNWeeks, db.SeriesRecurrenceMonthly:
+		if start.IsZero() {
+			return nil, fmt.Errorf("%w: start_date is required", ErrInvalidSeriesRule)
+		}
+		weeks := 1
+		if recurrence == db.SeriesRecurrenceEveryNWeeks {
+			if intervalWeeks < 2 {
+				return nil, fmt.Errorf("%w: interval_weeks must be at least 2", ErrInvalidSeriesRule)
+			}
+			weeks = intervalWeeks
+		}
+		if occurrences < minSeriesOccurrences || occurrences > maxSeriesOccurrences {
+			return nil, fmt.Errorf("%w: occurrences must be between %d and %d",
+				ErrInvalidSeriesRule, minSeriesOccurrences, maxSeriesOccurrences)
+		}
+		result = make([]time.Time, 0, occurrences)
+		for i := 0; i < occurrences; i++ {
+			if recurrence == db.SeriesRecurrenceMonthly {
+				result = append(result, addMonthsClamped(start, i))
+				continue
+			}
+			result = append(result, start.AddDate(0, 0, 7*weeks*i))
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown recurrence %q", ErrInvalidSeriesRule, recurrence)
+	}
+
+	if len(result) < minSeriesOccurrences || len(result) > maxSeriesOccurrences {
+		return nil, fmt.Errorf("%w: a series books between %d and %d appointments",
+			ErrInvalidSeriesRule, minSeriesOccurrences, maxSeriesOccurrences)
+	}
+	if !result[0].After(now) {
+		return nil, ErrSeriesDatePast
+	}
+	return result, nil
+}
+
+// addMonthsClamped adds months to t without spilling into the following month
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+func TestSeriesOccurrences(t *testing.T) {
+	now := time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	start := time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		recurrence    db.SeriesRecurrence
+		start         time.Time
+		intervalWeeks int
+		occurrences   int
+		dates         []time.Time
+		want          []time.Time
+		wantErr       error
+	}{
+		{
+			name:        "weekly",
+			recurrence:  db.SeriesRecurrenceWeekly,
+			start:       start,
+			occurrences: 3,
+			want:        []time.Time{day(1, 31), day(2, 7), day(2, 14)},
+		},
+		{
+			name:          "every four weeks",
+			recurrence:    db.SeriesRecurrenceEveryNWeeks,
+			start:         start,
+			intervalWeeks: 4,
+			occurrences:   3,
+			want:          []time.Time{day(1, 31), day(2, 28), day(3, 28)},
+		},
+		{
+			name:        "monthly keeps the day where the month allows",
+			recurrence:  db.SeriesRecurrenceMonthly,
+			start:       start,
+			occurrences: 4,
+			want:        []time.Time{day(1, 31), day(2, 28), day(3, 31), day(4, 30)},
+		},
+		{
+			name:       "dates are sorted",
+			recurrence: db.SeriesRecurrenceDates,
+			dates:      []time.Time{day(3, 5), day(1, 20), day(2, 11)},
+			want:       []time.Time{day(1, 20), day(2, 11), day(3, 5)},
+		},
+		{
+			name:       "duplicate dates",
+			recurrence: db.SeriesRecurrenceDates,
+			dates:      []time.Time{day(1, 20), day(1, 20)},
+			wantErr:    ErrInvalidSeriesRule,
+		},
+		{
+			name:       "single date",
+			recurrence: db.SeriesRecurrenceDates,
+			dates:      []time.Time{day(1, 20)},
+			wantErr:    ErrInvalidSeriesRule,
+		},
+		{
+			name:          "interval too short",
+			recurrence:    db.SeriesRecurrenceEveryNWeeks,
+			start:         start,
+			intervalWeeks: 1,
+			occurrences:   3,
+			wantErr:       ErrInvalidSeriesRule,
+		},
+		{
+			name:        "too many occurrences",
+			recurrence:  db.SeriesRecurrenceWeekly,
+			start:       start,
+			occurrences: 13,
+			wantErr:     ErrInvalidSeriesRule,
+		},
+		{
+			name:        "missing start date",
+			recurrence:  db.SeriesRecurrenceWeekly,
+			occurrences: 3,
+			wantErr:     ErrInvalidSeriesRule,
+		},
+		{
+			name:        "starts in the past",
+			recurrence:  db.SeriesRecurrenceWeekly,
+			start:       day(1, 3),
+			occurrences: 3,
+			wantErr:     ErrSeriesDatePast,
+		},
+		{
+			name:       "unknown recurrence",
+			recurrence: "daily",
+			start:      start,
+			wantErr:    ErrInvalidSeriesRule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seriesOccurrences(tt.recurrence, tt.start, tt.intervalWeeks, tt.occurrences, tt.dates, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v; want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences; want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %s; want %s", i+1, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
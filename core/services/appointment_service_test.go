@@ -1 +1,38 @@
 package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestValidateReschedule(t *testing.T) {
+	current := time.Date(2025, 9, 2, 10, 0, 0, 0, time.UTC)
+	appointment := &db.Appointment{AppointmentDate: pgtype.Timestamptz{Time: current, Valid: true}}
+
+	tests := []struct {
+		name     string
+		newStart time.Time
+		count    int
+		max      int
+		wantErr  error
+	}{
+		{"first reschedule", current.Add(24 * time.Hour), 0, 2, nil},
+		{"last allowed reschedule", current.Add(time.Hour), 1, 2, nil},
+		{"limit reached", current.Add(time.Hour), 2, 2, ErrRescheduleLimitReached},
+		{"rescheduling disabled", current.Add(time.Hour), 0, 0, ErrRescheduleLimitReached},
+		{"same time", current, 0, 2, ErrSameAppointmentTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateReschedule(appointment, tt.newStart, tt.count, tt.max)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateReschedule() error = %v; want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/templates/emails"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultExceptionRangeDays is how far ahead exceptions are listed when no end date is given
+const defaultExceptionRangeDays = 365
+
+// disruptableStatuses are the booking statuses a new closure can still affect
+var disruptableStatuses = []string{
+	string(db.AppointmentStatusPending),
+	string(db.AppointmentStatusConfirmed),
+}
+
+// ListAvailabilityExceptions lists a centre's closures and overrides in a date range
+func (service *ServicesHandler) ListAvailabilityExceptions(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.ListAvailabilityExceptionsDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid query parameters"), context)
+	}
+
+	from := truncateToDay(time.Now())
+	if dto.From != "" {
+		from, _ = time.Parse("2006-01-02", dto.From)
+	}
+	to := from.AddDate(0, 0, defaultExceptionRangeDays)
+	if dto.To != "" {
+		to, _ = time.Parse("2006-01-02", dto.To)
+	}
+	if to.Before(from) {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("to date must not be before from date"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	exceptions, err := service.availabilityPort.ListAvailabilityExceptions(ctx, db.List_Availability_ExceptionsParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		ExceptionDate:      toDate(from),
+		ExceptionDate_2:    toDate(to),
+	})
+	if err != nil {
+		utils.Error("Failed to list availability exceptions",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if len(exceptions) == 0 {
+		return utils.ResponseMessage(http.StatusOK, []*db.DiagnosticCentreAvailabilityException{}, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, exceptions, context)
+}
+
+// CreateAvailabilityException closes a centre for a day or overrides its hours or capacity,
+// replacing any exception already set for that day. Patients booked outside the new
+// opening hours are notified.
+func (service *ServicesHandler) CreateAvailabilityException(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.CreateAvailabilityExceptionDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	params, err := buildAvailabilityExceptionParams(dto, time.Now().UTC())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, context)
+	}
+	params.CreatedBy = currentUser.UserID.String()
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	exception, err := service.availabilityPort.UpsertAvailabilityException(ctx, params)
+	if err != nil {
+		utils.Error("Failed to create availability exception",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID},
+			utils.LogField{Key: "date", Value: dto.Date})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	affected, err := service.disruptedAppointments(ctx, exception)
+	if err != nil {
+		// The exception is saved; failing to look up bookings must not undo it
+		utils.Error("Failed to find appointments affected by availability exception",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "exception_id", Value: exception.ID})
+	}
+	if len(affected) > 0 {
+		go service.notifyDisruptedAppointments(exception, affected)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, domain.AvailabilityExceptionResponse{
+		Exception:            exception,
+		AffectedAppointments: len(affected),
+	}, context)
+}
+
+// DeleteAvailabilityException reopens a day on the centre's regular weekly hours
+func (service *ServicesHandler) DeleteAvailabilityException(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.AvailabilityExceptionParamDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	params := db.Get_Availability_ExceptionParams{
+		ID:                 dto.ExceptionID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+	}
+	if _, err := service.availabilityPort.GetAvailabilityException(ctx, params); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("availability exception not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if err := service.availabilityPort.DeleteAvailabilityException(ctx, db.Delete_Availability_ExceptionParams{
+		ID:                 dto.ExceptionID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+	}); err != nil {
+		utils.Error("Failed to delete availability exception",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "exception_id", Value: dto.ExceptionID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(
+		http.StatusOK,
+		map[string]string{"message": "Availability exception deleted successfully"},
+		context,
+	)
+}
+
+// buildAvailabilityExceptionParams validates the request and converts it to query params
+func buildAvailabilityExceptionParams(
+	dto *domain.CreateAvailabilityExceptionDTO,
+	now time.Time,
+) (db.Upsert_Availability_ExceptionParams, error) {
+	params := db.Upsert_Availability_ExceptionParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		IsClosed:           dto.IsClosed,
+		Reason:             strings.TrimSpace(dto.Reason),
+	}
+
+	date, err := time.Parse("2006-01-02", dto.Date)
+	if err != nil {
+		return params, fmt.Errorf("invalid date: %w", err)
+	}
+	if date.Before(truncateToDay(now)) {
+		return params, errors.New("cannot add an exception for a past date")
+	}
+	params.ExceptionDate = toDate(date)
+
+	// A closure ignores any hours or capacity sent along with it
+	if dto.IsClosed {
+		return params, nil
+	}
+
+	if dto.StartTime != "" {
+		if params.StartTime, err = parseClockTime(dto.StartTime); err != nil {
+			return params, fmt.Errorf("invalid start time: %w", err)
+		}
+		if params.EndTime, err = parseClockTime(dto.EndTime); err != nil {
+			return params, fmt.Errorf("invalid end time: %w", err)
+		}
+		if params.StartTime.Microseconds >= params.EndTime.Microseconds {
+			return params, errors.New("end time must be after start time")
+		}
+	}
+	if dto.MaxAppointments != nil {
+		params.MaxAppointments = pgtype.Int4{Int32: *dto.MaxAppointments, Valid: true}
+	}
+	if !params.StartTime.Valid && !params.MaxAppointments.Valid {
+		return params, errors.New("an override must set opening hours or max appointments, or close the day")
+	}
+	return params, nil
+}
+
+// disruptedAppointments finds the active bookings that fall outside the exception's opening hours.
+// Bookings above a reduced capacity are kept, since they were accepted under the old limit.
+func (service *ServicesHandler) disruptedAppointments(
+	ctx context.Context,
+	exception *db.DiagnosticCentreAvailabilityException,
+) ([]*db.GetCentreAppointmentsRow, error) {
+	if !exception.IsClosed && !exception.StartTime.Valid {
+		return nil, nil
+	}
+
+	day := truncateToDay(exception.ExceptionDate.Time)
+	booked, err := service.appointmentPort.GetCentreAppointments(ctx, db.GetCentreAppointmentsParams{
+		DiagnosticCentreID: exception.DiagnosticCentreID,
+		Column2:            disruptableStatuses,
+		AppointmentDate:    toTimestamptz(day),
+		AppointmentDate_2:  toTimestamptz(day.AddDate(0, 0, 1)),
+		Limit:              maxBookingsPerRange,
+		Offset:             0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booked appointments: %w", err)
+	}
+
+	affected := make([]*db.GetCentreAppointmentsRow, 0, len(booked))
+	for _, appointment := range booked {
+		if isDisruptedBy(exception, appointment.AppointmentDate.Time, appointment.TimeSlot) {
+			affected = append(affected, appointment)
+		}
+	}
+	return affected, nil
+}
+
+// isDisruptedBy reports whether a booking no longer fits in the exception's opening hours
+func isDisruptedBy(exception *db.DiagnosticCentreAvailabilityException, start time.Time, timeSlot string) bool {
+	if exception.IsClosed {
+		return true
+	}
+	if !exception.StartTime.Valid || !exception.EndTime.Valid {
+		return false
+	}
+
+	day := truncateToDay(start)
+	opens := day.Add(time.Duration(exception.StartTime.Microseconds) * time.Microsecond)
+	closes := day.Add(time.Duration(exception.EndTime.Microseconds) * time.Microsecond)
+
+	end := start
+	if parts := strings.Split(timeSlot, "-"); len(parts) == 2 {
+		if slotEnd, err := time.Parse("15:04", parts[1]); err == nil {
+			end = day.Add(time.Duration(slotEnd.Hour())*time.Hour + time.Duration(slotEnd.Minute())*time.Minute)
+		}
+	}
+	return start.UTC().Before(opens) || end.After(closes)
+}
+
+// notifyDisruptedAppointments tells each affected patient, in-app and by email, that their booking needs rescheduling
+func (service *ServicesHandler) notifyDisruptedAppointments(
+	exception *db.DiagnosticCentreAvailabilityException,
+	affected []*db.GetCentreAppointmentsRow,
+) {
+	ctx := context.Background()
+	centre, err := service.diagnosticPort.GetDiagnosticCentre(ctx, exception.DiagnosticCentreID)
+	if err != nil {
+		utils.Error("Failed to get centre details for disruption notifications",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: exception.DiagnosticCentreID})
+		return
+	}
+
+	date := exception.ExceptionDate.Time.Format("2006-01-02")
+	message := fmt.Sprintf("%s is closed on %s. Please reschedule your appointment.", centre.DiagnosticCentreName, date)
+	if !exception.IsClosed {
+		message = fmt.Sprintf(
+			"%s has changed its hours on %s to %s. Please reschedule your appointment.",
+			centre.DiagnosticCentreName,
+			date,
+			formatClockRange(exception.StartTime, exception.EndTime),
+		)
+	}
+
+	for _, row := range affected {
+		appointment, err := service.appointmentPort.GetAppointment(ctx, row.ID)
+		if err != nil {
+			utils.Error("Failed to get disrupted appointment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: row.ID})
+			continue
+		}
+
+		email, err := service.appointmentDisruptionEmail(ctx, appointment, centre.DiagnosticCentreName, exception.Reason)
+		if err == nil {
+			err = service.notify(ctx, userNotification{
+				UserID:  appointment.PatientID,
+				Type:    db.NotificationTypeAPPOINTMENTDISRUPTED,
+				Title:   emails.TitleAppointmentDisrupted,
+				Message: message,
+				Metadata: map[string]interface{}{
+					"appointment_id":       appointment.ID,
+					"diagnostic_centre_id": appointment.DiagnosticCentreID,
+					"exception_id":         exception.ID,
+					"exception_date":       date,
+					"reason":               exception.Reason,
+				},
+				Email: email,
+			})
+		}
+		if err != nil {
+			utils.Error("Failed to send disruption notification",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		}
+	}
+
+	utils.Info("Notified patients of availability exception",
+		utils.LogField{Key: "exception_id", Value: exception.ID},
+		utils.LogField{Key: "affected_appointments", Value: len(affected)})
+}
+
+// appointmentDisruptionEmail builds the email telling a patient their booking needs rescheduling
+func (service *ServicesHandler) appointmentDisruptionEmail(
+	ctx context.Context,
+	appointment *db.Appointment,
+	centreName, reason string,
+) (*outboxEmail, error) {
+	patient, err := service.userPort.GetUser(ctx, appointment.PatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outboxEmail{
+		To:       patient.Email.String,
+		Subject:  emails.TitleAppointmentDisrupted,
+		Template: emails.TemplateAppointmentDisrupted,
+		Data: &emails.AppointmentData{
+			EmailData: emails.EmailData{
+				AppName: "Diagnoxix",
+			},
+			PatientName:     patient.Fullname.String,
+			AppointmentID:   appointment.ID,
+			AppointmentDate: appointment.AppointmentDate.Time,
+			TimeSlot:        appointment.TimeSlot,
+			CentreName:      centreName,
+			Notes:           reason,
+		},
+	}, nil
+}
+
+// parseClockTime parses an HH:MM time of day
+func parseClockTime(value string) (pgtype.Time, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return pgtype.Time{}, err
+	}
+	seconds := int64(parsed.Hour()*3600 + parsed.Minute()*60)
+	return pgtype.Time{Microseconds: seconds * 1000000, Valid: true}, nil
+}
+
+func formatClockRange(start, end pgtype.Time) string {
+	day := time.Time{}
+	return fmt.Sprintf("%s-%s",
+		day.Add(time.Duration(start.Microseconds)*time.Microsecond).Format("15:04"),
+		day.Add(time.Duration(end.Microseconds)*time.Microsecond).Format("15:04"))
+}
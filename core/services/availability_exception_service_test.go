@@ -0,0 +1,113 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestBuildAvailabilityExceptionParams(t *testing.T) {
+	now := time.Date(2025, 9, 1, 15, 0, 0, 0, time.UTC)
+	capacity := int32(2)
+
+	tests := []struct {
+		name    string
+		dto     domain.CreateAvailabilityExceptionDTO
+		wantErr bool
+	}{
+		{"closure", domain.CreateAvailabilityExceptionDTO{Date: "2025-12-25", IsClosed: true}, false},
+		{"closure today", domain.CreateAvailabilityExceptionDTO{Date: "2025-09-01", IsClosed: true}, false},
+		{"past date", domain.CreateAvailabilityExceptionDTO{Date: "2025-08-31", IsClosed: true}, true},
+		{"override hours", domain.CreateAvailabilityExceptionDTO{Date: "2025-09-02", StartTime: "10:00", EndTime: "14:00"}, false},
+		{"override capacity", domain.CreateAvailabilityExceptionDTO{Date: "2025-09-02", MaxAppointments: &capacity}, false},
+		{"inverted hours", domain.CreateAvailabilityExceptionDTO{Date: "2025-09-02", StartTime: "14:00", EndTime: "10:00"}, true},
+		{"empty override", domain.CreateAvailabilityExceptionDTO{Date: "2025-09-02"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := buildAvailabilityExceptionParams(&tt.dto, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.dto.IsClosed && (params.StartTime.Valid || params.MaxAppointments.Valid) {
+				t.Errorf("closure should not carry hours or capacity")
+			}
+		})
+	}
+}
+
+func TestIsDisruptedBy(t *testing.T) {
+	day := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	shortened := &db.DiagnosticCentreAvailabilityException{
+		ExceptionDate: pgtype.Date{Time: day, Valid: true},
+		StartTime:     clockTime(10, 0),
+		EndTime:       clockTime(12, 0),
+	}
+	closed := &db.DiagnosticCentreAvailabilityException{
+		ExceptionDate: pgtype.Date{Time: day, Valid: true},
+		IsClosed:      true,
+	}
+	capacityOnly := &db.DiagnosticCentreAvailabilityException{
+		ExceptionDate:   pgtype.Date{Time: day, Valid: true},
+		MaxAppointments: pgtype.Int4{Int32: 1, Valid: true},
+	}
+
+	tests := []struct {
+		name      string
+		exception *db.DiagnosticCentreAvailabilityException
+		start     time.Time
+		timeSlot  string
+		want      bool
+	}{
+		{"closed day", closed, day.Add(10 * time.Hour), "10:00-10:30", true},
+		{"before new opening", shortened, day.Add(9 * time.Hour), "09:00-09:30", true},
+		{"inside new hours", shortened, day.Add(10 * time.Hour), "10:00-10:30", false},
+		{"ends at closing", shortened, day.Add(11*time.Hour + 30*time.Minute), "11:30-12:00", false},
+		{"runs past closing", shortened, day.Add(11*time.Hour + 45*time.Minute), "11:45-12:15", true},
+		{"capacity change keeps bookings", capacityOnly, day.Add(9 * time.Hour), "09:00-09:30", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDisruptedBy(tt.exception, tt.start, tt.timeSlot); got != tt.want {
+				t.Errorf("isDisruptedBy() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSearchDate(t *testing.T) {
+	// 2025-09-03 is a Wednesday
+	now := time.Date(2025, 9, 3, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		date      string
+		dayOfWeek string
+		want      string
+		wantErr   bool
+	}{
+		{"today", "", "wednesday", "2025-09-03", false},
+		{"later this week", "", "friday", "2025-09-05", false},
+		{"next week", "", "monday", "2025-09-08", false},
+		{"explicit date", "2025-12-25", "", "2025-12-25", false},
+		{"date matches day", "2025-12-25", "thursday", "2025-12-25", false},
+		{"date contradicts day", "2025-12-25", "monday", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSearchDate(tt.date, tt.dayOfWeek, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Format("2006-01-02") != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got.Format("2006-01-02"))
+			}
+		})
+	}
+}
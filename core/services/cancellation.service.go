@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// Policy applied to centres that have not set their own
+const (
+	defaultFreeCancellationHours      = 24
+	defaultLateCancellationFeePercent = 50
+)
+
+// GetCancellationPolicy returns the cancellation policy in force at a diagnostic centre
+func (service *ServicesHandler) GetCancellationPolicy(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.GetDiagnosticParamDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	policy, err := service.centreCancellationPolicy(ctx, dto.DiagnosticCentreID)
+	if err != nil {
+		utils.Error("Failed to get cancellation policy",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, policy, context)
+}
+
+// UpdateCancellationPolicy sets the cancellation policy of a diagnostic centre
+func (service *ServicesHandler) UpdateCancellationPolicy(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.UpdateCancellationPolicyDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	policy, err := service.diagnosticPort.UpsertCancellationPolicy(ctx, db.Upsert_Cancellation_PolicyParams{
+		DiagnosticCentreID:         dto.DiagnosticCentreID,
+		FreeCancellationHours:      dto.FreeCancellationHours,
+		LateCancellationFeePercent: toNumeric(roundAmount(dto.LateCancellationFeePercent)),
+		UpdatedBy:                  currentUser.UserID.String(),
+	})
+	if err != nil {
+		utils.Error("Failed to update cancellation policy",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, &domain.CancellationPolicy{
+		DiagnosticCentreID:         policy.DiagnosticCentreID,
+		FreeCancellationHours:      policy.FreeCancellationHours,
+		LateCancellationFeePercent: roundAmount(dto.LateCancellationFeePercent),
+	}, context)
+}
+
+// centreCancellationPolicy loads a centre's cancellation policy, falling back to the default
+func (service *ServicesHandler) centreCancellationPolicy(
+	ctx context.Context,
+	diagnosticCentreID string,
+) (*domain.CancellationPolicy, error) {
+	policy, err := service.diagnosticPort.GetCancellationPolicy(ctx, diagnosticCentreID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.CancellationPolicy{
+				DiagnosticCentreID:         diagnosticCentreID,
+				FreeCancellationHours:      defaultFreeCancellationHours,
+				LateCancellationFeePercent: defaultLateCancellationFeePercent,
+				IsDefault:                  true,
+			}, nil
+		}
+		return nil, err
+	}
+
+	percent, err := numericToFloat(policy.LateCancellationFeePercent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cancellation fee percent: %w", err)
+	}
+	return &domain.CancellationPolicy{
+		DiagnosticCentreID:         policy.DiagnosticCentreID,
+		FreeCancellationHours:      policy.FreeCancellationHours,
+		LateCancellationFeePercent: percent,
+	}, nil
+}
+
+// cancellationFee is the share of the amount paid that the centre keeps when an
+// appointment starting at start is cancelled at now
+func cancellationFee(policy *domain.CancellationPolicy, amountPaid float64, start, now time.Time) float64 {
+	freeUntil := start.Add(-time.Duration(policy.FreeCancellationHours) * time.Hour)
+	if amountPaid <= 0 || now.Before(freeUntil) {
+		return 0
+	}
+	return roundAmount(amountPaid * policy.LateCancellationFeePercent / 100)
+}
+
+// reschedulingFeesOwed sums the fees of every earlier reschedule of a booking that were not
+// paid when rescheduling, which are kept from its refund instead
+func (service *ServicesHandler) reschedulingFeesOwed(ctx context.Context, appointmentID string) (float64, error) {
+	summary, err := service.appointmentPort.GetAppointmentRescheduleSummary(ctx, appointmentID)
+	if err != nil {
+		return 0, err
+	}
+	fees, err := numericToFloat(summary.ReschedulingFees)
+	if err != nil {
+		return 0, err
+	}
+	paid, err := numericToFloat(summary.ReschedulingFeesPaid)
+	if err != nil {
+		return 0, err
+	}
+	return roundAmount(math.Max(0, fees-paid)), nil
+}
+
+// successfulAppointmentPayment returns the appointment's payment when it went through, or nil
+func (service *ServicesHandler) successfulAppointmentPayment(
+	ctx context.Context,
+	appointment *db.Appointment,
+) (*db.Payment, error) {
+	if !appointment.PaymentID.Valid {
+		return nil, nil
+	}
+	payment, err := service.paymentPort.GetPayment(ctx, uuid.UUID(appointment.PaymentID.Bytes).String())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if payment.PaymentStatus != db.PaymentStatusSuccess {
+		return nil, nil
+	}
+	return payment, nil
+}
+
+// appointmentAmountPaid is the part of a payment that settled the appointment; a payment
+// made upfront for a series is split across its occurrences
+func appointmentAmountPaid(appointment *db.Appointment, payment *db.Payment) (float64, error) {
+	if appointment.PaymentAmount.Valid {
+		return numericToFloat(appointment.PaymentAmount)
+	}
+	return numericToFloat(payment.Amount)
+}
+
+// openCancellationRefund records the refund of what is left after the cancellation fees within
+// the cancellation's tx, through the payment provider, into the patient's wallet or at the
+// centre's counter, so an appointment is never cancelled without it. The appointments the
+// payment settled are marked refunded only once the refund is processed. A payment with too
+// little left to refund, as when the centre refunded it already, cancels without a refund.
+func (service *ServicesHandler) openCancellationRefund(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	payment *db.Payment,
+	amount float64,
+	reason string,
+	refundedBy string,
+	destination db.RefundDestination,
+	appointments ...*db.Appointment,
+) (*db.PaymentRefund, error) {
+	appointmentIDs := make([]string, len(appointments))
+	for i, appointment := range appointments {
+		appointmentIDs[i] = appointment.ID
+	}
+	refund, err := service.createRefund(ctx, tx, payment.ID, roundAmount(amount), reason, refundedBy, destination, appointmentIDs...)
+	if errors.Is(err, ErrPaymentNotRefundable) || errors.Is(err, ErrRefundExceedsCaptured) {
+		utils.Warn("Cancelled appointment left without a refund",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID},
+			utils.LogField{Key: "amount", Value: amount})
+		return nil, nil
+	}
+	return refund, err
+}
+
+// sendCancellationRefund sends a provider refund opened by a cancellation once the cancellation
+// has committed, and reports where the refund stands. A refund that could not be sent stays
+// pending and is sent by the reconciliation job.
+func (service *ServicesHandler) sendCancellationRefund(
+	ctx context.Context,
+	payment *db.Payment,
+	refund *db.PaymentRefund,
+) domain.RefundStatus {
+	if refund == nil {
+		return domain.RefundStatusFailed
+	}
+	if refund.Destination == db.RefundDestinationProvider {
+		sent, err := service.sendRefund(ctx, payment, refund)
+		if err != nil {
+			utils.Error("Failed to send refund of cancelled appointment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "payment_id", Value: payment.ID},
+				utils.LogField{Key: "refund_id", Value: refund.ID})
+			if errors.Is(err, ErrRefundRejected) {
+				return domain.RefundStatusFailed
+			}
+			return domain.RefundStatusPending
+		}
+		refund = sent
+	}
+
+	utils.Info("Refund issued for cancelled appointment",
+		utils.LogField{Key: "payment_id", Value: payment.ID},
+		utils.LogField{Key: "refund_id", Value: refund.ID},
+		utils.LogField{Key: "status", Value: refund.Status})
+	if refund.Status == db.PaymentRefundStatusProcessed {
+		return domain.RefundStatusProcessed
+	}
+	return domain.RefundStatusPending
+}
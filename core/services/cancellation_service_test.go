@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+)
+
+func TestCancellationFee(t *testing.T) {
+	start := time.Date(2025, 9, 2, 10, 0, 0, 0, time.UTC)
+	standard := &domain.CancellationPolicy{FreeCancellationHours: 24, LateCancellationFeePercent: 50}
+	noFee := &domain.CancellationPolicy{FreeCancellationHours: 24, LateCancellationFeePercent: 0}
+	noNotice := &domain.CancellationPolicy{FreeCancellationHours: 0, LateCancellationFeePercent: 25}
+
+	tests := []struct {
+		name   string
+		policy *domain.CancellationPolicy
+		amount float64
+		now    time.Time
+		want   float64
+	}{
+		{"well ahead", standard, 5100, start.Add(-48 * time.Hour), 0},
+		{"at the free limit", standard, 5100, start.Add(-24 * time.Hour), 2550},
+		{"just inside the free window", standard, 5100, start.Add(-24*time.Hour - time.Minute), 0},
+		{"same day", standard, 5100, start.Add(-2 * time.Hour), 2550},
+		{"after start", standard, 5100, start.Add(time.Hour), 2550},
+		{"rounded to kobo", standard, 100.01, start.Add(-time.Hour), 50.01},
+		{"late but centre charges nothing", noFee, 5100, start.Add(-time.Hour), 0},
+		{"no notice required", noNotice, 4000, start.Add(-time.Hour), 0},
+		{"no notice required but started", noNotice, 4000, start.Add(time.Minute), 1000},
+		{"nothing paid", standard, 0, start.Add(-time.Hour), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cancellationFee(tt.policy, tt.amount, start, tt.now); got != tt.want {
+				t.Errorf("cancellationFee() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type rescheduleSummary struct {
+	ports.AppointmentRepository
+	summary *db.GetAppointmentRescheduleSummaryRow
+}
+
+func (r *rescheduleSummary) GetAppointmentRescheduleSummary(ctx context.Context, id string) (*db.GetAppointmentRescheduleSummaryRow, error) {
+	return r.summary, nil
+}
+
+func TestReschedulingFeesOwed(t *testing.T) {
+	tests := []struct {
+		name string
+		fees float64
+		paid float64
+		want float64
+	}{
+		{name: "never rescheduled"},
+		{name: "fees unpaid", fees: 1000, want: 1000},
+		{name: "one of two fees paid", fees: 1000, paid: 500, want: 500},
+		{name: "fees paid", fees: 1000, paid: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &ServicesHandler{appointmentPort: &rescheduleSummary{summary: &db.GetAppointmentRescheduleSummaryRow{
+				ReschedulingFees:     toNumeric(tt.fees),
+				ReschedulingFeesPaid: toNumeric(tt.paid),
+			}}}
+			got, err := service.reschedulingFeesOwed(context.Background(), "apt-1")
+			if err != nil {
+				t.Fatalf("reschedulingFeesOwed() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("reschedulingFeesOwed() = %.2f; want %.2f", got, tt.want)
+			}
+		})
+	}
+}
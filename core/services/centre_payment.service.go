@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultPayAtCentreHoldMinutes is how long after its start a pay-at-centre appointment waits
+// for its payment when PAY_AT_CENTRE_HOLD_MINUTES is unset
+const defaultPayAtCentreHoldMinutes = 30
+
+// uniqueViolation is the Postgres error code of a duplicate key
+const uniqueViolation = "23505"
+
+var (
+	ErrPaymentNotCollectable = errors.New("payment is not awaiting payment at the centre")
+	ErrDuplicateReceipt      = errors.New("receipt number is already recorded for another payment")
+)
+
+// RecordCentrePayment records a pay-at-centre payment the centre received in cash or by bank
+// transfer and confirms the appointments waiting on it, as a verified card payment would
+func (s *ServicesHandler) RecordCentrePayment(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.RecordCentrePaymentDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	payment, err := s.paymentPort.GetPayment(ctx, dto.PaymentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("payment not found"), c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if err := s.authorizeCentreAccess(ctx, currentUser, payment.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+
+	receipt, err := s.receiveCentrePayment(
+		ctx,
+		payment.ID,
+		db.PaymentMethod(dto.PaymentMethod),
+		dto.ReceiptNumber,
+		currentUser.UserID.String(),
+	)
+	if err != nil {
+		utils.Error("Failed to record centre payment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		switch {
+		case errors.Is(err, ErrPaymentNotCollectable), errors.Is(err, ErrDuplicateReceipt):
+			return utils.ErrorResponse(http.StatusConflict, err, c)
+		default:
+			return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+		}
+	}
+
+	go s.sendPaymentReceiptEmail(payment.ID)
+
+	utils.Info("Centre payment recorded",
+		utils.LogField{Key: "payment_id", Value: payment.ID},
+		utils.LogField{Key: "appointments_confirmed", Value: len(receipt.Appointments)})
+	return utils.ResponseMessage(http.StatusOK, receipt, c)
+}
+
+// receiveCentrePayment marks a pending pay-at-centre payment successful under the centre's
+// receipt and confirms the appointments waiting on it, in one transaction
+func (s *ServicesHandler) receiveCentrePayment(
+	ctx context.Context,
+	paymentID string,
+	method db.PaymentMethod,
+	receiptNumber string,
+	receivedBy string,
+) (*domain.CentrePaymentReceipt, error) {
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.LockPayment(ctx, paymentID); err != nil {
+		return nil, fmt.Errorf("failed to lock payment: %w", err)
+	}
+	recorded, err := tx.RecordCentrePayment(ctx, db.Record_Centre_PaymentParams{
+		ID:            paymentID,
+		PaymentMethod: method,
+		ReceiptNumber: toText(receiptNumber),
+		ReceivedBy:    toUUID(receivedBy),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentNotCollectable
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateReceipt, receiptNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record receipt: %w", err)
+	}
+
+	payload, err := utils.MarshalJSONField(map[string]interface{}{
+		"payment_method": method,
+		"receipt_number": receiptNumber,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal receipt: %w", err)
+	}
+	payment, err := s.applyPaymentEvent(ctx, tx, recorded, paymentEvent{
+		Source:        db.PaymentEventSourceInternal,
+		Type:          PaymentEventCentreReceived,
+		To:            db.PaymentStatusSuccess,
+		Amount:        recorded.Amount,
+		TransactionID: receiptNumber,
+		Payload:       payload,
+		ActorID:       receivedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed, err := tx.ConfirmAppointmentsByPayment(ctx, toUUID(payment.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm appointments: %w", err)
+	}
+	notifications, err := s.dispatchAppointmentConfirmations(ctx, tx, confirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	pushNotifications(ctx, notifications)
+	if confirmed == nil {
+		confirmed = []*db.Appointment{}
+	}
+	return &domain.CentrePaymentReceipt{Payment: payment, Appointments: confirmed}, nil
+}
+
+// holdAppointmentForCentrePayment records the pending payment of an appointment the patient
+// pays for at the centre within tx. No provider transaction is opened; the slot is held until
+// payAtCentreHold after the appointment starts, and the centre's provider is kept on the
+// payment only because every payment names one.
+func (s *ServicesHandler) holdAppointmentForCentrePayment(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	appointment *db.Appointment,
+	amount float64,
+	currency string,
+	metadata map[string]interface{},
+) (*db.Payment, error) {
+	setting, err := s.centrePaymentSetting(ctx, appointment.DiagnosticCentreID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment provider setting: %w", err)
+	}
+	metadataBytes, err := utils.MarshalJSONField(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payment metadata: %w", err)
+	}
+
+	paymentReference := fmt.Sprintf("CTR-%s-%s", appointment.ID, time.Now().Format("20060102150405"))
+	payment, err := tx.CreatePayment(ctx, db.Create_PaymentParams{
+		AppointmentID:      appointment.ID,
+		PatientID:          appointment.PatientID,
+		DiagnosticCentreID: appointment.DiagnosticCentreID,
+		Amount:             toNumeric(amount),
+		Currency:           currency,
+		PaymentMethod:      db.PaymentMethodCash,
+		PaymentProvider:    setting.PaymentProvider,
+		PaymentMetadata:    metadataBytes,
+		ProviderReference:  pgtype.Text{String: paymentReference, Valid: true},
+		WalletAmount:       toNumeric(0),
+		HoldExpiresAt:      toTimestamptz(appointment.AppointmentDate.Time.Add(s.payAtCentreHold())),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+	if err := recordPaymentInitialized(ctx, tx, payment); err != nil {
+		return nil, fmt.Errorf("failed to record payment event: %w", err)
+	}
+	return payment, nil
+}
+
+// expireCentrePayments cancels pay-at-centre appointments whose hold ended without the centre
+// recording their payment. Returns how many appointments were expired.
+func (s *ServicesHandler) expireCentrePayments(ctx context.Context) int {
+	payments, err := s.paymentPort.ListExpiredCentrePayments(ctx, db.List_Expired_Centre_PaymentsParams{
+		HoldExpiresAt: toTimestamptz(time.Now().UTC()),
+		Limit:         unpaidPaymentBatchSize,
+	})
+	if err != nil {
+		utils.Error("Failed to list expired centre payments",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return 0
+	}
+
+	expired := 0
+	for _, payment := range payments {
+		expired += s.releaseUnpaidAppointments(ctx, payment)
+	}
+	return expired
+}
+
+// payAtCentreHold reads how long after its start a pay-at-centre appointment waits for its
+// payment from configuration
+func (s *ServicesHandler) payAtCentreHold() time.Duration {
+	minutes := defaultPayAtCentreHoldMinutes
+	if s.Config.PAY_AT_CENTRE_HOLD_MINUTES != "" {
+		parsed, err := strconv.Atoi(s.Config.PAY_AT_CENTRE_HOLD_MINUTES)
+		if err != nil || parsed < 0 {
+			utils.Warn("Invalid PAY_AT_CENTRE_HOLD_MINUTES configuration, using default",
+				utils.LogField{Key: "pay_at_centre_hold_minutes", Value: s.Config.PAY_AT_CENTRE_HOLD_MINUTES})
+		} else {
+			minutes = parsed
+		}
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// isCentrePayment reports whether a payment is collected at the centre rather than online
+func isCentrePayment(payment *db.Payment) bool {
+	return payment.PaymentMethod == db.PaymentMethodCash || payment.PaymentMethod == db.PaymentMethodTransfer
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/config"
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/utils"
+	"go.uber.org/zap"
+)
+
+func TestPayAtCentreHold(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	tests := []struct {
+		setting string
+		want    time.Duration
+	}{
+		{setting: "", want: 30 * time.Minute},
+		{setting: "90", want: 90 * time.Minute},
+		{setting: "0", want: 0},
+		{setting: "-5", want: 30 * time.Minute},
+		{setting: "soon", want: 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		service := &ServicesHandler{Config: config.EnvConfiguration{PAY_AT_CENTRE_HOLD_MINUTES: tt.setting}}
+		if got := service.payAtCentreHold(); got != tt.want {
+			t.Errorf("payAtCentreHold() with %q = %s; want %s", tt.setting, got, tt.want)
+		}
+	}
+}
+
+func TestCentrePaymentRefundDestination(t *testing.T) {
+	for _, method := range []db.PaymentMethod{db.PaymentMethodCash, db.PaymentMethodTransfer} {
+		payment := &db.Payment{PaymentMethod: method, Amount: toNumeric(15000)}
+		if !isCentrePayment(payment) {
+			t.Errorf("isCentrePayment(%s) = false", method)
+		}
+		if got := refundDestination(payment, "wallet"); got != db.RefundDestinationCentre {
+			t.Errorf("refundDestination(%s) = %q; want %q", method, got, db.RefundDestinationCentre)
+		}
+	}
+	if isCentrePayment(&db.Payment{PaymentMethod: db.PaymentMethodCard}) {
+		t.Error("isCentrePayment(card) = true")
+	}
+}
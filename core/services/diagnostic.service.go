@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/adapters/external/templates/emails"
@@ -299,16 +300,22 @@ func (service *ServicesHandler) SearchDiagnosticCentre(context echo.Context) err
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid coordinates")
 	}
 
+	searchDate, err := resolveSearchDate(query.Date, query.DayOfWeek, time.Now().UTC())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, context)
+	}
+
 	param, _ := SetDefaultPagination(&query.PaginationQueryDTO).(*domain.PaginationQueryDTO)
 
 	// Build search parameters
 	params := db.Get_Nearest_Diagnostic_CentresParams{
 		Radians:   query.Latitude,
 		Radians_2: query.Longitude,
-		Column4:   query.DayOfWeek,
+		Column4:   strings.ToLower(searchDate.Weekday().String()),
 		Column5:   query.Test, // Empty string will match all days in SQL query
 		Limit:     param.GetLimit(),
 		Offset:    param.GetOffset(),
+		Column8:   toDate(searchDate),
 	}
 
 	hasFilters := false
@@ -341,6 +348,31 @@ func (service *ServicesHandler) SearchDiagnosticCentre(context echo.Context) err
 	return utils.ResponseMessage(http.StatusOK, result, context)
 }
 
+// resolveSearchDate picks the calendar date a search is for, so closures on that
+// date can be excluded. Without an explicit date it is the next occurrence of
+// dayOfWeek, counting today.
+func resolveSearchDate(date, dayOfWeek string, now time.Time) (time.Time, error) {
+	if date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date: %w", err)
+		}
+		if dayOfWeek != "" && !strings.EqualFold(parsed.Weekday().String(), dayOfWeek) {
+			return time.Time{}, fmt.Errorf("date %s is not a %s", date, dayOfWeek)
+		}
+		return parsed, nil
+	}
+
+	day := truncateToDay(now)
+	for i := 0; i < 7; i++ {
+		if strings.EqualFold(day.Weekday().String(), dayOfWeek) {
+			return day, nil
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, fmt.Errorf("invalid day of week: %s", dayOfWeek)
+}
+
 func (service *ServicesHandler) UpdateDiagnosticCentre(context echo.Context) error {
 	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
 	if err != nil {
@@ -762,12 +794,16 @@ func (service *ServicesHandler) AssignAdmin(context echo.Context) error {
 		CentreName:    diagnostic_centre.DiagnosticCentreName,
 		CentreAddress: add,
 	}
-	go service.emailGoroutine(
-		emailData,
-		managerDetails.Email,
-		emails.SubjectDiagnosticCentreManagement,
-		emails.TemplateDiagnosticCentreManagement,
-	)
+	if err := service.queueEmail(context.Request().Context(), service.outboxPort, outboxEmail{
+		To:       managerDetails.Email,
+		Subject:  emails.SubjectDiagnosticCentreManagement,
+		Template: emails.TemplateDiagnosticCentreManagement,
+		Data:     emailData,
+	}); err != nil {
+		utils.Error("Failed to queue email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "template", Value: emails.TemplateDiagnosticCentreManagement})
+	}
 	return utils.ResponseMessage(http.StatusOK, assignedAdmin, context)
 }
 
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// emailOutboxBatchSize caps the messages claimed at once; a full batch is followed by another
+	emailOutboxBatchSize = 50
+	// defaultEmailOutboxWorkers is used when EMAIL_OUTBOX_WORKERS is unset
+	defaultEmailOutboxWorkers = 4
+	// emailDeliveryTimeout is how long a claimed message stays with its worker. One still
+	// sending after that was lost, say by a restart, and is claimed again.
+	emailDeliveryTimeout = 5 * time.Minute
+	// emailRetryBase is the wait after the first failed attempt; it doubles with every
+	// attempt up to emailRetryMax
+	emailRetryBase = 30 * time.Second
+	emailRetryMax  = 2 * time.Hour
+)
+
+// ErrOutboxEmailNotFound also covers sensitive email, whose body is not kept to be replayed
+var ErrOutboxEmailNotFound = errors.New("dead-lettered email not found")
+
+// outboxEmail is an email to render and queue for delivery
+type outboxEmail struct {
+	To          string
+	Subject     string
+	Template    string
+	Data        interface{}
+	Attachments []domain.EmailAttachment
+	// Sensitive email carries a password or account token. Its body is dropped once it is
+	// sent or dead-lettered, and whoever needs it again asks for a new one.
+	Sensitive bool
+}
+
+// emailEnqueuer is met by the outbox repository and by transactions, so an email can be
+// queued in the same transaction as the change it reports
+type emailEnqueuer interface {
+	EnqueueEmail(ctx context.Context, arg db.Enqueue_EmailParams) (*db.EmailOutbox, error)
+}
+
+// queueEmail renders an email and queues it for delivery. The message is rendered now, so
+// it says what was true when it was queued however late it goes out. The rendered body is
+// dropped once delivered.
+func (service *ServicesHandler) queueEmail(ctx context.Context, queue emailEnqueuer, email outboxEmail) error {
+	// Accounts registered by phone have no email address
+	if email.To == "" {
+		return nil
+	}
+
+	body, err := service.notificationPort.RenderEmail(email.Template, email.Data)
+	if err != nil {
+		return err
+	}
+	attachments := email.Attachments
+	if attachments == nil {
+		attachments = []domain.EmailAttachment{}
+	}
+	content, err := json.Marshal(attachments)
+	if err != nil {
+		return err
+	}
+
+	_, err = queue.EnqueueEmail(ctx, db.Enqueue_EmailParams{
+		Recipient:   email.To,
+		Subject:     email.Subject,
+		Template:    email.Template,
+		HtmlBody:    toText(body),
+		Attachments: content,
+		Sensitive:   email.Sensitive,
+	})
+	return err
+}
+
+// deliverQueuedEmails claims the due messages of the outbox and hands them to a pool of
+// workers, until no full batch is left
+func (service *ServicesHandler) deliverQueuedEmails() {
+	ctx := context.Background()
+	for {
+		messages, err := service.outboxPort.ClaimOutboxEmails(ctx, db.Claim_Outbox_EmailsParams{
+			Limit:       emailOutboxBatchSize,
+			LockedUntil: toTimestamptz(time.Now().Add(emailDeliveryTimeout)),
+		})
+		if err != nil {
+			utils.Error("Failed to claim queued emails",
+				utils.LogField{Key: "error", Value: err.Error()})
+			return
+		}
+
+		queue := make(chan *db.EmailOutbox)
+		var wg sync.WaitGroup
+		for i := 0; i < service.emailOutboxWorkers(); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for message := range queue {
+					service.deliverEmail(ctx, message)
+				}
+			}()
+		}
+		for _, message := range messages {
+			queue <- message
+		}
+		close(queue)
+		wg.Wait()
+
+		if len(messages) < emailOutboxBatchSize {
+			return
+		}
+	}
+}
+
+// deliverEmail sends one claimed message and records the outcome. A failed message is retried
+// with exponential backoff until it runs out of attempts, when it is dead-lettered.
+func (service *ServicesHandler) deliverEmail(ctx context.Context, message *db.EmailOutbox) {
+	var attachments []domain.EmailAttachment
+	err := json.Unmarshal(message.Attachments, &attachments)
+	if err == nil {
+		err = service.notificationPort.SendHTMLEmail(message.Recipient, message.Subject, message.HtmlBody.String, attachments)
+	}
+	if err == nil {
+		if err := service.outboxPort.MarkEmailSent(ctx, message.ID); err != nil {
+			utils.Error("Failed to mark email sent",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "email_id", Value: message.ID})
+		}
+		return
+	}
+
+	lastError := toText(err.Error())
+	if message.Attempts >= message.MaxAttempts {
+		utils.Error("Email dead-lettered",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "email_id", Value: message.ID},
+			utils.LogField{Key: "attempts", Value: message.Attempts})
+		err = service.outboxPort.DeadLetterEmail(ctx, db.Dead_Letter_EmailParams{
+			ID:        message.ID,
+			LastError: lastError,
+		})
+	} else {
+		utils.Warn("Email delivery failed, will retry",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "email_id", Value: message.ID},
+			utils.LogField{Key: "attempts", Value: message.Attempts})
+		err = service.outboxPort.RetryEmail(ctx, db.Retry_EmailParams{
+			ID:            message.ID,
+			NextAttemptAt: toTimestamptz(time.Now().Add(emailRetryDelay(message.Attempts))),
+			LastError:     lastError,
+		})
+	}
+	if err != nil {
+		utils.Error("Failed to record email delivery failure",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "email_id", Value: message.ID})
+	}
+}
+
+// emailRetryDelay is the wait before the next attempt at a message that failed attempts times
+func emailRetryDelay(attempts int32) time.Duration {
+	delay := emailRetryBase
+	for i := int32(1); i < attempts && delay < emailRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, emailRetryMax)
+}
+
+// emailOutboxWorkers reads how many workers deliver email in parallel from configuration
+func (service *ServicesHandler) emailOutboxWorkers() int {
+	if service.Config.EMAIL_OUTBOX_WORKERS != "" {
+		parsed, err := strconv.Atoi(service.Config.EMAIL_OUTBOX_WORKERS)
+		if err == nil && parsed > 0 {
+			return parsed
+		}
+		utils.Warn("Invalid EMAIL_OUTBOX_WORKERS configuration, using default",
+			utils.LogField{Key: "email_outbox_workers", Value: service.Config.EMAIL_OUTBOX_WORKERS})
+	}
+	return defaultEmailOutboxWorkers
+}
+
+// ListOutboxEmails pages through queued email by delivery status, dead-lettered email by default
+func (service *ServicesHandler) ListOutboxEmails(c echo.Context) error {
+	if _, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumADMIN}); err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListOutboxEmailsDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+	status := db.EmailOutboxStatusDead
+	if dto.Status != "" {
+		status = db.EmailOutboxStatus(dto.Status)
+	}
+
+	param, _ := SetDefaultPagination(&dto.PaginationQueryDTO).(*domain.PaginationQueryDTO)
+	messages, err := service.outboxPort.ListOutboxEmails(c.Request().Context(), db.List_Outbox_EmailsParams{
+		Status: status,
+		Limit:  param.GetLimit(),
+		Offset: param.GetOffset(),
+	})
+	if err != nil {
+		utils.Error("Failed to list queued emails",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "status", Value: status})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	response := make([]domain.OutboxEmail, 0, len(messages))
+	for _, message := range messages {
+		response = append(response, outboxEmailSummary(message))
+	}
+	return utils.ResponseMessage(http.StatusOK, response, c)
+}
+
+// ReplayOutboxEmail queues a dead-lettered email again with a fresh set of attempts
+func (service *ServicesHandler) ReplayOutboxEmail(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumADMIN})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.ReplayOutboxEmailDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	message, err := service.outboxPort.ReplayEmail(c.Request().Context(), dto.EmailID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, ErrOutboxEmailNotFound, c)
+		}
+		utils.Error("Failed to replay email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "email_id", Value: dto.EmailID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	utils.Info("Dead-lettered email replayed",
+		utils.LogField{Key: "email_id", Value: message.ID},
+		utils.LogField{Key: "user_id", Value: currentUser.UserID})
+
+	return utils.ResponseMessage(http.StatusOK, outboxEmailSummary(message), c)
+}
+
+func outboxEmailSummary(message *db.EmailOutbox) domain.OutboxEmail {
+	summary := domain.OutboxEmail{
+		ID:            message.ID,
+		Recipient:     message.Recipient,
+		Subject:       message.Subject,
+		Template:      message.Template,
+		Status:        string(message.Status),
+		Attempts:      message.Attempts,
+		MaxAttempts:   message.MaxAttempts,
+		NextAttemptAt: message.NextAttemptAt.Time,
+		LastError:     message.LastError.String,
+		CreatedAt:     message.CreatedAt.Time,
+	}
+	if message.SentAt.Valid {
+		summary.SentAt = &message.SentAt.Time
+	}
+	return summary
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func TestEmailRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{6, 16 * time.Minute},
+		{9, 2 * time.Hour},
+		{40, 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := emailRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("emailRetryDelay(%d) = %v; want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+type outboxMailer struct {
+	ports.NotificationService
+	err  error
+	sent []domain.EmailAttachment
+}
+
+func (m *outboxMailer) SendHTMLEmail(to, subject, htmlContent string, attachments []domain.EmailAttachment) error {
+	m.sent = attachments
+	return m.err
+}
+
+type outboxRecorder struct {
+	ports.EmailOutboxRepository
+	sent    bool
+	retry   *db.Retry_EmailParams
+	deadErr string
+}
+
+func (r *outboxRecorder) MarkEmailSent(ctx context.Context, id string) error {
+	r.sent = true
+	return nil
+}
+
+func (r *outboxRecorder) RetryEmail(ctx context.Context, arg db.Retry_EmailParams) error {
+	r.retry = &arg
+	return nil
+}
+
+func (r *outboxRecorder) DeadLetterEmail(ctx context.Context, arg db.Dead_Letter_EmailParams) error {
+	r.deadErr = arg.LastError.String
+	return nil
+}
+
+func TestDeliverEmail(t *testing.T) {
+	// Failed deliveries are logged
+	utils.Logger = zap.NewNop()
+	message := func(attempts int32) *db.EmailOutbox {
+		return &db.EmailOutbox{
+			ID:          "email-1",
+			Recipient:   "patient@example.com",
+			Subject:     "Payment Confirmed",
+			HtmlBody:    pgtype.Text{String: "<p>Paid</p>", Valid: true},
+			Attachments: []byte(`[{"filename":"receipt.pdf","content_type":"application/pdf","content":"JVBERg=="}]`),
+			Attempts:    attempts,
+			MaxAttempts: 8,
+		}
+	}
+	unreachable := errors.New("dial tcp: connection refused")
+
+	t.Run("sent", func(t *testing.T) {
+		mailer, outbox := &outboxMailer{}, &outboxRecorder{}
+		service := &ServicesHandler{notificationPort: mailer, outboxPort: outbox}
+		service.deliverEmail(context.Background(), message(1))
+		if !outbox.sent {
+			t.Error("email not marked sent")
+		}
+		if len(mailer.sent) != 1 || mailer.sent[0].Filename != "receipt.pdf" || string(mailer.sent[0].Content) != "%PDF" {
+			t.Errorf("attachments = %+v; want the receipt", mailer.sent)
+		}
+	})
+
+	t.Run("retried", func(t *testing.T) {
+		outbox := &outboxRecorder{}
+		service := &ServicesHandler{notificationPort: &outboxMailer{err: unreachable}, outboxPort: outbox}
+		before := time.Now()
+		service.deliverEmail(context.Background(), message(3))
+		if outbox.retry == nil {
+			t.Fatal("email not retried")
+		}
+		if next := outbox.retry.NextAttemptAt.Time; next.Before(before.Add(2*time.Minute)) || next.After(time.Now().Add(2*time.Minute)) {
+			t.Errorf("NextAttemptAt = %v; want two minutes from now", next)
+		}
+		if outbox.retry.LastError.String != unreachable.Error() {
+			t.Errorf("LastError = %q; want %q", outbox.retry.LastError.String, unreachable)
+		}
+	})
+
+	t.Run("dead-lettered", func(t *testing.T) {
+		outbox := &outboxRecorder{}
+		service := &ServicesHandler{notificationPort: &outboxMailer{err: unreachable}, outboxPort: outbox}
+		service.deliverEmail(context.Background(), message(8))
+		if outbox.retry != nil || outbox.deadErr != unreachable.Error() {
+			t.Errorf("retry = %+v, dead-letter error = %q; want dead-lettered", outbox.retry, outbox.deadErr)
+		}
+	})
+}
+
+func (m *outboxMailer) RenderEmail(templateName string, data interface{}) (string, error) {
+	return "<p>" + templateName + "</p>", nil
+}
+
+type enqueueRecorder struct {
+	queued *db.Enqueue_EmailParams
+}
+
+func (r *enqueueRecorder) EnqueueEmail(ctx context.Context, arg db.Enqueue_EmailParams) (*db.EmailOutbox, error) {
+	r.queued = &arg
+	return &db.EmailOutbox{}, nil
+}
+
+func TestQueueEmailMarksSensitive(t *testing.T) {
+	queue := &enqueueRecorder{}
+	service := &ServicesHandler{notificationPort: &outboxMailer{}}
+
+	err := service.queueEmail(context.Background(), queue, outboxEmail{
+		To:        "manager@example.com",
+		Subject:   "Welcome",
+		Template:  "dc_manager_notification",
+		Sensitive: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !queue.queued.Sensitive || queue.queued.HtmlBody.String != "<p>dc_manager_notification</p>" {
+		t.Errorf("queued = %+v; want the rendered body marked sensitive", queue.queued)
+	}
+}
@@ -21,6 +21,15 @@ func InitializeServices(repos *repository.Repositories, cfg *config.EnvConfigura
 		repos.Appointment,
 		repos.TestPrice,
 		repos.Notification,
+		repos.Waitlist,
+		repos.Series,
+		repos.LineItem,
+		repos.Settlement,
+		repos.Wallet,
+		repos.Invoice,
+		repos.PromoCode,
+		repos.Insurance,
+		repos.EmailOutbox,
 		*cfg,
 	)
 	return &Service{
@@ -0,0 +1,491 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrInsurerNotFound          = errors.New("insurer not found")
+	ErrInsurancePlanNotFound    = errors.New("insurance plan not found")
+	ErrInsurancePolicyNotFound  = errors.New("insurance policy not found")
+	ErrInsurancePolicyInactive  = errors.New("insurance policy is not active")
+	ErrCoverageNotApplicable    = errors.New("insurance plan does not cover these tests")
+	ErrPreauthorizationRequired = errors.New("insurer pre-authorization is required for these tests")
+	ErrDuplicateInsurer         = errors.New("insurer already exists")
+	ErrDuplicateInsurancePlan   = errors.New("insurance plan already exists")
+	ErrDuplicateInsurancePolicy = errors.New("member number is already enrolled on this plan")
+)
+
+// insuranceReaders may look up insurers, plans and coverage rules
+var insuranceReaders = []db.UserEnum{
+	db.UserEnumADMIN,
+	db.UserEnumPATIENT,
+	db.UserEnumDIAGNOSTICCENTREOWNER,
+	db.UserEnumDIAGNOSTICCENTREMANAGER,
+}
+
+// CreateInsurer registers an HMO or insurer
+func (s *ServicesHandler) CreateInsurer(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumADMIN})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.CreateInsurerDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	insurer, err := s.insurancePort.CreateInsurer(c.Request().Context(), db.Create_InsurerParams{
+		Name:      strings.TrimSpace(dto.Name),
+		Code:      strings.ToUpper(dto.Code),
+		Email:     toText(dto.Email),
+		Phone:     toText(dto.Phone),
+		CreatedBy: toUUID(currentUser.UserID.String()),
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return utils.ErrorResponse(http.StatusConflict, fmt.Errorf("%w: %s", ErrDuplicateInsurer, strings.ToUpper(dto.Code)), c)
+	}
+	if err != nil {
+		utils.Error("Failed to create insurer",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, insurer, c)
+}
+
+// ListInsurers lists the active insurers by name
+func (s *ServicesHandler) ListInsurers(c echo.Context) error {
+	if _, err := PrivateMiddlewareContext(c, insuranceReaders); err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListInsurersDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	param, _ := SetDefaultPagination(&dto.PaginationQueryDTO).(*domain.PaginationQueryDTO)
+	insurers, err := s.insurancePort.ListInsurers(c.Request().Context(), db.List_InsurersParams{
+		Limit:  param.GetLimit(),
+		Offset: param.GetOffset(),
+	})
+	if err != nil {
+		utils.Error("Failed to list insurers",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if insurers == nil {
+		insurers = []*db.Insurer{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, insurers, c)
+}
+
+// CreateInsurancePlan adds a plan to an insurer
+func (s *ServicesHandler) CreateInsurancePlan(c echo.Context) error {
+	if _, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumADMIN}); err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.CreateInsurancePlanDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	if _, err := s.insurancePort.GetInsurer(ctx, dto.InsurerID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, ErrInsurerNotFound, c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	currency := strings.ToUpper(dto.Currency)
+	if currency == "" {
+		currency = "NGN"
+	}
+	plan, err := s.insurancePort.CreateInsurancePlan(ctx, db.Create_Insurance_PlanParams{
+		InsurerID: dto.InsurerID,
+		Name:      strings.TrimSpace(dto.Name),
+		Code:      strings.ToUpper(dto.Code),
+		Currency:  currency,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return utils.ErrorResponse(http.StatusConflict, fmt.Errorf("%w: %s", ErrDuplicateInsurancePlan, strings.ToUpper(dto.Code)), c)
+	}
+	if err != nil {
+		utils.Error("Failed to create insurance plan",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "insurer_id", Value: dto.InsurerID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, plan, c)
+}
+
+// ListInsurancePlans lists the active plans of an insurer
+func (s *ServicesHandler) ListInsurancePlans(c echo.Context) error {
+	if _, err := PrivateMiddlewareContext(c, insuranceReaders); err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListInsurancePlansDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	plans, err := s.insurancePort.ListInsurancePlans(c.Request().Context(), dto.InsurerID)
+	if err != nil {
+		utils.Error("Failed to list insurance plans",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "insurer_id", Value: dto.InsurerID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if plans == nil {
+		plans = []*db.InsurancePlan{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, plans, c)
+}
+
+// SetCoverageRule sets how a plan covers a test type. Tests without a rule are not covered.
+func (s *ServicesHandler) SetCoverageRule(c echo.Context) error {
+	if _, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumADMIN}); err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.SetCoverageRuleDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	testType := strings.ToUpper(strings.ReplaceAll(dto.TestType, " ", "_"))
+	if !s.appointmentPort.IsValidTestType(ctx, testType) {
+		return utils.ErrorResponse(http.StatusBadRequest, fmt.Errorf("invalid test type: %s", testType), c)
+	}
+	if _, err := s.insurancePort.GetInsurancePlan(ctx, dto.PlanID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, ErrInsurancePlanNotFound, c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	rule, err := s.insurancePort.UpsertCoverageRule(ctx, db.Upsert_Coverage_RuleParams{
+		PlanID:                   dto.PlanID,
+		TestType:                 testType,
+		CoveredPercent:           toNumeric(dto.CoveredPercent),
+		Copay:                    toNumeric(roundAmount(dto.Copay)),
+		RequiresPreauthorization: dto.RequiresPreauthorization,
+	})
+	if err != nil {
+		utils.Error("Failed to set coverage rule",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "plan_id", Value: dto.PlanID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, rule, c)
+}
+
+// ListCoverageRules lists how a plan covers each test type
+func (s *ServicesHandler) ListCoverageRules(c echo.Context) error {
+	if _, err := PrivateMiddlewareContext(c, insuranceReaders); err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListCoverageRulesDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	rules, err := s.insurancePort.ListCoverageRules(c.Request().Context(), dto.PlanID)
+	if err != nil {
+		utils.Error("Failed to list coverage rules",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "plan_id", Value: dto.PlanID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if rules == nil {
+		rules = []*db.InsuranceCoverageRule{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, rules, c)
+}
+
+// EnrolPolicy records the current patient's membership of an insurance plan
+func (s *ServicesHandler) EnrolPolicy(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.EnrolPolicyDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	plan, err := s.insurancePort.GetInsurancePlan(ctx, dto.PlanID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !plan.Active) {
+		return utils.ErrorResponse(http.StatusNotFound, ErrInsurancePlanNotFound, c)
+	}
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	validFrom := time.Now()
+	if dto.ValidFrom != nil {
+		validFrom = *dto.ValidFrom
+	}
+	var validUntil pgtype.Timestamptz
+	if dto.ValidUntil != nil {
+		if !dto.ValidUntil.After(validFrom) {
+			return utils.ErrorResponse(http.StatusBadRequest, errors.New("valid_until must be after valid_from"), c)
+		}
+		validUntil = toTimestamptz(*dto.ValidUntil)
+	}
+
+	policy, err := s.insurancePort.CreatePatientPolicy(ctx, db.Create_Patient_PolicyParams{
+		PatientID:    currentUser.UserID.String(),
+		PlanID:       plan.ID,
+		MemberNumber: strings.TrimSpace(dto.MemberNumber),
+		ValidFrom:    toTimestamptz(validFrom),
+		ValidUntil:   validUntil,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return utils.ErrorResponse(http.StatusConflict, ErrDuplicateInsurancePolicy, c)
+	}
+	if err != nil {
+		utils.Error("Failed to enrol insurance policy",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "patient_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, policy, c)
+}
+
+// ListPolicies lists the current patient's insurance policies, active ones first
+func (s *ServicesHandler) ListPolicies(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	policies, err := s.insurancePort.ListPatientPolicies(c.Request().Context(), currentUser.UserID.String())
+	if err != nil {
+		utils.Error("Failed to list insurance policies",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "patient_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if policies == nil {
+		policies = []*db.PatientPolicy{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, policies, c)
+}
+
+// EndPolicy ends one of the current patient's insurance policies. Bookings it already
+// covers stay covered and are still claimed.
+func (s *ServicesHandler) EndPolicy(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.EndPolicyDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	policy, err := s.insurancePort.EndPatientPolicy(c.Request().Context(), db.End_Patient_PolicyParams{
+		ID:        dto.PolicyID,
+		PatientID: currentUser.UserID.String(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return utils.ErrorResponse(http.StatusNotFound, ErrInsurancePolicyNotFound, c)
+	}
+	if err != nil {
+		utils.Error("Failed to end insurance policy",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "policy_id", Value: dto.PolicyID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, policy, c)
+}
+
+// applyInsuranceCoverage checks a patient's policy can be used and takes the share its plan
+// covers off the quote; the insurer's share is claimed from the insurer once the tests are done
+func (s *ServicesHandler) applyInsuranceCoverage(
+	ctx context.Context,
+	quote *domain.AppointmentQuote,
+	policyID string,
+	patientID string,
+) error {
+	policy, err := s.insurancePort.GetPatientPolicy(ctx, db.Get_Patient_PolicyParams{
+		ID:        policyID,
+		PatientID: patientID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInsurancePolicyNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get insurance policy: %w", err)
+	}
+	if err := checkPolicy(policy, time.Now()); err != nil {
+		return err
+	}
+
+	plan, err := s.insurancePort.GetInsurancePlan(ctx, policy.PlanID)
+	if err != nil {
+		return fmt.Errorf("failed to get insurance plan: %w", err)
+	}
+	insurer, err := s.insurancePort.GetInsurer(ctx, plan.InsurerID)
+	if err != nil {
+		return fmt.Errorf("failed to get insurer: %w", err)
+	}
+	if !plan.Active || !insurer.Active {
+		return ErrInsurancePolicyInactive
+	}
+	if !strings.EqualFold(plan.Currency, quote.Currency) {
+		return ErrCoverageNotApplicable
+	}
+
+	rules, err := s.insurancePort.ListCoverageRules(ctx, plan.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list coverage rules: %w", err)
+	}
+	coverage, err := insuranceCoverage(quote.Items, rules)
+	if err != nil {
+		return err
+	}
+
+	coverage.PolicyID = policy.ID
+	coverage.InsurerID = insurer.ID
+	coverage.Insurer = insurer.Name
+	coverage.PlanID = plan.ID
+	coverage.Plan = plan.Name
+	coverage.MemberNumber = policy.MemberNumber
+	quote.Coverage = coverage
+	quote.Total = roundAmount(quote.Total - coverage.InsurerAmount)
+	return nil
+}
+
+// checkPolicy reports whether a policy may cover a booking made at now
+func checkPolicy(policy *db.PatientPolicy, now time.Time) error {
+	if !policy.Active || now.Before(policy.ValidFrom.Time) || (policy.ValidUntil.Valid && !now.Before(policy.ValidUntil.Time)) {
+		return ErrInsurancePolicyInactive
+	}
+	return nil
+}
+
+// insuranceCoverage splits the price of each quoted test between the insurer and the patient.
+// The copay is taken off the price first and the plan pays its percentage of the rest; tests
+// the plan has no rule for are left to the patient.
+func insuranceCoverage(items []domain.QuoteItem, rules []*db.InsuranceCoverageRule) (*domain.QuoteCoverage, error) {
+	byTest := make(map[string]*db.InsuranceCoverageRule, len(rules))
+	for _, rule := range rules {
+		byTest[rule.TestType] = rule
+	}
+
+	coverage := &domain.QuoteCoverage{Lines: make([]domain.CoverageLine, 0, len(items))}
+	for _, item := range items {
+		line := domain.CoverageLine{TestType: item.TestType, Price: item.Price, PatientAmount: item.Price}
+		if rule, ok := byTest[item.TestType]; ok {
+			percent, err := numericToFloat(rule.CoveredPercent)
+			if err != nil {
+				return nil, fmt.Errorf("invalid covered percentage: %w", err)
+			}
+			copay, err := numericToFloat(rule.Copay)
+			if err != nil {
+				return nil, fmt.Errorf("invalid copay: %w", err)
+			}
+			line.CoveredPercent = percent
+			line.Copay = math.Min(copay, item.Price)
+			line.InsurerAmount = roundAmount((item.Price - line.Copay) * percent / 100)
+			line.PatientAmount = roundAmount(item.Price - line.InsurerAmount)
+			if line.InsurerAmount > 0 && rule.RequiresPreauthorization {
+				coverage.PreauthorizationRequired = true
+			}
+		}
+		coverage.InsurerAmount += line.InsurerAmount
+		coverage.PatientAmount += line.PatientAmount
+		coverage.Lines = append(coverage.Lines, line)
+	}
+
+	coverage.InsurerAmount = roundAmount(coverage.InsurerAmount)
+	coverage.PatientAmount = roundAmount(coverage.PatientAmount)
+	if coverage.InsurerAmount <= 0 {
+		return nil, ErrCoverageNotApplicable
+	}
+	return coverage, nil
+}
+
+// recordCoverage stores the insurer's share of a booked appointment within tx, ready to be
+// claimed once the appointment is completed
+func recordCoverage(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	appointment *db.Appointment,
+	quote *domain.AppointmentQuote,
+	authorizationCode string,
+) error {
+	coverage := quote.Coverage
+	lines, err := json.Marshal(coverage.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to encode coverage lines: %w", err)
+	}
+
+	_, err = tx.CreateAppointmentCoverage(ctx, db.Create_Appointment_CoverageParams{
+		AppointmentID:        appointment.ID,
+		PolicyID:             coverage.PolicyID,
+		InsurerID:            coverage.InsurerID,
+		PlanID:               coverage.PlanID,
+		PatientID:            appointment.PatientID,
+		DiagnosticCentreID:   appointment.DiagnosticCentreID,
+		MemberNumber:         coverage.MemberNumber,
+		Currency:             quote.Currency,
+		TotalAmount:          toNumeric(roundAmount(coverage.InsurerAmount + coverage.PatientAmount)),
+		InsurerAmount:        toNumeric(coverage.InsurerAmount),
+		PatientAmount:        toNumeric(coverage.PatientAmount),
+		PreauthorizationCode: toText(strings.TrimSpace(authorizationCode)),
+		Lines:                lines,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record insurance coverage: %w", err)
+	}
+	return nil
+}
+
+// isCoverageError reports whether err is an insurance policy the patient cannot use
+func isCoverageError(err error) bool {
+	return errors.Is(err, ErrInsurancePolicyNotFound) ||
+		errors.Is(err, ErrInsurancePolicyInactive) ||
+		errors.Is(err, ErrCoverageNotApplicable) ||
+		errors.Is(err, ErrPreauthorizationRequired)
+}
@@ -0,0 +1,407 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrInsuranceClaimNotFound   = errors.New("insurance claim not found")
+	ErrNothingToClaim           = errors.New("no completed appointments are waiting to be claimed from this insurer")
+	ErrClaimStatusTransition    = errors.New("insurance claim cannot move to this status")
+	ErrClaimAmountExceedsClaim  = errors.New("approved amount cannot exceed the claimed amount")
+	errInvalidInsuranceClaimDTO = errors.New("invalid parameters")
+)
+
+// claimTransitions lists the statuses an insurance claim may move to from each status
+var claimTransitions = map[db.InsuranceClaimStatus][]db.InsuranceClaimStatus{
+	db.InsuranceClaimStatusDraft:     {db.InsuranceClaimStatusSubmitted},
+	db.InsuranceClaimStatusSubmitted: {db.InsuranceClaimStatusApproved, db.InsuranceClaimStatusRejected},
+	db.InsuranceClaimStatusApproved:  {db.InsuranceClaimStatusSettled},
+}
+
+// CreateInsuranceClaim batches a centre's completed appointments covered by an insurer that
+// have not been claimed yet into a draft claim
+func (s *ServicesHandler) CreateInsuranceClaim(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.CreateInsuranceClaimDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	if err := s.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+	insurer, err := s.insurancePort.GetInsurer(ctx, dto.InsurerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return utils.ErrorResponse(http.StatusNotFound, ErrInsurerNotFound, c)
+	}
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	currency := strings.ToUpper(dto.Currency)
+	if currency == "" {
+		currency = "NGN"
+	}
+	until := time.Now()
+	if dto.Until != nil {
+		until = *dto.Until
+	}
+
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	defer tx.Rollback(ctx)
+
+	claim, err := tx.CreateInsuranceClaim(ctx, db.Create_Insurance_ClaimParams{
+		InsurerCode:        insurer.Code,
+		InsurerID:          insurer.ID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		Currency:           currency,
+		CreatedBy:          toUUID(currentUser.UserID.String()),
+	})
+	if err != nil {
+		utils.Error("Failed to create insurance claim",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	coverages, err := tx.AttachClaimCoverages(ctx, db.Attach_Claim_CoveragesParams{
+		ClaimID:            toUUID(claim.ID),
+		InsurerID:          insurer.ID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		Currency:           currency,
+		AppointmentDate:    toTimestamptz(until),
+	})
+	if err != nil {
+		utils.Error("Failed to attach claim coverages",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "claim_id", Value: claim.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if len(coverages) == 0 {
+		return utils.ErrorResponse(http.StatusUnprocessableEntity, ErrNothingToClaim, c)
+	}
+	totalled, err := tx.TotalInsuranceClaim(ctx, claim.ID)
+	if err != nil {
+		utils.Error("Failed to total insurance claim",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "claim_id", Value: claim.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	utils.Info("Insurance claim created",
+		utils.LogField{Key: "claim_id", Value: totalled.ID},
+		utils.LogField{Key: "claim_number", Value: totalled.ClaimNumber},
+		utils.LogField{Key: "coverage_count", Value: totalled.CoverageCount})
+	return utils.ResponseMessage(http.StatusCreated, totalled, c)
+}
+
+// ListInsuranceClaims lists a centre's insurance claims, newest first
+func (s *ServicesHandler) ListInsuranceClaims(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListInsuranceClaimsDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	ctx := c.Request().Context()
+	if err := s.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+
+	param, _ := SetDefaultPagination(&dto.PaginationQueryDTO).(*domain.PaginationQueryDTO)
+	claims, err := s.insurancePort.ListInsuranceClaims(ctx, db.List_Insurance_ClaimsParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		Limit:              param.GetLimit(),
+		Offset:             param.GetOffset(),
+	})
+	if err != nil {
+		utils.Error("Failed to list insurance claims",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if claims == nil {
+		claims = []*db.InsuranceClaim{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, claims, c)
+}
+
+// GetInsuranceClaim returns an insurance claim with the appointments it claims for
+func (s *ServicesHandler) GetInsuranceClaim(c echo.Context) error {
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.GetInsuranceClaimDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errInvalidInsuranceClaimDTO, c)
+	}
+
+	statement, err := s.ownedInsuranceClaim(c, dto.ClaimID)
+	if err != nil {
+		return utils.ErrorResponse(insuranceClaimErrorStatus(err), err, c)
+	}
+	return utils.ResponseMessage(http.StatusOK, statement, c)
+}
+
+// ExportInsuranceClaim returns an insurance claim as a CSV or JSON file for the insurer
+func (s *ServicesHandler) ExportInsuranceClaim(c echo.Context) error {
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ExportInsuranceClaimDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errInvalidInsuranceClaimDTO, c)
+	}
+
+	statement, err := s.ownedInsuranceClaim(c, dto.ClaimID)
+	if err != nil {
+		return utils.ErrorResponse(insuranceClaimErrorStatus(err), err, c)
+	}
+
+	var content []byte
+	contentType, extension := "text/csv", "csv"
+	if dto.Format == "json" {
+		contentType, extension = echo.MIMEApplicationJSON, "json"
+		content, err = json.MarshalIndent(statement, "", "  ")
+	} else {
+		content, err = insuranceClaimCSV(statement)
+	}
+	if err != nil {
+		utils.Error("Failed to export insurance claim",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "claim_id", Value: statement.Claim.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	filename := fmt.Sprintf("%s.%s", strings.ToLower(statement.Claim.ClaimNumber), extension)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.Blob(http.StatusOK, contentType, content)
+}
+
+// UpdateInsuranceClaimStatus records a claim's progress with the insurer
+func (s *ServicesHandler) UpdateInsuranceClaimStatus(c echo.Context) error {
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.UpdateInsuranceClaimStatusDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	statement, err := s.ownedInsuranceClaim(c, dto.ClaimID)
+	if err != nil {
+		return utils.ErrorResponse(insuranceClaimErrorStatus(err), err, c)
+	}
+
+	params, err := claimStatusParams(statement.Claim, dto)
+	if err != nil {
+		return utils.ErrorResponse(insuranceClaimErrorStatus(err), err, c)
+	}
+	claim, err := s.insurancePort.UpdateInsuranceClaimStatus(c.Request().Context(), params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return utils.ErrorResponse(http.StatusConflict,
+			fmt.Errorf("%w: the claim was updated in the meantime", ErrClaimStatusTransition), c)
+	}
+	if err != nil {
+		utils.Error("Failed to update insurance claim",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "claim_id", Value: dto.ClaimID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, claim, c)
+}
+
+// claimStatusParams checks a claim may move to the requested status and builds its update.
+// An approval without an amount approves everything claimed.
+func claimStatusParams(
+	claim *db.InsuranceClaim,
+	dto *domain.UpdateInsuranceClaimStatusDTO,
+) (db.Update_Insurance_Claim_StatusParams, error) {
+	status := db.InsuranceClaimStatus(dto.Status)
+	if !slices.Contains(claimTransitions[claim.Status], status) {
+		return db.Update_Insurance_Claim_StatusParams{},
+			fmt.Errorf("%w: %s to %s", ErrClaimStatusTransition, claim.Status, status)
+	}
+
+	var approved pgtype.Numeric
+	if status == db.InsuranceClaimStatusApproved {
+		claimed, err := numericToFloat(claim.ClaimedAmount)
+		if err != nil {
+			return db.Update_Insurance_Claim_StatusParams{}, fmt.Errorf("invalid claimed amount: %w", err)
+		}
+		amount := claimed
+		if dto.ApprovedAmount > 0 {
+			amount = roundAmount(dto.ApprovedAmount)
+		}
+		if amount > claimed {
+			return db.Update_Insurance_Claim_StatusParams{}, ErrClaimAmountExceedsClaim
+		}
+		approved = toNumeric(amount)
+	}
+
+	return db.Update_Insurance_Claim_StatusParams{
+		ID:               claim.ID,
+		Status:           status,
+		ApprovedAmount:   approved,
+		InsurerReference: toText(strings.TrimSpace(dto.InsurerReference)),
+		Notes:            toText(strings.TrimSpace(dto.Notes)),
+		Status_2:         claim.Status,
+	}, nil
+}
+
+// ownedInsuranceClaim loads a claim with its entries once the current user is confirmed as
+// staff of its centre
+func (s *ServicesHandler) ownedInsuranceClaim(c echo.Context, claimID string) (*domain.InsuranceClaimStatement, error) {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrUnauthorized, err)
+	}
+
+	ctx := c.Request().Context()
+	claim, err := s.insurancePort.GetInsuranceClaim(ctx, claimID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInsuranceClaimNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeCentreAccess(ctx, currentUser, claim.DiagnosticCentreID); err != nil {
+		return nil, err
+	}
+
+	insurer, err := s.insurancePort.GetInsurer(ctx, claim.InsurerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get insurer: %w", err)
+	}
+	rows, err := s.insurancePort.ListClaimEntries(ctx, toUUID(claim.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list claim entries: %w", err)
+	}
+	entries, err := claimEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.InsuranceClaimStatement{Claim: claim, Insurer: insurer, Entries: entries}, nil
+}
+
+// claimEntries turns the covered appointments of a claim into its entries
+func claimEntries(rows []*db.List_Claim_EntriesRow) ([]domain.InsuranceClaimEntry, error) {
+	entries := make([]domain.InsuranceClaimEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := domain.InsuranceClaimEntry{
+			AppointmentID:        row.AppointmentID,
+			AppointmentDate:      row.AppointmentDate.Time,
+			PatientName:          row.PatientName.String,
+			MemberNumber:         row.MemberNumber,
+			PreauthorizationCode: row.PreauthorizationCode.String,
+		}
+		var err error
+		if entry.TotalAmount, err = numericToFloat(row.TotalAmount); err != nil {
+			return nil, fmt.Errorf("invalid claim entry amount: %w", err)
+		}
+		if entry.InsurerAmount, err = numericToFloat(row.InsurerAmount); err != nil {
+			return nil, fmt.Errorf("invalid claim entry amount: %w", err)
+		}
+		if entry.PatientAmount, err = numericToFloat(row.PatientAmount); err != nil {
+			return nil, fmt.Errorf("invalid claim entry amount: %w", err)
+		}
+		if err := json.Unmarshal(row.Lines, &entry.Lines); err != nil {
+			return nil, fmt.Errorf("invalid claim entry lines: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// insuranceClaimErrorStatus maps insurance claim errors to HTTP status codes
+func insuranceClaimErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, errInvalidInsuranceClaimDTO):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrInsuranceClaimNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrClaimStatusTransition):
+		return http.StatusConflict
+	case errors.Is(err, ErrClaimAmountExceedsClaim):
+		return http.StatusUnprocessableEntity
+	default:
+		return centreAccessErrorStatus(err)
+	}
+}
+
+// insuranceClaimCSV writes a claim as CSV: one row per test of each covered appointment,
+// then the claim's total
+func insuranceClaimCSV(statement *domain.InsuranceClaimStatement) ([]byte, error) {
+	claim := statement.Claim
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{
+		"claim_number", "appointment_id", "appointment_date", "patient_name", "member_number",
+		"preauthorization_code", "test_type", "price", "copay", "covered_percent",
+		"insurer_amount", "patient_amount", "currency",
+	}}
+	for _, entry := range statement.Entries {
+		for _, line := range entry.Lines {
+			rows = append(rows, []string{
+				claim.ClaimNumber,
+				entry.AppointmentID,
+				entry.AppointmentDate.Format(time.RFC3339),
+				entry.PatientName,
+				entry.MemberNumber,
+				entry.PreauthorizationCode,
+				line.TestType,
+				strconv.FormatFloat(line.Price, 'f', 2, 64),
+				strconv.FormatFloat(line.Copay, 'f', 2, 64),
+				strconv.FormatFloat(line.CoveredPercent, 'f', -1, 64),
+				strconv.FormatFloat(line.InsurerAmount, 'f', 2, 64),
+				strconv.FormatFloat(line.PatientAmount, 'f', 2, 64),
+				claim.Currency,
+			})
+		}
+	}
+	rows = append(rows, []string{
+		claim.ClaimNumber, "total", "", "", "", "", "", "", "", "",
+		formatAmount(claim.ClaimedAmount), "", claim.Currency,
+	})
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
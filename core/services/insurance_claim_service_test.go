@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+)
+
+func TestClaimStatusParams(t *testing.T) {
+	claim := func(status db.InsuranceClaimStatus) *db.InsuranceClaim {
+		return &db.InsuranceClaim{ID: "claim-1", Status: status, ClaimedAmount: toNumeric(72000)}
+	}
+
+	tests := []struct {
+		name     string
+		claim    *db.InsuranceClaim
+		dto      domain.UpdateInsuranceClaimStatusDTO
+		approved float64
+		err      error
+	}{
+		{"submit a draft", claim(db.InsuranceClaimStatusDraft), domain.UpdateInsuranceClaimStatusDTO{Status: "submitted"}, 0, nil},
+		{"approve in full", claim(db.InsuranceClaimStatusSubmitted), domain.UpdateInsuranceClaimStatusDTO{Status: "approved"}, 72000, nil},
+		{"approve in part", claim(db.InsuranceClaimStatusSubmitted), domain.UpdateInsuranceClaimStatusDTO{Status: "approved", ApprovedAmount: 65000}, 65000, nil},
+		{"reject", claim(db.InsuranceClaimStatusSubmitted), domain.UpdateInsuranceClaimStatusDTO{Status: "rejected"}, 0, nil},
+		{"settle", claim(db.InsuranceClaimStatusApproved), domain.UpdateInsuranceClaimStatusDTO{Status: "settled"}, 0, nil},
+		{"approve more than claimed", claim(db.InsuranceClaimStatusSubmitted), domain.UpdateInsuranceClaimStatusDTO{Status: "approved", ApprovedAmount: 80000}, 0, ErrClaimAmountExceedsClaim},
+		{"approve a draft", claim(db.InsuranceClaimStatusDraft), domain.UpdateInsuranceClaimStatusDTO{Status: "approved"}, 0, ErrClaimStatusTransition},
+		{"settle a rejected claim", claim(db.InsuranceClaimStatusRejected), domain.UpdateInsuranceClaimStatusDTO{Status: "settled"}, 0, ErrClaimStatusTransition},
+		{"settle twice", claim(db.InsuranceClaimStatusSettled), domain.UpdateInsuranceClaimStatusDTO{Status: "settled"}, 0, ErrClaimStatusTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := claimStatusParams(tt.claim, &tt.dto)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("claimStatusParams() error = %v; want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if params.Status != db.InsuranceClaimStatus(tt.dto.Status) || params.Status_2 != tt.claim.Status {
+				t.Errorf("claimStatusParams() = %s from %s; want %s from %s", params.Status, params.Status_2, tt.dto.Status, tt.claim.Status)
+			}
+			if tt.approved == 0 {
+				if params.ApprovedAmount.Valid {
+					t.Errorf("ApprovedAmount = %v; want none", params.ApprovedAmount)
+				}
+				return
+			}
+			if got, _ := numericToFloat(params.ApprovedAmount); got != tt.approved {
+				t.Errorf("ApprovedAmount = %v; want %v", got, tt.approved)
+			}
+		})
+	}
+}
+
+func TestClaimEntriesCSV(t *testing.T) {
+	date := toTimestamptz(time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC))
+	entries, err := claimEntries([]*db.List_Claim_EntriesRow{{
+		AppointmentID:        "apt-1",
+		MemberNumber:         "HYG/12345/A",
+		PreauthorizationCode: toText("PA-889"),
+		TotalAmount:          toNumeric(13000),
+		InsurerAmount:        toNumeric(7200),
+		PatientAmount:        toNumeric(5800),
+		Lines: []byte(`[{"test_type":"LIPID_PROFILE","price":10000,"covered_percent":80,"copay":1000,"insurer_amount":7200,"patient_amount":2800},` +
+			`{"test_type":"MRI","price":3000,"covered_percent":0,"copay":0,"insurer_amount":0,"patient_amount":3000}]`),
+		AppointmentDate: date,
+		PatientName:     toText("Ada Obi"),
+	}})
+	if err != nil {
+		t.Fatalf("claimEntries() error = %v", err)
+	}
+
+	content, err := insuranceClaimCSV(&domain.InsuranceClaimStatement{
+		Claim: &db.InsuranceClaim{
+			ClaimNumber:   "CLM-HYGEIA-000042",
+			Currency:      "NGN",
+			ClaimedAmount: toNumeric(7200),
+		},
+		Entries: entries,
+	})
+	if err != nil {
+		t.Fatalf("insuranceClaimCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"claim_number,appointment_id,appointment_date,patient_name,member_number,preauthorization_code,test_type,price,copay,covered_percent,insurer_amount,patient_amount,currency",
+		"CLM-HYGEIA-000042,apt-1,2026-10-20T09:00:00Z,Ada Obi,HYG/12345/A,PA-889,LIPID_PROFILE,10000.00,1000.00,80,7200.00,2800.00,NGN",
+		"CLM-HYGEIA-000042,apt-1,2026-10-20T09:00:00Z,Ada Obi,HYG/12345/A,PA-889,MRI,3000.00,0.00,0,0.00,3000.00,NGN",
+		"CLM-HYGEIA-000042,total,,,,,,,,,7200.00,,NGN",
+		"",
+	}, "\n")
+	if string(content) != want {
+		t.Errorf("insuranceClaimCSV() =\n%s\nwant\n%s", content, want)
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+)
+
+func TestInsuranceCoverage(t *testing.T) {
+	items := []domain.QuoteItem{
+		{TestType: "LIPID_PROFILE", Price: 10000},
+		{TestType: "MALARIA_TEST", Price: 3000},
+		{TestType: "MRI", Price: 50000},
+	}
+	rules := []*db.InsuranceCoverageRule{
+		{TestType: "LIPID_PROFILE", CoveredPercent: toNumeric(80), Copay: toNumeric(1000)},
+		{TestType: "MALARIA_TEST", CoveredPercent: toNumeric(100), Copay: toNumeric(5000), RequiresPreauthorization: true},
+	}
+
+	coverage, err := insuranceCoverage(items, rules)
+	if err != nil {
+		t.Fatalf("insuranceCoverage() error = %v", err)
+	}
+	want := []domain.CoverageLine{
+		{TestType: "LIPID_PROFILE", Price: 10000, CoveredPercent: 80, Copay: 1000, InsurerAmount: 7200, PatientAmount: 2800},
+		{TestType: "MALARIA_TEST", Price: 3000, CoveredPercent: 100, Copay: 3000, InsurerAmount: 0, PatientAmount: 3000},
+		{TestType: "MRI", Price: 50000, InsurerAmount: 0, PatientAmount: 50000},
+	}
+	if len(coverage.Lines) != len(want) {
+		t.Fatalf("insuranceCoverage() lines = %+v; want %+v", coverage.Lines, want)
+	}
+	for i := range want {
+		if coverage.Lines[i] != want[i] {
+			t.Errorf("line %d = %+v; want %+v", i, coverage.Lines[i], want[i])
+		}
+	}
+	if coverage.InsurerAmount != 7200 || coverage.PatientAmount != 55800 {
+		t.Errorf("insuranceCoverage() = insurer %v, patient %v; want 7200 and 55800", coverage.InsurerAmount, coverage.PatientAmount)
+	}
+	// The malaria test needs pre-authorization, but its copay leaves the insurer nothing to pay
+	if coverage.PreauthorizationRequired {
+		t.Error("PreauthorizationRequired = true; want false")
+	}
+
+	rules[1].Copay = toNumeric(0)
+	coverage, err = insuranceCoverage(items, rules)
+	if err != nil {
+		t.Fatalf("insuranceCoverage() error = %v", err)
+	}
+	if !coverage.PreauthorizationRequired || coverage.InsurerAmount != 10200 {
+		t.Errorf("insuranceCoverage() = %+v; want 10200 covered with pre-authorization", coverage)
+	}
+
+	if _, err := insuranceCoverage(items[2:], rules); !errors.Is(err, ErrCoverageNotApplicable) {
+		t.Errorf("insuranceCoverage() error = %v; want %v", err, ErrCoverageNotApplicable)
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	policy := func(edit func(*db.PatientPolicy)) *db.PatientPolicy {
+		p := &db.PatientPolicy{
+			Active:    true,
+			ValidFrom: toTimestamptz(now.AddDate(0, -1, 0)),
+		}
+		if edit != nil {
+			edit(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name   string
+		policy *db.PatientPolicy
+		err    error
+	}{
+		{"open ended", policy(nil), nil},
+		{"within its validity", policy(func(p *db.PatientPolicy) { p.ValidUntil = toTimestamptz(now.AddDate(0, 1, 0)) }), nil},
+		{"expired", policy(func(p *db.PatientPolicy) { p.ValidUntil = toTimestamptz(now) }), ErrInsurancePolicyInactive},
+		{"not started", policy(func(p *db.PatientPolicy) { p.ValidFrom = toTimestamptz(now.Add(time.Hour)) }), ErrInsurancePolicyInactive},
+		{"ended", policy(func(p *db.PatientPolicy) { p.Active = false }), ErrInsurancePolicyInactive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkPolicy(tt.policy, now); !errors.Is(err, tt.err) {
+				t.Errorf("checkPolicy() error = %v; want %v", err, tt.err)
+			}
+		})
+	}
+}
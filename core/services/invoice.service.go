@@ -0,0 +1,598 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/templates/documents"
+	"github.com/diagnoxix/adapters/external/templates/emails"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// receiptSweepDelay leaves a payment's own receipt call time to queue the email before
+	// the sweep picks the payment up
+	receiptSweepDelay = 2 * time.Minute
+	// receiptSweepWindow bounds how far back the sweep looks, so payments made before
+	// receipts were emailed are not mailed now
+	receiptSweepWindow = 72 * time.Hour
+	// receiptSweepBatchSize caps the receipts queued in one run; the rest wait for the next
+	receiptSweepBatchSize = 100
+)
+
+var ErrPaymentNotInvoiceable = errors.New("only successful payments have a receipt")
+
+// GetPaymentReceipt returns the invoice and receipt of a successful payment as a PDF. Patients
+// get the receipts of their own payments and centre staff those of their centre.
+func (s *ServicesHandler) GetPaymentReceipt(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumPATIENT,
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.GetPaymentDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	ctx := c.Request().Context()
+	payment, err := s.paymentPort.GetPayment(ctx, dto.PaymentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("payment not found"), c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if currentUser.UserType == db.UserEnumPATIENT {
+		if payment.PatientID != currentUser.UserID.String() {
+			return utils.ErrorResponse(http.StatusForbidden, errors.New("not authorized to view this receipt"), c)
+		}
+	} else if err := s.authorizeCentreAccess(ctx, currentUser, payment.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+	if !isInvoiceable(payment) {
+		return utils.ErrorResponse(http.StatusConflict, ErrPaymentNotInvoiceable, c)
+	}
+
+	invoice, err := s.issueInvoice(ctx, payment)
+	if err != nil {
+		utils.Error("Failed to issue invoice",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	_, content, err := s.renderPaymentReceipt(ctx, payment, invoice)
+	if err != nil {
+		utils.Error("Failed to render payment receipt",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", receiptFilename(invoice)))
+	return c.Blob(http.StatusOK, "application/pdf", content)
+}
+
+// sendPaymentReceiptEmail emails the patient the confirmation of a successful payment with its
+// receipt attached. Every path that completes a payment calls it, so the invoice is claimed
+// first and the email goes out only once.
+func (s *ServicesHandler) sendPaymentReceiptEmail(paymentID string) {
+	ctx := context.Background()
+	payment, err := s.paymentPort.GetPayment(ctx, paymentID)
+	if err != nil {
+		utils.Error("Failed to get payment for receipt email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: paymentID})
+		return
+	}
+	if payment.PaymentStatus != db.PaymentStatusSuccess {
+		return
+	}
+
+	invoice, err := s.issueInvoice(ctx, payment)
+	if err != nil {
+		utils.Error("Failed to issue invoice",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return
+	}
+	if invoice.EmailedAt.Valid {
+		return
+	}
+
+	receipt, content, err := s.renderPaymentReceipt(ctx, payment, invoice)
+	if err != nil {
+		utils.Error("Failed to render payment receipt",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return
+	}
+
+	data := emails.PaymentData{
+		EmailData: emails.EmailData{
+			AppName: "Diagnoxix",
+		},
+		PatientName:   receipt.Patient.Fullname.String,
+		TransactionID: receipt.Document.PaymentReference,
+		PaymentAmount: receipt.Document.Total,
+		PaymentMethod: receipt.Document.PaymentMethod,
+		PaymentDate:   receipt.Document.PaidAt,
+		CentreName:    receipt.Document.Centre.Name,
+	}
+	if len(receipt.Appointments) > 0 {
+		appointment := receipt.Appointments[0]
+		data.AppointmentID = appointment.ID
+		data.AppointmentDate = appointment.AppointmentDate.Time
+		data.TimeSlot = appointment.TimeSlot
+	}
+	data.TestType = strings.Join(receipt.Tests, ", ")
+
+	// The claim and the queued email commit together, so a concurrent call finds the invoice
+	// taken and stops, and a failed queue leaves it to be claimed again
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		utils.Error("Failed to begin receipt email transaction",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.MarkInvoiceEmailed(ctx, invoice.ID); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			utils.Error("Failed to claim invoice for receipt email",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "invoice_number", Value: invoice.InvoiceNumber})
+		}
+		return
+	}
+	if err := s.queueEmail(ctx, tx, outboxEmail{
+		To:       receipt.Patient.Email.String,
+		Subject:  emails.TitlePaymentConfirmed,
+		Template: emails.TemplatePaymentConfirmation,
+		Data:     &data,
+		Attachments: []domain.EmailAttachment{{
+			Filename:    receiptFilename(invoice),
+			ContentType: "application/pdf",
+			Content:     content,
+		}},
+	}); err != nil {
+		utils.Error("Failed to queue payment receipt email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		utils.Error("Failed to commit receipt email transaction",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return
+	}
+
+	utils.Info("Payment receipt email queued",
+		utils.LogField{Key: "payment_id", Value: payment.ID},
+		utils.LogField{Key: "invoice_number", Value: invoice.InvoiceNumber})
+}
+
+// queueMissedReceipts queues the receipt emails of recent successful payments that were never
+// queued, because the instance completing the payment stopped or the queueing failed after
+// the payment committed. The invoice claim keeps a receipt from going out twice.
+func (s *ServicesHandler) queueMissedReceipts() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	payments, err := s.paymentPort.ListUnreceiptedPayments(ctx, db.List_Unreceipted_PaymentsParams{
+		UpdatedAt:   toTimestamptz(now.Add(-receiptSweepWindow)),
+		UpdatedAt_2: toTimestamptz(now.Add(-receiptSweepDelay)),
+		Limit:       receiptSweepBatchSize,
+	})
+	if err != nil {
+		utils.Error("Failed to list payments missing a receipt email",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return
+	}
+
+	for _, payment := range payments {
+		s.sendPaymentReceiptEmail(payment.ID)
+	}
+	if len(payments) > 0 {
+		utils.Info("Missed payment receipt emails swept",
+			utils.LogField{Key: "payments", Value: len(payments)})
+	}
+}
+
+// renderPaymentReceipt builds the receipt of an invoiced payment and renders it as a PDF
+func (s *ServicesHandler) renderPaymentReceipt(
+	ctx context.Context,
+	payment *db.Payment,
+	invoice *db.PaymentInvoice,
+) (*paymentReceipt, []byte, error) {
+	receipt, err := s.loadPaymentReceipt(ctx, payment, invoice)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := documents.RenderReceipt(receipt.Document)
+	if err != nil {
+		return nil, nil, err
+	}
+	return receipt, content, nil
+}
+
+// issueInvoice returns the invoice of a payment, issuing it with the next number of the centre's
+// sequence the first time. The number is taken in the transaction that stores the invoice, so a
+// payment invoiced concurrently gives its number back and the sequence has no gaps.
+func (s *ServicesHandler) issueInvoice(ctx context.Context, payment *db.Payment) (*db.PaymentInvoice, error) {
+	invoice, err := s.invoicePort.GetPaymentInvoice(ctx, payment.ID)
+	if err == nil {
+		return invoice, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	sequence, err := tx.NextInvoiceNumber(ctx, payment.DiagnosticCentreID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take invoice number: %w", err)
+	}
+	invoice, err = tx.CreatePaymentInvoice(ctx, db.Create_Payment_InvoiceParams{
+		PaymentID:          payment.ID,
+		DiagnosticCentreID: payment.DiagnosticCentreID,
+		SequenceNumber:     sequence,
+		InvoiceNumber:      invoiceNumber(payment.DiagnosticCentreID, sequence),
+		TaxRate:            toNumeric(s.invoiceTaxPercent()),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		if err := tx.Rollback(ctx); err != nil {
+			return nil, fmt.Errorf("failed to roll back invoice number: %w", err)
+		}
+		return s.invoicePort.GetPaymentInvoice(ctx, payment.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit invoice: %w", err)
+	}
+	return invoice, nil
+}
+
+// paymentReceipt is a receipt document together with the records it was built from
+type paymentReceipt struct {
+	Document     *documents.ReceiptData
+	Patient      *db.User
+	Appointments []*db.Appointment
+	Tests        []string
+}
+
+// loadPaymentReceipt loads what a payment's receipt shows and builds it
+func (s *ServicesHandler) loadPaymentReceipt(
+	ctx context.Context,
+	payment *db.Payment,
+	invoice *db.PaymentInvoice,
+) (*paymentReceipt, error) {
+	centre, err := s.diagnosticPort.GetDiagnosticCentre(ctx, payment.DiagnosticCentreID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diagnostic centre: %w", err)
+	}
+	patient, err := s.userPort.GetUser(ctx, payment.PatientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get patient: %w", err)
+	}
+	appointments, err := s.invoicePort.ListPaymentAppointments(ctx, toUUID(payment.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment appointments: %w", err)
+	}
+	lineItems := make(map[string][]*db.AppointmentLineItem, len(appointments))
+	var tests []string
+	for _, appointment := range appointments {
+		items, err := s.lineItemPort.ListAppointmentLineItems(ctx, appointment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list line items: %w", err)
+		}
+		lineItems[appointment.ID] = items
+		for _, item := range items {
+			tests = append(tests, emails.FormatTestType(item.TestType))
+		}
+	}
+	refunds, err := s.paymentPort.ListPaymentRefunds(ctx, payment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refunds: %w", err)
+	}
+	redemption, err := s.promoCodePort.GetPaymentPromoRedemption(ctx, payment.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		redemption, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo redemption: %w", err)
+	}
+	coverages, err := s.insurancePort.ListPaymentCoverages(ctx, toUUID(payment.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insurance coverages: %w", err)
+	}
+	insurers := make(map[string]string, len(coverages))
+	for _, coverage := range coverages {
+		if _, ok := insurers[coverage.InsurerID]; ok {
+			continue
+		}
+		insurer, err := s.insurancePort.GetInsurer(ctx, coverage.InsurerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get insurer: %w", err)
+		}
+		insurers[coverage.InsurerID] = insurer.Name
+	}
+	deductions, err := receiptDeductions(redemption, coverages, insurers)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := buildReceipt(payment, invoice, centre, patient, appointments, lineItems, deductions, refunds)
+	if err != nil {
+		return nil, err
+	}
+	return &paymentReceipt{
+		Document:     document,
+		Patient:      patient,
+		Appointments: appointments,
+		Tests:        tests,
+	}, nil
+}
+
+// buildReceipt lays out a payment's receipt: a line per test of each appointment it paid for,
+// the deductions taken off the tests, whatever the payment charged beyond them as fees, the
+// tax included at the invoice's rate and the refunds made since
+func buildReceipt(
+	payment *db.Payment,
+	invoice *db.PaymentInvoice,
+	centre *db.Get_Diagnostic_CentreRow,
+	patient *db.User,
+	appointments []*db.Appointment,
+	lineItems map[string][]*db.AppointmentLineItem,
+	deductions []documents.ReceiptLine,
+	refunds []*db.PaymentRefund,
+) (*documents.ReceiptData, error) {
+	total, err := numericToFloat(payment.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payment amount: %w", err)
+	}
+	taxRate, err := numericToFloat(invoice.TaxRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tax rate: %w", err)
+	}
+
+	receipt := &documents.ReceiptData{
+		InvoiceNumber:    invoice.InvoiceNumber,
+		IssuedAt:         invoice.IssuedAt.Time,
+		PaymentReference: paymentReceiptReference(payment),
+		PaymentMethod:    paymentMethodLabel(payment),
+		PaidAt:           paymentPaidAt(payment),
+		Currency:         payment.Currency,
+		Centre:           centreReceiptParty(centre),
+		BilledTo:         patientReceiptParty(patient),
+		Total:            total,
+		TaxRate:          taxRate,
+		TaxAmount:        roundAmount(total * taxRate / (100 + taxRate)),
+	}
+
+	itemsTotal := 0.0
+	for _, appointment := range appointments {
+		detail := fmt.Sprintf("Appointment on %s, %s",
+			appointment.AppointmentDate.Time.Format("2 January 2006"), appointment.TimeSlot)
+		items := lineItems[appointment.ID]
+		if len(items) == 0 {
+			amount, err := numericToFloat(appointment.PaymentAmount)
+			if err != nil {
+				amount = 0
+			}
+			receipt.Items = append(receipt.Items, documents.ReceiptLine{
+				Description: "Diagnostic appointment",
+				Detail:      detail,
+				Amount:      amount,
+			})
+			itemsTotal += amount
+			continue
+		}
+		for i, item := range items {
+			amount, err := numericToFloat(item.Price)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse line item price: %w", err)
+			}
+			line := documents.ReceiptLine{Description: emails.FormatTestType(item.TestType), Amount: amount}
+			if i == 0 {
+				line.Detail = detail
+			}
+			receipt.Items = append(receipt.Items, line)
+			itemsTotal += amount
+		}
+	}
+	for _, deduction := range deductions {
+		receipt.Items = append(receipt.Items, deduction)
+		itemsTotal += deduction.Amount
+	}
+	switch difference := roundAmount(total - itemsTotal); {
+	case difference > 0:
+		receipt.Items = append(receipt.Items, documents.ReceiptLine{Description: "Booking fee", Amount: difference})
+	case difference < 0:
+		receipt.Items = append(receipt.Items, documents.ReceiptLine{Description: "Discount", Amount: difference})
+	}
+
+	refunded := 0.0
+	for _, refund := range refunds {
+		if refund.Status != db.PaymentRefundStatusProcessed {
+			continue
+		}
+		amount, err := numericToFloat(refund.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse refund amount: %w", err)
+		}
+		receipt.Adjustments = append(receipt.Adjustments, documents.ReceiptLine{
+			Description: fmt.Sprintf("Refund on %s", refund.ProcessedAt.Time.Format("2 January 2006")),
+			Detail:      refund.Reason,
+			Amount:      -amount,
+		})
+		refunded += amount
+	}
+	receipt.NetPaid = roundAmount(total - refunded)
+	return receipt, nil
+}
+
+// receiptDeductions lists what was taken off the tests of a payment: the promo code discount
+// if one was redeemed and the share each insurer covers, named from insurers by insurer ID
+func receiptDeductions(
+	redemption *db.PromoRedemption,
+	coverages []*db.AppointmentCoverage,
+	insurers map[string]string,
+) ([]documents.ReceiptLine, error) {
+	var deductions []documents.ReceiptLine
+	if redemption != nil && !redemption.ReleasedAt.Valid {
+		discount, err := numericToFloat(redemption.DiscountAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse promo discount: %w", err)
+		}
+		deductions = append(deductions, documents.ReceiptLine{
+			Description: fmt.Sprintf("Discount (%s)", redemption.Code),
+			Amount:      -discount,
+		})
+	}
+	for _, coverage := range coverages {
+		covered, err := numericToFloat(coverage.InsurerAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse insurer amount: %w", err)
+		}
+		deductions = append(deductions, documents.ReceiptLine{
+			Description: fmt.Sprintf("Covered by %s", insurers[coverage.InsurerID]),
+			Detail:      fmt.Sprintf("Member %s", coverage.MemberNumber),
+			Amount:      -covered,
+		})
+	}
+	return deductions, nil
+}
+
+// centreReceiptParty prints a centre with its address and contact details
+func centreReceiptParty(centre *db.Get_Diagnostic_CentreRow) documents.ReceiptParty {
+	party := documents.ReceiptParty{Name: centre.DiagnosticCentreName}
+
+	var address domain.Address
+	if err := utils.UnmarshalJSONField(centre.Address, &address); err == nil {
+		if line := joinNonEmpty(", ", address.Street, address.City, address.State, address.Country); line != "" {
+			party.Lines = append(party.Lines, line)
+		}
+	}
+	var contact domain.Contact
+	if err := utils.UnmarshalJSONField(centre.Contact, &contact); err == nil {
+		if line := joinNonEmpty(", ", contact.Phone...); line != "" {
+			party.Lines = append(party.Lines, line)
+		}
+		if contact.Email != "" {
+			party.Lines = append(party.Lines, contact.Email)
+		}
+	}
+	return party
+}
+
+// patientReceiptParty prints a patient with their contact details
+func patientReceiptParty(patient *db.User) documents.ReceiptParty {
+	party := documents.ReceiptParty{Name: patient.Fullname.String}
+	if patient.Email.Valid {
+		party.Lines = append(party.Lines, patient.Email.String)
+	}
+	if patient.PhoneNumber.Valid {
+		party.Lines = append(party.Lines, patient.PhoneNumber.String)
+	}
+	return party
+}
+
+// paymentMethodLabel names how a payment was made, with the part the wallet paid
+func paymentMethodLabel(payment *db.Payment) string {
+	label := string(payment.PaymentMethod)
+	share, err := paymentWalletAmount(payment)
+	if err == nil && share > 0 && payment.PaymentMethod != db.PaymentMethodWallet {
+		label = fmt.Sprintf("%s, %s %s from wallet", label, payment.Currency, documents.FormatAmount(share))
+	}
+	return label
+}
+
+// paymentReceiptReference is the reference a patient can quote for a payment: the centre's
+// receipt for payments made there, the provider's reference otherwise
+func paymentReceiptReference(payment *db.Payment) string {
+	if payment.ReceiptNumber.Valid {
+		return payment.ReceiptNumber.String
+	}
+	return payment.ProviderReference.String
+}
+
+// paymentPaidAt is when a payment was made
+func paymentPaidAt(payment *db.Payment) time.Time {
+	switch {
+	case payment.ReceivedAt.Valid:
+		return payment.ReceivedAt.Time
+	case payment.PaymentDate.Valid:
+		return payment.PaymentDate.Time
+	default:
+		return payment.UpdatedAt.Time
+	}
+}
+
+// isInvoiceable reports whether a payment was taken, including those refunded since
+func isInvoiceable(payment *db.Payment) bool {
+	return payment.PaymentStatus == db.PaymentStatusSuccess || payment.PaymentStatus == db.PaymentStatusRefunded
+}
+
+// invoiceNumber formats a centre's invoice sequence number. The centre's ID prefix keeps numbers
+// unique across centres, as every centre counts from one.
+func invoiceNumber(diagnosticCentreID string, sequence int64) string {
+	prefix := strings.ToUpper(strings.ReplaceAll(diagnosticCentreID, "-", ""))
+	if len(prefix) > 8 {
+		prefix = prefix[:8]
+	}
+	return fmt.Sprintf("INV-%s-%06d", prefix, sequence)
+}
+
+// receiptFilename names the PDF of an invoice
+func receiptFilename(invoice *db.PaymentInvoice) string {
+	return strings.ToLower(invoice.InvoiceNumber) + ".pdf"
+}
+
+// invoiceTaxPercent reads the percentage of tax included in payment amounts from configuration
+func (s *ServicesHandler) invoiceTaxPercent() float64 {
+	if s.Config.INVOICE_TAX_PERCENT == "" {
+		return 0
+	}
+	percent, err := strconv.ParseFloat(s.Config.INVOICE_TAX_PERCENT, 64)
+	if err != nil || percent < 0 || percent >= 100 || math.IsNaN(percent) {
+		utils.Warn("Invalid INVOICE_TAX_PERCENT configuration, invoicing without tax",
+			utils.LogField{Key: "invoice_tax_percent", Value: s.Config.INVOICE_TAX_PERCENT})
+		return 0
+	}
+	return percent
+}
+
+// joinNonEmpty joins the values that are not blank
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
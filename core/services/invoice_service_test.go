@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/templates/documents"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"go.uber.org/zap"
+)
+
+func TestBuildReceipt(t *testing.T) {
+	paid := toTimestamptz(time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC))
+	payment := &db.Payment{
+		ID:                "pay-1",
+		Amount:            toNumeric(21500),
+		Currency:          "NGN",
+		PaymentMethod:     db.PaymentMethodCard,
+		PaymentStatus:     db.PaymentStatusSuccess,
+		PaymentDate:       paid,
+		ProviderReference: toText("APT-ref"),
+		WalletAmount:      toNumeric(1500),
+	}
+	invoice := &db.PaymentInvoice{InvoiceNumber: "INV-5F0C6D1E-000007", TaxRate: toNumeric(7.5), IssuedAt: paid}
+	centre := &db.Get_Diagnostic_CentreRow{
+		DiagnosticCentreName: "Lagos Diagnostics",
+		Address:              []byte(`{"street":"12 Marina Road","city":"Lagos","state":"Lagos","country":"Nigeria"}`),
+		Contact:              []byte(`{"phone":["+2348000000000"],"email":"desk@lagosdiagnostics.ng"}`),
+	}
+	patient := &db.User{
+		Fullname: toText("Ada Obi"),
+		Email:    toText("ada@example.com"),
+	}
+	appointments := []*db.Appointment{
+		{ID: "apt-1", AppointmentDate: paid, TimeSlot: "09:00"},
+		{ID: "apt-2", AppointmentDate: paid, TimeSlot: "11:00", PaymentAmount: toNumeric(6000)},
+	}
+	lineItems := map[string][]*db.AppointmentLineItem{
+		"apt-1": {
+			{TestType: "BLOOD_TEST", Price: toNumeric(10000)},
+			{TestType: "URINE_TEST", Price: toNumeric(5000)},
+		},
+	}
+	refunds := []*db.PaymentRefund{
+		{Amount: toNumeric(5000), Status: db.PaymentRefundStatusProcessed, Reason: "Cancelled", ProcessedAt: paid},
+		{Amount: toNumeric(1000), Status: db.PaymentRefundStatusFailed},
+	}
+
+	receipt, err := buildReceipt(payment, invoice, centre, patient, appointments, lineItems, nil, refunds)
+	if err != nil {
+		t.Fatalf("buildReceipt() error = %v", err)
+	}
+
+	wantItems := []documents.ReceiptLine{
+		{Description: "Blood Test", Detail: "Appointment on 4 March 2026, 09:00", Amount: 10000},
+		{Description: "Urine Test", Amount: 5000},
+		{Description: "Diagnostic appointment", Detail: "Appointment on 4 March 2026, 11:00", Amount: 6000},
+		{Description: "Booking fee", Amount: 500},
+	}
+	if len(receipt.Items) != len(wantItems) {
+		t.Fatalf("buildReceipt() items = %+v; want %+v", receipt.Items, wantItems)
+	}
+	for i, want := range wantItems {
+		if receipt.Items[i] != want {
+			t.Errorf("item %d = %+v; want %+v", i, receipt.Items[i], want)
+		}
+	}
+	if receipt.TaxAmount != 1500 {
+		t.Errorf("TaxAmount = %v; want 1500", receipt.TaxAmount)
+	}
+	if len(receipt.Adjustments) != 1 || receipt.Adjustments[0].Amount != -5000 || receipt.NetPaid != 16500 {
+		t.Errorf("Adjustments = %+v, NetPaid = %v; want one -5000 refund and 16500", receipt.Adjustments, receipt.NetPaid)
+	}
+	if receipt.PaymentMethod != "card, NGN 1,500.00 from wallet" {
+		t.Errorf("PaymentMethod = %q", receipt.PaymentMethod)
+	}
+	wantCentre := []string{"12 Marina Road, Lagos, Lagos, Nigeria", "+2348000000000", "desk@lagosdiagnostics.ng"}
+	if len(receipt.Centre.Lines) != len(wantCentre) {
+		t.Fatalf("Centre.Lines = %q; want %q", receipt.Centre.Lines, wantCentre)
+	}
+	for i, want := range wantCentre {
+		if receipt.Centre.Lines[i] != want {
+			t.Errorf("Centre.Lines[%d] = %q; want %q", i, receipt.Centre.Lines[i], want)
+		}
+	}
+}
+
+func TestBuildReceiptDiscount(t *testing.T) {
+	payment := &db.Payment{Amount: toNumeric(9000), Currency: "NGN", PaymentMethod: db.PaymentMethodCash, ReceiptNumber: toText("R-12")}
+	invoice := &db.PaymentInvoice{TaxRate: toNumeric(0)}
+	appointments := []*db.Appointment{{ID: "apt-1"}}
+	lineItems := map[string][]*db.AppointmentLineItem{"apt-1": {{TestType: "XRAY", Price: toNumeric(10000)}}}
+
+	receipt, err := buildReceipt(payment, invoice, &db.Get_Diagnostic_CentreRow{}, &db.User{}, appointments, lineItems, nil, nil)
+	if err != nil {
+		t.Fatalf("buildReceipt() error = %v", err)
+	}
+	last := receipt.Items[len(receipt.Items)-1]
+	if last.Description != "Discount" || last.Amount != -1000 {
+		t.Errorf("last item = %+v; want a -1000 discount", last)
+	}
+	if receipt.TaxAmount != 0 || receipt.NetPaid != 9000 || receipt.PaymentReference != "R-12" {
+		t.Errorf("receipt = %+v", receipt)
+	}
+}
+
+func TestBuildReceiptPromoCode(t *testing.T) {
+	payment := &db.Payment{Amount: toNumeric(8100), Currency: "NGN", PaymentMethod: db.PaymentMethodCard}
+	invoice := &db.PaymentInvoice{TaxRate: toNumeric(0)}
+	appointments := []*db.Appointment{{ID: "apt-1"}}
+	appointments[0].AppointmentDate = toTimestamptz(time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC))
+	appointments[0].TimeSlot = "09:00"
+	lineItems := map[string][]*db.AppointmentLineItem{"apt-1": {{TestType: "LIPID_PROFILE", Price: toNumeric(10000)}}}
+	redemption := &db.PromoRedemption{Code: "OCTLIPID20", DiscountAmount: toNumeric(2000)}
+	deductions, err := receiptDeductions(redemption, nil, nil)
+	if err != nil {
+		t.Fatalf("receiptDeductions() error = %v", err)
+	}
+
+	receipt, err := buildReceipt(payment, invoice, &db.Get_Diagnostic_CentreRow{}, &db.User{}, appointments, lineItems, deductions, nil)
+	if err != nil {
+		t.Fatalf("buildReceipt() error = %v", err)
+	}
+	want := []documents.ReceiptLine{
+		{Description: "Lipid Profile", Detail: "Appointment on 20 October 2026, 09:00", Amount: 10000},
+		{Description: "Discount (OCTLIPID20)", Amount: -2000},
+		{Description: "Booking fee", Amount: 100},
+	}
+	if len(receipt.Items) != len(want) {
+		t.Fatalf("buildReceipt() items = %+v; want %+v", receipt.Items, want)
+	}
+	for i := range want {
+		if receipt.Items[i] != want[i] {
+			t.Errorf("item %d = %+v; want %+v", i, receipt.Items[i], want[i])
+		}
+	}
+}
+
+func TestBuildReceiptInsuranceCoverage(t *testing.T) {
+	payment := &db.Payment{Amount: toNumeric(2900), Currency: "NGN", PaymentMethod: db.PaymentMethodCard}
+	invoice := &db.PaymentInvoice{TaxRate: toNumeric(0)}
+	appointments := []*db.Appointment{{ID: "apt-1", TimeSlot: "09:00"}}
+	appointments[0].AppointmentDate = toTimestamptz(time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC))
+	lineItems := map[string][]*db.AppointmentLineItem{"apt-1": {{TestType: "LIPID_PROFILE", Price: toNumeric(10000)}}}
+	coverages := []*db.AppointmentCoverage{{InsurerID: "ins-1", MemberNumber: "HYG/12345/A", InsurerAmount: toNumeric(7200)}}
+
+	deductions, err := receiptDeductions(&db.PromoRedemption{Code: "GONE", ReleasedAt: toTimestamptz(time.Now())}, coverages,
+		map[string]string{"ins-1": "Hygeia HMO"})
+	if err != nil {
+		t.Fatalf("receiptDeductions() error = %v", err)
+	}
+	receipt, err := buildReceipt(payment, invoice, &db.Get_Diagnostic_CentreRow{}, &db.User{}, appointments, lineItems, deductions, nil)
+	if err != nil {
+		t.Fatalf("buildReceipt() error = %v", err)
+	}
+	want := []documents.ReceiptLine{
+		{Description: "Lipid Profile", Detail: "Appointment on 20 October 2026, 09:00", Amount: 10000},
+		{Description: "Covered by Hygeia HMO", Detail: "Member HYG/12345/A", Amount: -7200},
+		{Description: "Booking fee", Amount: 100},
+	}
+	if len(receipt.Items) != len(want) {
+		t.Fatalf("buildReceipt() items = %+v; want %+v", receipt.Items, want)
+	}
+	for i := range want {
+		if receipt.Items[i] != want[i] {
+			t.Errorf("item %d = %+v; want %+v", i, receipt.Items[i], want[i])
+		}
+	}
+}
+
+func TestInvoiceNumber(t *testing.T) {
+	if got, want := invoiceNumber("5f0c6d1e-8f7a-4c3b-9a2d-1e4f5a6b7c8d", 42), "INV-5F0C6D1E-000042"; got != want {
+		t.Errorf("invoiceNumber() = %q; want %q", got, want)
+	}
+	if got, want := receiptFilename(&db.PaymentInvoice{InvoiceNumber: "INV-5F0C6D1E-000042"}), "inv-5f0c6d1e-000042.pdf"; got != want {
+		t.Errorf("receiptFilename() = %q; want %q", got, want)
+	}
+}
+
+// unreceiptedPayments serves the payments missing a receipt and records which are fetched again
+type unreceiptedPayments struct {
+	ports.PaymentRepository
+	window  db.List_Unreceipted_PaymentsParams
+	payment []*db.Payment
+	fetched []string
+}
+
+func (r *unreceiptedPayments) ListUnreceiptedPayments(ctx context.Context, arg db.List_Unreceipted_PaymentsParams) ([]*db.Payment, error) {
+	r.window = arg
+	return r.payment, nil
+}
+
+func (r *unreceiptedPayments) GetPayment(ctx context.Context, id string) (*db.Payment, error) {
+	r.fetched = append(r.fetched, id)
+	// Refunded since, so no receipt is sent
+	return &db.Payment{ID: id, PaymentStatus: db.PaymentStatusRefunded}, nil
+}
+
+func TestQueueMissedReceipts(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	payments := &unreceiptedPayments{payment: []*db.Payment{{ID: "pay-1"}, {ID: "pay-2"}}}
+	service := &ServicesHandler{paymentPort: payments}
+
+	now := time.Now().UTC()
+	service.queueMissedReceipts()
+
+	if got := now.Sub(payments.window.UpdatedAt_2.Time); got < receiptSweepDelay-time.Second || got > receiptSweepDelay+time.Second {
+		t.Errorf("sweep stops %v back; want %v", got, receiptSweepDelay)
+	}
+	if got := now.Sub(payments.window.UpdatedAt.Time); got < receiptSweepWindow-time.Second || got > receiptSweepWindow+time.Second {
+		t.Errorf("sweep starts %v back; want %v", got, receiptSweepWindow)
+	}
+	if len(payments.fetched) != 2 || payments.fetched[0] != "pay-1" || payments.fetched[1] != "pay-2" {
+		t.Errorf("receipts attempted for %v; want [pay-1 pay-2]", payments.fetched)
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// maxBundleTests caps the number of tests booked under one appointment
+const maxBundleTests = 10
+
+var (
+	ErrDuplicateBundleTest      = errors.New("a test can only be booked once per appointment")
+	ErrTooManyBundleTests       = fmt.Errorf("an appointment can bundle at most %d tests", maxBundleTests)
+	ErrIllegalResultTransition  = errors.New("line item result cannot make this transition from its current status")
+	ErrLineItemNotInAppointment = errors.New("line item does not belong to this appointment")
+)
+
+// lineItemResultTransitions lists the result statuses a line item may move to from each status:
+//
+//	pending -> processing -> ready
+//	pending -> ready
+var lineItemResultTransitions = map[db.LineItemResultStatus][]db.LineItemResultStatus{
+	db.LineItemResultStatusPending:    {db.LineItemResultStatusProcessing, db.LineItemResultStatusReady},
+	db.LineItemResultStatusProcessing: {db.LineItemResultStatusReady},
+}
+
+// ListAppointmentLineItems lists the tests booked under one of the patient's appointments
+func (service *ServicesHandler) ListAppointmentLineItems(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.GetAppointmentDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	appointment, err := service.appointmentPort.GetAppointment(ctx, dto.AppointmentID)
+	if err != nil || appointment.PatientID != currentUser.UserID.String() {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("appointment not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	items, err := service.appointmentLineItems(ctx, appointment.ID)
+	if err != nil {
+		utils.Error("Failed to list appointment line items",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, items, context)
+}
+
+// ListCentreAppointmentLineItems lists the tests booked under an appointment at a centre
+func (service *ServicesHandler) ListCentreAppointmentLineItems(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.AppointmentTransitionDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	appointment, err := service.appointmentPort.GetAppointment(ctx, dto.AppointmentID)
+	if err != nil || appointment.DiagnosticCentreID != dto.DiagnosticCentreID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("appointment not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	items, err := service.appointmentLineItems(ctx, appointment.ID)
+	if err != nil {
+		utils.Error("Failed to list appointment line items",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, items, context)
+}
+
+// UpdateLineItemResultStatus records the progress of a single test's result
+func (service *ServicesHandler) UpdateLineItemResultStatus(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.UpdateLineItemResultDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	appointment, err := service.appointmentPort.GetAppointment(ctx, dto.AppointmentID)
+	if err != nil || appointment.DiagnosticCentreID != dto.DiagnosticCentreID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("appointment not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	item, err := service.lineItemPort.GetAppointmentLineItem(ctx, dto.LineItemID)
+	if err != nil || item.AppointmentID != appointment.ID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("line item not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	updated, err := service.advanceLineItemResult(ctx, item, db.LineItemResultStatus(dto.ResultStatus))
+	if err != nil {
+		if errors.Is(err, ErrIllegalResultTransition) {
+			return utils.ErrorResponse(http.StatusConflict, err, context)
+		}
+		utils.Error("Failed to update line item result status",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "line_item_id", Value: item.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, updated, context)
+}
+
+// advanceLineItemResult moves a line item's result on, failing with ErrIllegalResultTransition
+// when the move is not allowed or another request changed the status since it was read
+func (service *ServicesHandler) advanceLineItemResult(
+	ctx context.Context,
+	item *db.AppointmentLineItem,
+	next db.LineItemResultStatus,
+) (*db.AppointmentLineItem, error) {
+	if err := validateResultTransition(item.ResultStatus, next); err != nil {
+		return nil, err
+	}
+	updated, err := service.lineItemPort.UpdateLineItemResultStatus(ctx, db.Update_Line_Item_Result_StatusParams{
+		ID:             item.ID,
+		ResultStatus:   next,
+		ResultStatus_2: item.ResultStatus,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIllegalResultTransition
+	}
+	return updated, err
+}
+
+// attachRecordToLineItem links an uploaded medical record to the test it reports on and
+// marks the test's result as ready
+func (service *ServicesHandler) attachRecordToLineItem(ctx context.Context, item *db.AppointmentLineItem, recordID string) error {
+	if err := service.lineItemPort.AttachLineItemRecord(ctx, db.Attach_Line_Item_RecordParams{
+		LineItemID:      item.ID,
+		MedicalRecordID: recordID,
+	}); err != nil {
+		return err
+	}
+	if item.ResultStatus == db.LineItemResultStatusReady {
+		return nil
+	}
+	_, err := service.advanceLineItemResult(ctx, item, db.LineItemResultStatusReady)
+	return err
+}
+
+// appointmentLineItems returns an appointment's line items with the records attached to each
+func (service *ServicesHandler) appointmentLineItems(ctx context.Context, appointmentID string) ([]*domain.AppointmentLineItem, error) {
+	items, err := service.lineItemPort.ListAppointmentLineItems(ctx, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := service.lineItemPort.ListAppointmentLineItemRecords(ctx, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordIDs := make(map[string][]string, len(items))
+	for _, record := range records {
+		recordIDs[record.LineItemID] = append(recordIDs[record.LineItemID], record.MedicalRecordID)
+	}
+	result := make([]*domain.AppointmentLineItem, 0, len(items))
+	for _, item := range items {
+		ids := recordIDs[item.ID]
+		if ids == nil {
+			ids = []string{}
+		}
+		result = append(result, &domain.AppointmentLineItem{AppointmentLineItem: item, RecordIDs: ids})
+	}
+	return result, nil
+}
+
+// createLineItems records each quoted test as a line item of the appointment within tx
+func createLineItems(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	appointmentID string,
+	quote *domain.AppointmentQuote,
+) error {
+	for _, item := range quote.Items {
+		if _, err := tx.CreateAppointmentLineItem(ctx, db.Create_Appointment_Line_ItemParams{
+			AppointmentID: appointmentID,
+			TestType:      item.TestType,
+			TestPriceID:   toUUID(item.TestPriceID),
+			Price:         toNumeric(item.Price),
+			Currency:      quote.Currency,
+		}); err != nil {
+			return fmt.Errorf("failed to create line item %s: %w", item.TestType, err)
+		}
+	}
+	return nil
+}
+
+// bundleTestTypes normalises the tests booked under one appointment, the primary test first
+func bundleTestTypes(primary string, others []string) ([]string, error) {
+	normalise := func(testType string) string {
+		return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(testType), " ", "_"))
+	}
+
+	testTypes := make([]string, 0, len(others)+1)
+	seen := make(map[string]bool, len(others)+1)
+	for _, testType := range append([]string{primary}, others...) {
+		testType = normalise(testType)
+		if seen[testType] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateBundleTest, testType)
+		}
+		seen[testType] = true
+		testTypes = append(testTypes, testType)
+	}
+	if len(testTypes) > maxBundleTests {
+		return nil, ErrTooManyBundleTests
+	}
+	return testTypes, nil
+}
+
+// validateResultTransition checks a line item result move against lineItemResultTransitions
+func validateResultTransition(from, to db.LineItemResultStatus) error {
+	for _, allowed := range lineItemResultTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w (%s -> %s)", ErrIllegalResultTransition, from, to)
+}
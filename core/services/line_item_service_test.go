@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+func TestBundleTestTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary string
+		others  []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "single test",
+			primary: "blood test",
+			want:    []string{"BLOOD_TEST"},
+		},
+		{
+			name:    "primary first and normalised",
+			primary: "BLOOD_TEST",
+			others:  []string{" x-ray ", "urine_test"},
+			want:    []string{"BLOOD_TEST", "X-RAY", "URINE_TEST"},
+		},
+		{
+			name:    "duplicate after normalising",
+			primary: "BLOOD_TEST",
+			others:  []string{"blood test"},
+			wantErr: ErrDuplicateBundleTest,
+		},
+		{
+			name:    "too many tests",
+			primary: "T0",
+			others:  []string{"T1", "T2", "T3", "T4", "T5", "T6", "T7", "T8", "T9", "T10"},
+			wantErr: ErrTooManyBundleTests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bundleTestTypes(tt.primary, tt.others)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v; want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateResultTransition(t *testing.T) {
+	tests := []struct {
+		from, to db.LineItemResultStatus
+		allowed  bool
+	}{
+		{db.LineItemResultStatusPending, db.LineItemResultStatusProcessing, true},
+		{db.LineItemResultStatusPending, db.LineItemResultStatusReady, true},
+		{db.LineItemResultStatusProcessing, db.LineItemResultStatusReady, true},
+		{db.LineItemResultStatusProcessing, db.LineItemResultStatusPending, false},
+		{db.LineItemResultStatusReady, db.LineItemResultStatusProcessing, false},
+		{db.LineItemResultStatusReady, db.LineItemResultStatusReady, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"_to_"+string(tt.to), func(t *testing.T) {
+			err := validateResultTransition(tt.from, tt.to)
+			if tt.allowed && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrIllegalResultTransition) {
+				t.Fatalf("error = %v; want %v", err, ErrIllegalResultTransition)
+			}
+		})
+	}
+}
@@ -3,8 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/core/domain"
 	"github.com/diagnoxix/core/utils"
 	"github.com/google/uuid"
@@ -12,81 +12,65 @@ import (
 
 // Enhanced notification methods for WebSocket integration
 
-// CreateAndSendNotification creates a notification in the database and sends it via WebSocket
+// CreateAndSendNotification sends a notification through the dispatcher, on the channels the
+// user chose for its type
 func (srv *ServicesHandler) CreateAndSendNotification(ctx context.Context, userID uuid.UUID, notificationType domain.NotificationType, title, message string, metadata map[string]interface{}) error {
-	// Create notification in database
-	notification := &domain.Notification{
-		ID:        uuid.New(),
-		UserID:    userID,
-		Type:      notificationType,
-		Title:     title,
-		Message:   message,
-		Read:      false,
-		CreatedAt: time.Now(),
-		Metadata:  metadata,
+	return srv.notify(ctx, userNotification{
+		UserID:   userID.String(),
+		Type:     db.NotificationType(notificationType),
+		Title:    title,
+		Message:  message,
+		Metadata: metadata,
+	})
+}
+
+// SendAppointmentNotification sends appointment-related notifications
+func (srv *ServicesHandler) SendAppointmentNotification(ctx context.Context, userID uuid.UUID, appointmentID uuid.UUID, notificationType domain.NotificationType, customMessage string) error {
+	title, message := appointmentNotificationText(notificationType)
+	if customMessage != "" {
+		message = customMessage
 	}
 
-	// Save to database (assuming you have a method for this)
-	// err := srv.notificationRepo.CreateNotification(ctx, notification)
-	// if err != nil {
-	//     return err
-	// }
-
-	// Send via WebSocket if user is connected
-	if srv.WebSocketManager.IsUserConnected(userID.String()) {
-		srv.WebSocketManager.SendNotification(userID.String(), notification)
-		utils.Info("Notification sent via WebSocket", 
-			utils.LogField{Key: "user_id", Value: userID.String()},
-			utils.LogField{Key: "type", Value: string(notificationType)})
-	} else {
-		utils.Info("User not connected to WebSocket, notification saved to database only", 
-			utils.LogField{Key: "user_id", Value: userID.String()})
+	metadata := map[string]interface{}{
+		"appointment_id": appointmentID.String(),
+		"type":           "appointment",
 	}
 
-	return nil
+	return srv.CreateAndSendNotification(ctx, userID, notificationType, title, message, metadata)
 }
 
-// SendAppointmentNotification sends appointment-related notifications
-func (srv *ServicesHandler) SendAppointmentNotification(ctx context.Context, userID uuid.UUID, appointmentID uuid.UUID, notificationType domain.NotificationType, customMessage string) error {
-	var title, message string
-	
+// appointmentNotificationText is the title and message of an appointment notification
+func appointmentNotificationText(notificationType domain.NotificationType) (string, string) {
 	switch notificationType {
 	case domain.AppointmentCreated:
-		title = "Appointment Scheduled"
-		message = "Your appointment has been successfully scheduled"
+		return "Appointment Scheduled", "Your appointment has been successfully scheduled"
 	case domain.AppointmentConfirmed:
-		title = "Appointment Confirmed"
-		message = "Your appointment has been confirmed"
+		return "Appointment Confirmed", "Your appointment has been confirmed"
 	case domain.AppointmentRescheduled:
-		title = "Appointment Rescheduled"
-		message = "Your appointment has been rescheduled"
+		return "Appointment Rescheduled", "Your appointment has been rescheduled"
 	case domain.AppointmentCancelled:
-		title = "Appointment Cancelled"
-		message = "Your appointment has been cancelled"
+		return "Appointment Cancelled", "Your appointment has been cancelled"
 	case domain.AppointmentReminder:
-		title = "Appointment Reminder"
-		message = "You have an upcoming appointment"
+		return "Appointment Reminder", "You have an upcoming appointment"
+	case domain.AppointmentDisrupted:
+		return "Appointment Affected By Closure", "The diagnostic centre has changed its hours on the day of your appointment"
+	case domain.AppointmentCheckedIn:
+		return "Checked In", "You have been checked in for your appointment"
+	case domain.AppointmentStarted:
+		return "Appointment Started", "Your appointment is now in progress"
+	case domain.AppointmentCompleted:
+		return "Appointment Completed", "Your appointment has been completed"
+	case domain.AppointmentNoShow:
+		return "Missed Appointment", "Your appointment was marked as missed"
 	default:
-		title = "Appointment Update"
-		message = "Your appointment status has been updated"
+		return "Appointment Update", "Your appointment status has been updated"
 	}
-
-	if customMessage != "" {
-		message = customMessage
-	}
-
-	metadata := map[string]interface{}{
-		"appointment_id": appointmentID.String(),
-		"type":          "appointment",
-	}
-
-	return srv.CreateAndSendNotification(ctx, userID, notificationType, title, message, metadata)
 }
 
 // SendLabResultNotification sends lab result notifications
 func (srv *ServicesHandler) SendLabResultNotification(ctx context.Context, userID uuid.UUID, testName string, isAbnormal bool) error {
 	var title, message string
-	var notificationType domain.NotificationType = "LAB_RESULT_READY"
+	notificationType := domain.LabResultReady
 
 	if isAbnormal {
 		title = "Lab Results Available - Review Required"
@@ -116,7 +100,7 @@ func (srv *ServicesHandler) SendAIAnalysisNotification(ctx context.Context, user
 		"type":         "ai_analysis",
 	}
 
-	notificationType := domain.NotificationType("AI_ANALYSIS_COMPLETE")
+	notificationType := domain.AIAnalysisComplete
 	return srv.CreateAndSendNotification(ctx, userID, notificationType, title, message, metadata)
 }
 
@@ -126,20 +110,15 @@ func (srv *ServicesHandler) SendSystemNotification(ctx context.Context, userID u
 		"type": "system",
 	}
 
-	notificationType := domain.NotificationType("SYSTEM_NOTIFICATION")
+	notificationType := domain.SystemNotification
 	return srv.CreateAndSendNotification(ctx, userID, notificationType, title, message, metadata)
 }
 
-// BroadcastToAllUsers sends a notification to all connected users
+// BroadcastToAllUsers sends a notification to all connected users, on every instance
 func (srv *ServicesHandler) BroadcastToAllUsers(title, message string, data map[string]interface{}) {
-	// This would require getting all user IDs from your database
-	// For now, we'll just log that a broadcast was requested
 	utils.Info("Broadcast notification requested", 
 		utils.LogField{Key: "title", Value: title},
 		utils.LogField{Key: "connected_clients", Value: srv.WebSocketManager.GetConnectedClients()})
-	
-	// In a real implementation, you would:
-	// 1. Get all active user IDs from your database
-	// 2. Loop through them and send notifications
-	// 3. Only send via WebSocket to connected users
+
+	srv.WebSocketManager.Broadcast(string(domain.SystemNotification), title, message, data)
 }
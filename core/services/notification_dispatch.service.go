@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The channels a notification can go out on, as recorded with its delivery
+const (
+	channelEmail     = "email"
+	channelSMS       = "sms"
+	channelInApp     = "in_app"
+	channelWebSocket = "websocket"
+)
+
+// userNotification is a notification to a user with what it needs on every channel
+type userNotification struct {
+	UserID   string
+	Type     db.NotificationType
+	Title    string
+	Message  string
+	Metadata map[string]interface{}
+	// Email is what the notification sends by email. A notification without one has no
+	// email channel.
+	Email *outboxEmail
+}
+
+// notificationStore is met by the notification repository and by transactions, so the in-app
+// notification and the email are stored with the change they report
+type notificationStore interface {
+	emailEnqueuer
+	CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (*db.Notification, error)
+}
+
+// notificationRoute is the channels a notification goes out on
+type notificationRoute struct {
+	Email     bool
+	SMS       bool
+	InApp     bool
+	WebSocket bool
+}
+
+// defaultNotificationRoute applies to the types a user has not chosen channels for. SMS is
+// paid for by the message, so it is opt-in.
+var defaultNotificationRoute = notificationRoute{Email: true, InApp: true, WebSocket: true}
+
+// pendingNotification is a dispatched notification whose live channels, SMS and WebSocket,
+// are pushed once the change it reports is committed
+type pendingNotification struct {
+	service      *ServicesHandler
+	notification userNotification
+	route        notificationRoute
+	stored       *db.Notification
+	delivered    []string
+	skipped      []string
+	failed       []string
+}
+
+// notify dispatches a notification that is not part of a transaction and pushes it straight away
+func (service *ServicesHandler) notify(ctx context.Context, notification userNotification) error {
+	pending, err := service.dispatchNotification(ctx, service.notificationRepo, notification)
+	if err != nil {
+		return err
+	}
+	pending.push(ctx)
+	return nil
+}
+
+// dispatchNotification resolves the channels of a notification from the user's preferences and
+// quiet hours, and stores the in-app notification and the email on store. The returned
+// notification is pushed once store is committed.
+func (service *ServicesHandler) dispatchNotification(
+	ctx context.Context,
+	store notificationStore,
+	notification userNotification,
+) (*pendingNotification, error) {
+	preference, err := service.notificationRepo.GetNotificationPreference(ctx, db.Get_Notification_PreferenceParams{
+		UserID: notification.UserID,
+		Type:   notification.Type,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		preference, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	quiet, err := service.notificationRepo.GetNotificationQuietHours(ctx, notification.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		quiet, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	route, skipped := routeNotification(preference, quiet, notification.Email != nil, time.Now())
+	pending := &pendingNotification{
+		service:      service,
+		notification: notification,
+		route:        route,
+		skipped:      skipped,
+	}
+
+	if route.InApp {
+		metadata, err := utils.MarshalJSONField(notification.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		pending.stored, err = store.CreateNotification(ctx, db.CreateNotificationParams{
+			UserID:   notification.UserID,
+			Type:     notification.Type,
+			Title:    notification.Title,
+			Message:  notification.Message,
+			Metadata: metadata,
+		})
+		if err != nil {
+			return nil, err
+		}
+		pending.delivered = append(pending.delivered, channelInApp)
+	}
+
+	if route.Email {
+		if notification.Email.To == "" {
+			// Accounts registered by phone have no email address
+			pending.skipped = append(pending.skipped, channelEmail)
+		} else {
+			if err := service.queueEmail(ctx, store, *notification.Email); err != nil {
+				return nil, err
+			}
+			pending.delivered = append(pending.delivered, channelEmail)
+		}
+	}
+	return pending, nil
+}
+
+// push sends the live channels of a notification and records where it went
+func (pending *pendingNotification) push(ctx context.Context) {
+	service := pending.service
+	notification := pending.notification
+
+	if pending.route.WebSocket {
+		if service.WebSocketManager.IsUserConnected(notification.UserID) {
+			service.WebSocketManager.SendNotification(notification.UserID, pending.domainNotification())
+			pending.delivered = append(pending.delivered, channelWebSocket)
+		} else {
+			pending.skipped = append(pending.skipped, channelWebSocket)
+		}
+	}
+
+	if pending.route.SMS {
+		user, err := service.userPort.GetUser(ctx, notification.UserID)
+		switch {
+		case err != nil:
+			utils.Error("Failed to get user for notification SMS",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "user_id", Value: notification.UserID})
+			pending.failed = append(pending.failed, channelSMS)
+		case !user.PhoneVerifiedAt.Valid:
+			// Only a verified number is known to reach the user
+			pending.skipped = append(pending.skipped, channelSMS)
+		default:
+			if err := service.notificationPort.SendSMS(user.PhoneNumber.String, notification.Title+": "+notification.Message); err != nil {
+				utils.Error("Failed to send notification SMS",
+					utils.LogField{Key: "error", Value: err.Error()},
+					utils.LogField{Key: "user_id", Value: notification.UserID})
+				pending.failed = append(pending.failed, channelSMS)
+			} else {
+				pending.delivered = append(pending.delivered, channelSMS)
+			}
+		}
+	}
+
+	var notificationID pgtype.UUID
+	if pending.stored != nil {
+		notificationID = toUUID(pending.stored.ID)
+	}
+	if _, err := service.notificationRepo.RecordNotificationDelivery(ctx, db.Record_Notification_DeliveryParams{
+		UserID:         notification.UserID,
+		Type:           notification.Type,
+		NotificationID: notificationID,
+		Delivered:      nonNilChannels(pending.delivered),
+		Skipped:        nonNilChannels(pending.skipped),
+		Failed:         nonNilChannels(pending.failed),
+	}); err != nil {
+		utils.Error("Failed to record notification delivery",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: notification.UserID},
+			utils.LogField{Key: "type", Value: notification.Type})
+	}
+}
+
+// pushNotifications pushes notifications dispatched on a transaction once it is committed
+func pushNotifications(ctx context.Context, notifications []*pendingNotification) {
+	for _, notification := range notifications {
+		notification.push(ctx)
+	}
+}
+
+// domainNotification is the notification as pushed over WebSocket, under the ID of the in-app
+// notification when there is one
+func (pending *pendingNotification) domainNotification() *domain.Notification {
+	notification := &domain.Notification{
+		ID:        uuid.New(),
+		Type:      domain.NotificationType(pending.notification.Type),
+		Title:     pending.notification.Title,
+		Message:   pending.notification.Message,
+		CreatedAt: time.Now(),
+		Metadata:  pending.notification.Metadata,
+	}
+	notification.UserID, _ = uuid.Parse(pending.notification.UserID)
+	if pending.stored != nil {
+		if id, err := uuid.Parse(pending.stored.ID); err == nil {
+			notification.ID = id
+		}
+		notification.CreatedAt = pending.stored.CreatedAt.Time
+	}
+	return notification
+}
+
+// routeNotification resolves the channels a notification goes out on from the user's preference
+// for its type, the defaults when there is none, and their quiet hours. It also returns the
+// channels held back. Email is only a channel of notifications that have one.
+func routeNotification(
+	preference *db.NotificationPreference,
+	quiet *db.NotificationQuietHour,
+	hasEmail bool,
+	now time.Time,
+) (notificationRoute, []string) {
+	route := defaultNotificationRoute
+	if preference != nil {
+		route = notificationRoute{
+			Email:     preference.Email,
+			SMS:       preference.Sms,
+			InApp:     preference.InApp,
+			WebSocket: preference.Websocket,
+		}
+	}
+	if !hasEmail {
+		route.Email = false
+	}
+
+	var skipped []string
+	if hasEmail && !route.Email {
+		skipped = append(skipped, channelEmail)
+	}
+	if !route.InApp {
+		skipped = append(skipped, channelInApp)
+	}
+	// Quiet hours hold back the channels that interrupt; the in-app notification and the
+	// email wait to be read
+	interrupt := !inQuietHours(quiet, now)
+	route.SMS = route.SMS && interrupt
+	route.WebSocket = route.WebSocket && interrupt
+	if !route.SMS {
+		skipped = append(skipped, channelSMS)
+	}
+	if !route.WebSocket {
+		skipped = append(skipped, channelWebSocket)
+	}
+	return route, skipped
+}
+
+// inQuietHours reports whether now falls in the quiet hours, read in their time zone. A window
+// that ends before it starts runs past midnight.
+func inQuietHours(quiet *db.NotificationQuietHour, now time.Time) bool {
+	if quiet == nil {
+		return false
+	}
+	location, err := time.LoadLocation(quiet.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+	clock := int64(local.Hour()*3600+local.Minute()*60+local.Second()) * 1000000
+	start, end := quiet.StartsAt.Microseconds, quiet.EndsAt.Microseconds
+	if start < end {
+		return clock >= start && clock < end
+	}
+	return clock >= start || clock < end
+}
+
+// nonNilChannels stores an empty list rather than NULL
+func nonNilChannels(channels []string) []string {
+	if channels == nil {
+		return []string{}
+	}
+	return channels
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+func quietHoursFixture(t *testing.T, start, end, timezone string) *db.NotificationQuietHour {
+	t.Helper()
+	startsAt, err := parseClockTime(start)
+	if err != nil {
+		t.Fatalf("parseClockTime(%q): %v", start, err)
+	}
+	endsAt, err := parseClockTime(end)
+	if err != nil {
+		t.Fatalf("parseClockTime(%q): %v", end, err)
+	}
+	return &db.NotificationQuietHour{StartsAt: startsAt, EndsAt: endsAt, Timezone: timezone}
+}
+
+func TestRouteNotification(t *testing.T) {
+	noon := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		preference  *db.NotificationPreference
+		quiet       *db.NotificationQuietHour
+		hasEmail    bool
+		wantRoute   notificationRoute
+		wantSkipped []string
+	}{
+		{
+			name:        "defaults",
+			hasEmail:    true,
+			wantRoute:   notificationRoute{Email: true, InApp: true, WebSocket: true},
+			wantSkipped: []string{channelSMS},
+		},
+		{
+			name:        "preference",
+			preference:  &db.NotificationPreference{Email: false, Sms: true, InApp: true, Websocket: false},
+			hasEmail:    true,
+			wantRoute:   notificationRoute{SMS: true, InApp: true},
+			wantSkipped: []string{channelEmail, channelWebSocket},
+		},
+		{
+			name:        "no email",
+			hasEmail:    false,
+			wantRoute:   notificationRoute{InApp: true, WebSocket: true},
+			wantSkipped: []string{channelSMS},
+		},
+		{
+			name:        "quiet hours",
+			preference:  &db.NotificationPreference{Email: true, Sms: true, InApp: true, Websocket: true},
+			quiet:       quietHoursFixture(t, "11:00", "13:00", "UTC"),
+			hasEmail:    true,
+			wantRoute:   notificationRoute{Email: true, InApp: true},
+			wantSkipped: []string{channelSMS, channelWebSocket},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, skipped := routeNotification(tt.preference, tt.quiet, tt.hasEmail, noon)
+			if route != tt.wantRoute {
+				t.Errorf("route = %+v; want %+v", route, tt.wantRoute)
+			}
+			if !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("skipped = %v; want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		quiet *db.NotificationQuietHour
+		now   time.Time
+		want  bool
+	}{
+		{"none", nil, time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC), false},
+		{"same day inside", quietHoursFixture(t, "13:00", "15:00", "UTC"), time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC), true},
+		{"same day end", quietHoursFixture(t, "13:00", "15:00", "UTC"), time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC), false},
+		{"overnight before midnight", quietHoursFixture(t, "22:00", "07:00", "UTC"), time.Date(2025, 6, 2, 23, 30, 0, 0, time.UTC), true},
+		{"overnight after midnight", quietHoursFixture(t, "22:00", "07:00", "UTC"), time.Date(2025, 6, 2, 6, 59, 0, 0, time.UTC), true},
+		{"overnight daytime", quietHoursFixture(t, "22:00", "07:00", "UTC"), time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC), false},
+		// 21:30 UTC is 22:30 in Lagos
+		{"time zone", quietHoursFixture(t, "22:00", "07:00", "Africa/Lagos"), time.Date(2025, 6, 2, 21, 30, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inQuietHours(tt.quiet, tt.now); got != tt.want {
+				t.Errorf("inQuietHours() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultQuietHoursTimezone is used when quiet hours are set without a time zone
+const defaultQuietHoursTimezone = "Africa/Lagos"
+
+var (
+	ErrUnknownNotificationType = errors.New("unknown notification type")
+	ErrInvalidQuietHours       = errors.New("quiet hours must be HH:MM times that differ")
+)
+
+var notificationUsers = []db.UserEnum{
+	db.UserEnumPATIENT,
+	db.UserEnumDIAGNOSTICCENTREMANAGER,
+	db.UserEnumDIAGNOSTICCENTREOWNER,
+}
+
+// GetNotificationPreferences returns the channels of every notification type for the signed in
+// user, with the defaults for the types they have not set, and their quiet hours
+func (service *ServicesHandler) GetNotificationPreferences(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, notificationUsers)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+	ctx := c.Request().Context()
+	userID := currentUser.UserID.String()
+
+	preferences, err := service.notificationRepo.ListNotificationPreferences(ctx, userID)
+	if err != nil {
+		utils.Error("Failed to list notification preferences",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: userID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	quiet, err := service.notificationRepo.GetNotificationQuietHours(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		quiet, err = nil, nil
+	}
+	if err != nil {
+		utils.Error("Failed to get quiet hours",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: userID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, notificationPreferences(preferences, quiet), c)
+}
+
+// UpdateNotificationPreference sets the channels one type of notification goes out on for the
+// signed in user
+func (service *ServicesHandler) UpdateNotificationPreference(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, notificationUsers)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.UpdateNotificationPreferenceDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+	notificationType := db.NotificationType(dto.Type)
+	if !notificationType.Valid() {
+		return utils.ErrorResponse(http.StatusBadRequest, ErrUnknownNotificationType, c)
+	}
+
+	preference, err := service.notificationRepo.UpsertNotificationPreference(c.Request().Context(), db.Upsert_Notification_PreferenceParams{
+		UserID:    currentUser.UserID.String(),
+		Type:      notificationType,
+		Email:     *dto.Email,
+		Sms:       *dto.SMS,
+		InApp:     *dto.InApp,
+		Websocket: *dto.WebSocket,
+	})
+	if err != nil {
+		utils.Error("Failed to update notification preference",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID},
+			utils.LogField{Key: "type", Value: dto.Type})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, notificationPreference(preference.Type, preference), c)
+}
+
+// SetQuietHours sets the daily window when nothing is sent to the signed in user by SMS or
+// pushed over WebSocket
+func (service *ServicesHandler) SetQuietHours(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, notificationUsers)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.SetQuietHoursDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+	start, startErr := parseClockTime(dto.Start)
+	end, endErr := parseClockTime(dto.End)
+	if startErr != nil || endErr != nil || start.Microseconds == end.Microseconds {
+		return utils.ErrorResponse(http.StatusBadRequest, ErrInvalidQuietHours, c)
+	}
+	timezone := dto.Timezone
+	if timezone == "" {
+		timezone = defaultQuietHoursTimezone
+	}
+
+	quiet, err := service.notificationRepo.UpsertNotificationQuietHours(c.Request().Context(), db.Upsert_Notification_Quiet_HoursParams{
+		UserID:   currentUser.UserID.String(),
+		StartsAt: start,
+		EndsAt:   end,
+		Timezone: timezone,
+	})
+	if err != nil {
+		utils.Error("Failed to set quiet hours",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, quietHours(quiet), c)
+}
+
+// ClearQuietHours removes the signed in user's quiet hours
+func (service *ServicesHandler) ClearQuietHours(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, notificationUsers)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	if err := service.notificationRepo.DeleteNotificationQuietHours(c.Request().Context(), currentUser.UserID.String()); err != nil {
+		utils.Error("Failed to clear quiet hours",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, map[string]string{
+		"message": "Quiet hours cleared",
+	}, c)
+}
+
+// notificationPreferences lists every notification type with the channels the user chose for
+// it, or the defaults
+func notificationPreferences(
+	preferences []*db.NotificationPreference,
+	quiet *db.NotificationQuietHour,
+) domain.NotificationPreferences {
+	chosen := make(map[db.NotificationType]*db.NotificationPreference, len(preferences))
+	for _, preference := range preferences {
+		chosen[preference.Type] = preference
+	}
+
+	response := domain.NotificationPreferences{}
+	for _, notificationType := range db.AllNotificationTypeValues() {
+		response.Preferences = append(response.Preferences, notificationPreference(notificationType, chosen[notificationType]))
+	}
+	if quiet != nil {
+		hours := quietHours(quiet)
+		response.QuietHours = &hours
+	}
+	return response
+}
+
+func notificationPreference(notificationType db.NotificationType, preference *db.NotificationPreference) domain.NotificationPreference {
+	if preference == nil {
+		return domain.NotificationPreference{
+			Type:      domain.NotificationType(notificationType),
+			Email:     defaultNotificationRoute.Email,
+			SMS:       defaultNotificationRoute.SMS,
+			InApp:     defaultNotificationRoute.InApp,
+			WebSocket: defaultNotificationRoute.WebSocket,
+			Default:   true,
+		}
+	}
+	return domain.NotificationPreference{
+		Type:      domain.NotificationType(notificationType),
+		Email:     preference.Email,
+		SMS:       preference.Sms,
+		InApp:     preference.InApp,
+		WebSocket: preference.Websocket,
+	}
+}
+
+func quietHours(quiet *db.NotificationQuietHour) domain.QuietHours {
+	return domain.QuietHours{
+		Start:    formatClockTime(quiet.StartsAt),
+		End:      formatClockTime(quiet.EndsAt),
+		Timezone: quiet.Timezone,
+	}
+}
+
+// formatClockTime formats a time of day as HH:MM
+func formatClockTime(clock pgtype.Time) string {
+	return time.Time{}.Add(time.Duration(clock.Microseconds) * time.Microsecond).Format("15:04")
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/diagnoxix/core/domain"
 	"github.com/diagnoxix/core/utils"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 )
 
@@ -172,7 +171,7 @@ func (s *ServicesHandler) withRetry(ctx context.Context, operation string, f fun
 func (s *ServicesHandler) validatePaymentStatus(current, new db.PaymentStatus) error {
 	// Define valid status transitions
 	validTransitions := map[db.PaymentStatus][]db.PaymentStatus{
-		db.PaymentStatusPending:   {db.PaymentStatusSuccess, db.PaymentStatusFailed},
+		db.PaymentStatusPending:   {db.PaymentStatusSuccess, db.PaymentStatusFailed, db.PaymentStatusCancelled},
 		db.PaymentStatusSuccess:   {db.PaymentStatusRefunded},
 		db.PaymentStatusFailed:    {db.PaymentStatusPending}, // Allow retry
 		db.PaymentStatusRefunded:  {},                        // Terminal state
@@ -420,90 +419,7 @@ func (s *ServicesHandler) ListPayments(ctx echo.Context) error {
 	return utils.ResponseMessage(http.StatusOK, payments, ctx)
 }
 
-// RefundPayment processes a payment refund
-func (s *ServicesHandler) RPayment(ctx echo.Context) error {
-	// Get payment to validate it
-	dto := ctx.Get(utils.ValidatedBodyDTO).(*domain.RefundPaymentDTO)
-	payment, err := s.paymentPort.GetPayment(ctx.Request().Context(), dto.PaymentID)
-	if err != nil {
-		if errors.Is(err, utils.ErrNotFound) {
-			return utils.ErrorResponse(http.StatusNotFound, err, ctx)
-		}
-		return utils.ErrorResponse(http.StatusFailedDependency, err, ctx)
-	}
-
-	// Validate payment can be refunded
-	if payment.PaymentStatus != db.PaymentStatusSuccess {
-		return utils.ErrorResponse(http.StatusBadRequest, errors.New("payment cannot be refunded"), ctx)
-	}
-	if payment.RefundAmount.Valid {
-		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid refund"), ctx)
-	}
-
-	var paymentAmount float64
-	err = payment.Amount.Scan(&paymentAmount)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusBadRequest, errors.New("failed to parse amount"), ctx)
-	}
-
-	if dto.RefundAmount > paymentAmount {
-		return utils.ErrorResponse(http.StatusBadRequest, errors.New("refund amount cannot be greater than payment amount"), ctx)
-	}
-
-	var refundAmount pgtype.Numeric
-	if err := refundAmount.Scan(dto.RefundAmount); err != nil {
-		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid refund amount format"), ctx)
-	}
-
-	refundedByUUID, err := uuid.Parse(dto.RefundedBy)
-	if err != nil {
-		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid refunded by ID"), ctx)
-	}
-
-	// Process refund
-	refundedPayment, err := s.paymentPort.RefundPayment(ctx.Request().Context(), db.Refund_PaymentParams{
-		ID:           dto.PaymentID,
-		RefundAmount: refundAmount,
-		RefundReason: pgtype.Text{String: dto.RefundReason, Valid: true},
-		RefundedBy:   pgtype.UUID{Bytes: refundedByUUID, Valid: true},
-	})
-	if err != nil {
-		return utils.ErrorResponse(http.StatusUnprocessableEntity, err, ctx)
-	}
-
-	return utils.ResponseMessage(http.StatusAccepted, refundedPayment, ctx)
-}
-
-// HandlePaymentWebhook processes payment webhooks from payment provider
-func (s *ServicesHandler) HandlePaymentWebhook(ctx echo.Context) error {
-	dto := ctx.Get(utils.ValidatedBodyDTO).(*domain.PaymentWebhookDTO)
-	var pgMetadata []byte
-	if dto.Metadata != nil {
-		metadata, err := json.Marshal(dto.Metadata)
-		if err != nil {
-			return utils.ErrorResponse(http.StatusBadRequest, err, ctx)
-		}
-		pgMetadata = metadata
-	}
-
-	// Update payment status
-	payment, err := s.paymentPort.UpdatePaymentStatus(ctx.Request().Context(), db.Update_Payment_StatusParams{
-		ID:              dto.PaymentID,
-		PaymentStatus:   db.PaymentStatus(dto.Status),
-		TransactionID:   pgtype.Text{String: dto.TransactionID, Valid: true},
-		PaymentMetadata: pgMetadata,
-	})
-	if err != nil {
-		if errors.Is(err, utils.ErrNotFound) {
-			return utils.ErrorResponse(http.StatusNotFound, err, ctx)
-		}
-		return utils.ErrorResponse(http.StatusPreconditionFailed, err, ctx)
-	}
-
-	return utils.ResponseMessage(http.StatusAccepted, payment, ctx)
-}
-
-// VerifyPayment verifies a payment using Paystack and updates the payment status
+// VerifyPayment verifies a payment with its provider and updates the payment status
 func (s *ServicesHandler) VerifyPayment(c echo.Context) error {
 	// Authentication & Authorization
 	_, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER, db.UserEnumDIAGNOSTICCENTREMANAGER})
@@ -543,67 +459,11 @@ func (s *ServicesHandler) VerifyPayment(c echo.Context) error {
 
 // verifyAndUpdatePaymentWithRetry handles payment verification and status updates with retry logic
 func (s *ServicesHandler) verifyAndUpdatePaymentWithRetry(ctx context.Context, reference string) (*db.Payment, error) {
-	var verificationResponse *domain.PaystackVerificationResponse
+	var verification *domain.TransactionVerification
 	var payment *db.Payment
 
-	// Verify payment with provider using retry
-	err := s.withRetry(ctx, "verify_payment", func() error {
-		var err error
-		paystackResponse, err := s.paymentService.VerifyTransaction(reference)
-		if err != nil {
-			return &PaymentError{
-				Code:    ErrCodePaymentVerification,
-				Message: "payment verification failed",
-				Err:     err,
-				Metadata: map[string]interface{}{
-					"reference": reference,
-				},
-				Retryable: true,
-			}
-		}
-		// Convert PaystackResponse.Data to domain.PaystackVerificationData
-		verificationResponse = &domain.PaystackVerificationResponse{
-			Status:  paystackResponse.Status,
-			Message: paystackResponse.Message,
-			Data: domain.PaystackVerificationData{
-				Status:    paystackResponse.Data.Status,
-				Reference: paystackResponse.Data.Reference,
-				Amount:    paystackResponse.Data.Amount,
-				Channel:   paystackResponse.Data.Channel,
-				Currency:  paystackResponse.Data.Currency,
-				PaidAt:    ParseTime(paystackResponse.Data.PaidAt),
-				CreatedAt: ParseTime(paystackResponse.Data.CreatedAt),
-				Customer: domain.PaystackCustomer{
-					ID:        paystackResponse.Data.Customer.ID,
-					FirstName: paystackResponse.Data.Customer.FirstName,
-					LastName:  paystackResponse.Data.Customer.LastName,
-					Email:     paystackResponse.Data.Customer.Email,
-					Phone:     paystackResponse.Data.Customer.Phone,
-				},
-				Metadata: paystackResponse.Data.Metadata,
-			},
-		}
-		if !verificationResponse.Status || verificationResponse.Data.Status != "success" {
-			return &PaymentError{
-				Code:    ErrCodePaymentVerification,
-				Message: fmt.Sprintf("payment verification unsuccessful: %s", verificationResponse.Message),
-				Err:     fmt.Errorf("invalid status: %s", verificationResponse.Data.Status),
-				Metadata: map[string]interface{}{
-					"reference":       reference,
-					"response_status": verificationResponse.Status,
-					"payment_status":  verificationResponse.Data.Status,
-				},
-				Retryable: verificationResponse.Data.Status == "pending",
-			}
-		}
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	// Get payment by reference using retry
-	err = s.withRetry(ctx, "get_payment", func() error {
+	err := s.withRetry(ctx, "get_payment", func() error {
 		var err error
 		payment, err = s.paymentPort.GetPaymentByReference(ctx, reference)
 		if err != nil {
@@ -634,19 +494,63 @@ func (s *ServicesHandler) verifyAndUpdatePaymentWithRetry(ctx context.Context, r
 		return nil, err
 	}
 
-	// Validate status transition
-	if err := s.validatePaymentStatus(payment.PaymentStatus, db.PaymentStatusSuccess); err != nil {
+	// The payment is verified with the provider it was made with
+	provider, err := s.paymentProvider(payment.PaymentProvider)
+	if err != nil {
+		return nil, &PaymentError{
+			Code:    ErrCodeSystemError,
+			Message: "payment provider is not configured",
+			Err:     err,
+			Metadata: map[string]interface{}{
+				"reference": reference,
+				"provider":  payment.PaymentProvider,
+			},
+		}
+	}
+
+	// Verify payment with provider using retry
+	err = s.withRetry(ctx, "verify_payment", func() error {
+		var err error
+		verification, err = provider.VerifyTransaction(reference)
+		if err != nil {
+			return &PaymentError{
+				Code:    ErrCodePaymentVerification,
+				Message: "payment verification failed",
+				Err:     err,
+				Metadata: map[string]interface{}{
+					"reference": reference,
+				},
+				Retryable: true,
+			}
+		}
+		if verification.Status != domain.TransactionStatusSuccess {
+			return &PaymentError{
+				Code:    ErrCodePaymentVerification,
+				Message: fmt.Sprintf("payment verification unsuccessful: %s", verification.Status),
+				Err:     fmt.Errorf("invalid status: %s", verification.Status),
+				Metadata: map[string]interface{}{
+					"reference":      reference,
+					"provider":       verification.Provider,
+					"payment_status": verification.Status,
+				},
+				Retryable: verification.Status == domain.TransactionStatusPending,
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	// Process and validate metadata
 	metadataToUpdate := map[string]interface{}{
-		"provider_reference": verificationResponse.Data.Reference,
-		"transaction_time":   verificationResponse.Data.PaidAt.Format(time.RFC3339),
-		"payment_method":     verificationResponse.Data.Channel,
-		"currency":           verificationResponse.Data.Currency,
-		"customer":           verificationResponse.Data.Customer,
-		"original_metadata":  verificationResponse.Data.Metadata,
+		"provider":           verification.Provider,
+		"provider_reference": verification.Reference,
+		"transaction_time":   verification.PaidAt.Format(time.RFC3339),
+		"payment_method":     verification.Channel,
+		"currency":           verification.Currency,
+		"customer":           verification.Customer,
+		"original_metadata":  verification.Metadata,
 	}
 
 	metadata, err := s.validateAndParseMetadata(metadataToUpdate)
@@ -662,13 +566,12 @@ func (s *ServicesHandler) verifyAndUpdatePaymentWithRetry(ctx context.Context, r
 		}
 	}
 
-	// Update payment status with validated data
-	updatedPayment, err := s.paymentPort.UpdatePaymentStatus(ctx, db.Update_Payment_StatusParams{
-		ID:              payment.ID,
-		PaymentStatus:   db.PaymentStatusSuccess,
-		TransactionID:   pgtype.Text{String: verificationResponse.Data.Reference, Valid: true},
-		PaymentMetadata: metadata,
-	})
+	// Record the charge in the payment's ledger; the status transition is validated there
+	updatedPayment, err := s.applyVerifiedCharge(ctx, payment, verification, metadata)
+	var pErr *PaymentError
+	if errors.As(err, &pErr) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, &PaymentError{
 			Code:    ErrCodeSystemError,
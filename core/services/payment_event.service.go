@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Internal payment event types; provider events keep the provider's own names
+const (
+	PaymentEventInitialized     = "payment.initialized"
+	PaymentEventChargeVerified  = "charge.verified"
+	PaymentEventRefundRequested = "refund.requested"
+	PaymentEventRefundProcessed = "refund.processed"
+	PaymentEventRefundFailed    = "refund.failed"
+	PaymentEventExpired         = "payment.expired"
+	PaymentEventWalletCharged   = "wallet.charged"
+	PaymentEventCancelled       = "payment.cancelled"
+	PaymentEventCentreReceived  = "centre.received"
+)
+
+var (
+	ErrDuplicatePaymentEvent = errors.New("payment event has already been recorded")
+	ErrPaymentStatusChanged  = errors.New("payment status changed while the event was applied")
+)
+
+// paymentEvent is one entry appended to a payment's ledger. When To is set the payment
+// row is moved to that status; otherwise the event is only recorded.
+type paymentEvent struct {
+	Source          db.PaymentEventSource
+	Type            string
+	ProviderEventID string
+	To              db.PaymentStatus
+	Amount          pgtype.Numeric
+	TransactionID   string
+	Payload         []byte
+	ActorID         string
+	RefundAmount    pgtype.Numeric
+	RefundReason    string
+	RefundedBy      string
+}
+
+// GetPaymentTimeline lists every event recorded against a payment, oldest first
+func (s *ServicesHandler) GetPaymentTimeline(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.GetPaymentDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	ctx := c.Request().Context()
+	payment, err := s.paymentPort.GetPayment(ctx, dto.PaymentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("payment not found"), c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if err := s.authorizeCentreAccess(ctx, currentUser, payment.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+
+	events, err := s.paymentPort.ListPaymentEvents(ctx, payment.ID)
+	if err != nil {
+		utils.Error("Failed to list payment events",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if events == nil {
+		events = []*db.PaymentEvent{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, domain.PaymentTimeline{
+		Payment: payment,
+		Events:  events,
+	}, c)
+}
+
+// applyPaymentEvent appends event to the payment's ledger within tx and projects it onto the
+// payment row. A provider event that was recorded before fails with ErrDuplicatePaymentEvent,
+// and a provider event observed after the payment already reached its status is recorded
+// without moving the payment. The projection only applies while the payment is still in the
+// status it was read in; otherwise ErrPaymentStatusChanged is returned and tx must be rolled back.
+func (s *ServicesHandler) applyPaymentEvent(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	payment *db.Payment,
+	event paymentEvent,
+) (*db.Payment, error) {
+	to := event.To
+	if to == payment.PaymentStatus && event.Source != db.PaymentEventSourceInternal {
+		to = ""
+	}
+	if to != "" {
+		if err := s.validatePaymentStatus(payment.PaymentStatus, to); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := tx.CreatePaymentEvent(ctx, db.Create_Payment_EventParams{
+		PaymentID:       payment.ID,
+		Source:          event.Source,
+		EventType:       event.Type,
+		PaymentProvider: payment.PaymentProvider,
+		ProviderEventID: toText(event.ProviderEventID),
+		FromStatus:      db.NullPaymentStatus{PaymentStatus: payment.PaymentStatus, Valid: true},
+		ToStatus:        db.NullPaymentStatus{PaymentStatus: to, Valid: to != ""},
+		Amount:          event.Amount,
+		Payload:         event.Payload,
+		ActorID:         toUUID(event.ActorID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicatePaymentEvent, event.ProviderEventID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record payment event: %w", err)
+	}
+	if to == "" {
+		return payment, nil
+	}
+
+	var refundReason pgtype.Text
+	var refundedBy pgtype.UUID
+	if to == db.PaymentStatusRefunded {
+		refundReason = toText(event.RefundReason)
+		refundedBy = toUUID(event.RefundedBy)
+	}
+	updated, err := tx.ProjectPaymentStatus(ctx, db.Project_Payment_StatusParams{
+		ID:              payment.ID,
+		PaymentStatus:   to,
+		PaymentStatus_2: payment.PaymentStatus,
+		TransactionID:   toText(event.TransactionID),
+		PaymentMetadata: event.Payload,
+		RefundAmount:    event.RefundAmount,
+		RefundReason:    refundReason,
+		RefundedBy:      refundedBy,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentStatusChanged
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to project payment status: %w", err)
+	}
+
+	// A payment that will never complete gives back the promo code use it took
+	if to == db.PaymentStatusFailed || to == db.PaymentStatusCancelled {
+		if err := releasePromoCode(ctx, tx, updated); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// recordPaymentEvent applies a payment event in a transaction of its own
+func (s *ServicesHandler) recordPaymentEvent(
+	ctx context.Context,
+	payment *db.Payment,
+	event paymentEvent,
+) (*db.Payment, error) {
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	updated, err := s.applyPaymentEvent(ctx, tx, payment, event)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit payment event: %w", err)
+	}
+	return updated, nil
+}
+
+// recordPaymentInitialized opens the ledger of a newly created payment within tx
+func recordPaymentInitialized(ctx context.Context, tx ports.AppointmentTx, payment *db.Payment) error {
+	_, err := tx.CreatePaymentEvent(ctx, db.Create_Payment_EventParams{
+		PaymentID:       payment.ID,
+		Source:          db.PaymentEventSourceInternal,
+		EventType:       PaymentEventInitialized,
+		PaymentProvider: payment.PaymentProvider,
+		ToStatus:        db.NullPaymentStatus{PaymentStatus: payment.PaymentStatus, Valid: true},
+		Amount:          payment.Amount,
+		Payload:         payment.ProviderMetadata,
+		ActorID:         toUUID(payment.PatientID),
+	})
+	return err
+}
+
+// applyVerifiedCharge records a charge confirmed through the provider's verification API. The
+// webhook for the same charge shares its provider event ID, so whichever arrives second, or a
+// racing verification, leaves the payment as the first one projected it. A charge for less, or
+// in another currency, than the payment leaves it as it was.
+func (s *ServicesHandler) applyVerifiedCharge(
+	ctx context.Context,
+	payment *db.Payment,
+	verification *domain.TransactionVerification,
+	metadata []byte,
+) (*db.Payment, error) {
+	if err := chargeAmountMatches(payment, verification.Amount, verification.Currency); err != nil {
+		return nil, &PaymentError{
+			Code:          ErrCodeInvalidAmount,
+			Message:       "verified charge does not match the payment",
+			Err:           err,
+			TransactionID: verification.Reference,
+			PaymentID:     payment.ID,
+		}
+	}
+
+	updated, err := s.recordPaymentEvent(ctx, payment, paymentEvent{
+		Source:          db.PaymentEventSourceVerification,
+		Type:            PaymentEventChargeVerified,
+		ProviderEventID: chargeEventID(verification.TransactionID),
+		To:              db.PaymentStatusSuccess,
+		Amount:          toNumeric(verification.Amount),
+		TransactionID:   verification.Reference,
+		Payload:         metadata,
+	})
+	if errors.Is(err, ErrDuplicatePaymentEvent) || errors.Is(err, ErrPaymentStatusChanged) {
+		current, getErr := s.paymentPort.GetPayment(ctx, payment.ID)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if current.PaymentStatus != db.PaymentStatusSuccess {
+			return nil, err
+		}
+		return current, nil
+	}
+	if err == nil {
+		go s.sendPaymentReceiptEmail(updated.ID)
+	}
+	return updated, err
+}
+
+// chargeEventID keys a provider charge in the ledger by the provider's transaction ID, which
+// its webhook and verification API both report
+func chargeEventID(transactionID string) string {
+	if transactionID == "" {
+		return ""
+	}
+	return "charge:" + transactionID
+}
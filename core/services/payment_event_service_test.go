@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/ports"
+	"github.com/jackc/pgx/v5"
+)
+
+// ledgerTx records payment events in memory and projects them onto a single payment
+type ledgerTx struct {
+	ports.AppointmentTx
+	payment  db.Payment
+	events   []db.Create_Payment_EventParams
+	released []string
+}
+
+func (tx *ledgerTx) CreatePaymentEvent(_ context.Context, arg db.Create_Payment_EventParams) (*db.PaymentEvent, error) {
+	for _, event := range tx.events {
+		if arg.ProviderEventID.Valid && event.ProviderEventID == arg.ProviderEventID {
+			return nil, pgx.ErrNoRows
+		}
+	}
+	tx.events = append(tx.events, arg)
+	return &db.PaymentEvent{PaymentID: arg.PaymentID, EventType: arg.EventType}, nil
+}
+
+func (tx *ledgerTx) ProjectPaymentStatus(_ context.Context, arg db.Project_Payment_StatusParams) (*db.Payment, error) {
+	if tx.payment.PaymentStatus != arg.PaymentStatus_2 {
+		return nil, pgx.ErrNoRows
+	}
+	tx.payment.PaymentStatus = arg.PaymentStatus
+	payment := tx.payment
+	return &payment, nil
+}
+
+func (tx *ledgerTx) ReleasePromoRedemption(_ context.Context, paymentID string) (int64, error) {
+	tx.released = append(tx.released, paymentID)
+	return 1, nil
+}
+
+func TestApplyPaymentEvent(t *testing.T) {
+	service := &ServicesHandler{}
+	ctx := context.Background()
+	charge := func(source db.PaymentEventSource, id string) paymentEvent {
+		return paymentEvent{
+			Source:          source,
+			Type:            "charge.success",
+			ProviderEventID: chargeEventID(id),
+			To:              db.PaymentStatusSuccess,
+		}
+	}
+
+	t.Run("charge moves a pending payment to success", func(t *testing.T) {
+		tx := &ledgerTx{payment: db.Payment{ID: "payment-id", PaymentStatus: db.PaymentStatusPending}}
+		read := tx.payment
+
+		updated, err := service.applyPaymentEvent(ctx, tx, &read, charge(db.PaymentEventSourceWebhook, "42"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.PaymentStatus != db.PaymentStatusSuccess {
+			t.Errorf("status = %s; want success", updated.PaymentStatus)
+		}
+		if len(tx.events) != 1 || tx.events[0].ToStatus.PaymentStatus != db.PaymentStatusSuccess {
+			t.Errorf("unexpected ledger %+v", tx.events)
+		}
+	})
+
+	t.Run("redelivered event is a no-op", func(t *testing.T) {
+		tx := &ledgerTx{payment: db.Payment{ID: "payment-id", PaymentStatus: db.PaymentStatusPending}}
+		read := tx.payment
+		if _, err := service.applyPaymentEvent(ctx, tx, &read, charge(db.PaymentEventSourceWebhook, "42")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// A racing verification of the same charge shares its provider event ID
+		_, err := service.applyPaymentEvent(ctx, tx, &read, charge(db.PaymentEventSourceVerification, "42"))
+		if !errors.Is(err, ErrDuplicatePaymentEvent) {
+			t.Fatalf("error = %v; want %v", err, ErrDuplicatePaymentEvent)
+		}
+		if len(tx.events) != 1 {
+			t.Errorf("recorded %d events; want 1", len(tx.events))
+		}
+	})
+
+	t.Run("provider event after the transition is recorded only", func(t *testing.T) {
+		tx := &ledgerTx{payment: db.Payment{ID: "payment-id", PaymentStatus: db.PaymentStatusSuccess}}
+		read := tx.payment
+
+		updated, err := service.applyPaymentEvent(ctx, tx, &read, charge(db.PaymentEventSourceWebhook, "43"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.PaymentStatus != db.PaymentStatusSuccess || len(tx.events) != 1 || tx.events[0].ToStatus.Valid {
+			t.Errorf("unexpected projection %s with ledger %+v", updated.PaymentStatus, tx.events)
+		}
+	})
+
+	t.Run("illegal internal transition is not recorded", func(t *testing.T) {
+		tx := &ledgerTx{payment: db.Payment{ID: "payment-id", PaymentStatus: db.PaymentStatusPending}}
+		read := tx.payment
+
+		_, err := service.applyPaymentEvent(ctx, tx, &read, paymentEvent{
+			Source: db.PaymentEventSourceInternal,
+			Type:   PaymentEventRefundRequested,
+			To:     db.PaymentStatusRefunded,
+		})
+		var pErr *PaymentError
+		if !errors.As(err, &pErr) || pErr.Code != ErrCodeInvalidStatus {
+			t.Fatalf("error = %v; want %s", err, ErrCodeInvalidStatus)
+		}
+		if len(tx.events) != 0 {
+			t.Errorf("recorded %d events; want 0", len(tx.events))
+		}
+	})
+
+	t.Run("failed payment gives back its promo code", func(t *testing.T) {
+		tx := &ledgerTx{payment: db.Payment{ID: "payment-id", PaymentStatus: db.PaymentStatusPending}}
+		read := tx.payment
+
+		_, err := service.applyPaymentEvent(ctx, tx, &read, paymentEvent{
+			Source: db.PaymentEventSourceInternal,
+			Type:   PaymentEventExpired,
+			To:     db.PaymentStatusFailed,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tx.released) != 1 || tx.released[0] != "payment-id" {
+			t.Errorf("released %v; want the failed payment", tx.released)
+		}
+	})
+
+	t.Run("stale read is rejected", func(t *testing.T) {
+		tx := &ledgerTx{payment: db.Payment{ID: "payment-id", PaymentStatus: db.PaymentStatusFailed}}
+		read := db.Payment{ID: "payment-id", PaymentStatus: db.PaymentStatusPending}
+
+		_, err := service.applyPaymentEvent(ctx, tx, &read, charge(db.PaymentEventSourceWebhook, "44"))
+		if !errors.Is(err, ErrPaymentStatusChanged) {
+			t.Fatalf("error = %v; want %v", err, ErrPaymentStatusChanged)
+		}
+	})
+}
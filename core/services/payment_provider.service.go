@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/diagnoxix/adapters/config"
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/flutterwave"
+	"github.com/diagnoxix/adapters/external/monnify"
+	"github.com/diagnoxix/adapters/external/paystack"
+	"github.com/diagnoxix/adapters/external/stripe"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// Provider used by centres that have not picked one
+const defaultPaymentProvider = db.PaymentProviderPAYSTACK
+
+var ErrPaymentProviderNotConfigured = errors.New("payment provider is not configured")
+
+// GetPaymentProviderSetting returns the payment provider a diagnostic centre collects payments with
+func (service *ServicesHandler) GetPaymentProviderSetting(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.GetDiagnosticParamDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	setting, err := service.centrePaymentSetting(ctx, dto.DiagnosticCentreID)
+	if err != nil {
+		utils.Error("Failed to get payment provider setting",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, setting, context)
+}
+
+// UpdatePaymentProviderSetting sets the payment provider of a diagnostic centre
+func (service *ServicesHandler) UpdatePaymentProviderSetting(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.UpdatePaymentProviderDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	provider := db.PaymentProvider(dto.PaymentProvider)
+	if _, ok := service.paymentProviders[provider]; !ok {
+		return utils.ErrorResponse(http.StatusUnprocessableEntity,
+			fmt.Errorf("%w: %s", ErrPaymentProviderNotConfigured, provider), context)
+	}
+
+	setting, err := service.diagnosticPort.UpsertPaymentSettings(ctx, db.Upsert_Payment_SettingsParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		PaymentProvider:    provider,
+		AllowFallback:      dto.AllowFallback,
+		UpdatedBy:          currentUser.UserID.String(),
+	})
+	if err != nil {
+		utils.Error("Failed to update payment provider setting",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, &domain.PaymentProviderSetting{
+		DiagnosticCentreID: setting.DiagnosticCentreID,
+		PaymentProvider:    setting.PaymentProvider,
+		AllowFallback:      setting.AllowFallback,
+		Available:          service.paymentProviderOrder,
+	}, context)
+}
+
+// centrePaymentSetting loads a centre's payment provider setting, falling back to the default
+func (service *ServicesHandler) centrePaymentSetting(
+	ctx context.Context,
+	diagnosticCentreID string,
+) (*domain.PaymentProviderSetting, error) {
+	setting, err := service.diagnosticPort.GetPaymentSettings(ctx, diagnosticCentreID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.PaymentProviderSetting{
+				DiagnosticCentreID: diagnosticCentreID,
+				PaymentProvider:    defaultPaymentProvider,
+				AllowFallback:      true,
+				IsDefault:          true,
+				Available:          service.paymentProviderOrder,
+			}, nil
+		}
+		return nil, err
+	}
+	return &domain.PaymentProviderSetting{
+		DiagnosticCentreID: setting.DiagnosticCentreID,
+		PaymentProvider:    setting.PaymentProvider,
+		AllowFallback:      setting.AllowFallback,
+		Available:          service.paymentProviderOrder,
+	}, nil
+}
+
+// initializeCentreTransaction starts a transaction with the centre's payment provider, moving
+// on to the next available provider while the centre allows fallback
+func (service *ServicesHandler) initializeCentreTransaction(
+	ctx context.Context,
+	diagnosticCentreID string,
+	request domain.TransactionRequest,
+) (*domain.TransactionCheckout, error) {
+	setting, err := service.centrePaymentSetting(ctx, diagnosticCentreID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment provider setting: %w", err)
+	}
+
+	var candidates []ports.PaymentProviderService
+	for _, provider := range providerCandidates(setting.PaymentProvider, setting.AllowFallback, service.paymentProviderOrder) {
+		candidates = append(candidates, service.paymentProviders[provider])
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentProviderNotConfigured, setting.PaymentProvider)
+	}
+	return initializeWithFallback(candidates, request)
+}
+
+// paymentProvider returns the adapter of the provider a payment was made with
+func (service *ServicesHandler) paymentProvider(provider db.PaymentProvider) (ports.PaymentProviderService, error) {
+	adapter, ok := service.paymentProviders[provider]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentProviderNotConfigured, provider)
+	}
+	return adapter, nil
+}
+
+// initializeWithFallback tries each provider in turn until one accepts the transaction. Only
+// an unavailable provider is skipped; a provider that rejects the request ends the attempt.
+func initializeWithFallback(
+	providers []ports.PaymentProviderService,
+	request domain.TransactionRequest,
+) (*domain.TransactionCheckout, error) {
+	var err error
+	for _, provider := range providers {
+		var checkout *domain.TransactionCheckout
+		checkout, err = provider.InitializeTransaction(request)
+		if err == nil {
+			return checkout, nil
+		}
+		if !errors.Is(err, domain.ErrProviderUnavailable) {
+			return nil, err
+		}
+		utils.Warn("Payment provider unavailable, trying the next one",
+			utils.LogField{Key: "provider", Value: provider.Provider()},
+			utils.LogField{Key: "reference", Value: request.Reference},
+			utils.LogField{Key: "error", Value: err.Error()})
+	}
+	return nil, err
+}
+
+// providerCandidates lists the configured providers a transaction may start with, the
+// preferred one first. An unconfigured preferred provider is skipped only when fallback is allowed.
+func providerCandidates(
+	preferred db.PaymentProvider,
+	allowFallback bool,
+	order []db.PaymentProvider,
+) []db.PaymentProvider {
+	var candidates []db.PaymentProvider
+	for _, provider := range order {
+		if provider == preferred {
+			candidates = append(candidates, provider)
+		}
+	}
+	if !allowFallback {
+		return candidates
+	}
+	for _, provider := range order {
+		if provider != preferred {
+			candidates = append(candidates, provider)
+		}
+	}
+	return candidates
+}
+
+// newPaymentProviders builds an adapter for every provider with credentials configured, and the
+// order they are tried in. Paystack is always available, as it was the only provider before.
+func newPaymentProviders(conn config.EnvConfiguration) (map[db.PaymentProvider]ports.PaymentProviderService, []db.PaymentProvider) {
+	providers := map[db.PaymentProvider]ports.PaymentProviderService{
+		db.PaymentProviderPAYSTACK: paystack.NewPaystackAdapter(&paystack.PaystackConfig{
+			SecretKey: conn.PAYSTACK_SECRET_KEY,
+			BaseURL:   conn.PAYSTACK_BASE_URL,
+		}),
+	}
+	if conn.FLUTTERWAVE_SECRET_KEY != "" {
+		providers[db.PaymentProviderFLUTTERWAVE] = flutterwave.NewFlutterwaveAdapter(&flutterwave.FlutterwaveConfig{
+			SecretKey:   conn.FLUTTERWAVE_SECRET_KEY,
+			BaseURL:     conn.FLUTTERWAVE_BASE_URL,
+			RedirectURL: conn.PAYMENT_REDIRECT_URL,
+		})
+	}
+	if conn.STRIPE_SECRET_KEY != "" {
+		providers[db.PaymentProviderSTRIPE] = stripe.NewStripeAdapter(&stripe.StripeConfig{
+			SecretKey:   conn.STRIPE_SECRET_KEY,
+			BaseURL:     conn.STRIPE_BASE_URL,
+			RedirectURL: conn.PAYMENT_REDIRECT_URL,
+		})
+	}
+	if conn.MONNIFY_API_KEY != "" {
+		providers[db.PaymentProviderMONNIFY] = monnify.NewMonnifyAdapter(&monnify.MonnifyConfig{
+			APIKey:       conn.MONNIFY_API_KEY,
+			SecretKey:    conn.MONNIFY_SECRET_KEY,
+			ContractCode: conn.MONNIFY_CONTRACT_CODE,
+			BaseURL:      conn.MONNIFY_BASE_URL,
+			RedirectURL:  conn.PAYMENT_REDIRECT_URL,
+		})
+	}
+
+	return providers, paymentProviderOrder(conn.PAYMENT_PROVIDER_ORDER, providers)
+}
+
+// paymentProviderOrder reads the fallback order from configuration, keeping only configured
+// providers. Configured providers missing from the list are tried last.
+func paymentProviderOrder(
+	configured string,
+	providers map[db.PaymentProvider]ports.PaymentProviderService,
+) []db.PaymentProvider {
+	if configured == "" {
+		configured = "PAYSTACK,FLUTTERWAVE,STRIPE,MONNIFY"
+	}
+
+	var order []db.PaymentProvider
+	seen := map[db.PaymentProvider]bool{}
+	add := func(provider db.PaymentProvider) {
+		if _, ok := providers[provider]; ok && !seen[provider] {
+			seen[provider] = true
+			order = append(order, provider)
+		}
+	}
+	for _, name := range strings.Split(configured, ",") {
+		provider := db.PaymentProvider(strings.ToUpper(strings.TrimSpace(name)))
+		if !provider.Valid() {
+			utils.Warn("Ignoring unknown payment provider in PAYMENT_PROVIDER_ORDER",
+				utils.LogField{Key: "provider", Value: name})
+			continue
+		}
+		add(provider)
+	}
+	for _, provider := range db.AllPaymentProviderValues() {
+		add(provider)
+	}
+	return order
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"go.uber.org/zap"
+)
+
+// stubProvider answers InitializeTransaction with a fixed error, or a checkout when err is nil
+type stubProvider struct {
+	ports.PaymentProviderService
+	provider db.PaymentProvider
+	err      error
+	calls    int
+}
+
+func (p *stubProvider) Provider() db.PaymentProvider { return p.provider }
+
+func (p *stubProvider) InitializeTransaction(request domain.TransactionRequest) (*domain.TransactionCheckout, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &domain.TransactionCheckout{Provider: p.provider, Reference: request.Reference}, nil
+}
+
+func TestProviderCandidates(t *testing.T) {
+	order := []db.PaymentProvider{
+		db.PaymentProviderPAYSTACK,
+		db.PaymentProviderFLUTTERWAVE,
+		db.PaymentProviderSTRIPE,
+	}
+	tests := []struct {
+		name          string
+		preferred     db.PaymentProvider
+		allowFallback bool
+		want          []db.PaymentProvider
+	}{
+		{
+			name:          "preferred first then the rest in order",
+			preferred:     db.PaymentProviderSTRIPE,
+			allowFallback: true,
+			want:          []db.PaymentProvider{db.PaymentProviderSTRIPE, db.PaymentProviderPAYSTACK, db.PaymentProviderFLUTTERWAVE},
+		},
+		{
+			name:      "no fallback",
+			preferred: db.PaymentProviderFLUTTERWAVE,
+			want:      []db.PaymentProvider{db.PaymentProviderFLUTTERWAVE},
+		},
+		{
+			name:          "unconfigured preferred falls back",
+			preferred:     db.PaymentProviderMONNIFY,
+			allowFallback: true,
+			want:          order,
+		},
+		{
+			name:      "unconfigured preferred without fallback",
+			preferred: db.PaymentProviderMONNIFY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := providerCandidates(tt.preferred, tt.allowFallback, order)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitializeWithFallback(t *testing.T) {
+	// Skipped providers are logged
+	utils.Logger = zap.NewNop()
+	request := domain.TransactionRequest{Reference: "MED-1", Amount: 5000}
+	unavailable := fmt.Errorf("%w: status 503", domain.ErrProviderUnavailable)
+
+	t.Run("skips unavailable providers", func(t *testing.T) {
+		down := &stubProvider{provider: db.PaymentProviderPAYSTACK, err: unavailable}
+		up := &stubProvider{provider: db.PaymentProviderFLUTTERWAVE}
+
+		checkout, err := initializeWithFallback([]ports.PaymentProviderService{down, up}, request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if checkout.Provider != db.PaymentProviderFLUTTERWAVE {
+			t.Errorf("provider = %s; want FLUTTERWAVE", checkout.Provider)
+		}
+	})
+
+	t.Run("rejection does not fall back", func(t *testing.T) {
+		rejecting := &stubProvider{provider: db.PaymentProviderPAYSTACK, err: errors.New("transaction rejected: invalid email")}
+		next := &stubProvider{provider: db.PaymentProviderFLUTTERWAVE}
+
+		if _, err := initializeWithFallback([]ports.PaymentProviderService{rejecting, next}, request); err == nil {
+			t.Fatal("expected the rejection to be returned")
+		}
+		if next.calls != 0 {
+			t.Errorf("next provider called %d times; want 0", next.calls)
+		}
+	})
+
+	t.Run("all unavailable", func(t *testing.T) {
+		providers := []ports.PaymentProviderService{
+			&stubProvider{provider: db.PaymentProviderPAYSTACK, err: unavailable},
+			&stubProvider{provider: db.PaymentProviderSTRIPE, err: unavailable},
+		}
+		_, err := initializeWithFallback(providers, request)
+		if !errors.Is(err, domain.ErrProviderUnavailable) {
+			t.Fatalf("error = %v; want %v", err, domain.ErrProviderUnavailable)
+		}
+	})
+}
+
+func TestPaymentProviderOrder(t *testing.T) {
+	// Unknown providers are logged
+	utils.Logger = zap.NewNop()
+	providers := map[db.PaymentProvider]ports.PaymentProviderService{
+		db.PaymentProviderPAYSTACK: &stubProvider{},
+		db.PaymentProviderSTRIPE:   &stubProvider{},
+		db.PaymentProviderMONNIFY:  &stubProvider{},
+	}
+
+	got := paymentProviderOrder(" stripe ,UNKNOWN,FLUTTERWAVE,stripe", providers)
+	want := []db.PaymentProvider{db.PaymentProviderSTRIPE, db.PaymentProviderPAYSTACK, db.PaymentProviderMONNIFY}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,549 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/templates/emails"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// defaultReconciliationHours is how far back provider transactions are compared when
+	// PAYMENT_RECONCILIATION_HOURS is unset
+	defaultReconciliationHours = 48
+	// defaultUnpaidAppointmentTimeoutMinutes is how long an appointment waits for payment when
+	// UNPAID_APPOINTMENT_TIMEOUT_MINUTES is unset
+	defaultUnpaidAppointmentTimeoutMinutes = 60
+	// unpaidPaymentBatchSize caps the payments expired in one run; the rest wait for the next
+	unpaidPaymentBatchSize = 100
+)
+
+const expiredAppointmentReason = "Payment was not completed in time"
+
+// GetPaymentDiscrepancyReport returns the discrepancies the reconciliation job found on a day
+func (s *ServicesHandler) GetPaymentDiscrepancyReport(c echo.Context) error {
+	_, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumADMIN})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.GetPaymentDiscrepancyReportDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	day := truncateToDay(time.Now()).AddDate(0, 0, -1)
+	if dto.Date != "" {
+		day, err = time.Parse("2006-01-02", dto.Date)
+		if err != nil {
+			return utils.ErrorResponse(http.StatusBadRequest, err, c)
+		}
+	}
+
+	report, err := s.paymentDiscrepancyReport(c.Request().Context(), day)
+	if err != nil {
+		utils.Error("Failed to build payment discrepancy report",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "date", Value: day.Format("2006-01-02")})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, report, c)
+}
+
+// reconcilePayments compares the recent transactions of every configured provider with the
+// payments recorded for them. Payments a provider reports paid but that were never verified
+// are verified now; every other difference is recorded for finance. Refunds that never reached
+// their provider are then sent, and those still pending at providers without refund webhooks
+// asked after.
+func (s *ServicesHandler) reconcilePayments() {
+	ctx := context.Background()
+	to := time.Now().UTC()
+	from := to.Add(-s.reconciliationWindow())
+
+	for _, name := range s.paymentProviderOrder {
+		provider, err := s.paymentProvider(name)
+		if err != nil {
+			continue
+		}
+		if err := s.reconcileProvider(ctx, provider, from, to); err != nil {
+			utils.Error("Failed to reconcile payments",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "provider", Value: name})
+		}
+	}
+	s.sendUnsentRefunds(ctx)
+	s.pollPendingRefunds(ctx)
+}
+
+// reconcileProvider reconciles the transactions one provider reports between from and to
+func (s *ServicesHandler) reconcileProvider(
+	ctx context.Context,
+	provider ports.PaymentProviderService,
+	from time.Time,
+	to time.Time,
+) error {
+	transactions, err := provider.ListTransactions(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to list provider transactions: %w", err)
+	}
+
+	references := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		if transaction.Reference != "" {
+			references = append(references, transaction.Reference)
+		}
+	}
+	if len(references) == 0 {
+		return nil
+	}
+
+	payments, err := s.paymentPort.ListPaymentsByReferences(ctx, db.List_Payments_By_ReferencesParams{
+		PaymentProvider: provider.Provider(),
+		Column2:         references,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list payments: %w", err)
+	}
+	byReference := make(map[string]*db.Payment, len(payments))
+	for _, payment := range payments {
+		byReference[payment.ProviderReference.String] = payment
+	}
+
+	discrepancies := 0
+	for _, transaction := range transactions {
+		if transaction.Reference == "" {
+			continue
+		}
+		payment := byReference[transaction.Reference]
+		kind, found := classifyTransaction(transaction, payment)
+		if !found {
+			continue
+		}
+		discrepancies++
+
+		// A charge the provider captured but the payment never recorded is verified now;
+		// the discrepancy is kept as resolved so finance can see it happened. Only charges
+		// for the full amount get here; the rest are amount mismatches left to finance.
+		resolved := false
+		if kind == db.PaymentDiscrepancyKindStatusMismatch && transaction.Status == domain.TransactionStatusSuccess {
+			err := s.settleVerifiedCharge(ctx, transaction.Reference)
+			switch {
+			case errors.Is(err, ErrWebhookAmountMismatch):
+				// The provider reported a different amount when verified again
+				kind = db.PaymentDiscrepancyKindAmountMismatch
+			case err != nil:
+				utils.Warn("Failed to settle reconciled payment",
+					utils.LogField{Key: "error", Value: err.Error()},
+					utils.LogField{Key: "reference", Value: transaction.Reference})
+			default:
+				resolved = true
+			}
+		}
+		s.recordPaymentDiscrepancy(ctx, transaction, payment, kind, resolved)
+	}
+
+	utils.Info("Payment reconciliation finished",
+		utils.LogField{Key: "provider", Value: provider.Provider()},
+		utils.LogField{Key: "transactions", Value: len(transactions)},
+		utils.LogField{Key: "discrepancies", Value: discrepancies})
+	return nil
+}
+
+// settleVerifiedCharge verifies a payment with its provider and confirms the appointments it pays
+// for. A charge for another amount fails with ErrWebhookAmountMismatch and settles nothing.
+func (s *ServicesHandler) settleVerifiedCharge(ctx context.Context, reference string) error {
+	payment, err := s.verifyAndUpdatePaymentWithRetry(ctx, reference)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	confirmed, err := tx.ConfirmAppointmentsByPayment(ctx, toUUID(payment.ID))
+	if err != nil {
+		return fmt.Errorf("failed to confirm appointments: %w", err)
+	}
+	notifications, err := s.dispatchAppointmentConfirmations(ctx, tx, confirmed)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	pushNotifications(ctx, notifications)
+	return nil
+}
+
+// recordPaymentDiscrepancy stores a difference between a provider transaction and its payment.
+// A difference found again on a later run updates the one already recorded.
+func (s *ServicesHandler) recordPaymentDiscrepancy(
+	ctx context.Context,
+	transaction *domain.TransactionVerification,
+	payment *db.Payment,
+	kind db.PaymentDiscrepancyKind,
+	resolved bool,
+) {
+	arg := db.Upsert_Payment_DiscrepancyParams{
+		PaymentProvider:   transaction.Provider,
+		ProviderReference: transaction.Reference,
+		Kind:              kind,
+		ProviderStatus:    string(transaction.Status),
+		ProviderAmount:    toNumeric(transaction.Amount),
+		Currency:          strings.ToUpper(transaction.Currency),
+		Resolved:          resolved,
+	}
+	if payment != nil {
+		arg.PaymentID = toUUID(payment.ID)
+		arg.PaymentStatus = db.NullPaymentStatus{PaymentStatus: payment.PaymentStatus, Valid: true}
+		// Only the part charged through the provider can be compared with it
+		if charged, err := providerChargeAmount(payment); err == nil {
+			arg.PaymentAmount = toNumeric(charged)
+		}
+	}
+
+	if _, err := s.paymentPort.UpsertPaymentDiscrepancy(ctx, arg); err != nil {
+		utils.Error("Failed to record payment discrepancy",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "reference", Value: transaction.Reference},
+			utils.LogField{Key: "kind", Value: kind})
+	}
+}
+
+// classifyTransaction reports how a provider transaction differs from the payment recorded
+// under its reference, if it does. Transactions the provider has not settled are ignored.
+func classifyTransaction(
+	transaction *domain.TransactionVerification,
+	payment *db.Payment,
+) (db.PaymentDiscrepancyKind, bool) {
+	switch transaction.Status {
+	case domain.TransactionStatusSuccess:
+	case domain.TransactionStatusFailed:
+		// A charge reversed at the provider after the payment was recorded as captured
+		if payment != nil && payment.PaymentStatus == db.PaymentStatusSuccess {
+			return db.PaymentDiscrepancyKindStatusMismatch, true
+		}
+		return "", false
+	default:
+		return "", false
+	}
+
+	if payment == nil {
+		return db.PaymentDiscrepancyKindUnknownReference, true
+	}
+	if !transactionAmountMatches(transaction, payment) {
+		return db.PaymentDiscrepancyKindAmountMismatch, true
+	}
+	switch payment.PaymentStatus {
+	case db.PaymentStatusPending, db.PaymentStatusFailed:
+		return db.PaymentDiscrepancyKindStatusMismatch, true
+	case db.PaymentStatusCancelled:
+		return db.PaymentDiscrepancyKindPaidAfterExpiry, true
+	}
+	return "", false
+}
+
+// transactionAmountMatches compares a provider transaction with its payment to the kobo
+func transactionAmountMatches(transaction *domain.TransactionVerification, payment *db.Payment) bool {
+	amount, err := providerChargeAmount(payment)
+	if err != nil {
+		return false
+	}
+	return roundAmount(amount) == roundAmount(transaction.Amount) &&
+		strings.EqualFold(payment.Currency, transaction.Currency)
+}
+
+// expireUnpaidAppointments cancels appointments whose payment was not completed within the
+// timeout so their slots can be booked again. Each payment is checked with its provider once
+// more first, so a patient who paid without the payment being verified keeps the booking.
+// Appointments paid for at the centre are expired once their hold ends instead.
+func (s *ServicesHandler) expireUnpaidAppointments() {
+	ctx := context.Background()
+	cutoff := time.Now().UTC().Add(-s.unpaidAppointmentTimeout())
+
+	payments, err := s.paymentPort.ListUnpaidAppointmentPayments(ctx, db.List_Unpaid_Appointment_PaymentsParams{
+		CreatedAt: toTimestamptz(cutoff),
+		Limit:     unpaidPaymentBatchSize,
+	})
+	if err != nil {
+		utils.Error("Failed to list unpaid appointment payments",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return
+	}
+
+	expired := 0
+	for _, payment := range payments {
+		charge, err := s.paidAtProvider(payment)
+		if err != nil {
+			// The provider could not say, so the payment waits for the next run
+			utils.Warn("Skipping expiry of unverified payment",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "payment_id", Value: payment.ID})
+			continue
+		}
+		if charge != nil {
+			// A charge for another amount is neither settled nor expired; the patient paid
+			// something, so finance decides from the discrepancy what happens to the booking
+			if !transactionAmountMatches(charge, payment) {
+				utils.Warn("Payment found paid at expiry for another amount",
+					utils.LogField{Key: "payment_id", Value: payment.ID},
+					utils.LogField{Key: "amount", Value: charge.Amount},
+					utils.LogField{Key: "currency", Value: charge.Currency})
+				s.recordPaymentDiscrepancy(ctx, charge, payment, db.PaymentDiscrepancyKindAmountMismatch, false)
+				continue
+			}
+			err := s.settleVerifiedCharge(ctx, paymentProviderReference(payment))
+			if errors.Is(err, ErrWebhookAmountMismatch) {
+				s.recordPaymentDiscrepancy(ctx, charge, payment, db.PaymentDiscrepancyKindAmountMismatch, false)
+			}
+			if err != nil {
+				utils.Warn("Failed to settle payment found paid at expiry",
+					utils.LogField{Key: "error", Value: err.Error()},
+					utils.LogField{Key: "payment_id", Value: payment.ID})
+			}
+			continue
+		}
+
+		expired += s.releaseUnpaidAppointments(ctx, payment)
+	}
+	expired += s.expireCentrePayments(ctx)
+
+	if expired > 0 {
+		utils.Info("Expired unpaid appointments",
+			utils.LogField{Key: "payments", Value: len(payments)},
+			utils.LogField{Key: "appointments", Value: expired})
+	}
+}
+
+// releaseUnpaidAppointments expires an unpaid payment, tells its patients and offers the freed
+// slots to the waitlist. Returns how many appointments were expired.
+func (s *ServicesHandler) releaseUnpaidAppointments(ctx context.Context, payment *db.Payment) int {
+	appointments, err := s.expirePayment(ctx, payment)
+	if err != nil {
+		utils.Error("Failed to expire unpaid appointments",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return 0
+	}
+
+	for _, appointment := range appointments {
+		s.notifyAppointmentExpired(ctx, appointment)
+		go s.offerFreedSlot(appointment.DiagnosticCentreID, appointment.AppointmentDate.Time)
+	}
+	return len(appointments)
+}
+
+// paidAtProvider asks the payment's provider whether it was paid, and returns the successful
+// charge if it was. Only an unreachable provider is an error; a reference the provider does not
+// know was never paid.
+func (s *ServicesHandler) paidAtProvider(payment *db.Payment) (*domain.TransactionVerification, error) {
+	provider, err := s.paymentProvider(payment.PaymentProvider)
+	if err != nil {
+		// A provider no longer configured cannot be asked, and cannot take the payment either
+		return nil, nil
+	}
+	verification, err := provider.VerifyTransaction(paymentProviderReference(payment))
+	if errors.Is(err, domain.ErrProviderUnavailable) {
+		return nil, err
+	}
+	if err != nil || verification.Status != domain.TransactionStatusSuccess {
+		return nil, nil
+	}
+	return verification, nil
+}
+
+// expirePayment cancels an unpaid payment and the appointments waiting on it in one transaction,
+// giving back anything the patient's wallet put towards it
+func (s *ServicesHandler) expirePayment(ctx context.Context, payment *db.Payment) ([]*db.Appointment, error) {
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := s.applyPaymentEvent(ctx, tx, payment, paymentEvent{
+		Source: db.PaymentEventSourceInternal,
+		Type:   PaymentEventExpired,
+		To:     db.PaymentStatusCancelled,
+		Amount: payment.Amount,
+	}); err != nil {
+		return nil, err
+	}
+
+	appointments, err := tx.ExpireAppointmentsByPayment(ctx, db.Expire_Appointments_By_PaymentParams{
+		PaymentID:          toUUID(payment.ID),
+		CancellationReason: toText(expiredAppointmentReason),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire appointments: %w", err)
+	}
+	if _, err := releaseWalletShare(ctx, tx, payment); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return appointments, nil
+}
+
+// notifyAppointmentExpired tells the patient an unpaid appointment was released
+func (s *ServicesHandler) notifyAppointmentExpired(ctx context.Context, appointment *db.Appointment) {
+	notification, err := s.appointmentNotification(ctx, appointment, db.NotificationTypeAPPOINTMENTCANCELLED, map[string]interface{}{
+		"appointment_id":       appointment.ID,
+		"diagnostic_centre_id": appointment.DiagnosticCentreID,
+		"payment_id":           uuidString(appointment.PaymentID),
+	})
+	if err == nil {
+		notification.Title = "Appointment Expired"
+		notification.Message = expiredAppointmentReason
+		err = s.notify(ctx, notification)
+	}
+	if err != nil {
+		utils.Error("Failed to send expiry notification",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+	}
+}
+
+// sendPaymentDiscrepancyReport emails finance the discrepancies found yesterday
+func (s *ServicesHandler) sendPaymentDiscrepancyReport() {
+	ctx := context.Background()
+	day := truncateToDay(time.Now()).AddDate(0, 0, -1)
+
+	report, err := s.paymentDiscrepancyReport(ctx, day)
+	if err != nil {
+		utils.Error("Failed to build payment discrepancy report",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "date", Value: day.Format("2006-01-02")})
+		return
+	}
+
+	utils.Info("Payment discrepancy report",
+		utils.LogField{Key: "date", Value: day.Format("2006-01-02")},
+		utils.LogField{Key: "total", Value: report.Total},
+		utils.LogField{Key: "unresolved", Value: report.Unresolved})
+	if s.Config.FINANCE_REPORT_EMAIL == "" {
+		return
+	}
+
+	data := emails.PaymentDiscrepancyReportData{
+		Date:          report.Date,
+		Total:         report.Total,
+		Unresolved:    report.Unresolved,
+		Discrepancies: make([]emails.PaymentDiscrepancyRow, 0, len(report.Discrepancies)),
+	}
+	for _, discrepancy := range report.Discrepancies {
+		data.Discrepancies = append(data.Discrepancies, discrepancyRow(discrepancy))
+	}
+
+	if err := s.queueEmail(ctx, s.outboxPort, outboxEmail{
+		To:       s.Config.FINANCE_REPORT_EMAIL,
+		Subject:  fmt.Sprintf("%s - %s", emails.TitlePaymentDiscrepancyReport, day.Format("2 Jan 2006")),
+		Template: emails.TemplatePaymentDiscrepancyReport,
+		Data:     &data,
+	}); err != nil {
+		utils.Error("Failed to queue payment discrepancy report",
+			utils.LogField{Key: "error", Value: err.Error()})
+	}
+}
+
+// paymentDiscrepancyReport collects the discrepancies detected on day
+func (s *ServicesHandler) paymentDiscrepancyReport(ctx context.Context, day time.Time) (*domain.PaymentDiscrepancyReport, error) {
+	day = truncateToDay(day)
+	discrepancies, err := s.paymentPort.ListPaymentDiscrepancies(ctx, db.List_Payment_DiscrepanciesParams{
+		DetectedAt:   toTimestamptz(day),
+		DetectedAt_2: toTimestamptz(day.AddDate(0, 0, 1)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildDiscrepancyReport(day, discrepancies), nil
+}
+
+// buildDiscrepancyReport totals a day's discrepancies by kind and by whether they were resolved
+func buildDiscrepancyReport(day time.Time, discrepancies []*db.PaymentDiscrepancy) *domain.PaymentDiscrepancyReport {
+	report := &domain.PaymentDiscrepancyReport{
+		Date:          day,
+		Total:         len(discrepancies),
+		ByKind:        map[db.PaymentDiscrepancyKind]int{},
+		Discrepancies: discrepancies,
+	}
+	if report.Discrepancies == nil {
+		report.Discrepancies = []*db.PaymentDiscrepancy{}
+	}
+	for _, discrepancy := range discrepancies {
+		report.ByKind[discrepancy.Kind]++
+		if !discrepancy.Resolved {
+			report.Unresolved++
+		}
+	}
+	return report
+}
+
+// discrepancyRow formats a discrepancy for the finance report email
+func discrepancyRow(discrepancy *db.PaymentDiscrepancy) emails.PaymentDiscrepancyRow {
+	row := emails.PaymentDiscrepancyRow{
+		Provider:       string(discrepancy.PaymentProvider),
+		Reference:      discrepancy.ProviderReference,
+		Kind:           strings.ReplaceAll(string(discrepancy.Kind), "_", " "),
+		PaymentStatus:  "-",
+		PaymentAmount:  "-",
+		ProviderStatus: discrepancy.ProviderStatus,
+		Currency:       discrepancy.Currency,
+		Resolved:       discrepancy.Resolved,
+	}
+	if discrepancy.PaymentStatus.Valid {
+		row.PaymentStatus = string(discrepancy.PaymentStatus.PaymentStatus)
+	}
+	if amount, err := numericToFloat(discrepancy.PaymentAmount); discrepancy.PaymentAmount.Valid && err == nil {
+		row.PaymentAmount = strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+	if amount, err := numericToFloat(discrepancy.ProviderAmount); err == nil {
+		row.ProviderAmount = strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+	return row
+}
+
+// reconciliationWindow reads how far back provider transactions are reconciled from configuration
+func (s *ServicesHandler) reconciliationWindow() time.Duration {
+	hours := defaultReconciliationHours
+	if s.Config.PAYMENT_RECONCILIATION_HOURS != "" {
+		parsed, err := strconv.Atoi(s.Config.PAYMENT_RECONCILIATION_HOURS)
+		if err != nil || parsed <= 0 {
+			utils.Warn("Invalid PAYMENT_RECONCILIATION_HOURS configuration, using default",
+				utils.LogField{Key: "payment_reconciliation_hours", Value: s.Config.PAYMENT_RECONCILIATION_HOURS})
+		} else {
+			hours = parsed
+		}
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// unpaidAppointmentTimeout reads how long an appointment waits for payment from configuration
+func (s *ServicesHandler) unpaidAppointmentTimeout() time.Duration {
+	minutes := defaultUnpaidAppointmentTimeoutMinutes
+	if s.Config.UNPAID_APPOINTMENT_TIMEOUT_MINUTES != "" {
+		parsed, err := strconv.Atoi(s.Config.UNPAID_APPOINTMENT_TIMEOUT_MINUTES)
+		if err != nil || parsed <= 0 {
+			utils.Warn("Invalid UNPAID_APPOINTMENT_TIMEOUT_MINUTES configuration, using default",
+				utils.LogField{Key: "unpaid_appointment_timeout_minutes", Value: s.Config.UNPAID_APPOINTMENT_TIMEOUT_MINUTES})
+		} else {
+			minutes = parsed
+		}
+	}
+	return time.Duration(minutes) * time.Minute
+}
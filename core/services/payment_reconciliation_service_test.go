@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func TestClassifyTransaction(t *testing.T) {
+	payment := func(status db.PaymentStatus, amount float64) *db.Payment {
+		return &db.Payment{PaymentStatus: status, Amount: toNumeric(amount), Currency: "NGN"}
+	}
+	transaction := func(status domain.TransactionStatus, amount float64) *domain.TransactionVerification {
+		return &domain.TransactionVerification{Status: status, Amount: amount, Currency: "ngn"}
+	}
+
+	tests := []struct {
+		name        string
+		transaction *domain.TransactionVerification
+		payment     *db.Payment
+		want        db.PaymentDiscrepancyKind
+		wantFound   bool
+	}{
+		{
+			name:        "in step",
+			transaction: transaction(domain.TransactionStatusSuccess, 5000),
+			payment:     payment(db.PaymentStatusSuccess, 5000),
+		},
+		{
+			name:        "refunded payment",
+			transaction: transaction(domain.TransactionStatusSuccess, 5000),
+			payment:     payment(db.PaymentStatusRefunded, 5000),
+		},
+		{
+			name:        "unknown reference",
+			transaction: transaction(domain.TransactionStatusSuccess, 5000),
+			want:        db.PaymentDiscrepancyKindUnknownReference,
+			wantFound:   true,
+		},
+		{
+			name:        "paid but pending",
+			transaction: transaction(domain.TransactionStatusSuccess, 5000),
+			payment:     payment(db.PaymentStatusPending, 5000),
+			want:        db.PaymentDiscrepancyKindStatusMismatch,
+			wantFound:   true,
+		},
+		{
+			name:        "paid after expiry",
+			transaction: transaction(domain.TransactionStatusSuccess, 5000),
+			payment:     payment(db.PaymentStatusCancelled, 5000),
+			want:        db.PaymentDiscrepancyKindPaidAfterExpiry,
+			wantFound:   true,
+		},
+		{
+			name:        "amount differs",
+			transaction: transaction(domain.TransactionStatusSuccess, 4000),
+			payment:     payment(db.PaymentStatusPending, 5000),
+			want:        db.PaymentDiscrepancyKindAmountMismatch,
+			wantFound:   true,
+		},
+		{
+			name:        "reversed after capture",
+			transaction: transaction(domain.TransactionStatusFailed, 5000),
+			payment:     payment(db.PaymentStatusSuccess, 5000),
+			want:        db.PaymentDiscrepancyKindStatusMismatch,
+			wantFound:   true,
+		},
+		{
+			name:        "failed and unknown",
+			transaction: transaction(domain.TransactionStatusFailed, 5000),
+		},
+		{
+			name:        "still pending",
+			transaction: transaction(domain.TransactionStatusPending, 5000),
+			payment:     payment(db.PaymentStatusPending, 5000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := classifyTransaction(tt.transaction, tt.payment)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("classifyTransaction() = %q, %v; want %q, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestBuildDiscrepancyReport(t *testing.T) {
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	report := buildDiscrepancyReport(day, []*db.PaymentDiscrepancy{
+		{Kind: db.PaymentDiscrepancyKindStatusMismatch, Resolved: true},
+		{Kind: db.PaymentDiscrepancyKindStatusMismatch},
+		{Kind: db.PaymentDiscrepancyKindUnknownReference},
+	})
+
+	if report.Total != 3 || report.Unresolved != 2 {
+		t.Errorf("total, unresolved = %d, %d; want 3, 2", report.Total, report.Unresolved)
+	}
+	if report.ByKind[db.PaymentDiscrepancyKindStatusMismatch] != 2 || report.ByKind[db.PaymentDiscrepancyKindUnknownReference] != 1 {
+		t.Errorf("by kind = %v", report.ByKind)
+	}
+
+	empty := buildDiscrepancyReport(day, nil)
+	if empty.Discrepancies == nil || empty.Total != 0 {
+		t.Errorf("empty report = %+v; want no discrepancies listed", empty)
+	}
+}
+
+type expiryProvider struct {
+	ports.PaymentProviderService
+	charge *domain.TransactionVerification
+}
+
+func (p *expiryProvider) VerifyTransaction(reference string) (*domain.TransactionVerification, error) {
+	return p.charge, nil
+}
+
+type expiryPayments struct {
+	ports.PaymentRepository
+	unpaid        []*db.Payment
+	discrepancies []db.Upsert_Payment_DiscrepancyParams
+}
+
+func (r *expiryPayments) ListUnpaidAppointmentPayments(ctx context.Context, arg db.List_Unpaid_Appointment_PaymentsParams) ([]*db.Payment, error) {
+	return r.unpaid, nil
+}
+
+func (r *expiryPayments) ListExpiredCentrePayments(ctx context.Context, arg db.List_Expired_Centre_PaymentsParams) ([]*db.Payment, error) {
+	return nil, nil
+}
+
+func (r *expiryPayments) UpsertPaymentDiscrepancy(ctx context.Context, arg db.Upsert_Payment_DiscrepancyParams) (*db.PaymentDiscrepancy, error) {
+	r.discrepancies = append(r.discrepancies, arg)
+	return &db.PaymentDiscrepancy{}, nil
+}
+
+func TestExpireUnpaidAppointmentsLeavesUnderpaidCharge(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	payments := &expiryPayments{unpaid: []*db.Payment{{
+		ID:                "payment-1",
+		PaymentProvider:   db.PaymentProviderPAYSTACK,
+		PaymentStatus:     db.PaymentStatusPending,
+		ProviderReference: pgtype.Text{String: "ref-1", Valid: true},
+		Amount:            toNumeric(5000),
+		Currency:          "NGN",
+	}}}
+	// Without an appointment repository, settling or expiring the payment would panic
+	service := &ServicesHandler{
+		paymentPort: payments,
+		paymentProviders: map[db.PaymentProvider]ports.PaymentProviderService{
+			db.PaymentProviderPAYSTACK: &expiryProvider{charge: &domain.TransactionVerification{
+				Provider:  db.PaymentProviderPAYSTACK,
+				Reference: "ref-1",
+				Status:    domain.TransactionStatusSuccess,
+				Amount:    500,
+				Currency:  "NGN",
+			}},
+		},
+	}
+
+	service.expireUnpaidAppointments()
+
+	if len(payments.discrepancies) != 1 {
+		t.Fatalf("recorded %d discrepancies; want 1", len(payments.discrepancies))
+	}
+	got := payments.discrepancies[0]
+	if got.Kind != db.PaymentDiscrepancyKindAmountMismatch || got.Resolved {
+		t.Errorf("discrepancy = %s resolved %v; want unresolved %s",
+			got.Kind, got.Resolved, db.PaymentDiscrepancyKindAmountMismatch)
+	}
+}
@@ -0,0 +1,726 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrPaymentNotRefundable  = errors.New("only successful payments can be refunded")
+	ErrRefundExceedsCaptured = errors.New("refund exceeds the amount left to refund")
+	ErrRefundRejected        = errors.New("payment provider did not accept the refund")
+	ErrRefundSettled         = errors.New("refund has already been settled")
+)
+
+const (
+	// refundPollDelay is how long a refund is left for the provider to settle before it is
+	// asked after
+	refundPollDelay = 15 * time.Minute
+	// refundPollBatchSize caps the refunds asked after in one run; the rest wait for the next
+	refundPollBatchSize = 100
+	// refundSendDelay leaves a refund's own request time to send it before it is sent again
+	refundSendDelay = 5 * time.Minute
+)
+
+// refundOutcome is what a provider reported about a refund and the ledger event recording it
+type refundOutcome struct {
+	Status           db.PaymentRefundStatus
+	ProviderRefundID string
+	FailureReason    string
+	Source           db.PaymentEventSource
+	Type             string
+	ProviderEventID  string
+	Payload          []byte
+	ActorID          string
+}
+
+// RPayment refunds part or all of a payment through the provider it was made with, or into the
+// patient's wallet. A provider refund stays pending until the provider reports it processed or
+// failed; a wallet refund is processed at once.
+func (s *ServicesHandler) RPayment(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.RefundPaymentDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	payment, err := s.paymentPort.GetPayment(ctx, dto.PaymentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("payment not found"), c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if err := s.authorizeCentreAccess(ctx, currentUser, payment.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+
+	refund, err := s.requestRefund(
+		ctx,
+		payment,
+		roundAmount(dto.RefundAmount),
+		dto.RefundReason,
+		currentUser.UserID.String(),
+		refundDestination(payment, dto.RefundTo),
+	)
+	if err != nil {
+		utils.Error("Failed to refund payment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID},
+			utils.LogField{Key: "amount", Value: dto.RefundAmount})
+		switch {
+		case errors.Is(err, ErrPaymentNotRefundable),
+			errors.Is(err, ErrRefundExceedsCaptured),
+			errors.Is(err, ErrPaymentProviderNotConfigured):
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, c)
+		case errors.Is(err, ErrRefundRejected):
+			return utils.ErrorResponse(http.StatusBadGateway, err, c)
+		default:
+			return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+		}
+	}
+
+	return utils.ResponseMessage(http.StatusAccepted, refund, c)
+}
+
+// ListPaymentRefunds lists every refund of a payment, oldest first
+func (s *ServicesHandler) ListPaymentRefunds(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.GetPaymentDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	ctx := c.Request().Context()
+	payment, err := s.paymentPort.GetPayment(ctx, dto.PaymentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("payment not found"), c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if err := s.authorizeCentreAccess(ctx, currentUser, payment.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+
+	refunds, err := s.paymentPort.ListPaymentRefunds(ctx, payment.ID)
+	if err != nil {
+		utils.Error("Failed to list payment refunds",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "payment_id", Value: payment.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if refunds == nil {
+		refunds = []*db.PaymentRefund{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, refunds, c)
+}
+
+// requestRefund records a pending refund and asks the payment's provider to execute it. When
+// the provider does not accept it the refund is marked failed and ErrRefundRejected is returned.
+// Refunds to the wallet or handed back by the centre are settled straight away instead.
+func (s *ServicesHandler) requestRefund(
+	ctx context.Context,
+	payment *db.Payment,
+	amount float64,
+	reason string,
+	requestedBy string,
+	destination db.RefundDestination,
+) (*db.PaymentRefund, error) {
+	if destination == db.RefundDestinationProvider {
+		if _, err := s.paymentProvider(payment.PaymentProvider); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	refund, err := s.createRefund(ctx, tx, payment.ID, amount, reason, requestedBy, destination)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit refund: %w", err)
+	}
+
+	if refund.Destination != db.RefundDestinationProvider {
+		return refund, nil
+	}
+	return s.sendRefund(ctx, payment, refund)
+}
+
+// createRefund records a refund of a payment within tx once the payment is known to have enough
+// left to refund, and links it to the appointments it pays back. A provider refund is left
+// pending for sendRefund once tx commits and takes no more than the provider charged; the part
+// of it the wallet paid for is credited back to the wallet instead. A refund into the patient's
+// wallet or handed back by the centre that collected the payment at its counter is processed at
+// once.
+func (s *ServicesHandler) createRefund(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	paymentID string,
+	amount float64,
+	reason string,
+	requestedBy string,
+	destination db.RefundDestination,
+	appointmentIDs ...string,
+) (*db.PaymentRefund, error) {
+	payment, totals, err := lockRefundablePayment(ctx, tx, paymentID, amount)
+	if err != nil {
+		return nil, err
+	}
+	if destination != db.RefundDestinationProvider {
+		return s.openRefund(ctx, tx, payment, amount, reason, requestedBy, destination, appointmentIDs...)
+	}
+
+	charged, err := providerChargeAmount(payment)
+	if err != nil {
+		return nil, err
+	}
+	providerCommitted, err := numericToFloat(totals.ProviderCommitted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse refunded amount: %w", err)
+	}
+	providerAmount, walletAmount := splitProviderRefund(amount, charged, providerCommitted)
+	if providerAmount == 0 {
+		return s.openRefund(ctx, tx, payment, walletAmount, reason, requestedBy, db.RefundDestinationWallet, appointmentIDs...)
+	}
+	// The appointments count as refunded once the provider's part is processed
+	if walletAmount > 0 {
+		if _, err := s.openRefund(ctx, tx, payment, walletAmount, reason, requestedBy, db.RefundDestinationWallet); err != nil {
+			return nil, err
+		}
+	}
+	return s.openRefund(ctx, tx, payment, providerAmount, reason, requestedBy, destination, appointmentIDs...)
+}
+
+// openRefund records a refund of payment that createRefund has checked and sized, as described
+// there
+func (s *ServicesHandler) openRefund(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	payment *db.Payment,
+	amount float64,
+	reason string,
+	requestedBy string,
+	destination db.RefundDestination,
+	appointmentIDs ...string,
+) (*db.PaymentRefund, error) {
+	refund, err := tx.CreatePaymentRefund(ctx, db.Create_Payment_RefundParams{
+		PaymentID:       payment.ID,
+		PaymentProvider: payment.PaymentProvider,
+		Amount:          toNumeric(amount),
+		Currency:        payment.Currency,
+		Reason:          reason,
+		Status:          db.PaymentRefundStatusPending,
+		RequestedBy:     toUUID(requestedBy),
+		Destination:     destination,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refund: %w", err)
+	}
+	for _, appointmentID := range appointmentIDs {
+		if err := tx.CreateAppointmentRefund(ctx, db.Create_Appointment_RefundParams{
+			RefundID:      refund.ID,
+			AppointmentID: appointmentID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to link refund to appointment: %w", err)
+		}
+	}
+
+	if destination == db.RefundDestinationProvider {
+		payload, err := utils.MarshalJSONField(refund)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal refund: %w", err)
+		}
+		if _, err := s.applyPaymentEvent(ctx, tx, payment, paymentEvent{
+			Source:  db.PaymentEventSourceInternal,
+			Type:    PaymentEventRefundRequested,
+			Amount:  refund.Amount,
+			Payload: payload,
+			ActorID: requestedBy,
+		}); err != nil {
+			return nil, err
+		}
+		return refund, nil
+	}
+
+	var payload []byte
+	if destination == db.RefundDestinationWallet {
+		credit, err := creditWallet(
+			ctx,
+			tx,
+			payment,
+			amount,
+			db.WalletTransactionKindRefund,
+			refund.ID,
+			reason,
+			requestedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if payload, err = utils.MarshalJSONField(credit); err != nil {
+			return nil, fmt.Errorf("failed to marshal wallet credit: %w", err)
+		}
+	}
+
+	settled, err := tx.SettlePaymentRefund(ctx, db.Settle_Payment_RefundParams{
+		ID:     refund.ID,
+		Status: db.PaymentRefundStatusProcessed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle refund: %w", err)
+	}
+	if err := s.projectRefund(ctx, tx, payment, settled, refundOutcome{
+		Status:  db.PaymentRefundStatusProcessed,
+		Source:  db.PaymentEventSourceInternal,
+		Type:    PaymentEventRefundProcessed,
+		Payload: payload,
+		ActorID: requestedBy,
+	}); err != nil {
+		return nil, err
+	}
+	return settled, nil
+}
+
+// sendRefund asks the payment's provider to execute a pending refund that has not been sent.
+// The refund is claimed first, so it reaches the provider at most once: one left unclaimed,
+// because the provider is not configured or the claim failed, is sent again by
+// sendUnsentRefunds. When the provider does not accept it the refund is marked failed and
+// ErrRefundRejected is returned.
+func (s *ServicesHandler) sendRefund(
+	ctx context.Context,
+	payment *db.Payment,
+	refund *db.PaymentRefund,
+) (*db.PaymentRefund, error) {
+	provider, err := s.paymentProvider(payment.PaymentProvider)
+	if err != nil {
+		return refund, err
+	}
+	claimed, err := s.paymentPort.MarkPaymentRefundSent(ctx, refund.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Sent by another run, or settled already
+		return refund, nil
+	}
+	if err != nil {
+		return refund, fmt.Errorf("failed to claim refund: %w", err)
+	}
+	refund = claimed
+	requestedBy := uuidString(refund.RequestedBy)
+
+	amount, err := numericToFloat(refund.Amount)
+	if err != nil {
+		return refund, fmt.Errorf("failed to parse refund amount: %w", err)
+	}
+
+	// Refunds are not idempotent at the provider, so they are never retried here
+	result, err := provider.RefundTransaction(paymentProviderReference(payment), amount)
+	if err != nil {
+		failed, settleErr := s.settleRefund(ctx, refund, refundOutcome{
+			Status:        db.PaymentRefundStatusFailed,
+			FailureReason: err.Error(),
+			Source:        db.PaymentEventSourceInternal,
+			Type:          PaymentEventRefundFailed,
+			ActorID:       requestedBy,
+		})
+		if settleErr != nil {
+			utils.Error("Failed to mark refund failed",
+				utils.LogField{Key: "error", Value: settleErr.Error()},
+				utils.LogField{Key: "refund_id", Value: refund.ID})
+		}
+		if failed != nil {
+			refund = failed
+		}
+		return refund, fmt.Errorf("%w: %v", ErrRefundRejected, err)
+	}
+
+	outcome := refundOutcome{
+		Status:           db.PaymentRefundStatusPending,
+		ProviderRefundID: result.RefundID,
+		Source:           db.PaymentEventSourceInternal,
+		ActorID:          requestedBy,
+	}
+	switch result.Status {
+	case domain.RefundStatusProcessed:
+		outcome.Status, outcome.Type = db.PaymentRefundStatusProcessed, PaymentEventRefundProcessed
+	case domain.RefundStatusFailed:
+		outcome.Status, outcome.Type = db.PaymentRefundStatusFailed, PaymentEventRefundFailed
+	}
+	settled, err := s.settleRefund(ctx, refund, outcome)
+	if errors.Is(err, ErrRefundSettled) {
+		// The provider's webhook settled the refund before its response was stored
+		return refund, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if settled.Status == db.PaymentRefundStatusFailed {
+		return settled, fmt.Errorf("%w: refund %s failed", ErrRefundRejected, result.RefundID)
+	}
+	return settled, nil
+}
+
+// lockRefundablePayment locks a payment within tx once it is known to have enough left to refund
+// amount, and returns it with the totals of its refunds
+func lockRefundablePayment(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	paymentID string,
+	amount float64,
+) (*db.Payment, *db.Sum_Payment_RefundsRow, error) {
+	payment, err := tx.LockPayment(ctx, paymentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lock payment: %w", err)
+	}
+	if payment.PaymentStatus != db.PaymentStatusSuccess {
+		return nil, nil, fmt.Errorf("%w: payment is %s", ErrPaymentNotRefundable, payment.PaymentStatus)
+	}
+	totals, err := tx.SumPaymentRefunds(ctx, payment.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to total payment refunds: %w", err)
+	}
+	captured, err := numericToFloat(payment.Amount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse payment amount: %w", err)
+	}
+	committed, err := numericToFloat(totals.Committed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse refunded amount: %w", err)
+	}
+	if err := checkRefundAmount(amount, captured, committed); err != nil {
+		return nil, nil, fmt.Errorf("%w %s", err, payment.Currency)
+	}
+	return payment, totals, nil
+}
+
+// settleRefund applies what a provider reported about a pending refund. A refund that stays
+// pending only gains its provider refund ID; a processed refund adds to the payment's refunded
+// total and moves the payment to refunded once the whole amount is refunded.
+func (s *ServicesHandler) settleRefund(
+	ctx context.Context,
+	refund *db.PaymentRefund,
+	outcome refundOutcome,
+) (*db.PaymentRefund, error) {
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	payment, err := tx.LockPayment(ctx, refund.PaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock payment: %w", err)
+	}
+	settled, err := tx.SettlePaymentRefund(ctx, db.Settle_Payment_RefundParams{
+		ID:               refund.ID,
+		Status:           outcome.Status,
+		ProviderRefundID: toText(outcome.ProviderRefundID),
+		FailureReason:    toText(outcome.FailureReason),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrRefundSettled, refund.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle refund: %w", err)
+	}
+
+	if settled.Status != db.PaymentRefundStatusPending {
+		if err := s.projectRefund(ctx, tx, payment, settled, outcome); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit refund: %w", err)
+	}
+	return settled, nil
+}
+
+// projectRefund records a settled refund in the payment's ledger and, once processed, projects
+// the refunded total onto the payment and marks the appointments it pays back refunded within tx
+func (s *ServicesHandler) projectRefund(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	payment *db.Payment,
+	refund *db.PaymentRefund,
+	outcome refundOutcome,
+) error {
+	event := paymentEvent{
+		Source:          outcome.Source,
+		Type:            outcome.Type,
+		ProviderEventID: outcome.ProviderEventID,
+		Amount:          refund.Amount,
+		Payload:         outcome.Payload,
+		ActorID:         outcome.ActorID,
+	}
+	if refund.Status != db.PaymentRefundStatusProcessed {
+		_, err := s.applyPaymentEvent(ctx, tx, payment, event)
+		return err
+	}
+	if err := tx.MarkRefundedAppointments(ctx, refund.ID); err != nil {
+		return fmt.Errorf("failed to mark appointments refunded: %w", err)
+	}
+
+	totals, err := tx.SumPaymentRefunds(ctx, payment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to total payment refunds: %w", err)
+	}
+	captured, err := numericToFloat(payment.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to parse payment amount: %w", err)
+	}
+	processed, err := numericToFloat(totals.Processed)
+	if err != nil {
+		return fmt.Errorf("failed to parse refunded amount: %w", err)
+	}
+
+	event.RefundAmount = totals.Processed
+	event.RefundReason = refund.Reason
+	event.RefundedBy = uuidString(refund.RequestedBy)
+	if fullyRefunded(captured, processed) {
+		event.To = db.PaymentStatusRefunded
+	}
+	if _, err := s.applyPaymentEvent(ctx, tx, payment, event); err != nil {
+		return err
+	}
+	if event.To == db.PaymentStatusRefunded {
+		return nil
+	}
+
+	if _, err := tx.ProjectPaymentRefund(ctx, db.Project_Payment_RefundParams{
+		ID:           payment.ID,
+		RefundAmount: totals.Processed,
+		RefundReason: toText(refund.Reason),
+		RefundedBy:   refund.RequestedBy,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPaymentStatusChanged
+		}
+		return fmt.Errorf("failed to project payment refund: %w", err)
+	}
+	return nil
+}
+
+// pollPendingRefunds asks the providers that report no refund outcomes by webhook after the
+// refunds still pending with them, and settles the ones that have since processed or failed.
+// Refunds at providers that do report them, Paystack, are left to the webhook.
+func (s *ServicesHandler) pollPendingRefunds(ctx context.Context) {
+	refunds, err := s.paymentPort.ListPendingProviderRefunds(ctx, db.List_Pending_Provider_RefundsParams{
+		CreatedAt: toTimestamptz(time.Now().UTC().Add(-refundPollDelay)),
+		Limit:     refundPollBatchSize,
+	})
+	if err != nil {
+		utils.Error("Failed to list pending refunds",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return
+	}
+
+	settled := 0
+	for _, refund := range refunds {
+		provider, err := s.paymentProvider(refund.PaymentProvider)
+		if err != nil {
+			continue
+		}
+		statusPort, ok := provider.(ports.RefundStatusService)
+		if !ok {
+			continue
+		}
+
+		result, err := statusPort.GetRefund(refund.ProviderRefundID.String)
+		if err != nil {
+			utils.Warn("Failed to get refund status",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "refund_id", Value: refund.ID})
+			continue
+		}
+
+		outcome := refundOutcome{
+			ProviderRefundID: refund.ProviderRefundID.String,
+			Source:           db.PaymentEventSourceVerification,
+		}
+		switch result.Status {
+		case domain.RefundStatusProcessed:
+			outcome.Status, outcome.Type = db.PaymentRefundStatusProcessed, PaymentEventRefundProcessed
+		case domain.RefundStatusFailed:
+			outcome.Status, outcome.Type = db.PaymentRefundStatusFailed, PaymentEventRefundFailed
+			outcome.FailureReason = "refund failed at provider"
+		default:
+			continue
+		}
+		outcome.ProviderEventID = fmt.Sprintf("refund:%s:%s", refund.ProviderRefundID.String, outcome.Status)
+
+		if _, err := s.settleRefund(ctx, refund, outcome); err != nil && !errors.Is(err, ErrRefundSettled) {
+			utils.Error("Failed to settle polled refund",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "refund_id", Value: refund.ID})
+			continue
+		}
+		settled++
+	}
+
+	if len(refunds) > 0 {
+		utils.Info("Pending refunds polled",
+			utils.LogField{Key: "refunds", Value: len(refunds)},
+			utils.LogField{Key: "settled", Value: settled})
+	}
+}
+
+// providerRefund resolves the refund a provider event reports. Events that arrive before the
+// provider's refund ID was stored are matched to the oldest pending refund of the same amount.
+// Refunds started outside the platform, from the provider's dashboard, are recorded as they
+// are reported. Returns nil when a refund unknown to the platform did not go through.
+func (s *ServicesHandler) providerRefund(
+	ctx context.Context,
+	payment *db.Payment,
+	providerRefundID string,
+	amount float64,
+	reason string,
+	status db.PaymentRefundStatus,
+) (*db.PaymentRefund, error) {
+	if providerRefundID != "" {
+		refund, err := s.paymentPort.GetPaymentRefundByProviderID(ctx, db.Get_Payment_Refund_By_Provider_IDParams{
+			PaymentProvider:  payment.PaymentProvider,
+			ProviderRefundID: toText(providerRefundID),
+		})
+		if err == nil {
+			return refund, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get refund: %w", err)
+		}
+	}
+
+	refund, err := s.paymentPort.GetUnmatchedPaymentRefund(ctx, db.Get_Unmatched_Payment_RefundParams{
+		PaymentID: payment.ID,
+		Amount:    toNumeric(amount),
+	})
+	if err == nil {
+		return refund, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get refund: %w", err)
+	}
+	if status != db.PaymentRefundStatusProcessed {
+		return nil, nil
+	}
+
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	refund, err = tx.CreatePaymentRefund(ctx, db.Create_Payment_RefundParams{
+		PaymentID:        payment.ID,
+		PaymentProvider:  payment.PaymentProvider,
+		Amount:           toNumeric(amount),
+		Currency:         payment.Currency,
+		Reason:           reason,
+		Status:           db.PaymentRefundStatusPending,
+		ProviderRefundID: toText(providerRefundID),
+		Destination:      db.RefundDestinationProvider,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record provider refund: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit provider refund: %w", err)
+	}
+	utils.Warn("Recorded refund started at the payment provider",
+		utils.LogField{Key: "payment_id", Value: payment.ID},
+		utils.LogField{Key: "provider_refund_id", Value: providerRefundID})
+	return refund, nil
+}
+
+// checkRefundAmount fails when amount is more than what is left of captured once the refunds
+// already committed against it are taken out
+func checkRefundAmount(amount, captured, committed float64) error {
+	remaining := roundAmount(captured - committed)
+	if math.Round(amount*100) > math.Round(remaining*100) {
+		return fmt.Errorf("%w: %.2f requested, %.2f left", ErrRefundExceedsCaptured, amount, remaining)
+	}
+	return nil
+}
+
+// splitProviderRefund splits a provider refund of amount into what the provider can give back,
+// no more than it charged once its committed refunds are taken out, and the rest, which the
+// wallet paid for
+func splitProviderRefund(amount, charged, committed float64) (provider, wallet float64) {
+	provider = math.Min(amount, math.Max(roundAmount(charged-committed), 0))
+	return provider, roundAmount(amount - provider)
+}
+
+// fullyRefunded reports whether the processed refunds cover the captured amount
+func fullyRefunded(captured, processed float64) bool {
+	return math.Round(processed*100) >= math.Round(captured*100)
+}
+
+// paymentProviderReference is the reference a payment is known by at its provider
+func paymentProviderReference(payment *db.Payment) string {
+	if payment.ProviderReference.String != "" {
+		return payment.ProviderReference.String
+	}
+	return payment.TransactionID.String
+}
+
+// sendUnsentRefunds sends the provider refunds that were opened but never reached the
+// provider, such as a cancellation refund whose instance stopped after the cancellation
+// committed or whose provider was not configured at the time
+func (s *ServicesHandler) sendUnsentRefunds(ctx context.Context) {
+	refunds, err := s.paymentPort.ListUnsentProviderRefunds(ctx, db.List_Unsent_Provider_RefundsParams{
+		CreatedAt: toTimestamptz(time.Now().UTC().Add(-refundSendDelay)),
+		Limit:     refundPollBatchSize,
+	})
+	if err != nil {
+		utils.Error("Failed to list unsent refunds",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return
+	}
+
+	for _, refund := range refunds {
+		payment, err := s.paymentPort.GetPayment(ctx, refund.PaymentID)
+		if err != nil {
+			utils.Error("Failed to get payment of unsent refund",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "refund_id", Value: refund.ID})
+			continue
+		}
+		if _, err := s.sendRefund(ctx, payment, refund); err != nil {
+			utils.Warn("Failed to send refund",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "refund_id", Value: refund.ID})
+		}
+	}
+}
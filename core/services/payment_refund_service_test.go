@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func TestCheckRefundAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    float64
+		captured  float64
+		committed float64
+		wantErr   bool
+	}{
+		{name: "full refund", amount: 5000, captured: 5000},
+		{name: "partial refund", amount: 1500.50, captured: 5000, committed: 2000},
+		{name: "remainder to the kobo", amount: 0.1, captured: 0.3, committed: 0.2},
+		{name: "exceeds captured", amount: 5000.01, captured: 5000, wantErr: true},
+		{name: "exceeds what is left", amount: 3000, captured: 5000, committed: 2500, wantErr: true},
+		{name: "fully refunded", amount: 1, captured: 5000, committed: 5000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRefundAmount(tt.amount, tt.captured, tt.committed)
+			if tt.wantErr != errors.Is(err, ErrRefundExceedsCaptured) {
+				t.Errorf("error = %v; want exceeds captured %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitProviderRefund(t *testing.T) {
+	tests := []struct {
+		name                       string
+		amount, charged, committed float64
+		wantProvider, wantWallet   float64
+	}{
+		{name: "within the provider share", amount: 8000, charged: 10000, wantProvider: 8000},
+		{name: "beyond the provider share", amount: 15000, charged: 10000, wantProvider: 10000, wantWallet: 5000},
+		{name: "provider share partly refunded", amount: 6000, charged: 10000, committed: 7000, wantProvider: 3000, wantWallet: 3000},
+		{name: "provider share refunded", amount: 2000, charged: 10000, committed: 10000, wantWallet: 2000},
+		{name: "paid from the wallet", amount: 5000, wantWallet: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, wallet := splitProviderRefund(tt.amount, tt.charged, tt.committed)
+			if provider != tt.wantProvider || wallet != tt.wantWallet {
+				t.Errorf("splitProviderRefund() = %v, %v; want %v, %v", provider, wallet, tt.wantProvider, tt.wantWallet)
+			}
+		})
+	}
+}
+
+func TestFullyRefunded(t *testing.T) {
+	tests := []struct {
+		captured  float64
+		processed float64
+		want      bool
+	}{
+		{captured: 5000, processed: 5000, want: true},
+		{captured: 0.3, processed: 0.1 + 0.2, want: true},
+		{captured: 5000, processed: 4999.99},
+		{captured: 5000},
+	}
+
+	for _, tt := range tests {
+		if got := fullyRefunded(tt.captured, tt.processed); got != tt.want {
+			t.Errorf("fullyRefunded(%.2f, %.2f) = %v; want %v", tt.captured, tt.processed, got, tt.want)
+		}
+	}
+}
+
+type refundStatusProvider struct {
+	ports.PaymentProviderService
+	statuses map[string]domain.RefundStatus
+}
+
+func (p *refundStatusProvider) GetRefund(refundID string) (*domain.TransactionRefund, error) {
+	return &domain.TransactionRefund{RefundID: refundID, Status: p.statuses[refundID]}, nil
+}
+
+type pendingRefunds struct {
+	ports.PaymentRepository
+	refunds []*db.PaymentRefund
+}
+
+func (r *pendingRefunds) ListPendingProviderRefunds(ctx context.Context, arg db.List_Pending_Provider_RefundsParams) ([]*db.PaymentRefund, error) {
+	return r.refunds, nil
+}
+
+// settlingAppointments records the refunds settled, failing each settlement once it is counted
+type settlingAppointments struct {
+	ports.AppointmentRepository
+	settlements int
+}
+
+func (r *settlingAppointments) BeginTx(ctx context.Context) (ports.AppointmentTx, error) {
+	r.settlements++
+	return nil, errors.New("no database")
+}
+
+func TestPollPendingRefunds(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	refund := func(provider db.PaymentProvider, providerRefundID string) *db.PaymentRefund {
+		return &db.PaymentRefund{
+			ID:               "refund-" + providerRefundID,
+			PaymentProvider:  provider,
+			Status:           db.PaymentRefundStatusPending,
+			ProviderRefundID: pgtype.Text{String: providerRefundID, Valid: true},
+		}
+	}
+	appointments := &settlingAppointments{}
+	service := &ServicesHandler{
+		appointmentPort: appointments,
+		paymentPort: &pendingRefunds{refunds: []*db.PaymentRefund{
+			refund(db.PaymentProviderSTRIPE, "re_processed"),
+			refund(db.PaymentProviderSTRIPE, "re_pending"),
+			refund(db.PaymentProviderSTRIPE, "re_failed"),
+			// Paystack reports refunds by webhook, so it is never asked
+			refund(db.PaymentProviderPAYSTACK, "1234"),
+		}},
+		paymentProviders: map[db.PaymentProvider]ports.PaymentProviderService{
+			db.PaymentProviderSTRIPE: &refundStatusProvider{statuses: map[string]domain.RefundStatus{
+				"re_processed": domain.RefundStatusProcessed,
+				"re_pending":   domain.RefundStatusPending,
+				"re_failed":    domain.RefundStatusFailed,
+			}},
+			db.PaymentProviderPAYSTACK: &expiryProvider{},
+		},
+	}
+
+	service.pollPendingRefunds(context.Background())
+
+	if appointments.settlements != 2 {
+		t.Errorf("settled %d refunds; want the processed and failed 2", appointments.settlements)
+	}
+}
+
+// unsentRefunds serves refunds that never reached their provider and records those claimed
+// for sending, reporting each as sent already so no provider is called
+type unsentRefunds struct {
+	ports.PaymentRepository
+	refunds []*db.PaymentRefund
+	claimed []string
+}
+
+func (r *unsentRefunds) ListUnsentProviderRefunds(ctx context.Context, arg db.List_Unsent_Provider_RefundsParams) ([]*db.PaymentRefund, error) {
+	return r.refunds, nil
+}
+
+func (r *unsentRefunds) GetPayment(ctx context.Context, id string) (*db.Payment, error) {
+	for _, refund := range r.refunds {
+		if refund.PaymentID == id {
+			return &db.Payment{ID: id, PaymentProvider: refund.PaymentProvider}, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (r *unsentRefunds) MarkPaymentRefundSent(ctx context.Context, id string) (*db.PaymentRefund, error) {
+	r.claimed = append(r.claimed, id)
+	return nil, pgx.ErrNoRows
+}
+
+func TestSendUnsentRefunds(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	payments := &unsentRefunds{refunds: []*db.PaymentRefund{
+		{ID: "refund-stripe", PaymentID: "pay-stripe", PaymentProvider: db.PaymentProviderSTRIPE},
+		// A provider that is not configured leaves its refund unsent for a later run
+		{ID: "refund-monnify", PaymentID: "pay-monnify", PaymentProvider: db.PaymentProviderMONNIFY},
+	}}
+	service := &ServicesHandler{
+		paymentPort: payments,
+		paymentProviders: map[db.PaymentProvider]ports.PaymentProviderService{
+			db.PaymentProviderSTRIPE: &expiryProvider{},
+		},
+	}
+
+	service.sendUnsentRefunds(context.Background())
+
+	if len(payments.claimed) != 1 || payments.claimed[0] != "refund-stripe" {
+		t.Errorf("claimed %v; want [refund-stripe]", payments.claimed)
+	}
+}
+
+func TestSendCancellationRefund(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	service := &ServicesHandler{}
+	payment := &db.Payment{ID: "pay-1"}
+
+	if got := service.sendCancellationRefund(context.Background(), payment, nil); got != domain.RefundStatusFailed {
+		t.Errorf("without a refund = %v; want %v", got, domain.RefundStatusFailed)
+	}
+	wallet := &db.PaymentRefund{ID: "refund-1", Status: db.PaymentRefundStatusProcessed, Destination: db.RefundDestinationWallet}
+	if got := service.sendCancellationRefund(context.Background(), payment, wallet); got != domain.RefundStatusProcessed {
+		t.Errorf("wallet refund = %v; want %v", got, domain.RefundStatusProcessed)
+	}
+	// The provider is not configured, so the refund stays pending for the job to send
+	provider := &db.PaymentRefund{ID: "refund-2", Status: db.PaymentRefundStatusPending, Destination: db.RefundDestinationProvider}
+	if got := service.sendCancellationRefund(context.Background(), payment, provider); got != domain.RefundStatusPending {
+		t.Errorf("unsent provider refund = %v; want %v", got, domain.RefundStatusPending)
+	}
+}
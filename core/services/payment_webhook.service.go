@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/paystack"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrMalformedWebhookEvent   = errors.New("malformed webhook event")
+	ErrUnhandledWebhookEvent   = errors.New("webhook event is not handled")
+	ErrWebhookPaymentNotFound  = errors.New("no payment matches the webhook reference")
+	ErrWebhookAmountMismatch   = errors.New("charged amount does not match the payment")
+)
+
+// HandlePaystackWebhook verifies a Paystack webhook signature and applies the event to its payment,
+// or to the settlement a transfer pays out. Events that cannot apply (unknown references, stale
+// transitions) are acknowledged so Paystack stops retrying them; only internal failures are
+// answered with an error status.
+func (s *ServicesHandler) HandlePaystackWebhook(c echo.Context) error {
+	// The body validation middleware restores the raw body after binding it
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, c)
+	}
+	if !paystack.ValidSignature(s.Config.PAYSTACK_SECRET_KEY, body, c.Request().Header.Get(paystack.SignatureHeader)) {
+		utils.Warn("Rejected Paystack webhook with invalid signature",
+			utils.LogField{Key: "remote_ip", Value: c.RealIP()})
+		return utils.ErrorResponse(http.StatusUnauthorized, ErrInvalidWebhookSignature, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.PaystackWebhookDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	status := "processed"
+	if err := s.routePaystackEvent(c.Request().Context(), dto); err != nil {
+		var pErr *PaymentError
+		switch {
+		case errors.Is(err, ErrDuplicatePaymentEvent),
+			errors.Is(err, ErrRefundSettled),
+			errors.Is(err, ErrSettlementPayoutSettled):
+			status = "duplicate"
+		case errors.Is(err, ErrUnhandledWebhookEvent),
+			errors.Is(err, ErrWebhookPaymentNotFound),
+			errors.Is(err, ErrWebhookSettlementNotFound),
+			errors.As(err, &pErr) && pErr.Code == ErrCodeInvalidStatus:
+			utils.Warn("Ignoring Paystack webhook event",
+				utils.LogField{Key: "event", Value: dto.Event},
+				utils.LogField{Key: "reason", Value: err.Error()})
+			status = "ignored"
+		case errors.Is(err, ErrWebhookAmountMismatch), errors.Is(err, ErrMalformedWebhookEvent):
+			utils.Error("Rejected Paystack webhook event",
+				utils.LogField{Key: "event", Value: dto.Event},
+				utils.LogField{Key: "error", Value: err.Error()})
+			status = "rejected"
+		default:
+			utils.Error("Failed to process Paystack webhook event",
+				utils.LogField{Key: "event", Value: dto.Event},
+				utils.LogField{Key: "error", Value: err.Error()})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+		}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, map[string]string{
+		"event":  dto.Event,
+		"status": status,
+	}, c)
+}
+
+// routePaystackEvent dispatches a webhook event to the handler for its type
+func (s *ServicesHandler) routePaystackEvent(ctx context.Context, event *domain.PaystackWebhookDTO) error {
+	switch event.Event {
+	case domain.PaystackEventChargeSuccess:
+		var charge domain.PaystackChargeEvent
+		if err := json.Unmarshal(event.Data, &charge); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedWebhookEvent, err)
+		}
+		return s.applyPaystackCharge(ctx, &charge, event.Data)
+	case domain.PaystackEventRefundProcessed, domain.PaystackEventRefundFailed:
+		var refund domain.PaystackRefundEvent
+		if err := json.Unmarshal(event.Data, &refund); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedWebhookEvent, err)
+		}
+		return s.applyPaystackRefund(ctx, event.Event, &refund, event.Data)
+	case domain.PaystackEventTransferSuccess, domain.PaystackEventTransferFailed, domain.PaystackEventTransferReversed:
+		var transfer domain.PaystackTransferEvent
+		if err := json.Unmarshal(event.Data, &transfer); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedWebhookEvent, err)
+		}
+		return s.applyPaystackTransfer(ctx, event.Event, &transfer)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnhandledWebhookEvent, event.Event)
+	}
+}
+
+// applyPaystackCharge marks a payment successful and confirms the appointments it settles
+func (s *ServicesHandler) applyPaystackCharge(ctx context.Context, charge *domain.PaystackChargeEvent, raw json.RawMessage) error {
+	payment, err := s.webhookPayment(ctx, charge.Reference)
+	if err != nil {
+		return err
+	}
+	if err := paystackAmountMatches(payment, charge.Amount, charge.Currency); err != nil {
+		return err
+	}
+
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	updated, eventErr := s.applyPaymentEvent(ctx, tx, payment, paymentEvent{
+		Source:          db.PaymentEventSourceWebhook,
+		Type:            domain.PaystackEventChargeSuccess,
+		ProviderEventID: chargeEventID(strconv.FormatInt(charge.ID, 10)),
+		To:              db.PaymentStatusSuccess,
+		Amount:          toNumeric(float64(charge.Amount) / 100),
+		TransactionID:   charge.Reference,
+		Payload:         raw,
+	})
+	// A charge already applied through the verification API may still have appointments
+	// waiting on it, so a duplicate goes on to confirm them
+	duplicate := errors.Is(eventErr, ErrDuplicatePaymentEvent) && payment.PaymentStatus == db.PaymentStatusSuccess
+	if eventErr != nil && !duplicate {
+		return eventErr
+	}
+	if duplicate {
+		updated = payment
+	}
+
+	// Appointments the patient already confirmed are no longer pending and are left alone
+	confirmed, err := tx.ConfirmAppointmentsByPayment(ctx, toUUID(updated.ID))
+	if err != nil {
+		return fmt.Errorf("failed to confirm appointments: %w", err)
+	}
+	notifications, err := s.dispatchAppointmentConfirmations(ctx, tx, confirmed)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	pushNotifications(ctx, notifications)
+
+	go s.sendPaymentReceiptEmail(updated.ID)
+
+	utils.Info("Paystack charge applied",
+		utils.LogField{Key: "payment_id", Value: payment.ID},
+		utils.LogField{Key: "appointments_confirmed", Value: len(confirmed)})
+	return eventErr
+}
+
+// applyPaystackRefund settles the refund a refund.processed or refund.failed event reports
+func (s *ServicesHandler) applyPaystackRefund(
+	ctx context.Context,
+	eventType string,
+	event *domain.PaystackRefundEvent,
+	raw json.RawMessage,
+) error {
+	payment, err := s.webhookPayment(ctx, event.TransactionReference)
+	if err != nil {
+		return err
+	}
+
+	status := db.PaymentRefundStatusProcessed
+	if eventType == domain.PaystackEventRefundFailed {
+		status = db.PaymentRefundStatusFailed
+	}
+	reason := event.MerchantNote
+	if reason == "" {
+		reason = "Refund processed by Paystack"
+	}
+	var providerRefundID string
+	if event.ID != 0 {
+		providerRefundID = strconv.FormatInt(event.ID, 10)
+	}
+
+	refund, err := s.providerRefund(ctx, payment, providerRefundID, float64(event.Amount)/100, reason, status)
+	if err != nil {
+		return err
+	}
+	if refund == nil {
+		return fmt.Errorf("%w: refund %s", ErrUnhandledWebhookEvent, providerRefundID)
+	}
+
+	outcome := refundOutcome{
+		Status:           status,
+		ProviderRefundID: providerRefundID,
+		Source:           db.PaymentEventSourceWebhook,
+		Type:             eventType,
+		ProviderEventID:  paystackObjectEventID("refund", event.ID, event.Status),
+		Payload:          raw,
+	}
+	if status == db.PaymentRefundStatusFailed {
+		outcome.FailureReason = event.Status
+	}
+	if _, err := s.settleRefund(ctx, refund, outcome); err != nil {
+		return err
+	}
+
+	utils.Info("Paystack refund applied",
+		utils.LogField{Key: "payment_id", Value: payment.ID},
+		utils.LogField{Key: "refund_id", Value: refund.ID},
+		utils.LogField{Key: "status", Value: status})
+	return nil
+}
+
+// webhookPayment resolves the payment a webhook event refers to by its provider reference
+func (s *ServicesHandler) webhookPayment(ctx context.Context, reference string) (*db.Payment, error) {
+	if reference == "" {
+		return nil, fmt.Errorf("%w: missing reference", ErrMalformedWebhookEvent)
+	}
+	payment, err := s.paymentPort.GetPaymentByReference(ctx, reference)
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, utils.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrWebhookPaymentNotFound, reference)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment by reference: %w", err)
+	}
+	return payment, nil
+}
+
+// paystackAmountMatches checks a charge, in the currency's minor unit, against the part of the
+// payment it settles that was charged through Paystack
+func paystackAmountMatches(payment *db.Payment, minorAmount int64, currency string) error {
+	return chargeAmountMatches(payment, float64(minorAmount)/100, currency)
+}
+
+// chargeAmountMatches checks a charge any provider reports, in the currency's major unit,
+// against the part of the payment it settles that was charged through the provider
+func chargeAmountMatches(payment *db.Payment, charged float64, currency string) error {
+	amount, err := providerChargeAmount(payment)
+	if err != nil {
+		return fmt.Errorf("failed to parse payment amount: %w", err)
+	}
+	if math.Round(amount*100) != math.Round(charged*100) || !strings.EqualFold(payment.Currency, currency) {
+		return fmt.Errorf("%w: charged %.2f %s for %.2f %s",
+			ErrWebhookAmountMismatch, charged, currency, amount, payment.Currency)
+	}
+	return nil
+}
+
+// paystackObjectEventID keys a Paystack refund event in the ledger by the object it concerns
+// and the status it reports
+func paystackObjectEventID(object string, id int64, status string) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d:%s", object, id, status)
+}
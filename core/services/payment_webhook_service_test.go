@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/paystack"
+	"github.com/diagnoxix/core/domain"
+)
+
+func TestPaystackSignature(t *testing.T) {
+	body := []byte(`{"event":"charge.success","data":{"reference":"MED-1"}}`)
+	mac := hmac.New(sha512.New, []byte("sk_test_secret"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"valid", "sk_test_secret", body, signature, true},
+		{"wrong secret", "sk_test_other", body, signature, false},
+		{"tampered body", "sk_test_secret", []byte(`{"event":"charge.success","data":{"reference":"MED-2"}}`), signature, false},
+		{"missing signature", "sk_test_secret", body, "", false},
+		{"not hex", "sk_test_secret", body, "not-a-signature", false},
+		{"secret not configured", "", body, signature, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paystack.ValidSignature(tt.secret, tt.body, tt.signature); got != tt.want {
+				t.Errorf("ValidSignature = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaystackAmountMatches(t *testing.T) {
+	payment := &db.Payment{Amount: toNumeric(5100.5), Currency: "NGN"}
+
+	tests := []struct {
+		name     string
+		amount   int64
+		currency string
+		wantErr  error
+	}{
+		{"exact amount in kobo", 510050, "NGN", nil},
+		{"currency case ignored", 510050, "ngn", nil},
+		{"short payment", 510000, "NGN", ErrWebhookAmountMismatch},
+		{"other currency", 510050, "USD", ErrWebhookAmountMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := paystackAmountMatches(payment, tt.amount, tt.currency); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v; want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyVerifiedChargeRejectsMismatch(t *testing.T) {
+	payment := &db.Payment{ID: "payment-1", Amount: toNumeric(5100.5), Currency: "NGN"}
+	service := &ServicesHandler{}
+
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+	}{
+		{"short charge", 100, "NGN"},
+		{"other currency", 5100.5, "USD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mismatch is caught before the ledger is touched, so no repository is needed
+			_, err := service.applyVerifiedCharge(context.Background(), payment, &domain.TransactionVerification{
+				Reference: "ref-1",
+				Status:    domain.TransactionStatusSuccess,
+				Amount:    tt.amount,
+				Currency:  tt.currency,
+			}, nil)
+			var pErr *PaymentError
+			if !errors.As(err, &pErr) || pErr.Code != ErrCodeInvalidAmount {
+				t.Fatalf("error = %v; want a %s payment error", err, ErrCodeInvalidAmount)
+			}
+			if !errors.Is(err, ErrWebhookAmountMismatch) {
+				t.Errorf("error = %v; want %v", err, ErrWebhookAmountMismatch)
+			}
+		})
+	}
+}
+
+func TestRoutePaystackEventRejectsBeforeLookup(t *testing.T) {
+	service := &ServicesHandler{}
+
+	tests := []struct {
+		name    string
+		event   string
+		data    string
+		wantErr error
+	}{
+		{"unhandled event", "subscription.create", `{}`, ErrUnhandledWebhookEvent},
+		{"malformed data", domain.PaystackEventChargeSuccess, `[]`, ErrMalformedWebhookEvent},
+		{"missing reference", domain.PaystackEventRefundProcessed, `{"amount":1000}`, ErrMalformedWebhookEvent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.routePaystackEvent(context.Background(), &domain.PaystackWebhookDTO{
+				Event: tt.event,
+				Data:  json.RawMessage(tt.data),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v; want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// phoneCodeTTL is how long a verification code sent by SMS can be used
+	phoneCodeTTL = 10 * time.Minute
+	// phoneCodeMaxAttempts is how many times a code can be tried before a new one is needed
+	phoneCodeMaxAttempts = 5
+	// phoneCodeResendAfter is the least time between two codes sent to a number
+	phoneCodeResendAfter = time.Minute
+	// phoneCodesPerHour caps the codes sent to a number in any hour
+	phoneCodesPerHour = 5
+)
+
+var (
+	ErrPhoneCodeInvalid       = errors.New("invalid verification code")
+	ErrPhoneCodeExpired       = errors.New("verification code has expired, request a new one")
+	ErrPhoneCodeAttempts      = errors.New("too many attempts, request a new verification code")
+	ErrPhoneCodeThrottled     = errors.New("a verification code was sent recently, try again later")
+	ErrPhoneNotVerified       = errors.New("phone number has not been verified")
+	ErrPhoneAlreadyVerified   = errors.New("phone number is already verified")
+	ErrPhoneAlreadyRegistered = errors.New("phone number already registered")
+)
+
+// ResendPhoneVerification sends a new verification code to a phone number that has not been
+// verified yet
+func (service *ServicesHandler) ResendPhoneVerification(context echo.Context) error {
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.ResendPhoneVerificationDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New(utils.InvalidRequest), context)
+	}
+
+	user, err := service.userPort.GetUserByPhoneNumber(context.Request().Context(), dto.PhoneNumber)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("user not found"), context)
+		}
+		utils.Error("Failed to get user by phone number",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if user.PhoneVerifiedAt.Valid {
+		return utils.ErrorResponse(http.StatusConflict, ErrPhoneAlreadyVerified, context)
+	}
+
+	if err := service.sendPhoneCode(context.Request().Context(), user, time.Now()); err != nil {
+		utils.Error("Failed to resend phone verification code",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: user.ID})
+		return utils.ErrorResponse(phoneVerificationErrorStatus(err), err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, map[string]string{
+		"message": "Verification code sent to your phone",
+	}, context)
+}
+
+// sendPhoneCode sends a new verification code to the user's phone number, unless one was sent
+// too recently. Only a hash of the code is stored.
+func (service *ServicesHandler) sendPhoneCode(ctx context.Context, user *db.User, now time.Time) error {
+	phone := user.PhoneNumber.String
+	latest, err := service.userPort.GetLatestPhoneVerificationToken(ctx, phone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		latest, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	sent, err := service.userPort.CountPhoneVerificationTokensSince(ctx, db.CountPhoneVerificationTokensSinceParams{
+		PhoneNumber: phone,
+		CreatedAt:   toTimestamptz(now.Add(-time.Hour)),
+	})
+	if err != nil {
+		return err
+	}
+	if err := phoneCodeThrottle(latest, sent, now); err != nil {
+		return err
+	}
+
+	code, err := generatePhoneCode()
+	if err != nil {
+		return err
+	}
+	if _, err := service.userPort.CreatePhoneVerificationToken(ctx, db.CreatePhoneVerificationTokenParams{
+		UserID:      user.ID,
+		PhoneNumber: phone,
+		CodeHash:    hashPhoneCode(service.Config.JWT_KEY, phone, code),
+		ExpiresAt:   toTimestamptz(now.Add(phoneCodeTTL)),
+	}); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Your Diagnoxix verification code is %s. It expires in %d minutes.",
+		code, int(phoneCodeTTL.Minutes()))
+	return service.notificationPort.SendSMS(phone, message)
+}
+
+// verifyPhoneCode checks a code against the latest one sent to the phone number and, when it
+// matches, marks the number as verified. Every check counts as an attempt, so a code cannot be
+// guessed.
+func (service *ServicesHandler) verifyPhoneCode(ctx context.Context, phone, code string, now time.Time) error {
+	latest, err := service.userPort.GetLatestPhoneVerificationToken(ctx, phone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPhoneCodeInvalid
+		}
+		return err
+	}
+	if err := checkPhoneToken(latest, now); err != nil {
+		return err
+	}
+
+	token, err := service.userPort.IncrementPhoneVerificationAttempts(ctx, latest.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Consumed since it was read
+			return ErrPhoneCodeInvalid
+		}
+		return err
+	}
+	if err := checkPhoneToken(token, now); err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(token.CodeHash), []byte(hashPhoneCode(service.Config.JWT_KEY, phone, code))) {
+		return ErrPhoneCodeInvalid
+	}
+
+	consumed, err := service.userPort.ConsumePhoneVerificationToken(ctx, token.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrPhoneAlreadyRegistered
+		}
+		return err
+	}
+	if !consumed {
+		return ErrPhoneCodeInvalid
+	}
+	return nil
+}
+
+// checkPhoneToken reports why a stored code can no longer be used, if it cannot
+func checkPhoneToken(token *db.PhoneVerificationToken, now time.Time) error {
+	switch {
+	case token.ConsumedAt.Valid:
+		return ErrPhoneCodeInvalid
+	case !now.Before(token.ExpiresAt.Time):
+		return ErrPhoneCodeExpired
+	case token.Attempts > phoneCodeMaxAttempts:
+		return ErrPhoneCodeAttempts
+	}
+	return nil
+}
+
+// phoneCodeThrottle refuses a new code when the last one was sent less than a minute ago or
+// the hourly allowance of the number is used up
+func phoneCodeThrottle(latest *db.PhoneVerificationToken, sentInLastHour int64, now time.Time) error {
+	if latest != nil && now.Sub(latest.CreatedAt.Time) < phoneCodeResendAfter {
+		return ErrPhoneCodeThrottled
+	}
+	if sentInLastHour >= phoneCodesPerHour {
+		return ErrPhoneCodeThrottled
+	}
+	return nil
+}
+
+// generatePhoneCode returns a random six digit code
+func generatePhoneCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashPhoneCode keys the hash with the server secret, as six digits alone are quickly brute
+// forced from a leaked hash
+func hashPhoneCode(secret, phone, code string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(phone + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func phoneVerificationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrPhoneCodeInvalid), errors.Is(err, ErrPhoneCodeExpired):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrPhoneAlreadyRegistered), errors.Is(err, ErrPhoneAlreadyVerified):
+		return http.StatusConflict
+	case errors.Is(err, ErrPhoneCodeAttempts), errors.Is(err, ErrPhoneCodeThrottled):
+		return http.StatusTooManyRequests
+	case errors.Is(err, domain.ErrSMSUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+func TestPhoneCodeThrottle(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	sentAt := func(ago time.Duration) *db.PhoneVerificationToken {
+		return &db.PhoneVerificationToken{CreatedAt: toTimestamptz(now.Add(-ago))}
+	}
+
+	tests := []struct {
+		name   string
+		latest *db.PhoneVerificationToken
+		sent   int64
+		err    error
+	}{
+		{"first code", nil, 0, nil},
+		{"a while after the last", sentAt(2 * time.Minute), 1, nil},
+		{"right after the last", sentAt(30 * time.Second), 1, ErrPhoneCodeThrottled},
+		{"hourly allowance used", sentAt(5 * time.Minute), phoneCodesPerHour, ErrPhoneCodeThrottled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := phoneCodeThrottle(tt.latest, tt.sent, now); !errors.Is(err, tt.err) {
+				t.Errorf("phoneCodeThrottle() error = %v; want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckPhoneToken(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	token := func(edit func(*db.PhoneVerificationToken)) *db.PhoneVerificationToken {
+		tok := &db.PhoneVerificationToken{
+			Attempts:  1,
+			ExpiresAt: toTimestamptz(now.Add(5 * time.Minute)),
+		}
+		if edit != nil {
+			edit(tok)
+		}
+		return tok
+	}
+
+	tests := []struct {
+		name  string
+		token *db.PhoneVerificationToken
+		err   error
+	}{
+		{"usable", token(nil), nil},
+		{"last attempt", token(func(tok *db.PhoneVerificationToken) { tok.Attempts = phoneCodeMaxAttempts }), nil},
+		{"out of attempts", token(func(tok *db.PhoneVerificationToken) { tok.Attempts = phoneCodeMaxAttempts + 1 }), ErrPhoneCodeAttempts},
+		{"expired", token(func(tok *db.PhoneVerificationToken) { tok.ExpiresAt = toTimestamptz(now) }), ErrPhoneCodeExpired},
+		{"already used", token(func(tok *db.PhoneVerificationToken) { tok.ConsumedAt = toTimestamptz(now.Add(-time.Minute)) }), ErrPhoneCodeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkPhoneToken(tt.token, now); !errors.Is(err, tt.err) {
+				t.Errorf("checkPhoneToken() error = %v; want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPhoneCode(t *testing.T) {
+	code, err := generatePhoneCode()
+	if err != nil {
+		t.Fatalf("generatePhoneCode() error = %v", err)
+	}
+	if !regexp.MustCompile(`^\d{6}$`).MatchString(code) {
+		t.Errorf("generatePhoneCode() = %q; want six digits", code)
+	}
+
+	hash := hashPhoneCode("secret", "+2348012345678", "123456")
+	if hash != hashPhoneCode("secret", "+2348012345678", "123456") {
+		t.Error("hashPhoneCode() is not deterministic")
+	}
+	for _, other := range []string{
+		hashPhoneCode("other", "+2348012345678", "123456"),
+		hashPhoneCode("secret", "+2348012345679", "123456"),
+		hashPhoneCode("secret", "+2348012345678", "123457"),
+	} {
+		if other == hash {
+			t.Errorf("hashPhoneCode() = %s for a different secret, number or code", other)
+		}
+	}
+}
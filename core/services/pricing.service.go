@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+const feeNameBooking = "booking_fee"
+
+var (
+	ErrNoActivePrice   = errors.New("diagnostic centre has no active price for this test type")
+	ErrPriceMismatch   = errors.New("amount does not match the current price, request a new quote")
+	ErrMixedCurrencies = errors.New("tests booked together must be priced in the same currency")
+)
+
+// GetAppointmentQuote returns the server-side price of a test at a diagnostic centre
+func (service *ServicesHandler) GetAppointmentQuote(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.GetAppointmentQuoteDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid query parameters"), context)
+	}
+
+	testTypes, err := bundleTestTypes(dto.TestType, dto.TestTypes)
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, context)
+	}
+
+	quote, err := service.quoteAppointmentTests(context.Request().Context(), dto.DiagnosticCentreID, testTypes)
+	if err != nil {
+		utils.Error("Failed to quote appointment",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID.String()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		if errors.Is(err, ErrNoActivePrice) {
+			return utils.ErrorResponse(http.StatusNotFound, err, context)
+		}
+		if errors.Is(err, ErrMixedCurrencies) {
+			return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if dto.PromoCode != "" {
+		if _, err := service.applyPromoCode(context.Request().Context(), quote, dto.PromoCode, currentUser.UserID.String()); err != nil {
+			if isPromoCodeError(err) {
+				return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+			}
+			utils.Error("Failed to apply promo code",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+	}
+	if dto.PolicyID != "" {
+		if err := service.applyInsuranceCoverage(context.Request().Context(), quote, dto.PolicyID, currentUser.UserID.String()); err != nil {
+			if isCoverageError(err) {
+				return utils.ErrorResponse(http.StatusUnprocessableEntity, err, context)
+			}
+			utils.Error("Failed to apply insurance coverage",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+			return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+		}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, quote, context)
+}
+
+// quoteAppointment prices a test from the centre's active price list and adds platform fees
+func (service *ServicesHandler) quoteAppointment(
+	ctx context.Context,
+	diagnosticCentreID string,
+	testType string,
+) (*domain.AppointmentQuote, error) {
+	return service.quoteAppointmentTests(ctx, diagnosticCentreID, []string{testType})
+}
+
+// quoteAppointmentTests prices every test booked under one appointment from the centre's
+// active price list; platform fees are charged once per appointment
+func (service *ServicesHandler) quoteAppointmentTests(
+	ctx context.Context,
+	diagnosticCentreID string,
+	testTypes []string,
+) (*domain.AppointmentQuote, error) {
+	prices := make([]*db.DiagnosticCentreTestPrice, 0, len(testTypes))
+	amounts := make([]float64, 0, len(testTypes))
+	for _, testType := range testTypes {
+		testType = strings.ToUpper(strings.ReplaceAll(testType, " ", "_"))
+		price, err := service.testPricePort.GetActiveTestPrice(ctx, db.Get_Active_Test_PriceParams{
+			DiagnosticCentreID: diagnosticCentreID,
+			TestType:           testType,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("%w: %s", ErrNoActivePrice, testType)
+			}
+			return nil, fmt.Errorf("failed to get test price: %w", err)
+		}
+
+		amount, err := numericToFloat(price.Price)
+		if err != nil {
+			return nil, fmt.Errorf("invalid test price: %w", err)
+		}
+		if amount <= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoActivePrice, testType)
+		}
+		prices = append(prices, price)
+		amounts = append(amounts, amount)
+	}
+
+	return buildBundleQuote(prices, amounts, service.bookingFee())
+}
+
+// buildQuote assembles a quote from an active price and the platform booking fee
+func buildQuote(price *db.DiagnosticCentreTestPrice, amount, bookingFee float64) *domain.AppointmentQuote {
+	quote := &domain.AppointmentQuote{
+		DiagnosticCentreID: price.DiagnosticCentreID,
+		TestType:           price.TestType,
+		TestPriceID:        price.ID,
+		Price:              roundAmount(amount),
+		Currency:           price.Currency,
+		Items: []domain.QuoteItem{{
+			TestType:    price.TestType,
+			TestPriceID: price.ID,
+			Price:       roundAmount(amount),
+		}},
+		Fees: []domain.QuoteFee{},
+	}
+	if quote.Currency == "" {
+		quote.Currency = "NGN"
+	}
+
+	total := quote.Price
+	if bookingFee > 0 {
+		quote.Fees = append(quote.Fees, domain.QuoteFee{Name: feeNameBooking, Amount: roundAmount(bookingFee)})
+		total += roundAmount(bookingFee)
+	}
+	quote.Total = roundAmount(total)
+	return quote
+}
+
+// buildBundleQuote assembles the quote of several tests booked together. Each test keeps
+// its own price as a line item and the booking fee is charged once
+func buildBundleQuote(
+	prices []*db.DiagnosticCentreTestPrice,
+	amounts []float64,
+	bookingFee float64,
+) (*domain.AppointmentQuote, error) {
+	if len(prices) == 0 || len(prices) != len(amounts) {
+		return nil, errors.New("a quote needs at least one priced test")
+	}
+
+	items := make([]domain.QuoteItem, 0, len(prices))
+	var subtotal float64
+	for i, price := range prices {
+		if price.Currency != prices[0].Currency {
+			return nil, ErrMixedCurrencies
+		}
+		items = append(items, domain.QuoteItem{
+			TestType:    price.TestType,
+			TestPriceID: price.ID,
+			Price:       roundAmount(amounts[i]),
+		})
+		subtotal += roundAmount(amounts[i])
+	}
+
+	quote := buildQuote(prices[0], subtotal, bookingFee)
+	quote.Items = items
+	return quote, nil
+}
+
+// bookingFee reads the flat booking fee from configuration, ignoring invalid values
+func (service *ServicesHandler) bookingFee() float64 {
+	if service.Config.BOOKING_FEE == "" {
+		return 0
+	}
+	fee, err := strconv.ParseFloat(service.Config.BOOKING_FEE, 64)
+	if err != nil || fee < 0 {
+		utils.Warn("Invalid BOOKING_FEE configuration, ignoring",
+			utils.LogField{Key: "booking_fee", Value: service.Config.BOOKING_FEE})
+		return 0
+	}
+	return fee
+}
+
+// reschedulingFee reads the flat rescheduling fee from configuration, ignoring invalid values
+func (service *ServicesHandler) reschedulingFee() float64 {
+	if service.Config.RESCHEDULING_FEE == "" {
+		return 0
+	}
+	fee, err := strconv.ParseFloat(service.Config.RESCHEDULING_FEE, 64)
+	if err != nil || fee < 0 {
+		utils.Warn("Invalid RESCHEDULING_FEE configuration, ignoring",
+			utils.LogField{Key: "rescheduling_fee", Value: service.Config.RESCHEDULING_FEE})
+		return 0
+	}
+	return fee
+}
+
+func numericToFloat(n pgtype.Numeric) (float64, error) {
+	value, err := n.Float64Value()
+	if err != nil {
+		return 0, err
+	}
+	if !value.Valid {
+		return 0, errors.New("numeric value is null")
+	}
+	return value.Float64, nil
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
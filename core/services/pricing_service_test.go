@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/diagnoxix/adapters/db"
+)
+
+func TestBuildQuote(t *testing.T) {
+	price := &db.DiagnosticCentreTestPrice{
+		ID:                 "price-id",
+		DiagnosticCentreID: "centre-id",
+		TestType:           "BLOOD_TEST",
+		Currency:           "NGN",
+	}
+	tests := []struct {
+		name       string
+		amount     float64
+		bookingFee float64
+		fees       int
+		total      float64
+	}{
+		{"no fee", 5000, 0, 0, 5000},
+		{"with booking fee", 5000, 100, 1, 5100},
+		{"rounds to kobo", 19.999, 0.005, 1, 20.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := buildQuote(price, tt.amount, tt.bookingFee)
+			if len(quote.Fees) != tt.fees {
+				t.Errorf("expected %d fees, got %d", tt.fees, len(quote.Fees))
+			}
+			if quote.Total != tt.total {
+				t.Errorf("expected total %v, got %v", tt.total, quote.Total)
+			}
+			if quote.Currency != "NGN" {
+				t.Errorf("expected currency NGN, got %s", quote.Currency)
+			}
+		})
+	}
+}
+
+func TestBuildBundleQuote(t *testing.T) {
+	blood := &db.DiagnosticCentreTestPrice{ID: "blood-id", TestType: "BLOOD_TEST", Currency: "NGN"}
+	urine := &db.DiagnosticCentreTestPrice{ID: "urine-id", TestType: "URINE_TEST", Currency: "NGN"}
+	usd := &db.DiagnosticCentreTestPrice{ID: "xray-id", TestType: "X-RAY", Currency: "USD"}
+
+	quote, err := buildBundleQuote([]*db.DiagnosticCentreTestPrice{blood, urine}, []float64{5000, 2500}, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quote.Total != 7600 {
+		t.Errorf("expected total 7600 with the booking fee charged once, got %v", quote.Total)
+	}
+	if len(quote.Fees) != 1 {
+		t.Errorf("expected 1 fee, got %d", len(quote.Fees))
+	}
+	if len(quote.Items) != 2 || quote.Items[1].TestPriceID != "urine-id" || quote.Items[1].Price != 2500 {
+		t.Errorf("unexpected items %+v", quote.Items)
+	}
+
+	if _, err := buildBundleQuote([]*db.DiagnosticCentreTestPrice{blood, usd}, []float64{5000, 20}, 0); err != ErrMixedCurrencies {
+		t.Errorf("expected ErrMixedCurrencies, got %v", err)
+	}
+}
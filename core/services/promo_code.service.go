@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// minimumCharge is what a discounted booking is left to pay at least; payments must be for
+// a positive amount
+const minimumCharge = 1.0
+
+var (
+	ErrPromoCodeInvalid       = errors.New("promo code is not valid")
+	ErrPromoCodeNotApplicable = errors.New("promo code does not apply to this booking")
+	ErrPromoCodeExhausted     = errors.New("promo code is no longer available")
+	ErrPromoCodeAlreadyUsed   = errors.New("promo code has already been used on this account")
+	ErrPromoCodeFirstBooking  = errors.New("promo code is only valid on a first booking")
+	ErrDuplicatePromoCode     = errors.New("promo code already exists")
+)
+
+// CreatePromoCode creates a promo code. Admins may create codes for every centre; centre
+// owners only for centres they own.
+func (s *ServicesHandler) CreatePromoCode(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumADMIN,
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.CreatePromoCodeDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	if currentUser.UserType != db.UserEnumADMIN {
+		if len(dto.DiagnosticCentreIDs) == 0 {
+			return utils.ErrorResponse(http.StatusBadRequest,
+				errors.New("diagnostic_centre_ids must list the centres the code applies to"), c)
+		}
+		for _, centreID := range dto.DiagnosticCentreIDs {
+			if err := s.authorizeCentreAccess(ctx, currentUser, centreID); err != nil {
+				return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+			}
+		}
+	}
+
+	testTypes := make([]string, 0, len(dto.TestTypes))
+	for _, testType := range dto.TestTypes {
+		testType = strings.ToUpper(strings.ReplaceAll(testType, " ", "_"))
+		if !s.appointmentPort.IsValidTestType(ctx, testType) {
+			return utils.ErrorResponse(http.StatusBadRequest, fmt.Errorf("invalid test type: %s", testType), c)
+		}
+		testTypes = append(testTypes, testType)
+	}
+
+	params, err := promoCodeParams(dto, testTypes, time.Now())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, c)
+	}
+	params.CreatedBy = currentUser.UserID.String()
+
+	promo, err := s.promoCodePort.CreatePromoCode(ctx, params)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return utils.ErrorResponse(http.StatusConflict, fmt.Errorf("%w: %s", ErrDuplicatePromoCode, params.Code), c)
+	}
+	if err != nil {
+		utils.Error("Failed to create promo code",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	utils.Info("Promo code created",
+		utils.LogField{Key: "promo_code_id", Value: promo.ID},
+		utils.LogField{Key: "code", Value: promo.Code},
+		utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+	return utils.ResponseMessage(http.StatusCreated, promo, c)
+}
+
+// ListPromoCodes lists the promo codes the current user created, newest first
+func (s *ServicesHandler) ListPromoCodes(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumADMIN,
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListPromoCodesDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	param, _ := SetDefaultPagination(&dto.PaginationQueryDTO).(*domain.PaginationQueryDTO)
+	promos, err := s.promoCodePort.ListPromoCodes(c.Request().Context(), db.List_Promo_CodesParams{
+		CreatedBy: currentUser.UserID.String(),
+		Limit:     param.GetLimit(),
+		Offset:    param.GetOffset(),
+	})
+	if err != nil {
+		utils.Error("Failed to list promo codes",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if promos == nil {
+		promos = []*db.PromoCode{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, promos, c)
+}
+
+// DeactivatePromoCode withdraws a promo code. Bookings already made with it keep their
+// discount; admins may withdraw any code, centre owners only their own.
+func (s *ServicesHandler) DeactivatePromoCode(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{
+		db.UserEnumADMIN,
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.DeactivatePromoCodeDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	ctx := c.Request().Context()
+	promo, err := s.promoCodePort.GetPromoCode(ctx, dto.PromoCodeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("promo code not found"), c)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if currentUser.UserType != db.UserEnumADMIN && promo.CreatedBy != currentUser.UserID.String() {
+		return utils.ErrorResponse(http.StatusForbidden, utils.ErrPermissionDenied, c)
+	}
+
+	promo, err = s.promoCodePort.DeactivatePromoCode(ctx, promo.ID)
+	if err != nil {
+		utils.Error("Failed to deactivate promo code",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "promo_code_id", Value: dto.PromoCodeID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, promo, c)
+}
+
+// promoCodeParams checks a new promo code's terms and turns them into its insert parameters
+func promoCodeParams(dto *domain.CreatePromoCodeDTO, testTypes []string, now time.Time) (db.Create_Promo_CodeParams, error) {
+	discountType := db.PromoDiscountType(dto.DiscountType)
+	if discountType == db.PromoDiscountTypePercentage && dto.DiscountValue > 100 {
+		return db.Create_Promo_CodeParams{}, errors.New("a percentage discount cannot be more than 100")
+	}
+	if discountType == db.PromoDiscountTypeFixed && dto.MaxDiscount > 0 {
+		return db.Create_Promo_CodeParams{}, errors.New("max_discount only applies to percentage discounts")
+	}
+
+	startsAt := now
+	if dto.StartsAt != nil {
+		startsAt = *dto.StartsAt
+	}
+	var endsAt pgtype.Timestamptz
+	if dto.EndsAt != nil {
+		if !dto.EndsAt.After(startsAt) {
+			return db.Create_Promo_CodeParams{}, errors.New("ends_at must be after starts_at")
+		}
+		endsAt = toTimestamptz(*dto.EndsAt)
+	}
+
+	// Amounts are only meaningful in one currency, so fixed discounts and caps need one
+	currency := strings.ToUpper(dto.Currency)
+	if currency == "" && (discountType == db.PromoDiscountTypeFixed || dto.MaxDiscount > 0) {
+		currency = "NGN"
+	}
+
+	var maxDiscount pgtype.Numeric
+	if dto.MaxDiscount > 0 {
+		maxDiscount = toNumeric(dto.MaxDiscount)
+	}
+	var maxRedemptions pgtype.Int4
+	if dto.MaxRedemptions > 0 {
+		maxRedemptions = pgtype.Int4{Int32: dto.MaxRedemptions, Valid: true}
+	}
+	perUser := dto.MaxRedemptionsPerUser
+	if perUser == 0 {
+		perUser = 1
+	}
+	centreIDs := dto.DiagnosticCentreIDs
+	if centreIDs == nil {
+		centreIDs = []string{}
+	}
+
+	return db.Create_Promo_CodeParams{
+		Code:                  strings.ToUpper(dto.Code),
+		Description:           toText(dto.Description),
+		DiscountType:          discountType,
+		DiscountValue:         toNumeric(dto.DiscountValue),
+		MaxDiscount:           maxDiscount,
+		Currency:              pgtype.Text{String: currency, Valid: currency != ""},
+		DiagnosticCentreIds:   centreIDs,
+		TestTypes:             testTypes,
+		FirstBookingOnly:      dto.FirstBookingOnly,
+		StartsAt:              toTimestamptz(startsAt),
+		EndsAt:                endsAt,
+		MaxRedemptions:        maxRedemptions,
+		MaxRedemptionsPerUser: perUser,
+	}, nil
+}
+
+// applyPromoCode looks a promo code up, checks the patient may use it and takes its discount
+// off the quote. Whether the code still has uses left is checked again when it is redeemed.
+func (s *ServicesHandler) applyPromoCode(
+	ctx context.Context,
+	quote *domain.AppointmentQuote,
+	code string,
+	patientID string,
+) (*db.PromoCode, error) {
+	promo, err := s.promoCodePort.GetPromoCodeByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPromoCodeInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	amount, err := promoDiscount(promo, quote, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPromoCodeUser(ctx, s.promoCodePort, promo, patientID); err != nil {
+		return nil, err
+	}
+
+	quote.Discount = &domain.QuoteDiscount{
+		Code:        promo.Code,
+		Description: promo.Description.String,
+		Amount:      amount,
+	}
+	quote.Total = roundAmount(quote.Total - amount)
+	return promo, nil
+}
+
+// claimPromoCode takes one use of a quoted promo code within tx, before the booking's payment
+// is created. Claiming locks the code until tx ends, so the patient's earlier redemptions are
+// counted after any concurrent booking with the same code has committed.
+func claimPromoCode(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	promo *db.PromoCode,
+	patientID string,
+) error {
+	if _, err := tx.ClaimPromoCode(ctx, promo.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPromoCodeExhausted
+		}
+		return fmt.Errorf("failed to claim promo code: %w", err)
+	}
+	return checkPromoCodeUser(ctx, tx, promo, patientID)
+}
+
+// redeemPromoCode records the quoted discount against the booking's payment within tx, so
+// the code is only used up if the payment is created
+func redeemPromoCode(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	promo *db.PromoCode,
+	discount *domain.QuoteDiscount,
+	payment *db.Payment,
+) error {
+	_, err := tx.CreatePromoRedemption(ctx, db.Create_Promo_RedemptionParams{
+		PromoCodeID:    promo.ID,
+		Code:           promo.Code,
+		UserID:         payment.PatientID,
+		PaymentID:      payment.ID,
+		DiscountAmount: toNumeric(discount.Amount),
+		Currency:       payment.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record promo redemption: %w", err)
+	}
+	return nil
+}
+
+// promoCodeCounter counts a patient's earlier uses and payments, through the repository or
+// within a transaction
+type promoCodeCounter interface {
+	CountUserPromoRedemptions(ctx context.Context, arg db.Count_User_Promo_RedemptionsParams) (int64, error)
+	CountPatientPayments(ctx context.Context, patientID string) (int64, error)
+}
+
+// checkPromoCodeUser checks the per-patient limits of a promo code before the booking's
+// payment exists
+func checkPromoCodeUser(
+	ctx context.Context,
+	counter promoCodeCounter,
+	promo *db.PromoCode,
+	patientID string,
+) error {
+	used, err := counter.CountUserPromoRedemptions(ctx, db.Count_User_Promo_RedemptionsParams{
+		PromoCodeID: promo.ID,
+		UserID:      patientID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count promo redemptions: %w", err)
+	}
+	if used >= int64(promo.MaxRedemptionsPerUser) {
+		return ErrPromoCodeAlreadyUsed
+	}
+
+	if promo.FirstBookingOnly {
+		count, err := counter.CountPatientPayments(ctx, patientID)
+		if err != nil {
+			return fmt.Errorf("failed to count patient payments: %w", err)
+		}
+		if count > 0 {
+			return ErrPromoCodeFirstBooking
+		}
+	}
+	return nil
+}
+
+// promoDiscount works out what a promo code takes off a quote at now. Only the tests the code
+// covers are discounted, fees never are, and the booking is always left something to pay.
+func promoDiscount(promo *db.PromoCode, quote *domain.AppointmentQuote, now time.Time) (float64, error) {
+	if !promo.Active || now.Before(promo.StartsAt.Time) || (promo.EndsAt.Valid && !now.Before(promo.EndsAt.Time)) {
+		return 0, ErrPromoCodeInvalid
+	}
+	if promo.MaxRedemptions.Valid && promo.RedemptionCount >= promo.MaxRedemptions.Int32 {
+		return 0, ErrPromoCodeExhausted
+	}
+	if len(promo.DiagnosticCentreIds) > 0 && !slices.Contains(promo.DiagnosticCentreIds, quote.DiagnosticCentreID) {
+		return 0, ErrPromoCodeNotApplicable
+	}
+	if promo.Currency.Valid && !strings.EqualFold(promo.Currency.String, quote.Currency) {
+		return 0, ErrPromoCodeNotApplicable
+	}
+
+	var eligible float64
+	for _, item := range quote.Items {
+		if len(promo.TestTypes) == 0 || slices.Contains(promo.TestTypes, item.TestType) {
+			eligible += item.Price
+		}
+	}
+	if eligible <= 0 {
+		return 0, ErrPromoCodeNotApplicable
+	}
+
+	value, err := numericToFloat(promo.DiscountValue)
+	if err != nil {
+		return 0, fmt.Errorf("invalid promo discount: %w", err)
+	}
+	discount := value
+	if promo.DiscountType == db.PromoDiscountTypePercentage {
+		discount = eligible * value / 100
+		if promo.MaxDiscount.Valid {
+			maxDiscount, err := numericToFloat(promo.MaxDiscount)
+			if err != nil {
+				return 0, fmt.Errorf("invalid promo discount cap: %w", err)
+			}
+			discount = math.Min(discount, maxDiscount)
+		}
+	}
+	discount = math.Min(discount, eligible)
+	discount = math.Min(discount, quote.Total-minimumCharge)
+	if discount = roundAmount(discount); discount <= 0 {
+		return 0, ErrPromoCodeNotApplicable
+	}
+	return discount, nil
+}
+
+// releasePromoCode gives back the promo code use of a payment that failed or was cancelled
+func releasePromoCode(ctx context.Context, tx ports.AppointmentTx, payment *db.Payment) error {
+	if _, err := tx.ReleasePromoRedemption(ctx, payment.ID); err != nil {
+		return fmt.Errorf("failed to release promo redemption: %w", err)
+	}
+	return nil
+}
+
+// isPromoCodeError reports whether err is a promo code the patient cannot use
+func isPromoCodeError(err error) bool {
+	return errors.Is(err, ErrPromoCodeInvalid) ||
+		errors.Is(err, ErrPromoCodeNotApplicable) ||
+		errors.Is(err, ErrPromoCodeExhausted) ||
+		errors.Is(err, ErrPromoCodeAlreadyUsed) ||
+		errors.Is(err, ErrPromoCodeFirstBooking)
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testPromoQuote() *domain.AppointmentQuote {
+	return &domain.AppointmentQuote{
+		DiagnosticCentreID: "centre-1",
+		Currency:           "NGN",
+		Items: []domain.QuoteItem{
+			{TestType: "LIPID_PROFILE", Price: 10000},
+			{TestType: "BLOOD_TEST", Price: 5000},
+		},
+		Fees:  []domain.QuoteFee{{Name: feeNameBooking, Amount: 100}},
+		Total: 15100,
+	}
+}
+
+func TestPromoDiscount(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	promo := func(edit func(*db.PromoCode)) *db.PromoCode {
+		p := &db.PromoCode{
+			Code:                  "OCTLIPID20",
+			DiscountType:          db.PromoDiscountTypePercentage,
+			DiscountValue:         toNumeric(20),
+			Active:                true,
+			StartsAt:              toTimestamptz(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)),
+			EndsAt:                toTimestamptz(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)),
+			MaxRedemptionsPerUser: 1,
+		}
+		if edit != nil {
+			edit(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name  string
+		promo *db.PromoCode
+		want  float64
+		err   error
+	}{
+		{"percentage of every test", promo(nil), 3000, nil},
+		{"restricted to a test type", promo(func(p *db.PromoCode) { p.TestTypes = []string{"LIPID_PROFILE"} }), 2000, nil},
+		{"percentage capped", promo(func(p *db.PromoCode) {
+			p.MaxDiscount = toNumeric(1500)
+			p.Currency = toText("NGN")
+		}), 1500, nil},
+		{"fixed", promo(func(p *db.PromoCode) {
+			p.DiscountType = db.PromoDiscountTypeFixed
+			p.DiscountValue = toNumeric(2500)
+			p.Currency = toText("NGN")
+		}), 2500, nil},
+		{"fixed never exceeds the covered tests", promo(func(p *db.PromoCode) {
+			p.DiscountType = db.PromoDiscountTypeFixed
+			p.DiscountValue = toNumeric(8000)
+			p.Currency = toText("NGN")
+			p.TestTypes = []string{"BLOOD_TEST"}
+		}), 5000, nil},
+		{"full discount leaves the booking fee", promo(func(p *db.PromoCode) { p.DiscountValue = toNumeric(100) }), 15000, nil},
+		{"not started", promo(func(p *db.PromoCode) { p.StartsAt = toTimestamptz(now.Add(time.Hour)) }), 0, ErrPromoCodeInvalid},
+		{"ended", promo(func(p *db.PromoCode) { p.EndsAt = toTimestamptz(now) }), 0, ErrPromoCodeInvalid},
+		{"inactive", promo(func(p *db.PromoCode) { p.Active = false }), 0, ErrPromoCodeInvalid},
+		{"used up", promo(func(p *db.PromoCode) {
+			p.MaxRedemptions = pgtype.Int4{Int32: 10, Valid: true}
+			p.RedemptionCount = 10
+		}), 0, ErrPromoCodeExhausted},
+		{"other centre", promo(func(p *db.PromoCode) { p.DiagnosticCentreIds = []string{"centre-2"} }), 0, ErrPromoCodeNotApplicable},
+		{"other currency", promo(func(p *db.PromoCode) {
+			p.DiscountType = db.PromoDiscountTypeFixed
+			p.Currency = toText("USD")
+		}), 0, ErrPromoCodeNotApplicable},
+		{"no covered test", promo(func(p *db.PromoCode) { p.TestTypes = []string{"MRI"} }), 0, ErrPromoCodeNotApplicable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := promoDiscount(tt.promo, testPromoQuote(), now)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("promoDiscount() error = %v; want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("promoDiscount() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoDiscountMinimumCharge(t *testing.T) {
+	quote := testPromoQuote()
+	quote.Fees, quote.Total = nil, 15000
+	promo := &db.PromoCode{
+		DiscountType:  db.PromoDiscountTypePercentage,
+		DiscountValue: toNumeric(100),
+		Active:        true,
+	}
+
+	got, err := promoDiscount(promo, quote, time.Now())
+	if err != nil {
+		t.Fatalf("promoDiscount() error = %v", err)
+	}
+	if got != 15000-minimumCharge {
+		t.Errorf("promoDiscount() = %v; want %v", got, 15000-minimumCharge)
+	}
+}
+
+func TestPromoCodeParams(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+
+	params, err := promoCodeParams(&domain.CreatePromoCodeDTO{
+		Code:          "welcome5k",
+		DiscountType:  "fixed",
+		DiscountValue: 5000,
+	}, []string{}, now)
+	if err != nil {
+		t.Fatalf("promoCodeParams() error = %v", err)
+	}
+	if params.Code != "WELCOME5K" || params.Currency.String != "NGN" || params.MaxRedemptionsPerUser != 1 {
+		t.Errorf("promoCodeParams() = %+v", params)
+	}
+	if !params.StartsAt.Time.Equal(now) || params.EndsAt.Valid || params.MaxRedemptions.Valid || params.DiagnosticCentreIds == nil {
+		t.Errorf("promoCodeParams() defaults = %+v", params)
+	}
+
+	for name, dto := range map[string]*domain.CreatePromoCodeDTO{
+		"percentage over 100":   {Code: "HALF", DiscountType: "percentage", DiscountValue: 150},
+		"capped fixed discount": {Code: "CAP", DiscountType: "fixed", DiscountValue: 10, MaxDiscount: 5},
+		"ends before it starts": {Code: "LATE", DiscountType: "percentage", DiscountValue: 10, EndsAt: &before},
+	} {
+		if _, err := promoCodeParams(dto, nil, now); err == nil {
+			t.Errorf("%s: promoCodeParams() accepted %+v", name, dto)
+		}
+	}
+}
+
+// promoTx claims promo codes and counts a patient's history in memory
+type promoTx struct {
+	ports.AppointmentTx
+	exhausted   bool
+	redemptions int64
+	payments    int64
+	claimed     int
+}
+
+func (tx *promoTx) ClaimPromoCode(_ context.Context, id string) (*db.PromoCode, error) {
+	if tx.exhausted {
+		return nil, pgx.ErrNoRows
+	}
+	tx.claimed++
+	return &db.PromoCode{ID: id}, nil
+}
+
+func (tx *promoTx) CountUserPromoRedemptions(context.Context, db.Count_User_Promo_RedemptionsParams) (int64, error) {
+	return tx.redemptions, nil
+}
+
+func (tx *promoTx) CountPatientPayments(context.Context, string) (int64, error) {
+	return tx.payments, nil
+}
+
+func TestClaimPromoCode(t *testing.T) {
+	ctx := context.Background()
+	promo := &db.PromoCode{ID: "promo-1", MaxRedemptionsPerUser: 2, FirstBookingOnly: true}
+
+	tests := []struct {
+		name string
+		tx   *promoTx
+		err  error
+	}{
+		{"claimed", &promoTx{redemptions: 1}, nil},
+		{"used up by other patients", &promoTx{exhausted: true}, ErrPromoCodeExhausted},
+		{"patient limit reached", &promoTx{redemptions: 2}, ErrPromoCodeAlreadyUsed},
+		{"not a first booking", &promoTx{payments: 1}, ErrPromoCodeFirstBooking},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := claimPromoCode(ctx, tt.tx, promo, "patient-1")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("claimPromoCode() error = %v; want %v", err, tt.err)
+			}
+			if err != nil && !isPromoCodeError(err) {
+				t.Errorf("isPromoCodeError(%v) = false", err)
+			}
+		})
+	}
+}
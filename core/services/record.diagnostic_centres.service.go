@@ -10,6 +10,7 @@ import (
 	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/core/domain"
 	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 )
@@ -47,6 +48,19 @@ func (service *ServicesHandler) CreateMedicalRecord(cont echo.Context) error {
 		return utils.ErrorResponse(http.StatusNotFound, utils.ErrNotFoundDiagnositcCentre, cont)
 	}
 
+	// A record may report on one test of a bundled appointment
+	var lineItem *db.AppointmentLineItem
+	if dto.LineItemID != "" {
+		lineItem, err = service.recordLineItem(ctx, dto)
+		if err != nil {
+			if errors.Is(err, ErrLineItemNotInAppointment) {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			cont.Logger().Error("Failed to get line item:", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get line item")
+		}
+	}
+
 	// Parse SharedUntil string to time.Time
 	var sharedUntilTime pgtype.Timestamp
 	if dto.SharedUntil != "" {
@@ -91,6 +105,16 @@ func (service *ServicesHandler) CreateMedicalRecord(cont echo.Context) error {
 		}
 	}
 
+	if lineItem != nil {
+		// The record exists either way; a failed link is logged rather than failing the upload
+		if err := service.attachRecordToLineItem(ctx, lineItem, record.ID); err != nil {
+			utils.Error("Failed to attach medical record to line item",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "record_id", Value: record.ID},
+				utils.LogField{Key: "line_item_id", Value: lineItem.ID})
+		}
+	}
+
 	go func(
 		ctx context.Context,
 		logger echo.Logger,
@@ -142,6 +166,31 @@ func (service *ServicesHandler) CreateMedicalRecord(cont echo.Context) error {
 	}, cont)
 }
 
+// recordLineItem loads the line item a new record reports on, checking it belongs to the
+// record's patient, centre and schedule
+func (service *ServicesHandler) recordLineItem(
+	ctx context.Context,
+	dto *domain.CreateMedicalRecordDTO,
+) (*db.AppointmentLineItem, error) {
+	item, err := service.lineItemPort.GetAppointmentLineItem(ctx, dto.LineItemID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLineItemNotInAppointment
+		}
+		return nil, err
+	}
+	appointment, err := service.appointmentPort.GetAppointment(ctx, item.AppointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.PatientID != dto.PatientID.String() ||
+		appointment.DiagnosticCentreID != dto.DiagnosticCentreID.String() ||
+		appointment.ScheduleID != dto.ScheduleID.String() {
+		return nil, ErrLineItemNotInAppointment
+	}
+	return item, nil
+}
+
 // GetUploaderMedicalRecord retrieves a single medical record uploaded by a specific uploader.
 func (service *ServicesHandler) GetUploaderMedicalRecord(cont echo.Context) error {
 	manager, err := PrivateMiddlewareContext(cont, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREMANAGER})
@@ -5,9 +5,9 @@ import (
 	"time"
 
 	"github.com/diagnoxix/adapters/config"
+	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/adapters/external"
 	"github.com/diagnoxix/adapters/external/ai"
-	"github.com/diagnoxix/adapters/external/paystack"
 	"github.com/diagnoxix/core/jobs"
 	"github.com/diagnoxix/core/ports"
 	"github.com/diagnoxix/core/services/cache"
@@ -27,10 +27,26 @@ type ServicesHandler struct {
 	filePort         ports.FileService
 	notificationPort ports.NotificationService
 	notificationRepo ports.NotificationRepository
+	waitlistPort     ports.WaitlistRepository
+	seriesPort       ports.AppointmentSeriesRepository
+	lineItemPort     ports.LineItemRepository
+	settlementPort   ports.SettlementRepository
+	walletPort       ports.WalletRepository
+	invoicePort      ports.InvoiceRepository
+	promoCodePort    ports.PromoCodeRepository
+	insurancePort    ports.InsuranceRepository
+	outboxPort       ports.EmailOutboxRepository
 	Config           config.EnvConfiguration
 	Reminder         *jobs.ReminderJob
-	// Payment Gateway
-	paymentService ports.PaymentProviderService
+	Waitlist         *jobs.WaitlistJob
+	Reconciliation   *jobs.ReconciliationJob
+	Settlement       *jobs.SettlementJob
+	EmailOutbox      *jobs.EmailOutboxJob
+	// Payment Gateways, keyed by provider and listed in fallback order
+	paymentProviders     map[db.PaymentProvider]ports.PaymentProviderService
+	paymentProviderOrder []db.PaymentProvider
+	// Centre settlements are paid out through Paystack transfers
+	payoutPort ports.PayoutService
 	// AI Services
 	aiPort *ai.AIAdaptor
 	AI     *AIService
@@ -48,6 +64,15 @@ func ServicesAdapter(
 	appointmentPort ports.AppointmentRepository,
 	testPriceRepo ports.TestPriceRepository,
 	notificationRepo ports.NotificationRepository,
+	waitlistPort ports.WaitlistRepository,
+	seriesPort ports.AppointmentSeriesRepository,
+	lineItemPort ports.LineItemRepository,
+	settlementPort ports.SettlementRepository,
+	walletPort ports.WalletRepository,
+	invoicePort ports.InvoiceRepository,
+	promoCodePort ports.PromoCodeRepository,
+	insurancePort ports.InsuranceRepository,
+	outboxPort ports.EmailOutboxRepository,
 	conn config.EnvConfiguration,
 ) *ServicesHandler {
 	// Notification Service
@@ -58,13 +83,11 @@ func ServicesAdapter(
 		Password:  conn.EMAIL_APP_PASSWORD,
 		From:      conn.EMAIL_FROM_ADDRESS,
 		EmailType: ex.EmailType(conn.EMAIL_TYPE),
-	})
+	}).WithSMSProvider(newSMSProvider(conn))
 
-	// Paystack Service
-	payStackService := paystack.NewPaystackAdapter(&paystack.PaystackConfig{
-		SecretKey: conn.PAYSTACK_SECRET_KEY,
-		BaseURL:   conn.PAYSTACK_BASE_URL,
-	})
+	// Payment Services
+	paymentProviders, paymentProviderOrder := newPaymentProviders(conn)
+	payoutPort, _ := paymentProviders[db.PaymentProviderPAYSTACK].(ports.PayoutService)
 
 	// AI Adapter
 	aiAdaptor := ai.NewAIAdaptor(
@@ -77,14 +100,7 @@ func ServicesAdapter(
 		ai.WithPackageAnalyzer(ai.NewPackageAnalysis()),
 	)
 
-	// Reminder Manager Service
-	Reminder := jobs.NewReminderJob(
-		appointmentPort,
-		notificationService,
-		useRepo,
-		diagnosticCentreRepo,
-	)
-	return &ServicesHandler{
+	handler := &ServicesHandler{
 		userPort:         useRepo,
 		schedulePort:     schedulePort,
 		diagnosticPort:   diagnosticCentreRepo,
@@ -95,14 +111,56 @@ func ServicesAdapter(
 		testPricePort:    testPriceRepo,
 		notificationPort: notificationService,
 		notificationRepo: notificationRepo,
-		paymentService:   payStackService,
+		waitlistPort:     waitlistPort,
+		seriesPort:       seriesPort,
+		lineItemPort:     lineItemPort,
+		settlementPort:   settlementPort,
+		walletPort:       walletPort,
+		invoicePort:      invoicePort,
+		promoCodePort:    promoCodePort,
+		insurancePort:    insurancePort,
+		outboxPort:       outboxPort,
+		paymentProviders: paymentProviders,
 		Config:           conn,
 		aiPort:           aiAdaptor,
 		AI:               initializeAIService(conn),
-		WebSocketManager: websocket.NewWebSocketManager(),
+		WebSocketManager: initializeWebSocketManager(conn),
 		filePort:         ex.NewLocalFileService(),
-		Reminder:         Reminder,
+
+		paymentProviderOrder: paymentProviderOrder,
+		payoutPort:           payoutPort,
+	}
+	// Reminders go out through the notification dispatcher, so the job calls back into the handler
+	handler.Reminder = jobs.NewReminderJob(appointmentPort, handler.sendAppointmentReminder)
+	// Waitlist holds are released through the slot engine, so the job calls back into the handler
+	handler.Waitlist = jobs.NewWaitlistJob(handler.releaseExpiredWaitlistHolds)
+	handler.Reconciliation = jobs.NewReconciliationJob(
+		handler.reconcilePayments,
+		handler.expireUnpaidAppointments,
+		handler.sendPaymentDiscrepancyReport,
+	)
+	handler.Settlement = jobs.NewSettlementJob(handler.settleCentres)
+	handler.EmailOutbox = jobs.NewEmailOutboxJob(handler.deliverQueuedEmails, handler.queueMissedReceipts)
+	return handler
+}
+
+// initializeWebSocketManager creates a WebSocket manager that fans notifications out to every
+// instance through Redis if it is available, and to the sockets of this instance otherwise
+func initializeWebSocketManager(conn config.EnvConfiguration) *websocket.WebSocketManager {
+	if conn.REDIS_URL != "" {
+		manager, err := websocket.NewClusteredWebSocketManager(conn.REDIS_URL)
+		if err != nil {
+			utils.Warn("Failed to connect WebSocket manager to Redis, delivering to local sockets only",
+				utils.LogField{Key: "error", Value: err.Error()})
+			return websocket.NewWebSocketManager()
+		}
+
+		utils.Info("WebSocket manager clustered through Redis")
+		return manager
 	}
+
+	utils.Info("No Redis URL provided, WebSocket manager delivering to local sockets only")
+	return websocket.NewWebSocketManager()
 }
 
 // initializeAIService creates an AI service with caching if Redis is available
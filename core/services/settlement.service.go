@@ -0,0 +1,558 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// defaultPlatformCommissionPercent is kept from each payment when PLATFORM_COMMISSION_PERCENT is unset
+	defaultPlatformCommissionPercent = 10.0
+	// maxPayoutAttempts caps how often a failed payout is retried before it is left for finance
+	maxPayoutAttempts = 3
+	// payoutBatchSize caps the statements paid out in one run; the rest wait for the next
+	payoutBatchSize = 50
+)
+
+var (
+	ErrSettlementNotFound        = errors.New("settlement not found")
+	ErrPayoutAccountRejected     = errors.New("payout provider did not accept the bank account")
+	ErrSettlementPayoutSettled   = errors.New("settlement payout has already been settled")
+	ErrWebhookSettlementNotFound = errors.New("no settlement matches the transfer reference")
+
+	errInvalidSettlementParams = errors.New("invalid parameters")
+)
+
+// UpdatePayoutAccount registers the bank account a centre's settlements are paid into
+func (s *ServicesHandler) UpdatePayoutAccount(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedBodyDTO).(*domain.UpdatePayoutAccountDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), c)
+	}
+
+	ctx := c.Request().Context()
+	if err := s.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+
+	currency := strings.ToUpper(dto.Currency)
+	if currency == "" {
+		currency = "NGN"
+	}
+	recipient, err := s.payoutPort.CreateRecipient(domain.PayoutRecipientRequest{
+		Name:          dto.AccountName,
+		AccountNumber: dto.AccountNumber,
+		BankCode:      dto.BankCode,
+		Currency:      currency,
+	})
+	if err != nil {
+		utils.Error("Failed to register payout account",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		if errors.Is(err, domain.ErrProviderUnavailable) {
+			return utils.ErrorResponse(http.StatusBadGateway, err, c)
+		}
+		return utils.ErrorResponse(http.StatusUnprocessableEntity,
+			fmt.Errorf("%w: %v", ErrPayoutAccountRejected, err), c)
+	}
+
+	account, err := s.settlementPort.UpsertCentrePayoutAccount(ctx, db.Upsert_Centre_Payout_AccountParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		BankCode:           dto.BankCode,
+		AccountNumber:      dto.AccountNumber,
+		AccountName:        recipient.AccountName,
+		Currency:           currency,
+		RecipientCode:      recipient.RecipientCode,
+		UpdatedBy:          toUUID(currentUser.UserID.String()),
+	})
+	if err != nil {
+		utils.Error("Failed to save payout account",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, account, c)
+}
+
+// ListSettlements lists a centre's settlement statements, newest first
+func (s *ServicesHandler) ListSettlements(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListSettlementsDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	ctx := c.Request().Context()
+	if err := s.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, c)
+	}
+
+	param, _ := SetDefaultPagination(&dto.PaginationQueryDTO).(*domain.PaginationQueryDTO)
+	settlements, err := s.settlementPort.ListCentreSettlements(ctx, db.List_Centre_SettlementsParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		Limit:              param.GetLimit(),
+		Offset:             param.GetOffset(),
+	})
+	if err != nil {
+		utils.Error("Failed to list settlements",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if settlements == nil {
+		settlements = []*db.Settlement{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, settlements, c)
+}
+
+// GetSettlement returns a settlement statement with the payments and refunds it settled
+func (s *ServicesHandler) GetSettlement(c echo.Context) error {
+	statement, err := s.ownedSettlementStatement(c)
+	if err != nil {
+		return utils.ErrorResponse(settlementErrorStatus(err), err, c)
+	}
+	return utils.ResponseMessage(http.StatusOK, statement, c)
+}
+
+// ExportSettlement returns a settlement statement as a CSV file
+func (s *ServicesHandler) ExportSettlement(c echo.Context) error {
+	statement, err := s.ownedSettlementStatement(c)
+	if err != nil {
+		return utils.ErrorResponse(settlementErrorStatus(err), err, c)
+	}
+
+	content, err := settlementCSV(statement)
+	if err != nil {
+		utils.Error("Failed to export settlement",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "settlement_id", Value: statement.Settlement.ID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+
+	filename := fmt.Sprintf("settlement-%s-%s.csv",
+		statement.Settlement.PeriodEnd.Time.AddDate(0, 0, -1).Format("2006-01-02"),
+		strings.ToLower(statement.Settlement.Currency))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.Blob(http.StatusOK, "text/csv", content)
+}
+
+// ownedSettlementStatement loads the statement a request names once the owner of its centre is confirmed
+func (s *ServicesHandler) ownedSettlementStatement(c echo.Context) (*domain.SettlementStatement, error) {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrUnauthorized, err)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.GetSettlementDTO)
+	if !ok || dto == nil {
+		return nil, errInvalidSettlementParams
+	}
+
+	ctx := c.Request().Context()
+	settlement, err := s.settlementPort.GetSettlement(ctx, dto.SettlementID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSettlementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeCentreAccess(ctx, currentUser, settlement.DiagnosticCentreID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.settlementPort.ListSettlementEntries(ctx, settlement.ID)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []*db.SettlementEntry{}
+	}
+	return &domain.SettlementStatement{Settlement: settlement, Entries: entries}, nil
+}
+
+// settlementErrorStatus maps settlement lookup errors to HTTP status codes
+func settlementErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, errInvalidSettlementParams):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrSettlementNotFound):
+		return http.StatusNotFound
+	default:
+		return centreAccessErrorStatus(err)
+	}
+}
+
+// settlementCSV writes a statement as CSV: the opening balance carried into it, one row per
+// payment or refund it settled, then its totals
+func settlementCSV(statement *domain.SettlementStatement) ([]byte, error) {
+	settlement := statement.Settlement
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{"date", "type", "payment_id", "refund_id", "amount", "commission", "net", "currency"}}
+	if opening := formatAmount(settlement.OpeningBalance); opening != "0.00" {
+		rows = append(rows, []string{
+			settlement.PeriodStart.Time.Format(time.RFC3339), "opening_balance", "", "",
+			opening, "0.00", opening, settlement.Currency,
+		})
+	}
+	for _, entry := range statement.Entries {
+		rows = append(rows, []string{
+			entry.OccurredAt.Time.Format(time.RFC3339),
+			string(entry.Kind),
+			entry.PaymentID,
+			uuidString(entry.RefundID),
+			formatAmount(entry.Amount),
+			formatAmount(entry.CommissionAmount),
+			formatAmount(entry.NetAmount),
+			settlement.Currency,
+		})
+	}
+
+	gross, _ := numericToFloat(settlement.GrossAmount)
+	refunded, _ := numericToFloat(settlement.RefundedAmount)
+	rows = append(rows, []string{
+		settlement.PeriodEnd.Time.Format(time.RFC3339), "total", "", "",
+		strconv.FormatFloat(roundAmount(gross-refunded), 'f', 2, 64),
+		formatAmount(settlement.CommissionAmount),
+		formatAmount(settlement.NetAmount),
+		settlement.Currency,
+	})
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// settleCentres closes the statements of the settlement period that ended last and pays out
+// every statement waiting on a transfer
+func (s *ServicesHandler) settleCentres() {
+	ctx := context.Background()
+	periodEnd := settlementPeriodEnd(time.Now())
+	periodStart := periodEnd.AddDate(0, 0, -7)
+
+	centres, err := s.settlementPort.ListUnsettledCentres(ctx, toTimestamptz(periodEnd))
+	if err != nil {
+		utils.Error("Failed to list unsettled centres",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return
+	}
+
+	rate := s.platformCommissionPercent()
+	closed := 0
+	for _, centre := range centres {
+		settlement, err := s.closeSettlement(ctx, centre, periodStart, periodEnd, rate)
+		if err != nil {
+			utils.Error("Failed to close settlement",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "diagnostic_centre_id", Value: centre.DiagnosticCentreID},
+				utils.LogField{Key: "currency", Value: centre.Currency})
+			continue
+		}
+		if settlement != nil {
+			closed++
+		}
+	}
+	if closed > 0 {
+		utils.Info("Closed settlements",
+			utils.LogField{Key: "period_end", Value: periodEnd.Format("2006-01-02")},
+			utils.LogField{Key: "settlements", Value: closed})
+	}
+
+	s.payOutSettlements(ctx)
+}
+
+// closeSettlement closes a centre's statement for a period in one currency. It settles every
+// payment and refund no earlier statement settled and carries in the balance of the previous
+// statement when that one netted to nothing or less. It returns nil when there was nothing to
+// settle or the period was already closed.
+func (s *ServicesHandler) closeSettlement(
+	ctx context.Context,
+	centre *db.List_Unsettled_CentresRow,
+	periodStart time.Time,
+	periodEnd time.Time,
+	rate float64,
+) (*db.Settlement, error) {
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	opening := 0.0
+	latest, err := tx.GetLatestSettlement(ctx, db.Get_Latest_SettlementParams{
+		DiagnosticCentreID: centre.DiagnosticCentreID,
+		Currency:           centre.Currency,
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get latest settlement: %w", err)
+	case latest.Status == db.SettlementStatusCarriedForward:
+		if opening, err = numericToFloat(latest.NetAmount); err != nil {
+			return nil, fmt.Errorf("failed to parse carried forward balance: %w", err)
+		}
+	}
+
+	settlement, err := tx.CreateSettlement(ctx, db.Create_SettlementParams{
+		DiagnosticCentreID: centre.DiagnosticCentreID,
+		PeriodStart:        toTimestamptz(periodStart),
+		PeriodEnd:          toTimestamptz(periodEnd),
+		Currency:           centre.Currency,
+		CommissionRate:     toNumeric(rate),
+		OpeningBalance:     toNumeric(opening),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create settlement: %w", err)
+	}
+
+	payments, err := tx.AddSettlementPaymentEntries(ctx, settlement.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle payments: %w", err)
+	}
+	refunds, err := tx.AddSettlementRefundEntries(ctx, settlement.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle refunds: %w", err)
+	}
+	if payments+refunds == 0 {
+		return nil, nil
+	}
+
+	settlement, err = tx.TotalSettlement(ctx, settlement.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total settlement: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit settlement: %w", err)
+	}
+	return settlement, nil
+}
+
+// payOutSettlements transfers every statement waiting on a payout to its centre's bank account
+func (s *ServicesHandler) payOutSettlements(ctx context.Context) {
+	settlements, err := s.settlementPort.ListPayableSettlements(ctx, db.List_Payable_SettlementsParams{
+		PayoutAttempts: maxPayoutAttempts,
+		Limit:          payoutBatchSize,
+	})
+	if err != nil {
+		utils.Error("Failed to list payable settlements",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return
+	}
+
+	for _, settlement := range settlements {
+		if err := s.payOutSettlement(ctx, settlement); err != nil {
+			utils.Error("Failed to pay out settlement",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "settlement_id", Value: settlement.ID})
+		}
+	}
+}
+
+// payOutSettlement claims a statement and transfers its net amount. A statement whose centre
+// has no payout account in its currency stays pending until one is registered.
+func (s *ServicesHandler) payOutSettlement(ctx context.Context, settlement *db.Settlement) error {
+	account, err := s.settlementPort.GetCentrePayoutAccount(ctx, settlement.DiagnosticCentreID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !strings.EqualFold(account.Currency, settlement.Currency)) {
+		utils.Warn("Settlement is waiting on a payout account",
+			utils.LogField{Key: "settlement_id", Value: settlement.ID},
+			utils.LogField{Key: "diagnostic_centre_id", Value: settlement.DiagnosticCentreID},
+			utils.LogField{Key: "currency", Value: settlement.Currency})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get payout account: %w", err)
+	}
+
+	claimed, err := s.settlementPort.StartSettlementPayout(ctx, db.Start_Settlement_PayoutParams{
+		ID:              settlement.ID,
+		PayoutReference: toText(payoutReference(settlement)),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Another run claimed it first
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start payout: %w", err)
+	}
+
+	status, transferCode, reason := transferSettlement(s.payoutPort, claimed, account)
+	updated, err := s.settlementPort.UpdateSettlementPayout(ctx, db.Update_Settlement_PayoutParams{
+		ID:            claimed.ID,
+		Status:        status,
+		TransferCode:  toText(transferCode),
+		FailureReason: toText(reason),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The transfer webhook settled the payout first
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record payout: %w", err)
+	}
+
+	utils.Info("Settlement payout initiated",
+		utils.LogField{Key: "settlement_id", Value: updated.ID},
+		utils.LogField{Key: "reference", Value: updated.PayoutReference.String},
+		utils.LogField{Key: "status", Value: updated.Status})
+	return nil
+}
+
+// transferSettlement asks the payout provider to pay a claimed statement and reports the status
+// the statement moves to. A transfer the provider could not be asked about may still have gone
+// through, so it stays processing until the transfer webhook says otherwise rather than being
+// retried under a new reference.
+func transferSettlement(
+	payout ports.PayoutService,
+	settlement *db.Settlement,
+	account *db.CentrePayoutAccount,
+) (status db.SettlementStatus, transferCode string, reason string) {
+	amount, err := numericToFloat(settlement.NetAmount)
+	if err != nil {
+		return db.SettlementStatusFailed, "", err.Error()
+	}
+
+	transfer, err := payout.InitiateTransfer(domain.TransferRequest{
+		Reference:     settlement.PayoutReference.String,
+		RecipientCode: account.RecipientCode,
+		Amount:        amount,
+		Currency:      settlement.Currency,
+		Reason: fmt.Sprintf("Diagnoxix settlement %s to %s",
+			settlement.PeriodStart.Time.Format("2 Jan 2006"),
+			settlement.PeriodEnd.Time.AddDate(0, 0, -1).Format("2 Jan 2006")),
+	})
+	if errors.Is(err, domain.ErrProviderUnavailable) {
+		return db.SettlementStatusProcessing, "", err.Error()
+	}
+	if err != nil {
+		return db.SettlementStatusFailed, "", err.Error()
+	}
+
+	switch transfer.Status {
+	case domain.TransferStatusSuccess:
+		return db.SettlementStatusPaid, transfer.TransferCode, ""
+	case domain.TransferStatusFailed:
+		return db.SettlementStatusFailed, transfer.TransferCode, "transfer failed"
+	default:
+		return db.SettlementStatusProcessing, transfer.TransferCode, ""
+	}
+}
+
+// applyPaystackTransfer settles the payout a transfer.success, transfer.failed or
+// transfer.reversed event reports
+func (s *ServicesHandler) applyPaystackTransfer(
+	ctx context.Context,
+	eventType string,
+	transfer *domain.PaystackTransferEvent,
+) error {
+	if transfer.Reference == "" {
+		return fmt.Errorf("%w: missing reference", ErrMalformedWebhookEvent)
+	}
+	settlement, err := s.settlementPort.GetSettlementByPayoutReference(ctx, transfer.Reference)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrWebhookSettlementNotFound, transfer.Reference)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get settlement by payout reference: %w", err)
+	}
+
+	status, reason := db.SettlementStatusPaid, ""
+	if eventType != domain.PaystackEventTransferSuccess {
+		status, reason = db.SettlementStatusFailed, strings.TrimPrefix(eventType, "transfer.")
+	}
+	updated, err := s.settlementPort.UpdateSettlementPayout(ctx, db.Update_Settlement_PayoutParams{
+		ID:            settlement.ID,
+		Status:        status,
+		TransferCode:  toText(transfer.TransferCode),
+		FailureReason: toText(reason),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrSettlementPayoutSettled, settlement.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update settlement payout: %w", err)
+	}
+
+	if status == db.SettlementStatusFailed {
+		utils.Error("Settlement payout failed",
+			utils.LogField{Key: "settlement_id", Value: updated.ID},
+			utils.LogField{Key: "reference", Value: transfer.Reference},
+			utils.LogField{Key: "transfer_code", Value: transfer.TransferCode},
+			utils.LogField{Key: "attempts", Value: updated.PayoutAttempts})
+		return nil
+	}
+	utils.Info("Settlement paid",
+		utils.LogField{Key: "settlement_id", Value: updated.ID},
+		utils.LogField{Key: "reference", Value: transfer.Reference})
+	return nil
+}
+
+// payoutReference is the transfer reference of a statement's next payout attempt. Each attempt
+// gets its own, as the provider refuses a reference it has seen before.
+func payoutReference(settlement *db.Settlement) string {
+	return fmt.Sprintf("stl_%s_%d", strings.ReplaceAll(settlement.ID, "-", ""), settlement.PayoutAttempts+1)
+}
+
+// settlementPeriodEnd is the end of the last settlement period that ended by now. Periods run
+// for a week from Monday midnight UTC.
+func settlementPeriodEnd(now time.Time) time.Time {
+	day := truncateToDay(now)
+	sinceMonday := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -sinceMonday)
+}
+
+// platformCommissionPercent reads the platform's commission from configuration
+func (s *ServicesHandler) platformCommissionPercent() float64 {
+	percent := defaultPlatformCommissionPercent
+	if s.Config.PLATFORM_COMMISSION_PERCENT != "" {
+		parsed, err := strconv.ParseFloat(s.Config.PLATFORM_COMMISSION_PERCENT, 64)
+		if err != nil || parsed < 0 || parsed >= 100 {
+			utils.Warn("Invalid PLATFORM_COMMISSION_PERCENT configuration, using default",
+				utils.LogField{Key: "platform_commission_percent", Value: s.Config.PLATFORM_COMMISSION_PERCENT})
+		} else {
+			percent = parsed
+		}
+	}
+	return percent
+}
+
+// formatAmount formats a stored amount to two decimal places, or empty when it is null
+func formatAmount(n pgtype.Numeric) string {
+	amount, err := numericToFloat(n)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
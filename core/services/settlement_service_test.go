@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type fakePayout struct {
+	transfer *domain.Transfer
+	err      error
+	request  domain.TransferRequest
+}
+
+func (f *fakePayout) CreateRecipient(req domain.PayoutRecipientRequest) (*domain.PayoutRecipient, error) {
+	return &domain.PayoutRecipient{RecipientCode: "RCP_test", AccountName: req.Name}, nil
+}
+
+func (f *fakePayout) InitiateTransfer(req domain.TransferRequest) (*domain.Transfer, error) {
+	f.request = req
+	return f.transfer, f.err
+}
+
+func TestTransferSettlement(t *testing.T) {
+	settlement := &db.Settlement{
+		ID:              "5f0c6d1e-8f7a-4c3b-9a2d-1e4f5a6b7c8d",
+		PeriodStart:     toTimestamptz(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)),
+		PeriodEnd:       toTimestamptz(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)),
+		Currency:        "NGN",
+		NetAmount:       toNumeric(45000.5),
+		PayoutReference: toText("stl_ref_1"),
+	}
+	account := &db.CentrePayoutAccount{RecipientCode: "RCP_centre"}
+
+	tests := []struct {
+		name       string
+		payout     *fakePayout
+		wantStatus db.SettlementStatus
+		wantCode   string
+	}{
+		{
+			name:       "paid immediately",
+			payout:     &fakePayout{transfer: &domain.Transfer{TransferCode: "TRF_1", Status: domain.TransferStatusSuccess}},
+			wantStatus: db.SettlementStatusPaid,
+			wantCode:   "TRF_1",
+		},
+		{
+			name:       "awaiting webhook",
+			payout:     &fakePayout{transfer: &domain.Transfer{TransferCode: "TRF_2", Status: domain.TransferStatusPending}},
+			wantStatus: db.SettlementStatusProcessing,
+			wantCode:   "TRF_2",
+		},
+		{
+			name:       "failed by provider",
+			payout:     &fakePayout{transfer: &domain.Transfer{TransferCode: "TRF_3", Status: domain.TransferStatusFailed}},
+			wantStatus: db.SettlementStatusFailed,
+			wantCode:   "TRF_3",
+		},
+		{
+			name:       "rejected",
+			payout:     &fakePayout{err: errors.New("insufficient balance")},
+			wantStatus: db.SettlementStatusFailed,
+		},
+		{
+			name:       "provider unavailable",
+			payout:     &fakePayout{err: domain.ErrProviderUnavailable},
+			wantStatus: db.SettlementStatusProcessing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code, _ := transferSettlement(tt.payout, settlement, account)
+			if status != tt.wantStatus || code != tt.wantCode {
+				t.Errorf("transferSettlement() = %q, %q; want %q, %q", status, code, tt.wantStatus, tt.wantCode)
+			}
+			req := tt.payout.request
+			if req.Reference != "stl_ref_1" || req.RecipientCode != "RCP_centre" || req.Amount != 45000.5 {
+				t.Errorf("transfer request = %+v", req)
+			}
+		})
+	}
+}
+
+func TestSettlementPeriodEnd(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{now: monday, want: monday},
+		{now: monday.Add(2 * time.Hour), want: monday},
+		{now: time.Date(2026, 3, 15, 23, 59, 0, 0, time.UTC), want: monday},
+		{now: time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC), want: monday.AddDate(0, 0, -7)},
+	}
+
+	for _, tt := range tests {
+		if got := settlementPeriodEnd(tt.now); !got.Equal(tt.want) {
+			t.Errorf("settlementPeriodEnd(%s) = %s; want %s", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestPayoutReference(t *testing.T) {
+	settlement := &db.Settlement{ID: "5f0c6d1e-8f7a-4c3b-9a2d-1e4f5a6b7c8d", PayoutAttempts: 1}
+	if got, want := payoutReference(settlement), "stl_5f0c6d1e8f7a4c3b9a2d1e4f5a6b7c8d_2"; got != want {
+		t.Errorf("payoutReference() = %q; want %q", got, want)
+	}
+}
+
+func TestSettlementCSV(t *testing.T) {
+	occurred := toTimestamptz(time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC))
+	statement := &domain.SettlementStatement{
+		Settlement: &db.Settlement{
+			PeriodStart:      toTimestamptz(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)),
+			PeriodEnd:        toTimestamptz(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)),
+			Currency:         "NGN",
+			OpeningBalance:   toNumeric(-500),
+			GrossAmount:      toNumeric(10000),
+			RefundedAmount:   toNumeric(2000),
+			CommissionAmount: toNumeric(800),
+			NetAmount:        toNumeric(6700),
+		},
+		Entries: []*db.SettlementEntry{
+			{
+				Kind:             db.SettlementEntryKindPayment,
+				PaymentID:        "pay-1",
+				Amount:           toNumeric(10000),
+				CommissionAmount: toNumeric(1000),
+				NetAmount:        toNumeric(9000),
+				OccurredAt:       occurred,
+			},
+			{
+				Kind:             db.SettlementEntryKindRefund,
+				PaymentID:        "pay-1",
+				RefundID:         pgtype.UUID{Bytes: [16]byte{1}, Valid: true},
+				Amount:           toNumeric(-2000),
+				CommissionAmount: toNumeric(-200),
+				NetAmount:        toNumeric(-1800),
+				OccurredAt:       occurred,
+			},
+		},
+	}
+
+	content, err := settlementCSV(statement)
+	if err != nil {
+		t.Fatalf("settlementCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("settlementCSV() wrote %d lines; want 5:\n%s", len(lines), content)
+	}
+	if !strings.Contains(lines[1], "opening_balance,,,-500.00,0.00,-500.00,NGN") {
+		t.Errorf("opening balance row = %q", lines[1])
+	}
+	if !strings.Contains(lines[3], "refund,pay-1,01000000-0000-0000-0000-000000000000,-2000.00,-200.00,-1800.00,NGN") {
+		t.Errorf("refund row = %q", lines[3])
+	}
+	if !strings.HasSuffix(lines[4], "total,,,8000.00,800.00,6700.00,NGN") {
+		t.Errorf("total row = %q", lines[4])
+	}
+}
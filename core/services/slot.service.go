@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// maxSlotRangeDays bounds how many days of slots can be requested at once
+	maxSlotRangeDays = 31
+	// defaultSlotRangeDays is used when the caller does not supply an end date
+	defaultSlotRangeDays = 7
+	// maxBookingsPerRange caps the bookings loaded when computing slots
+	maxBookingsPerRange = 10000
+)
+
+var (
+	ErrSlotUnavailable = errors.New("requested time is not an available slot for this diagnostic centre")
+	ErrSlotFullyBooked = errors.New("requested slot is fully booked")
+)
+
+// activeBookingStatuses are the appointment statuses that occupy a slot. The booking trigger
+// (validate_appointment_time_slot) counts the same statuses against capacity.
+var activeBookingStatuses = []string{
+	string(db.AppointmentStatusPending),
+	string(db.AppointmentStatusConfirmed),
+	string(db.AppointmentStatusCheckedIn),
+	string(db.AppointmentStatusInProgress),
+	string(db.AppointmentStatusCompleted),
+}
+
+// GetAvailableSlots lists the free bookable slots of a diagnostic centre for a date range
+func (service *ServicesHandler) GetAvailableSlots(context echo.Context) error {
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.GetAvailableSlotsDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid query parameters"), context)
+	}
+
+	from, to, err := parseSlotRange(dto.From, dto.To, time.Now().UTC())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, context)
+	}
+
+	ctx := context.Request().Context()
+	centre, err := service.diagnosticPort.GetDiagnosticCentre(ctx, dto.DiagnosticCentreID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("diagnostic centre not found"), context)
+		}
+		utils.Error("Failed to get diagnostic centre",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if dto.TestType != "" {
+		testType := strings.ToUpper(strings.ReplaceAll(dto.TestType, " ", "_"))
+		if !slices.Contains(centre.AvailableTests, testType) {
+			return utils.ErrorResponse(
+				http.StatusUnprocessableEntity,
+				fmt.Errorf("diagnostic centre does not offer test type: %s", testType),
+				context,
+			)
+		}
+	}
+
+	slots, err := service.availableSlots(ctx, dto.DiagnosticCentreID, from, to, "")
+	if err != nil {
+		utils.Error("Failed to compute available slots",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	free := make([]domain.AvailableSlot, 0, len(slots))
+	for _, slot := range slots {
+		if slot.Remaining > 0 {
+			free = append(free, slot)
+		}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, free, context)
+}
+
+// availableSlots expands the centre's weekly availability into concrete slots
+// between from and to (inclusive dates), applies date exceptions and subtracts
+// existing bookings and waitlist holds. Holds offered to holderID do not count,
+// so the patient a slot is held for can still book it.
+func (service *ServicesHandler) availableSlots(
+	ctx context.Context,
+	diagnosticCentreID string,
+	from, to time.Time,
+	holderID string,
+) ([]domain.AvailableSlot, error) {
+	availability, err := service.availabilityPort.GetDiagnosticAvailability(ctx, diagnosticCentreID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	if len(availability) == 0 {
+		return []domain.AvailableSlot{}, nil
+	}
+
+	exceptions, err := service.availabilityPort.ListAvailabilityExceptions(ctx, db.List_Availability_ExceptionsParams{
+		DiagnosticCentreID: diagnosticCentreID,
+		ExceptionDate:      toDate(from),
+		ExceptionDate_2:    toDate(to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability exceptions: %w", err)
+	}
+
+	booked, err := service.appointmentPort.GetCentreAppointments(ctx, db.GetCentreAppointmentsParams{
+		DiagnosticCentreID: diagnosticCentreID,
+		Column2:            activeBookingStatuses,
+		AppointmentDate:    toTimestamptz(from),
+		AppointmentDate_2:  toTimestamptz(to.AddDate(0, 0, 1)),
+		Limit:              maxBookingsPerRange,
+		Offset:             0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booked appointments: %w", err)
+	}
+
+	holds, err := service.waitlistPort.ListWaitlistHolds(ctx, db.List_Waitlist_HoldsParams{
+		DiagnosticCentreID: diagnosticCentreID,
+		OfferedSlotStart:   toTimestamptz(from),
+		OfferedSlotStart_2: toTimestamptz(to.AddDate(0, 0, 1)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist holds: %w", err)
+	}
+	held := make([]*db.AppointmentWaitlistEntry, 0, len(holds))
+	for _, hold := range holds {
+		if hold.PatientID != holderID {
+			held = append(held, hold)
+		}
+	}
+
+	return expandSlots(availability, exceptions, booked, held, from, to, time.Now().UTC()), nil
+}
+
+// findFreeSlot returns the slot that starts exactly at the requested time, or
+// an error when the centre is closed then or the slot has no capacity left for
+// patientID.
+func (service *ServicesHandler) findFreeSlot(
+	ctx context.Context,
+	diagnosticCentreID string,
+	at time.Time,
+	patientID string,
+) (*domain.AvailableSlot, error) {
+	day := truncateToDay(at.UTC())
+	slots, err := service.availableSlots(ctx, diagnosticCentreID, day, day, patientID)
+	if err != nil {
+		return nil, err
+	}
+	return matchSlot(slots, at)
+}
+
+// matchSlot picks the slot starting at the given time
+func matchSlot(slots []domain.AvailableSlot, at time.Time) (*domain.AvailableSlot, error) {
+	for i := range slots {
+		if slots[i].StartTime.Equal(at) {
+			if slots[i].Remaining <= 0 {
+				return nil, ErrSlotFullyBooked
+			}
+			return &slots[i], nil
+		}
+	}
+	return nil, ErrSlotUnavailable
+}
+
+// expandSlots is the pure part of the slot engine. Slots are computed in UTC,
+// which is how the database triggers interpret availability. An exception
+// closes its day entirely or replaces that day's hours and capacity; it never
+// opens a weekday the centre has no weekly availability for. A slot held for a
+// waitlisted patient is taken just like a booked one.
+func expandSlots(
+	availability []*db.DiagnosticCentreAvailability,
+	exceptions []*db.DiagnosticCentreAvailabilityException,
+	booked []*db.GetCentreAppointmentsRow,
+	held []*db.AppointmentWaitlistEntry,
+	from, to, now time.Time,
+) []domain.AvailableSlot {
+	bookedCount := make(map[string]int32, len(booked)+len(held))
+	for _, appointment := range booked {
+		bookedCount[slotKey(appointment.AppointmentDate.Time, appointment.TimeSlot)]++
+	}
+	for _, hold := range held {
+		bookedCount[slotKey(hold.OfferedSlotStart.Time, hold.OfferedTimeSlot.String)]++
+	}
+
+	byDay := make(map[string][]*db.DiagnosticCentreAvailability, len(availability))
+	for _, row := range availability {
+		day := strings.ToLower(strings.TrimSpace(row.DayOfWeek))
+		byDay[day] = append(byDay[day], row)
+	}
+
+	byDate := make(map[string]*db.DiagnosticCentreAvailabilityException, len(exceptions))
+	for _, exception := range exceptions {
+		byDate[exception.ExceptionDate.Time.Format("2006-01-02")] = exception
+	}
+
+	slots := []domain.AvailableSlot{}
+	for day := truncateToDay(from); !day.After(truncateToDay(to)); day = day.AddDate(0, 0, 1) {
+		exception := byDate[day.Format("2006-01-02")]
+		if exception != nil && exception.IsClosed {
+			continue
+		}
+
+		for _, row := range byDay[strings.ToLower(day.Weekday().String())] {
+			startTime, endTime := row.StartTime, row.EndTime
+			if exception != nil && exception.StartTime.Valid && exception.EndTime.Valid {
+				startTime, endTime = exception.StartTime, exception.EndTime
+			}
+			if !startTime.Valid || !endTime.Valid || row.SlotDuration <= 0 {
+				continue
+			}
+
+			capacity := int32(1)
+			if row.MaxAppointments.Valid {
+				capacity = row.MaxAppointments.Int32
+			}
+			if exception != nil && exception.MaxAppointments.Valid {
+				capacity = exception.MaxAppointments.Int32
+			}
+			if capacity <= 0 {
+				continue
+			}
+
+			duration := time.Duration(row.SlotDuration) * time.Minute
+			step := duration
+			if row.BreakTime.Valid && row.BreakTime.Int32 > 0 {
+				step += time.Duration(row.BreakTime.Int32) * time.Minute
+			}
+
+			dayEnd := day.Add(time.Duration(endTime.Microseconds) * time.Microsecond)
+			for start := day.Add(time.Duration(startTime.Microseconds) * time.Microsecond); !start.Add(duration).After(dayEnd); start = start.Add(step) {
+				if start.Before(now) {
+					continue
+				}
+				end := start.Add(duration)
+				timeSlot := fmt.Sprintf("%s-%s", start.Format("15:04"), end.Format("15:04"))
+				taken := bookedCount[slotKey(start, timeSlot)]
+				remaining := capacity - taken
+				if remaining < 0 {
+					remaining = 0
+				}
+
+				slots = append(slots, domain.AvailableSlot{
+					Date:      day.Format("2006-01-02"),
+					TimeSlot:  timeSlot,
+					StartTime: start,
+					EndTime:   end,
+					Capacity:  capacity,
+					Booked:    taken,
+					Remaining: remaining,
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(slots, func(a, b domain.AvailableSlot) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return slots
+}
+
+// parseSlotRange validates the requested from/to dates, defaulting to a week from today
+func parseSlotRange(fromStr, toStr string, now time.Time) (time.Time, time.Time, error) {
+	from := truncateToDay(now)
+	if fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %w", err)
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, defaultSlotRangeDays-1)
+	if toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %w", err)
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("to date must not be before from date")
+	}
+	if to.Sub(from) >= maxSlotRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed %d days", maxSlotRangeDays)
+	}
+	return from, to, nil
+}
+
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func slotKey(start time.Time, timeSlot string) string {
+	return start.UTC().Format("2006-01-02") + " " + timeSlot
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func clockTime(hour, minute int) pgtype.Time {
+	return pgtype.Time{Microseconds: int64(hour*3600+minute*60) * 1000000, Valid: true}
+}
+
+func TestExpandSlots(t *testing.T) {
+	// 2025-09-01 is a Monday
+	monday := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	availability := []*db.DiagnosticCentreAvailability{
+		{
+			DayOfWeek:       "monday",
+			StartTime:       clockTime(9, 0),
+			EndTime:         clockTime(10, 30),
+			MaxAppointments: pgtype.Int4{Int32: 2, Valid: true},
+			SlotDuration:    30,
+			BreakTime:       pgtype.Int4{Int32: 10, Valid: true},
+		},
+	}
+	booked := []*db.GetCentreAppointmentsRow{
+		{AppointmentDate: toTimestamptz(monday.Add(9 * time.Hour)), TimeSlot: "09:00-09:30"},
+		{AppointmentDate: toTimestamptz(monday.Add(9 * time.Hour)), TimeSlot: "09:00-09:30"},
+		{AppointmentDate: toTimestamptz(monday.Add(9*time.Hour + 40*time.Minute)), TimeSlot: "09:40-10:10"},
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		now      time.Time
+		expected []struct {
+			slot      string
+			remaining int32
+		}
+	}{
+		{
+			name: "weekday with bookings",
+			from: monday,
+			to:   monday,
+			now:  monday.AddDate(0, 0, -1),
+			expected: []struct {
+				slot      string
+				remaining int32
+			}{
+				{"09:00-09:30", 0},
+				{"09:40-10:10", 1},
+			},
+		},
+		{
+			name: "closed day",
+			from: monday.AddDate(0, 0, 1),
+			to:   monday.AddDate(0, 0, 1),
+			now:  monday.AddDate(0, 0, -1),
+		},
+		{
+			name: "past slots are skipped",
+			from: monday,
+			to:   monday,
+			now:  monday.Add(9*time.Hour + 15*time.Minute),
+			expected: []struct {
+				slot      string
+				remaining int32
+			}{
+				{"09:40-10:10", 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := expandSlots(availability, nil, booked, nil, tt.from, tt.to, tt.now)
+			if len(slots) != len(tt.expected) {
+				t.Fatalf("expected %d slots, got %d", len(tt.expected), len(slots))
+			}
+			for i, want := range tt.expected {
+				if slots[i].TimeSlot != want.slot {
+					t.Errorf("slot %d: expected %s, got %s", i, want.slot, slots[i].TimeSlot)
+				}
+				if slots[i].Remaining != want.remaining {
+					t.Errorf("slot %d: expected %d remaining, got %d", i, want.remaining, slots[i].Remaining)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandSlotsWithExceptions(t *testing.T) {
+	monday := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	availability := []*db.DiagnosticCentreAvailability{
+		{
+			DayOfWeek:       "monday",
+			StartTime:       clockTime(9, 0),
+			EndTime:         clockTime(11, 0),
+			MaxAppointments: pgtype.Int4{Int32: 2, Valid: true},
+			SlotDuration:    30,
+		},
+	}
+	date := pgtype.Date{Time: monday, Valid: true}
+
+	tests := []struct {
+		name      string
+		exception *db.DiagnosticCentreAvailabilityException
+		slots     []string
+		capacity  int32
+	}{
+		{
+			name:      "full-day closure",
+			exception: &db.DiagnosticCentreAvailabilityException{ExceptionDate: date, IsClosed: true},
+		},
+		{
+			name: "shortened hours",
+			exception: &db.DiagnosticCentreAvailabilityException{
+				ExceptionDate: date,
+				StartTime:     clockTime(10, 0),
+				EndTime:       clockTime(11, 0),
+			},
+			slots:    []string{"10:00-10:30", "10:30-11:00"},
+			capacity: 2,
+		},
+		{
+			name: "reduced capacity",
+			exception: &db.DiagnosticCentreAvailabilityException{
+				ExceptionDate:   date,
+				MaxAppointments: pgtype.Int4{Int32: 1, Valid: true},
+			},
+			slots:    []string{"09:00-09:30", "09:30-10:00", "10:00-10:30", "10:30-11:00"},
+			capacity: 1,
+		},
+		{
+			name: "exception on another day",
+			exception: &db.DiagnosticCentreAvailabilityException{
+				ExceptionDate: pgtype.Date{Time: monday.AddDate(0, 0, 7), Valid: true},
+				IsClosed:      true,
+			},
+			slots:    []string{"09:00-09:30", "09:30-10:00", "10:00-10:30", "10:30-11:00"},
+			capacity: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exceptions := []*db.DiagnosticCentreAvailabilityException{tt.exception}
+			slots := expandSlots(availability, exceptions, nil, nil, monday, monday, monday.AddDate(0, 0, -1))
+			if len(slots) != len(tt.slots) {
+				t.Fatalf("expected %d slots, got %d", len(tt.slots), len(slots))
+			}
+			for i, want := range tt.slots {
+				if slots[i].TimeSlot != want {
+					t.Errorf("slot %d: expected %s, got %s", i, want, slots[i].TimeSlot)
+				}
+				if slots[i].Capacity != tt.capacity {
+					t.Errorf("slot %d: expected capacity %d, got %d", i, tt.capacity, slots[i].Capacity)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchSlot(t *testing.T) {
+	monday := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	availability := []*db.DiagnosticCentreAvailability{
+		{
+			DayOfWeek:       "monday",
+			StartTime:       clockTime(9, 0),
+			EndTime:         clockTime(10, 0),
+			MaxAppointments: pgtype.Int4{Int32: 1, Valid: true},
+			SlotDuration:    30,
+		},
+	}
+	booked := []*db.GetCentreAppointmentsRow{
+		{AppointmentDate: toTimestamptz(monday.Add(9 * time.Hour)), TimeSlot: "09:00-09:30"},
+	}
+	slots := expandSlots(availability, nil, booked, nil, monday, monday, monday.AddDate(0, 0, -1))
+
+	if _, err := matchSlot(slots, monday.Add(9*time.Hour)); err != ErrSlotFullyBooked {
+		t.Errorf("expected ErrSlotFullyBooked, got %v", err)
+	}
+	if _, err := matchSlot(slots, monday.Add(3*time.Hour)); err != ErrSlotUnavailable {
+		t.Errorf("expected ErrSlotUnavailable, got %v", err)
+	}
+	slot, err := matchSlot(slots, monday.Add(9*time.Hour+30*time.Minute))
+	if err != nil {
+		t.Fatalf("expected a free slot, got %v", err)
+	}
+	if slot.TimeSlot != "09:30-10:00" {
+		t.Errorf("expected 09:30-10:00, got %s", slot.TimeSlot)
+	}
+}
+
+func TestExpandSlotsCountsWaitlistHolds(t *testing.T) {
+	monday := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	availability := []*db.DiagnosticCentreAvailability{
+		{
+			DayOfWeek:       "monday",
+			StartTime:       clockTime(9, 0),
+			EndTime:         clockTime(10, 0),
+			MaxAppointments: pgtype.Int4{Int32: 2, Valid: true},
+			SlotDuration:    30,
+		},
+	}
+	booked := []*db.GetCentreAppointmentsRow{
+		{AppointmentDate: toTimestamptz(monday.Add(9 * time.Hour)), TimeSlot: "09:00-09:30"},
+	}
+	held := []*db.AppointmentWaitlistEntry{
+		{OfferedSlotStart: toTimestamptz(monday.Add(9 * time.Hour)), OfferedTimeSlot: toText("09:00-09:30")},
+	}
+	slots := expandSlots(availability, nil, booked, held, monday, monday, monday.AddDate(0, 0, -1))
+
+	if _, err := matchSlot(slots, monday.Add(9*time.Hour)); err != ErrSlotFullyBooked {
+		t.Errorf("expected held slot to be fully booked, got %v", err)
+	}
+	slot, err := matchSlot(slots, monday.Add(9*time.Hour+30*time.Minute))
+	if err != nil {
+		t.Fatalf("expected a free slot, got %v", err)
+	}
+	if slot.Remaining != 2 {
+		t.Errorf("expected 2 remaining, got %d", slot.Remaining)
+	}
+}
+
+func TestParseSlotRange(t *testing.T) {
+	now := time.Date(2025, 9, 1, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		from, to string
+		wantErr  bool
+	}{
+		{"", "", false},
+		{"2025-09-02", "2025-09-05", false},
+		{"2025-09-05", "2025-09-02", true},
+		{"2025-09-01", "2025-12-01", true},
+	}
+	for _, tt := range tests {
+		_, _, err := parseSlotRange(tt.from, tt.to, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSlotRange(%q, %q) error = %v; wantErr %v", tt.from, tt.to, err, tt.wantErr)
+		}
+	}
+}
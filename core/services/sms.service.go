@@ -0,0 +1,40 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/diagnoxix/adapters/config"
+	"github.com/diagnoxix/adapters/external/termii"
+	"github.com/diagnoxix/adapters/external/twilio"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+)
+
+// newSMSProvider builds the SMS gateway named by SMS_PROVIDER. It returns nil when none is
+// configured, in which case sending a text message fails with domain.ErrSMSUnavailable.
+// Configuration loading already refuses unknown names.
+func newSMSProvider(conn config.EnvConfiguration) ports.SMSProvider {
+	switch strings.ToUpper(strings.TrimSpace(conn.SMS_PROVIDER)) {
+	case "":
+		return nil
+	case "TERMII":
+		return termii.NewTermiiAdapter(&termii.TermiiConfig{
+			APIKey:   conn.TERMII_API_KEY,
+			SenderID: conn.TERMII_SENDER_ID,
+			Channel:  conn.TERMII_CHANNEL,
+			BaseURL:  conn.TERMII_BASE_URL,
+		})
+	case "TWILIO":
+		return twilio.NewTwilioAdapter(&twilio.TwilioConfig{
+			AccountSID:          conn.TWILIO_ACCOUNT_SID,
+			AuthToken:           conn.TWILIO_AUTH_TOKEN,
+			From:                conn.TWILIO_FROM_NUMBER,
+			MessagingServiceSID: conn.TWILIO_MESSAGING_SERVICE_SID,
+			BaseURL:             conn.TWILIO_BASE_URL,
+		})
+	default:
+		utils.Error("Unknown SMS_PROVIDER, text messages will not be sent",
+			utils.LogField{Key: "provider", Value: conn.SMS_PROVIDER})
+		return nil
+	}
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const defaultCurrency = "NGN"
+
+// ListTestPrices lists a diagnostic centre's test prices, including inactive ones
+func (service *ServicesHandler) ListTestPrices(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.ListTestPricesDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid query parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	prices, err := service.testPricePort.ListTestPrices(ctx, db.List_Test_PricesParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		Column2:            dto.ActiveOnly,
+	})
+	if err != nil {
+		utils.Error("Failed to list test prices",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if len(prices) == 0 {
+		return utils.ResponseMessage(http.StatusOK, []*db.DiagnosticCentreTestPrice{}, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, prices, context)
+}
+
+// CreateTestPrice adds a test to the centre's price list, reactivating it if it already exists
+func (service *ServicesHandler) CreateTestPrice(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.CreateTestPriceDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	currency := strings.ToUpper(dto.Currency)
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	price, err := service.testPricePort.UpsertTestPrice(ctx, db.Upsert_Test_PriceParams{
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		TestType:           dto.TestType,
+		Price:              toNumeric(roundAmount(dto.Price)),
+		Currency:           currency,
+	})
+	if err != nil {
+		utils.Error("Failed to create test price",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID},
+			utils.LogField{Key: "test_type", Value: dto.TestType})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, price, context)
+}
+
+// UpdateTestPrice changes the price, currency or active status of a test price
+func (service *ServicesHandler) UpdateTestPrice(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.UpdateTestPriceDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	existing, err := service.testPricePort.GetTestPrice(ctx, db.Get_Test_PriceParams{
+		ID:                 dto.TestPriceID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("test price not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	params := db.Update_Test_PriceParams{
+		ID:                 existing.ID,
+		DiagnosticCentreID: existing.DiagnosticCentreID,
+		Price:              existing.Price,
+		Currency:           existing.Currency,
+		IsActive:           existing.IsActive,
+	}
+	if dto.Price != nil {
+		params.Price = toNumeric(roundAmount(*dto.Price))
+	}
+	if dto.Currency != nil {
+		params.Currency = strings.ToUpper(*dto.Currency)
+	}
+	if dto.IsActive != nil {
+		params.IsActive = *dto.IsActive
+	}
+
+	price, err := service.testPricePort.UpdateTestPrice(ctx, params)
+	if err != nil {
+		utils.Error("Failed to update test price",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "test_price_id", Value: dto.TestPriceID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, price, context)
+}
+
+// DeactivateTestPrice removes a test from the bookable price list while keeping its history
+func (service *ServicesHandler) DeactivateTestPrice(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumDIAGNOSTICCENTREOWNER})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.TestPriceParamDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	existing, err := service.testPricePort.GetTestPrice(ctx, db.Get_Test_PriceParams{
+		ID:                 dto.TestPriceID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("test price not found"), context)
+		}
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	price, err := service.testPricePort.UpdateTestPrice(ctx, db.Update_Test_PriceParams{
+		ID:                 existing.ID,
+		DiagnosticCentreID: existing.DiagnosticCentreID,
+		Price:              existing.Price,
+		Currency:           existing.Currency,
+		IsActive:           false,
+	})
+	if err != nil {
+		utils.Error("Failed to deactivate test price",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "test_price_id", Value: dto.TestPriceID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, price, context)
+}
+
+// GetTestPriceHistory lists every price a test has had at the centre, newest first
+func (service *ServicesHandler) GetTestPriceHistory(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{
+		db.UserEnumDIAGNOSTICCENTREOWNER,
+		db.UserEnumDIAGNOSTICCENTREMANAGER,
+	})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.TestPriceParamDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	ctx := context.Request().Context()
+	if err := service.authorizeCentreAccess(ctx, currentUser, dto.DiagnosticCentreID); err != nil {
+		return utils.ErrorResponse(centreAccessErrorStatus(err), err, context)
+	}
+
+	history, err := service.testPricePort.ListTestPriceHistory(ctx, db.List_Test_Price_HistoryParams{
+		TestPriceID:        dto.TestPriceID,
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+	})
+	if err != nil {
+		utils.Error("Failed to list test price history",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "test_price_id", Value: dto.TestPriceID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if len(history) == 0 {
+		return utils.ResponseMessage(http.StatusOK, []*db.DiagnosticCentreTestPriceHistory{}, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, history, context)
+}
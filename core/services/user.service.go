@@ -12,8 +12,10 @@ import (
 	"github.com/diagnoxix/adapters/db"
 	"github.com/diagnoxix/adapters/external/templates/emails"
 	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
 	"github.com/diagnoxix/core/utils"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	"golang.org/x/oauth2"
@@ -39,8 +41,16 @@ func (service *ServicesHandler) Create(context echo.Context) error {
 		return utils.ErrorResponse(http.StatusBadRequest, err, context)
 	}
 	newUser.EmailVerified = pgtype.Bool{Bool: false, Valid: true}
+
+	// The account, its token and the email carrying it are committed together
+	tx, err := service.userPort.BeginUser(context.Request().Context())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(context.Request().Context())
+
 	createdUser, err := service.createUserHelper(
-		context, newUser, db.UserEnumDIAGNOSTICCENTREOWNER, db.UserEnumPATIENT)
+		context, tx, newUser, db.UserEnumDIAGNOSTICCENTREOWNER, db.UserEnumPATIENT)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusConflict, err, context)
 	}
@@ -56,7 +66,7 @@ func (service *ServicesHandler) Create(context echo.Context) error {
 	}
 
 	// Save verification token
-	verificationToken, err := service.userPort.CreateEmailVerificationToken(
+	verificationToken, err := tx.CreateEmailVerificationToken(
 		context.Request().Context(),
 		verificationParams,
 	)
@@ -76,7 +86,21 @@ func (service *ServicesHandler) Create(context echo.Context) error {
 		ExpiryDuration:   "24 hours",
 	}
 
-	go service.emailGoroutine(emaildata, createdUser.Email.String, emails.SubjectEmailVerification, emails.TemplateEmailVerification)
+	if err := service.queueEmail(context.Request().Context(), tx, outboxEmail{
+		To:        createdUser.Email.String,
+		Subject:   emails.SubjectEmailVerification,
+		Template:  emails.TemplateEmailVerification,
+		Data:      emaildata,
+		Sensitive: true,
+	}); err != nil {
+		utils.Error("Failed to queue email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "template", Value: emails.TemplateEmailVerification})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if err := tx.Commit(context.Request().Context()); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
 
 	return utils.ResponseMessage(http.StatusCreated, createdUser, context)
 }
@@ -84,16 +108,19 @@ func (service *ServicesHandler) Create(context echo.Context) error {
 func (service *ServicesHandler) CreateWithPhoneNumber(context echo.Context) error {
 	dto, _ := context.Get(utils.ValidatedBodyDTO).(*domain.PhoneRegisterDTO)
 
-	// Check if phone number already exists
-	// existingUser, _ := service.userPort.GetUserByPhoneNumber(
-	//     context.Request().Context(),
-	//     pgtype.Text{String: dto.PhoneNumber, Valid: true},
-	// )
-	// if existingUser != nil {
-	//     utils.Error("Phone number already registered",
-	//         utils.LogField{Key: "phone_number", Value: dto.PhoneNumber})
-	//     return utils.ErrorResponse(http.StatusConflict, errors.New("phone number already registered"), context)
-	// }
+	// A number belongs to the one account that verified it
+	existingUser, err := service.userPort.GetUserByPhoneNumber(context.Request().Context(), dto.PhoneNumber)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		utils.Error("Failed to get user by phone number",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	found := err == nil
+	if found && existingUser.PhoneVerifiedAt.Valid {
+		utils.Error("Phone number already registered",
+			utils.LogField{Key: "phone_number", Value: dto.PhoneNumber})
+		return utils.ErrorResponse(http.StatusConflict, ErrPhoneAlreadyRegistered, context)
+	}
 
 	// Hash password
 	hashedPassword, err := domain.HashPassword(dto.Password)
@@ -101,49 +128,56 @@ func (service *ServicesHandler) CreateWithPhoneNumber(context echo.Context) erro
 		return utils.ErrorResponse(http.StatusBadRequest, err, context)
 	}
 
-	// Create user
-	newUser := db.CreateUserParams{
-		Fullname:      pgtype.Text{String: fmt.Sprintf("%s %s", dto.FirstName, dto.LastName), Valid: true},
-		PhoneNumber:   pgtype.Text{String: dto.PhoneNumber, Valid: true},
-		Password:      hashedPassword,
-		UserType:      dto.UserType,
-		Email:         pgtype.Text{},                        // Empty email for phone-based users
-		EmailVerified: pgtype.Bool{Bool: true, Valid: true}, // Skip email verification
+	fullname := pgtype.Text{String: fmt.Sprintf("%s %s", dto.FirstName, dto.LastName), Valid: true}
+	var createdUser *db.User
+	if found && !existingUser.Email.Valid {
+		// Nobody proved they own the number, so an earlier sign-up with it is handed to this
+		// one rather than left beside it. Only whoever receives the code can use the account.
+		createdUser, err = service.userPort.ResetUnverifiedPhoneUser(context.Request().Context(), db.ResetUnverifiedPhoneUserParams{
+			ID:       existingUser.ID,
+			Password: hashedPassword,
+			UserType: dto.UserType,
+			Fullname: fullname,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The account was never sent a code, so it is left to its owner and this
+			// sign-up gets an account of its own
+			createdUser, err = nil, nil
+		}
+	}
+	if createdUser == nil && err == nil {
+		createdUser, err = service.userPort.CreateUser(context.Request().Context(), db.CreateUserParams{
+			Fullname:      fullname,
+			PhoneNumber:   pgtype.Text{String: dto.PhoneNumber, Valid: true},
+			Password:      hashedPassword,
+			UserType:      dto.UserType,
+			Email:         pgtype.Text{},                        // Empty email for phone-based users
+			EmailVerified: pgtype.Bool{Bool: true, Valid: true}, // Skip email verification
+		})
+		if err == nil {
+			// CreateUser returns the new account's ID and timestamps only
+			createdUser.PhoneNumber = pgtype.Text{String: dto.PhoneNumber, Valid: true}
+		}
 	}
-
-	createdUser, err := service.userPort.CreateUser(context.Request().Context(), newUser)
 	if err != nil {
 		utils.Error("Failed to create user with phone",
 			utils.LogField{Key: "error", Value: err.Error()})
 		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
 	}
 
-	// Generate SMS verification token
-	// token := GenerateRandomToken()
-	// expiresAt := time.Now().Add(15 * time.Minute)
-
-	// Save verification token (you need to extend the token table or create a new one)
-	// // For now, assuming you have a similar SMS verification token system
-	// smsVerificationParams := db.CreateSMSVerificationTokenParams{
-	//     PhoneNumber: dto.PhoneNumber,
-	//     Token:       token,
-	//     ExpiresAt:   pgtype.Timestamptz{Time: expiresAt, Valid: true},
-	// }
-
-	// _, err = service.userPort.CreateSMSVerificationToken(context.Request().Context(), smsVerificationParams)
-	// if err != nil {
-	//     utils.Error("Failed to create SMS verification token",
-	//         utils.LogField{Key: "error", Value: err.Error()})
-	//     return utils.ErrorResponse(http.StatusInternalServerError, err, context)
-	// }
-
-	// // Send SMS verification
-	// go service.sendSMSVerification(dto.PhoneNumber, token)
+	// Send SMS verification. The account exists either way; a failed code can be resent.
+	message := "Verification code sent to your phone"
+	if err := service.sendPhoneCode(context.Request().Context(), createdUser, time.Now()); err != nil {
+		utils.Error("Failed to send phone verification code",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: createdUser.ID})
+		message = "Account created, but the verification code could not be sent. Request a new one."
+	}
 
 	return utils.ResponseMessage(http.StatusCreated, map[string]interface{}{
 		"user_id":      createdUser.ID,
 		"phone_number": dto.PhoneNumber,
-		"message":      "Verification code sent to your phone",
+		"message":      message,
 	}, context)
 }
 
@@ -151,31 +185,12 @@ func (service *ServicesHandler) CreateWithPhoneNumber(context echo.Context) erro
 func (service *ServicesHandler) VerifyPhoneNumber(context echo.Context) error {
 	dto, _ := context.Get(utils.ValidatedBodyDTO).(*domain.VerifyPhoneDTO)
 
-	// Get and verify token
-	// token, err := service.userPort.GetSMSVerificationToken(context.Request().Context(), dto.Token)
-	// if err != nil {
-	//     utils.Error("Invalid SMS verification token",
-	//         utils.LogField{Key: "error", Value: err.Error()})
-	//     return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid verification code"), context)
-	// }
-
-	// Check if token is expired or used
-	// if token.ExpiresAt.Time.Before(time.Now()) || (token.Used.Valid && token.Used.Bool) {
-	//     utils.Error("Expired or used SMS token",
-	//         utils.LogField{Key: "token_id", Value: token.ID})
-	//     return utils.ErrorResponse(http.StatusBadRequest, errors.New("verification code expired"), context)
-	// }
-
-	// // Mark phone as verified
-	// err = service.userPort.MarkPhoneAsVerified(context.Request().Context(), dto.PhoneNumber)
-	// if err != nil {
-	//     utils.Error("Failed to mark phone as verified",
-	//         utils.LogField{Key: "error", Value: err.Error()})
-	//     return utils.ErrorResponse(http.StatusInternalServerError, err, context)
-	// }
-
-	// // Mark token as used
-	// service.userPort.MarkSMSVerificationTokenUsed(context.Request().Context(), token.ID)
+	if err := service.verifyPhoneCode(context.Request().Context(), dto.PhoneNumber, dto.Token, time.Now()); err != nil {
+		utils.Error("Phone verification failed",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "phone_number", Value: dto.PhoneNumber})
+		return utils.ErrorResponse(phoneVerificationErrorStatus(err), err, context)
+	}
 
 	utils.Info("Phone number verified successfully",
 		utils.LogField{Key: "phone_number", Value: dto.PhoneNumber})
@@ -226,7 +241,16 @@ func (service *ServicesHandler) CreateDiagnosticCentreManager(context echo.Conte
 		return utils.ErrorResponse(http.StatusBadRequest, err, context)
 	}
 	newUser.EmailVerified = pgtype.Bool{Bool: true, Valid: true}
-	createdUser, err := service.createUserHelper(context, newUser, db.UserEnumDIAGNOSTICCENTREMANAGER)
+
+	// The manager only learns their password from the email, so the account is committed
+	// only with it
+	tx, err := service.userPort.BeginUser(context.Request().Context())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(context.Request().Context())
+
+	createdUser, err := service.createUserHelper(context, tx, newUser, db.UserEnumDIAGNOSTICCENTREMANAGER)
 	if err != nil {
 		return utils.ErrorResponse(http.StatusConflict, err, context)
 	}
@@ -237,12 +261,21 @@ func (service *ServicesHandler) CreateDiagnosticCentreManager(context echo.Conte
 		Password:    password,
 	}
 
-	go service.emailGoroutine(
-		emaildata,
-		createdUser.Email.String,
-		emails.SubjectDiagnosticCentreManager,
-		emails.TemplateDiagnosticCentreManager,
-	)
+	if err := service.queueEmail(context.Request().Context(), tx, outboxEmail{
+		To:        createdUser.Email.String,
+		Subject:   emails.SubjectDiagnosticCentreManager,
+		Template:  emails.TemplateDiagnosticCentreManager,
+		Data:      emaildata,
+		Sensitive: true,
+	}); err != nil {
+		utils.Error("Failed to queue email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "template", Value: emails.TemplateDiagnosticCentreManager})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if err := tx.Commit(context.Request().Context()); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
 
 	return utils.ResponseMessage(http.StatusCreated, createdUser, context)
 }
@@ -250,8 +283,14 @@ func (service *ServicesHandler) CreateDiagnosticCentreManager(context echo.Conte
 func (service *ServicesHandler) Login(context echo.Context) error {
 	// This validated at the middleware level
 	dto, _ := context.Get(utils.ValidatedBodyDTO).(*domain.UserSignInDTO)
-	user, err := service.userPort.GetUserByEmail(
-		context.Request().Context(), pgtype.Text{String: dto.Email, Valid: true})
+	var user *db.User
+	var err error
+	if dto.Email != "" {
+		user, err = service.userPort.GetUserByEmail(
+			context.Request().Context(), pgtype.Text{String: dto.Email, Valid: true})
+	} else {
+		user, err = service.userPort.GetUserByPhoneNumber(context.Request().Context(), dto.PhoneNumber)
+	}
 	if err != nil {
 		utils.Error("Login failed - user not found",
 			utils.LogField{Key: "error", Value: err.Error()})
@@ -268,6 +307,13 @@ func (service *ServicesHandler) Login(context echo.Context) error {
 		return utils.ErrorResponse(http.StatusBadRequest, errors.New(utils.InvalidLoginRequest), context)
 	}
 
+	// Accounts registered by phone sign in by phone, once the number is verified
+	if dto.Email == "" && !user.PhoneVerifiedAt.Valid {
+		utils.Error("Login failed - phone number not verified",
+			utils.LogField{Key: "user_id", Value: user.ID})
+		return utils.ErrorResponse(http.StatusForbidden, ErrPhoneNotVerified, context)
+	}
+
 	// Generate token
 	userClaims := domain.CurrentUserDTO{
 		UserID:   uuid.MustParse(user.ID),
@@ -316,7 +362,13 @@ func (service *ServicesHandler) RequestPasswordReset(context echo.Context) error
 		Token:     token,
 		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
 	}
-	if err := service.userPort.CreatePasswordResetToken(context.Request().Context(), resetToken); err != nil {
+	tx, err := service.userPort.BeginUser(context.Request().Context())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(context.Request().Context())
+
+	if err := tx.CreatePasswordResetToken(context.Request().Context(), resetToken); err != nil {
 		utils.Error("Failed to create password reset token",
 			utils.LogField{Key: "error", Value: err.Error()},
 			utils.LogField{Key: "user_id", Value: user.ID})
@@ -330,12 +382,21 @@ func (service *ServicesHandler) RequestPasswordReset(context echo.Context) error
 		ExpiresIn: "15 minutes",
 	}
 
-	go service.emailGoroutine(
-		emailData,
-		user.Email.String,
-		emails.SubjectResetPassword,
-		emails.TemplateResetPassword,
-	)
+	if err := service.queueEmail(context.Request().Context(), tx, outboxEmail{
+		To:        user.Email.String,
+		Subject:   emails.SubjectResetPassword,
+		Template:  emails.TemplateResetPassword,
+		Data:      emailData,
+		Sensitive: true,
+	}); err != nil {
+		utils.Error("Failed to queue email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "template", Value: emails.TemplateResetPassword})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if err := tx.Commit(context.Request().Context()); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
 
 	return utils.ResponseMessage(http.StatusOK, map[string]string{
 		"message": "If your email exists in our system, you will receive password reset instructions",
@@ -523,8 +584,14 @@ func (service *ServicesHandler) ResendVerification(context echo.Context) error {
 		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
 	}
 
+	tx, err := service.userPort.BeginUser(context.Request().Context())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	defer tx.Rollback(context.Request().Context())
+
 	// Save token to database
-	_, err = service.userPort.CreateEmailVerificationToken(
+	_, err = tx.CreateEmailVerificationToken(
 		context.Request().Context(),
 		verificationParams,
 	)
@@ -543,12 +610,21 @@ func (service *ServicesHandler) ResendVerification(context echo.Context) error {
 		ExpiryDuration:   "24 hours",
 	}
 
-	go service.emailGoroutine(
-		emaildata,
-		user.Email.String,
-		emails.SubjectEmailVerification,
-		emails.TemplateEmailVerification,
-	)
+	if err := service.queueEmail(context.Request().Context(), tx, outboxEmail{
+		To:        user.Email.String,
+		Subject:   emails.SubjectEmailVerification,
+		Template:  emails.TemplateEmailVerification,
+		Data:      emaildata,
+		Sensitive: true,
+	}); err != nil {
+		utils.Error("Failed to queue email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "template", Value: emails.TemplateEmailVerification})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if err := tx.Commit(context.Request().Context()); err != nil {
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
 
 	return utils.ResponseMessage(http.StatusOK, map[string]string{
 		"message": "Verification email sent",
@@ -771,6 +847,7 @@ func (service *ServicesHandler) OwnerKYC(context echo.Context) error {
 
 func (service *ServicesHandler) createUserHelper(
 	context echo.Context,
+	tx ports.UserTx,
 	arg db.CreateUserParams,
 	userTypes ...db.UserEnum,
 ) (*db.User, error) {
@@ -793,7 +870,7 @@ func (service *ServicesHandler) createUserHelper(
 	}
 
 	// Create user
-	createdRow, err := service.userPort.CreateUser(context.Request().Context(), arg)
+	createdRow, err := tx.CreateUser(context.Request().Context(), arg)
 	if err != nil {
 		utils.Error("Failed to create user",
 			utils.LogField{Key: "error", Value: err.Error()})
@@ -1,12 +1,14 @@
 package services
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"net/http"
 	"os"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/diagnoxix/core/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 )
@@ -211,9 +214,6 @@ func buildUpdateDiagnosticCentreByOwnerParams(value *domain.UpdateDiagnosticBody
 	doctors := make([]string, len(value.Doctors))
 	copy(doctors, value.Doctors)
 
-	availableTests := make([]string, len(value.AvailableTests))
-	copy(availableTests, value.AvailableTests)
-
 	var adminID pgtype.UUID
 	if value.ADMINID != uuid.Nil {
 		adminID = pgtype.UUID{Bytes: value.ADMINID, Valid: true}
@@ -226,8 +226,9 @@ func buildUpdateDiagnosticCentreByOwnerParams(value *domain.UpdateDiagnosticBody
 		Address:              addressBytes,
 		Contact:              contactBytes,
 		Doctors:              doctors,
-		AvailableTests:       availableTests,
-		AdminID:              adminID,
+		// available_tests is kept in sync with the active test prices
+		AvailableTests: nil,
+		AdminID:        adminID,
 	}
 
 	utils.Info("Built update diagnostic centre parameters",
@@ -310,12 +311,24 @@ func toUUID(id string) pgtype.UUID {
 	return uid
 }
 
+// uuidString formats a nullable UUID, empty when it is null
+func uuidString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return uuid.UUID(id.Bytes).String()
+}
+
 // Helper functions and type definitions
 
 func toTimestamptz(t time.Time) pgtype.Timestamptz {
 	return pgtype.Timestamptz{Time: t, Valid: true}
 }
 
+func toDate(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: truncateToDay(t), Valid: true}
+}
+
 func toText(s string) pgtype.Text {
 	return pgtype.Text{String: s, Valid: len(s) > 0}
 }
@@ -435,6 +448,49 @@ func PrivateMiddlewareContext(c echo.Context, userTypes []db.UserEnum) (*domain.
 	return nil, ErrUnauthorized
 }
 
+// authorizeCentreAccess checks that the user owns or manages the diagnostic centre
+func (service *ServicesHandler) authorizeCentreAccess(
+	ctx context.Context,
+	user *domain.CurrentUserDTO,
+	diagnosticCentreID string,
+) error {
+	var err error
+	switch user.UserType {
+	case db.UserEnumDIAGNOSTICCENTREOWNER:
+		_, err = service.diagnosticPort.GetDiagnosticCentreByOwner(ctx, db.Get_Diagnostic_Centre_ByOwnerParams{
+			ID:        diagnosticCentreID,
+			CreatedBy: user.UserID.String(),
+		})
+	case db.UserEnumDIAGNOSTICCENTREMANAGER:
+		_, err = service.diagnosticPort.GetDiagnosticCentreByManager(ctx, db.Get_Diagnostic_Centre_ByManagerParams{
+			ID:      diagnosticCentreID,
+			AdminID: pgtype.UUID{Bytes: user.UserID, Valid: true},
+		})
+	default:
+		return utils.ErrPermissionDenied
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFoundDiagnositcCentre
+		}
+		return err
+	}
+	return nil
+}
+
+// centreAccessErrorStatus maps authorizeCentreAccess errors to HTTP status codes
+func centreAccessErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, utils.ErrNotFoundDiagnositcCentre):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // Helper function to convert UserEnum slice to string slice for logging
 func convertUserTypesToStrings(types []db.UserEnum) []string {
 	strings := make([]string, len(types))
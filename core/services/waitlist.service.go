@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/adapters/external/templates/emails"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultWaitlistHoldMinutes is how long a freed slot is held when WAITLIST_HOLD_MINUTES is unset
+const defaultWaitlistHoldMinutes = 15
+
+var (
+	ErrAlreadyWaitlisted  = errors.New("already on the waitlist for this test at this diagnostic centre")
+	ErrWaitlistWindowPast = errors.New("waitlist date window must not start before today")
+)
+
+// JoinWaitlist puts the patient on a centre's waitlist for a test within a date window
+func (service *ServicesHandler) JoinWaitlist(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedBodyDTO).(*domain.JoinWaitlistDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid request body"), context)
+	}
+
+	from, to, err := parseWaitlistWindow(dto.DateFrom, dto.DateTo, time.Now().UTC())
+	if err != nil {
+		return utils.ErrorResponse(http.StatusBadRequest, err, context)
+	}
+
+	ctx := context.Request().Context()
+	centre, err := service.diagnosticPort.GetDiagnosticCentre(ctx, dto.DiagnosticCentreID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("diagnostic centre not found"), context)
+		}
+		utils.Error("Failed to get diagnostic centre",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	testType := strings.ToUpper(strings.ReplaceAll(dto.TestType, " ", "_"))
+	if !slices.Contains(centre.AvailableTests, testType) {
+		return utils.ErrorResponse(
+			http.StatusUnprocessableEntity,
+			fmt.Errorf("diagnostic centre does not offer test type: %s", testType),
+			context,
+		)
+	}
+
+	entry, err := service.waitlistPort.CreateWaitlistEntry(ctx, db.Create_Waitlist_EntryParams{
+		PatientID:          currentUser.UserID.String(),
+		DiagnosticCentreID: dto.DiagnosticCentreID,
+		TestType:           testType,
+		DateFrom:           toDate(from),
+		DateTo:             toDate(to),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusConflict, ErrAlreadyWaitlisted, context)
+		}
+		utils.Error("Failed to join waitlist",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "patient_id", Value: currentUser.UserID.String()},
+			utils.LogField{Key: "diagnostic_centre_id", Value: dto.DiagnosticCentreID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	return utils.ResponseMessage(http.StatusCreated, entry, context)
+}
+
+// ListWaitlist lists the patient's waitlist entries, newest first
+func (service *ServicesHandler) ListWaitlist(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.ListWaitlistDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid query parameters"), context)
+	}
+	param, _ := SetDefaultPagination(&dto.PaginationQueryDTO).(*domain.PaginationQueryDTO)
+
+	entries, err := service.waitlistPort.ListPatientWaitlistEntries(
+		context.Request().Context(),
+		db.List_Patient_Waitlist_EntriesParams{
+			PatientID: currentUser.UserID.String(),
+			Limit:     param.GetLimit(),
+			Offset:    param.GetOffset(),
+		},
+	)
+	if err != nil {
+		utils.Error("Failed to list waitlist entries",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "patient_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+	if len(entries) == 0 {
+		return utils.ResponseMessage(http.StatusOK, []*db.AppointmentWaitlistEntry{}, context)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, entries, context)
+}
+
+// LeaveWaitlist takes the patient off a waitlist; a slot held for them passes to the next patient
+func (service *ServicesHandler) LeaveWaitlist(context echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(context, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, context)
+	}
+
+	dto, ok := context.Get(utils.ValidatedQueryParamDTO).(*domain.LeaveWaitlistDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), context)
+	}
+
+	entry, err := service.waitlistPort.CancelWaitlistEntry(context.Request().Context(), db.Cancel_Waitlist_EntryParams{
+		ID:        dto.WaitlistID,
+		PatientID: currentUser.UserID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrorResponse(http.StatusNotFound, errors.New("active waitlist entry not found"), context)
+		}
+		utils.Error("Failed to leave waitlist",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "waitlist_id", Value: dto.WaitlistID})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, context)
+	}
+
+	if entry.OfferedSlotStart.Valid {
+		go service.offerFreedSlot(entry.DiagnosticCentreID, entry.OfferedSlotStart.Time)
+	}
+
+	return utils.ResponseMessage(http.StatusOK, entry, context)
+}
+
+// parseWaitlistWindow validates the dates a patient is willing to wait for
+func parseWaitlistWindow(fromStr, toStr string, now time.Time) (time.Time, time.Time, error) {
+	from, to, err := parseSlotRange(fromStr, toStr, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if from.Before(truncateToDay(now)) {
+		return time.Time{}, time.Time{}, ErrWaitlistWindowPast
+	}
+	return from, to, nil
+}
+
+// offerFreedSlot holds a slot that has just freed up for the first patient
+// waiting on it, if the slot is still free and still ahead.
+func (service *ServicesHandler) offerFreedSlot(diagnosticCentreID string, start time.Time) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	if !start.After(now) {
+		return
+	}
+
+	slot, err := service.findFreeSlot(ctx, diagnosticCentreID, start.UTC(), "")
+	if err != nil {
+		if !errors.Is(err, ErrSlotUnavailable) && !errors.Is(err, ErrSlotFullyBooked) {
+			utils.Error("Failed to check freed slot for waitlist",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "diagnostic_centre_id", Value: diagnosticCentreID})
+		}
+		return
+	}
+
+	entry, err := service.waitlistPort.OfferWaitlistSlot(ctx, db.Offer_Waitlist_SlotParams{
+		DiagnosticCentreID: diagnosticCentreID,
+		OfferedSlotStart:   toTimestamptz(slot.StartTime),
+		OfferedTimeSlot:    toText(slot.TimeSlot),
+		HoldExpiresAt:      toTimestamptz(now.Add(service.waitlistHold())),
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			utils.Error("Failed to offer freed slot to waitlist",
+				utils.LogField{Key: "error", Value: err.Error()},
+				utils.LogField{Key: "diagnostic_centre_id", Value: diagnosticCentreID})
+		}
+		return
+	}
+
+	utils.Info("Freed slot offered to waitlisted patient",
+		utils.LogField{Key: "waitlist_id", Value: entry.ID},
+		utils.LogField{Key: "patient_id", Value: entry.PatientID},
+		utils.LogField{Key: "slot_start", Value: slot.StartTime})
+
+	service.notifyWaitlistOffer(entry)
+}
+
+// releaseExpiredWaitlistHolds closes lapsed holds and waiting entries whose
+// window has passed, and offers each released slot to the next patient.
+func (service *ServicesHandler) releaseExpiredWaitlistHolds() {
+	expired, err := service.waitlistPort.ExpireWaitlistEntries(context.Background())
+	if err != nil {
+		utils.Error("Failed to expire waitlist entries",
+			utils.LogField{Key: "error", Value: err.Error()})
+		return
+	}
+
+	for _, entry := range expired {
+		if entry.OfferedSlotStart.Valid {
+			service.offerFreedSlot(entry.DiagnosticCentreID, entry.OfferedSlotStart.Time)
+		}
+	}
+}
+
+// markWaitlistHoldBooked closes the patient's hold on a slot once they book it
+func (service *ServicesHandler) markWaitlistHoldBooked(ctx context.Context, appointment *db.Appointment) {
+	if err := service.waitlistPort.MarkWaitlistEntryBooked(ctx, db.Mark_Waitlist_Entry_BookedParams{
+		PatientID:          appointment.PatientID,
+		DiagnosticCentreID: appointment.DiagnosticCentreID,
+		OfferedSlotStart:   appointment.AppointmentDate,
+	}); err != nil {
+		// The hold simply lapses and the job finds the slot booked
+		utils.Error("Failed to mark waitlist hold as booked",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "appointment_id", Value: appointment.ID})
+	}
+}
+
+// notifyWaitlistOffer tells the patient a slot is held for them, in-app, over WebSocket and by email
+func (service *ServicesHandler) notifyWaitlistOffer(entry *db.AppointmentWaitlistEntry) {
+	ctx := context.Background()
+
+	centreName := "the diagnostic centre"
+	if centre, err := service.diagnosticPort.GetDiagnosticCentre(ctx, entry.DiagnosticCentreID); err == nil {
+		centreName = centre.DiagnosticCentreName
+	}
+
+	title := "A Slot Has Opened Up"
+	message := fmt.Sprintf("A slot at %s on %s (%s) is held for you until %s UTC. Book it before then to keep it.",
+		centreName,
+		entry.OfferedSlotStart.Time.UTC().Format("Mon, 2 Jan 2006"),
+		entry.OfferedTimeSlot.String,
+		entry.HoldExpiresAt.Time.UTC().Format("15:04"))
+	data := map[string]interface{}{
+		"waitlist_id":          entry.ID,
+		"diagnostic_centre_id": entry.DiagnosticCentreID,
+		"test_type":            entry.TestType,
+		"slot_start":           entry.OfferedSlotStart.Time,
+		"time_slot":            entry.OfferedTimeSlot.String,
+		"hold_expires_at":      entry.HoldExpiresAt.Time,
+	}
+
+	notification := userNotification{
+		UserID:   entry.PatientID,
+		Type:     db.NotificationTypeWAITLISTSLOTOFFERED,
+		Title:    title,
+		Message:  message,
+		Metadata: data,
+	}
+	patient, err := service.userPort.GetUser(ctx, entry.PatientID)
+	if err != nil {
+		// The offer still goes out on the other channels
+		utils.Error("Failed to get patient details for waitlist offer email",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "waitlist_id", Value: entry.ID})
+	} else {
+		notification.Email = &outboxEmail{
+			To:       patient.Email.String,
+			Subject:  emails.TitleWaitlistOffer,
+			Template: emails.TemplateWaitlistOffer,
+			Data: &emails.WaitlistOfferData{
+				EmailData: emails.EmailData{
+					AppName: "Diagnoxix",
+				},
+				PatientName:   patient.Fullname.String,
+				CentreName:    centreName,
+				TestType:      entry.TestType,
+				SlotStart:     entry.OfferedSlotStart.Time,
+				TimeSlot:      entry.OfferedTimeSlot.String,
+				HoldExpiresAt: entry.HoldExpiresAt.Time.UTC(),
+			},
+		}
+	}
+
+	if err := service.notify(ctx, notification); err != nil {
+		utils.Error("Failed to send waitlist offer notification",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "waitlist_id", Value: entry.ID})
+	}
+}
+
+// waitlistHold reads how long a freed slot is held from configuration
+func (service *ServicesHandler) waitlistHold() time.Duration {
+	minutes := defaultWaitlistHoldMinutes
+	if service.Config.WAITLIST_HOLD_MINUTES != "" {
+		parsed, err := strconv.Atoi(service.Config.WAITLIST_HOLD_MINUTES)
+		if err != nil || parsed <= 0 {
+			utils.Warn("Invalid WAITLIST_HOLD_MINUTES configuration, using default",
+				utils.LogField{Key: "waitlist_hold_minutes", Value: service.Config.WAITLIST_HOLD_MINUTES})
+		} else {
+			minutes = parsed
+		}
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWaitlistWindow(t *testing.T) {
+	now := time.Date(2025, 9, 1, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		from, to string
+		wantErr  error
+		wantFail bool
+	}{
+		{name: "today onwards", from: "2025-09-01", to: "2025-09-07"},
+		{name: "single day", from: "2025-09-03", to: "2025-09-03"},
+		{name: "starts yesterday", from: "2025-08-31", to: "2025-09-03", wantErr: ErrWaitlistWindowPast, wantFail: true},
+		{name: "reversed", from: "2025-09-05", to: "2025-09-03", wantFail: true},
+		{name: "too long", from: "2025-09-01", to: "2025-10-15", wantFail: true},
+		{name: "bad date", from: "01-09-2025", to: "2025-09-03", wantFail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := parseWaitlistWindow(tt.from, tt.to, now)
+			if tt.wantFail {
+				if err == nil {
+					t.Fatalf("expected an error, got window %s to %s", from, to)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v; want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if from.Format("2006-01-02") != tt.from || to.Format("2006-01-02") != tt.to {
+				t.Errorf("window = %s to %s; want %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"), tt.from, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+	"github.com/diagnoxix/core/ports"
+	"github.com/diagnoxix/core/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+var ErrInsufficientWalletBalance = errors.New("wallet balance does not cover the amount")
+
+// GetWallet returns the current patient's wallet balances, one per currency
+func (s *ServicesHandler) GetWallet(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	ctx := c.Request().Context()
+	wallets, err := s.walletPort.ListUserWallets(ctx, currentUser.UserID.String())
+	if err != nil {
+		utils.Error("Failed to list wallets",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if wallets == nil {
+		wallets = []*db.Wallet{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, wallets, c)
+}
+
+// ListWalletTransactions lists the credits and debits of the current patient's wallets, newest first
+func (s *ServicesHandler) ListWalletTransactions(c echo.Context) error {
+	currentUser, err := PrivateMiddlewareContext(c, []db.UserEnum{db.UserEnumPATIENT})
+	if err != nil {
+		return utils.ErrorResponse(http.StatusUnauthorized, err, c)
+	}
+
+	dto, ok := c.Get(utils.ValidatedQueryParamDTO).(*domain.ListWalletTransactionsDTO)
+	if !ok || dto == nil {
+		return utils.ErrorResponse(http.StatusBadRequest, errors.New("invalid parameters"), c)
+	}
+
+	ctx := c.Request().Context()
+	param, _ := SetDefaultPagination(&dto.PaginationQueryDTO).(*domain.PaginationQueryDTO)
+	transactions, err := s.walletPort.ListWalletTransactions(ctx, db.List_Wallet_TransactionsParams{
+		UserID: currentUser.UserID.String(),
+		Limit:  param.GetLimit(),
+		Offset: param.GetOffset(),
+	})
+	if err != nil {
+		utils.Error("Failed to list wallet transactions",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: currentUser.UserID.String()})
+		return utils.ErrorResponse(http.StatusInternalServerError, err, c)
+	}
+	if transactions == nil {
+		transactions = []*db.WalletTransaction{}
+	}
+
+	return utils.ResponseMessage(http.StatusOK, transactions, c)
+}
+
+// lockWallet reads a patient's wallet in a currency and holds it until tx ends, so concurrent
+// bookings see the balance one after the other. A patient without a wallet has nothing to spend
+// and gets nil.
+func lockWallet(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	patientID string,
+	currency string,
+) (*db.Wallet, float64, error) {
+	wallet, err := tx.LockWallet(ctx, db.Lock_WalletParams{
+		UserID:   patientID,
+		Currency: currency,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to lock wallet: %w", err)
+	}
+	balance, err := numericToFloat(wallet.Balance)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse wallet balance: %w", err)
+	}
+	return wallet, balance, nil
+}
+
+// walletShare is the part of an amount a wallet balance covers
+func walletShare(balance, amount float64) float64 {
+	return roundAmount(math.Max(0, math.Min(balance, amount)))
+}
+
+// debitWallet takes a booking payment's wallet share from the wallet locked for it within tx
+func debitWallet(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	wallet *db.Wallet,
+	payment *db.Payment,
+	amount float64,
+) error {
+	debited, err := tx.DebitWallet(ctx, db.Debit_WalletParams{
+		ID:      wallet.ID,
+		Balance: toNumeric(amount),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %.2f %s", ErrInsufficientWalletBalance, amount, wallet.Currency)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to debit wallet: %w", err)
+	}
+
+	if _, err := tx.CreateWalletTransaction(ctx, db.Create_Wallet_TransactionParams{
+		WalletID:     debited.ID,
+		Direction:    db.WalletTransactionDirectionDebit,
+		Kind:         db.WalletTransactionKindPayment,
+		Amount:       toNumeric(amount),
+		BalanceAfter: debited.Balance,
+		PaymentID:    toUUID(payment.ID),
+		Description:  "Payment for appointment " + payment.AppointmentID,
+		CreatedBy:    toUUID(payment.PatientID),
+	}); err != nil {
+		return fmt.Errorf("failed to record wallet debit: %w", err)
+	}
+	return nil
+}
+
+// creditWallet adds to a patient's wallet within tx and records the credit
+func creditWallet(
+	ctx context.Context,
+	tx ports.AppointmentTx,
+	payment *db.Payment,
+	amount float64,
+	kind db.WalletTransactionKind,
+	refundID string,
+	description string,
+	createdBy string,
+) (*db.WalletTransaction, error) {
+	credited, err := tx.CreditWallet(ctx, db.Credit_WalletParams{
+		UserID:   payment.PatientID,
+		Currency: payment.Currency,
+		Balance:  toNumeric(amount),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to credit wallet: %w", err)
+	}
+
+	transaction, err := tx.CreateWalletTransaction(ctx, db.Create_Wallet_TransactionParams{
+		WalletID:     credited.ID,
+		Direction:    db.WalletTransactionDirectionCredit,
+		Kind:         kind,
+		Amount:       toNumeric(amount),
+		BalanceAfter: credited.Balance,
+		PaymentID:    toUUID(payment.ID),
+		RefundID:     toUUID(refundID),
+		Description:  description,
+		CreatedBy:    toUUID(createdBy),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record wallet credit: %w", err)
+	}
+	return transaction, nil
+}
+
+// releaseWalletShare gives the wallet share of a payment that will never complete back to the
+// patient within tx. Payments the wallet took nothing from are left alone.
+func releaseWalletShare(ctx context.Context, tx ports.AppointmentTx, payment *db.Payment) (float64, error) {
+	share, err := paymentWalletAmount(payment)
+	if err != nil || share == 0 {
+		return 0, err
+	}
+	if _, err := creditWallet(
+		ctx,
+		tx,
+		payment,
+		share,
+		db.WalletTransactionKindPaymentReversal,
+		"",
+		"Released from unpaid appointment "+payment.AppointmentID,
+		"",
+	); err != nil {
+		return 0, err
+	}
+	return share, nil
+}
+
+// cancelHeldPayment cancels the unpaid payment of a cancelled booking that holds something:
+// a share the wallet paid, which is given back, or a pay-at-centre hold, which the centre can
+// then no longer record a receipt against. Returns the amount given back to the wallet.
+func (s *ServicesHandler) cancelHeldPayment(ctx context.Context, appointment *db.Appointment) (float64, error) {
+	if !appointment.PaymentID.Valid {
+		return 0, nil
+	}
+
+	tx, err := s.appointmentPort.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	payment, err := tx.LockPayment(ctx, uuidString(appointment.PaymentID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock payment: %w", err)
+	}
+	share, err := paymentWalletAmount(payment)
+	if err != nil || payment.PaymentStatus != db.PaymentStatusPending {
+		return 0, err
+	}
+	if share == 0 && !isCentrePayment(payment) {
+		return 0, nil
+	}
+
+	if _, err := s.applyPaymentEvent(ctx, tx, payment, paymentEvent{
+		Source:  db.PaymentEventSourceInternal,
+		Type:    PaymentEventCancelled,
+		To:      db.PaymentStatusCancelled,
+		Amount:  payment.Amount,
+		ActorID: appointment.PatientID,
+	}); err != nil {
+		return 0, err
+	}
+	released, err := releaseWalletShare(ctx, tx, payment)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit wallet release: %w", err)
+	}
+	return released, nil
+}
+
+// refundDestination is where a refund of a payment goes. A payment collected at the centre is
+// refunded by the centre, and one paid from the wallet alone is refunded to the wallet. A
+// provider refund of a payment the wallet paid part of is capped by createRefund, which credits
+// the wallet's part back to the wallet.
+func refundDestination(payment *db.Payment, requested string) db.RefundDestination {
+	if isCentrePayment(payment) {
+		return db.RefundDestinationCentre
+	}
+	if payment.PaymentMethod == db.PaymentMethodWallet {
+		return db.RefundDestinationWallet
+	}
+	if requested == string(db.RefundDestinationWallet) {
+		return db.RefundDestinationWallet
+	}
+	return db.RefundDestinationProvider
+}
+
+// paymentWalletAmount is the part of a payment taken from the patient's wallet
+func paymentWalletAmount(payment *db.Payment) (float64, error) {
+	if !payment.WalletAmount.Valid {
+		return 0, nil
+	}
+	return numericToFloat(payment.WalletAmount)
+}
+
+// providerChargeAmount is the part of a payment charged through its provider
+func providerChargeAmount(payment *db.Payment) (float64, error) {
+	amount, err := numericToFloat(payment.Amount)
+	if err != nil {
+		return 0, err
+	}
+	share, err := paymentWalletAmount(payment)
+	if err != nil {
+		return 0, err
+	}
+	return roundAmount(amount - share), nil
+}
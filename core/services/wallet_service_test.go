@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diagnoxix/adapters/db"
+	"github.com/diagnoxix/core/domain"
+)
+
+func TestWalletShare(t *testing.T) {
+	tests := []struct {
+		balance, amount, want float64
+	}{
+		{balance: 0, amount: 15000, want: 0},
+		{balance: 4000.255, amount: 15000, want: 4000.26},
+		{balance: 15000, amount: 15000, want: 15000},
+		{balance: 20000, amount: 15000, want: 15000},
+	}
+
+	for _, tt := range tests {
+		if got := walletShare(tt.balance, tt.amount); got != tt.want {
+			t.Errorf("walletShare(%v, %v) = %v; want %v", tt.balance, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestRefundDestination(t *testing.T) {
+	card := &db.Payment{PaymentMethod: db.PaymentMethodCard, Amount: toNumeric(15000)}
+	partWallet := &db.Payment{PaymentMethod: db.PaymentMethodCard, Amount: toNumeric(15000), WalletAmount: toNumeric(5000)}
+	wallet := &db.Payment{PaymentMethod: db.PaymentMethodWallet, Amount: toNumeric(15000), WalletAmount: toNumeric(15000)}
+
+	tests := []struct {
+		name      string
+		payment   *db.Payment
+		requested string
+		want      db.RefundDestination
+	}{
+		{name: "card default", payment: card, want: db.RefundDestinationProvider},
+		{name: "card to provider", payment: card, requested: "provider", want: db.RefundDestinationProvider},
+		{name: "card to wallet", payment: card, requested: "wallet", want: db.RefundDestinationWallet},
+		{name: "part wallet to provider", payment: partWallet, requested: "provider", want: db.RefundDestinationProvider},
+		{name: "part wallet to wallet", payment: partWallet, requested: "wallet", want: db.RefundDestinationWallet},
+		{name: "wallet", payment: wallet, want: db.RefundDestinationWallet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refundDestination(tt.payment, tt.requested); got != tt.want {
+				t.Errorf("refundDestination() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderChargeAmount(t *testing.T) {
+	payment := &db.Payment{Amount: toNumeric(15000), WalletAmount: toNumeric(4000.5), Currency: "NGN"}
+
+	amount, err := providerChargeAmount(payment)
+	if err != nil || amount != 10999.5 {
+		t.Fatalf("providerChargeAmount() = %v, %v; want 10999.5", amount, err)
+	}
+	if !transactionAmountMatches(&domain.TransactionVerification{Amount: 10999.5, Currency: "NGN"}, payment) {
+		t.Error("transactionAmountMatches() = false for the provider share")
+	}
+	if transactionAmountMatches(&domain.TransactionVerification{Amount: 15000, Currency: "NGN"}, payment) {
+		t.Error("transactionAmountMatches() = true for the full amount")
+	}
+	if err := paystackAmountMatches(payment, 1099950, "NGN"); err != nil {
+		t.Errorf("paystackAmountMatches() error = %v", err)
+	}
+	if err := paystackAmountMatches(payment, 1500000, "NGN"); !errors.Is(err, ErrWebhookAmountMismatch) {
+		t.Errorf("paystackAmountMatches() error = %v; want %v", err, ErrWebhookAmountMismatch)
+	}
+}
@@ -0,0 +1,157 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/diagnoxix/core/utils"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// notificationChannel carries every notification to every instance, which delivers it to
+	// the sockets it holds
+	notificationChannel = "diagnoxix:ws:notifications"
+	// presenceKeyPrefix keys a hash per user of the instances holding their socket, each with
+	// the unix time its claim lapses
+	presenceKeyPrefix = "diagnoxix:ws:presence:"
+	// presenceTTL outlives two ping intervals, so an instance that stops refreshing its claims,
+	// say because it crashed, stops counting its users as connected
+	presenceTTL = 90 * time.Second
+	// redisTimeout bounds every Redis call made on behalf of a socket
+	redisTimeout = 3 * time.Second
+)
+
+// cluster relays notifications between instances over Redis pub/sub and records which users
+// are connected to any of them
+type cluster struct {
+	client     *redis.Client
+	instanceID string
+}
+
+// NewClusteredWebSocketManager creates a WebSocket manager that shares notifications and
+// presence with the other instances connected to the same Redis
+func NewClusteredWebSocketManager(redisURL string) (*WebSocketManager, error) {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	client := redis.NewClient(opt)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	manager := NewWebSocketManager()
+	manager.cluster = &cluster{
+		client:     client,
+		instanceID: uuid.New().String(),
+	}
+	return manager, nil
+}
+
+// publish sends a notification to every instance, this one included. A notification Redis
+// does not take is delivered to the sockets held here.
+func (manager *WebSocketManager) publish(message *NotificationMessage) {
+	if manager.cluster != nil {
+		payload, err := json.Marshal(message)
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+			err = manager.cluster.client.Publish(ctx, notificationChannel, payload).Err()
+			cancel()
+		}
+		if err == nil {
+			return
+		}
+		utils.Warn("Failed to publish notification, delivering locally",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: message.UserID})
+	}
+
+	select {
+	case manager.broadcast <- message:
+	default:
+		utils.Warn("Failed to queue notification for broadcast", utils.LogField{Key: "user_id", Value: message.UserID})
+	}
+}
+
+// relay delivers the notifications published by any instance to the sockets held here
+func (manager *WebSocketManager) relay() {
+	subscription := manager.cluster.client.Subscribe(context.Background(), notificationChannel)
+	defer subscription.Close()
+
+	// The channel survives reconnects; it only closes with the subscription
+	for published := range subscription.Channel() {
+		var message NotificationMessage
+		if err := json.Unmarshal([]byte(published.Payload), &message); err != nil {
+			utils.Error("Failed to decode published notification", utils.LogField{Key: "error", Value: err.Error()})
+			continue
+		}
+		manager.broadcast <- &message
+	}
+}
+
+// claimPresence records that the users' sockets are held by this instance
+func (c *cluster) claimPresence(userIDs ...string) {
+	if len(userIDs) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	expires := strconv.FormatInt(time.Now().Add(presenceTTL).Unix(), 10)
+	pipe := c.client.Pipeline()
+	for _, userID := range userIDs {
+		pipe.HSet(ctx, presenceKeyPrefix+userID, c.instanceID, expires)
+		pipe.Expire(ctx, presenceKeyPrefix+userID, presenceTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		utils.Warn("Failed to record WebSocket presence",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "users", Value: len(userIDs)})
+	}
+}
+
+// releasePresence records that this instance no longer holds the user's socket
+func (c *cluster) releasePresence(userID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := c.client.HDel(ctx, presenceKeyPrefix+userID, c.instanceID).Err(); err != nil {
+		utils.Warn("Failed to clear WebSocket presence",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: userID})
+	}
+}
+
+// isConnected reports whether any instance holds the user's socket
+func (c *cluster) isConnected(userID string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	claims, err := c.client.HGetAll(ctx, presenceKeyPrefix+userID).Result()
+	if err != nil {
+		utils.Warn("Failed to read WebSocket presence",
+			utils.LogField{Key: "error", Value: err.Error()},
+			utils.LogField{Key: "user_id", Value: userID})
+		return false
+	}
+	return hasLiveClaim(claims, time.Now())
+}
+
+// hasLiveClaim reports whether any instance's claim on a socket has yet to lapse
+func hasLiveClaim(claims map[string]string, now time.Time) bool {
+	for _, expires := range claims {
+		unix, err := strconv.ParseInt(expires, 10, 64)
+		if err == nil && now.Unix() < unix {
+			return true
+		}
+	}
+	return false
+}
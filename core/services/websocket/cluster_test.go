@@ -0,0 +1,61 @@
+package websocket
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHasLiveClaim(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	later := strconv.FormatInt(now.Add(time.Minute).Unix(), 10)
+	earlier := strconv.FormatInt(now.Add(-time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name   string
+		claims map[string]string
+		want   bool
+	}{
+		{"no claims", map[string]string{}, false},
+		{"live claim", map[string]string{"a": later}, true},
+		{"lapsed claim", map[string]string{"a": earlier}, false},
+		{"one of several live", map[string]string{"a": earlier, "b": later}, true},
+		{"malformed claim", map[string]string{"a": "soon"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasLiveClaim(tt.claims, now); got != tt.want {
+				t.Errorf("hasLiveClaim() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBroadcastMessage(t *testing.T) {
+	manager := NewWebSocketManager()
+	alice := &Client{UserID: "alice", Send: make(chan *NotificationMessage, 1)}
+	bob := &Client{UserID: "bob", Send: make(chan *NotificationMessage, 1)}
+	manager.clients[alice.UserID] = alice
+	manager.clients[bob.UserID] = bob
+
+	manager.broadcastMessage(&NotificationMessage{UserID: "alice", ID: "direct"})
+	if got := len(alice.Send); got != 1 {
+		t.Fatalf("alice received %d messages; want 1", got)
+	}
+	if got := len(bob.Send); got != 0 {
+		t.Fatalf("bob received %d messages; want 0", got)
+	}
+	<-alice.Send
+
+	manager.broadcastMessage(&NotificationMessage{ID: "everyone"})
+	for _, client := range []*Client{alice, bob} {
+		select {
+		case message := <-client.Send:
+			if message.ID != "everyone" {
+				t.Errorf("%s received %q; want the broadcast", client.UserID, message.ID)
+			}
+		default:
+			t.Errorf("%s did not receive the broadcast", client.UserID)
+		}
+	}
+}
@@ -21,6 +21,9 @@ type (
 		unregister chan *Client
 		broadcast  chan *NotificationMessage
 		mutex      sync.RWMutex
+		// cluster shares notifications and presence with the other instances; without it
+		// the manager only knows the sockets it holds
+		cluster *cluster
 	}
 	// Client represents a WebSocket client connection
 	Client struct {
@@ -66,6 +69,11 @@ func NewWebSocketManager() *WebSocketManager {
 // Start begins the WebSocket manager's main loop
 func (manager *WebSocketManager) Start() {
 	go manager.run()
+	if manager.cluster != nil {
+		go manager.relay()
+		utils.Info("WebSocket manager started", utils.LogField{Key: "instance_id", Value: manager.cluster.instanceID})
+		return
+	}
 	utils.Info("WebSocket manager started")
 }
 
@@ -78,6 +86,10 @@ func (manager *WebSocketManager) run() {
 		select {
 		case client := <-manager.register:
 			manager.registerClient(client)
+			// Presence is written off the loop so a slow Redis does not hold up delivery
+			if manager.cluster != nil {
+				go manager.cluster.claimPresence(client.UserID)
+			}
 
 		case client := <-manager.unregister:
 			manager.unregisterClient(client)
@@ -87,6 +99,9 @@ func (manager *WebSocketManager) run() {
 
 		case <-ticker.C:
 			manager.pingClients()
+			if manager.cluster != nil {
+				go manager.cluster.claimPresence(manager.connectedUserIDs()...)
+			}
 		}
 	}
 }
@@ -123,52 +138,78 @@ func (manager *WebSocketManager) registerClient(client *Client) {
 	}
 }
 
-// unregisterClient removes a client from the manager
+// unregisterClient removes a client from the manager and, once the user has no socket here,
+// from the presence shared with the other instances. A client already replaced by a newer
+// connection of the same user, or already removed, is left alone.
 func (manager *WebSocketManager) unregisterClient(client *Client) {
 	manager.mutex.Lock()
-	defer manager.mutex.Unlock()
-
-	if _, exists := manager.clients[client.UserID]; exists {
+	current, exists := manager.clients[client.UserID]
+	removed := exists && current == client
+	if removed {
 		delete(manager.clients, client.UserID)
 		close(client.Send)
-		utils.Info("WebSocket client unregistered", utils.LogField{Key: "user_id", Value: client.UserID})
+	}
+	manager.mutex.Unlock()
+
+	if !removed {
+		return
+	}
+	utils.Info("WebSocket client unregistered", utils.LogField{Key: "user_id", Value: client.UserID})
+	if manager.cluster != nil {
+		go manager.cluster.releasePresence(client.UserID)
 	}
 }
 
-// broadcastMessage sends a message to the appropriate client
+// broadcastMessage sends a message to the appropriate client, or to every client when it is
+// addressed to no user. Clients whose send channel is full are unregistered.
 func (manager *WebSocketManager) broadcastMessage(message *NotificationMessage) {
-	manager.mutex.RLock()
-	defer manager.mutex.RUnlock()
+	var stale []*Client
 
-	if client, exists := manager.clients[message.UserID]; exists {
+	manager.mutex.RLock()
+	if message.UserID == "" {
+		for _, client := range manager.clients {
+			select {
+			case client.Send <- message:
+			default:
+				stale = append(stale, client)
+			}
+		}
+	} else if client, exists := manager.clients[message.UserID]; exists {
 		select {
 		case client.Send <- message:
 		default:
-			// Client's send channel is full, remove the client
-			close(client.Send)
-			delete(manager.clients, message.UserID)
+			stale = append(stale, client)
 		}
 	}
+	manager.mutex.RUnlock()
+
+	// The map is only changed under the write lock
+	for _, client := range stale {
+		manager.unregisterClient(client)
+	}
 }
 
-// pingClients sends ping messages to all connected clients
+// pingClients sends ping messages to all connected clients, unregistering those not seen for
+// too long or that cannot be reached
 func (manager *WebSocketManager) pingClients() {
-	manager.mutex.RLock()
-	defer manager.mutex.RUnlock()
+	var stale []*Client
 
-	for userID, client := range manager.clients {
+	manager.mutex.RLock()
+	for _, client := range manager.clients {
 		if time.Since(client.LastSeen) > 60*time.Second {
-			// Client hasn't been seen for too long, remove it
-			close(client.Send)
-			delete(manager.clients, userID)
+			stale = append(stale, client)
 			continue
 		}
 
 		if err := client.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-			close(client.Send)
-			delete(manager.clients, userID)
+			stale = append(stale, client)
 		}
 	}
+	manager.mutex.RUnlock()
+
+	for _, client := range stale {
+		manager.unregisterClient(client)
+	}
 }
 
 // SendNotification sends a notification to a specific user
@@ -183,11 +224,7 @@ func (manager *WebSocketManager) SendNotification(userID string, notification *d
 		ID:        notification.ID.String(),
 	}
 
-	select {
-	case manager.broadcast <- message:
-	default:
-		utils.Warn("Failed to queue notification for broadcast", utils.LogField{Key: "user_id", Value: userID})
-	}
+	manager.publish(message)
 }
 
 // SendCustomNotification sends a custom notification message
@@ -202,26 +239,50 @@ func (manager *WebSocketManager) SendCustomNotification(userID, notificationType
 		ID:        uuid.New().String(),
 	}
 
-	select {
-	case manager.broadcast <- notification:
-	default:
-		utils.Warn("Failed to queue custom notification for broadcast", utils.LogField{Key: "user_id", Value: userID})
-	}
+	manager.publish(notification)
 }
 
-// GetConnectedClients returns the number of connected clients
+// Broadcast sends a notification to every connected user
+func (manager *WebSocketManager) Broadcast(notificationType, title, message string, data map[string]interface{}) {
+	manager.publish(&NotificationMessage{
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		Data:      data,
+		Timestamp: time.Now(),
+		ID:        uuid.New().String(),
+	})
+}
+
+// GetConnectedClients returns the number of clients connected to this instance
 func (manager *WebSocketManager) GetConnectedClients() int {
 	manager.mutex.RLock()
 	defer manager.mutex.RUnlock()
 	return len(manager.clients)
 }
 
-// IsUserConnected checks if a user is currently connected
+// IsUserConnected checks if a user is currently connected to any instance
 func (manager *WebSocketManager) IsUserConnected(userID string) bool {
 	manager.mutex.RLock()
-	defer manager.mutex.RUnlock()
 	_, exists := manager.clients[userID]
-	return exists
+	manager.mutex.RUnlock()
+
+	if exists || manager.cluster == nil {
+		return exists
+	}
+	return manager.cluster.isConnected(userID)
+}
+
+// connectedUserIDs lists the users connected to this instance
+func (manager *WebSocketManager) connectedUserIDs() []string {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+
+	userIDs := make([]string, 0, len(manager.clients))
+	for userID := range manager.clients {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs
 }
 
 // HandleWebSocket handles WebSocket connection upgrades
@@ -0,0 +1,45 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/diagnoxix/core/utils"
+	"go.uber.org/zap"
+)
+
+func TestBroadcastMessageUnregistersFullClients(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	manager := NewWebSocketManager()
+	// An unbuffered channel nobody reads from is always full
+	full := &Client{UserID: "user-1", Send: make(chan *NotificationMessage)}
+	ready := &Client{UserID: "user-2", Send: make(chan *NotificationMessage, 1)}
+	manager.clients[full.UserID] = full
+	manager.clients[ready.UserID] = ready
+
+	manager.broadcastMessage(&NotificationMessage{Type: "announcement"})
+
+	if _, exists := manager.clients[full.UserID]; exists {
+		t.Error("full client still registered")
+	}
+	if _, open := <-full.Send; open {
+		t.Error("full client's send channel not closed")
+	}
+	if _, exists := manager.clients[ready.UserID]; !exists || len(ready.Send) != 1 {
+		t.Error("ready client not sent the broadcast")
+	}
+}
+
+func TestUnregisterClientLeavesReplacedClient(t *testing.T) {
+	utils.Logger = zap.NewNop()
+	manager := NewWebSocketManager()
+	replaced := &Client{UserID: "user-1", Send: make(chan *NotificationMessage)}
+	current := &Client{UserID: "user-1", Send: make(chan *NotificationMessage)}
+	manager.clients[current.UserID] = current
+
+	// The replaced connection's read loop unregisters it after the newer one registered
+	manager.unregisterClient(replaced)
+
+	if manager.clients[current.UserID] != current {
+		t.Error("newer client of the user was unregistered")
+	}
+}
@@ -113,11 +113,8 @@ func main() {
 	// Initialize all services
 	services := services.InitializeServices(repos, cfg)
 
-	// Initialize CronConfig with repositories
-	cronConfig := config.GetConfig(
-		repos.User,
-		repos.Diagnostic,
-		repos.Appointment, *cfg)
+	// Initialize CronConfig with the reminder job, which notifies through the services
+	cronConfig := config.GetConfig(services.Core.Reminder)
 	if err := cronConfig.Start(); err != nil {
 		log.Printf("Warning: Failed to start background services: %v", err)
 	}
@@ -129,8 +126,17 @@ func main() {
 	// Start WebSocket manager
 	services.Core.WebSocketManager.Start()
 
-	// Start reminder job
-	services.Core.Reminder.Start()
+	// Start waitlist hold expiry job
+	services.Core.Waitlist.Start()
+
+	// Start payment reconciliation job
+	services.Core.Reconciliation.Start()
+
+	// Start centre settlement job
+	services.Core.Settlement.Start()
+
+	// Start outbound email delivery
+	services.Core.EmailOutbox.Start()
 
 	// Add a middleware to skip JWT validation for specific routes under /v1
 	v1 := e.Group("/v1")